@@ -1,29 +1,59 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/doganarif/k9sight/internal/app"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/telemetry"
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
 )
 
 const version = "0.1.0"
 
 func main() {
-	if len(os.Args) > 1 {
-		switch os.Args[1] {
-		case "--version", "-v":
-			fmt.Printf("k9sight version %s\n", version)
-			os.Exit(0)
-		case "--help", "-h":
-			printHelp()
-			os.Exit(0)
-		}
+	var opts k8s.ClientOptions
+	var showVersion bool
+
+	flags := flag.NewFlagSet("k9sight", flag.ExitOnError)
+	flags.Usage = printHelp
+	flags.StringVar(&opts.Kubeconfig, "kubeconfig", "", "path to the kubeconfig file")
+	flags.StringVar(&opts.Context, "context", "", "kubeconfig context to use")
+	flags.StringVar(&opts.Namespace, "namespace", "", "namespace to start in")
+	flags.StringVar(&opts.Namespace, "n", "", "namespace to start in")
+	flags.BoolVar(&showVersion, "version", false, "show version information")
+	flags.BoolVar(&showVersion, "v", false, "show version information")
+	flags.Parse(os.Args[1:])
+
+	if showVersion {
+		fmt.Printf("k9sight version %s\n", version)
+		os.Exit(0)
 	}
+	var bookmark string
+	switch {
+	case flags.NArg() == 0:
+	case flags.Arg(0) == "open" && flags.NArg() == 2:
+		bookmark = flags.Arg(1)
+	case flags.Arg(0) == "open":
+		fmt.Fprintf(os.Stderr, "Usage: k9sight [OPTIONS] open BOOKMARK\n")
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "Unexpected argument: %s\n\n", flags.Arg(0))
+		printHelp()
+		os.Exit(2)
+	}
+
+	telemetry.Init(version)
+	// client-go logs errors through klog to stderr, which would draw over the UI
+	klog.SetLogger(logr.Discard())
 
-	model, err := app.New()
+	model, err := app.New(opts, bookmark)
 	if err != nil {
+		telemetry.Shutdown()
 		fmt.Fprintf(os.Stderr, "Error initializing application: %v\n", err)
 		os.Exit(1)
 	}
@@ -34,7 +64,9 @@ func main() {
 		tea.WithMouseCellMotion(),
 	)
 
-	if _, err := p.Run(); err != nil {
+	_, err = p.Run()
+	telemetry.Shutdown()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
 		os.Exit(1)
 	}
@@ -47,10 +79,14 @@ One screen to see why your pod is broken.
 
 USAGE:
     k9sight [OPTIONS]
+    k9sight [OPTIONS] open BOOKMARK    Start at a bookmark from the config file
 
 OPTIONS:
-    -h, --help       Show this help message
-    -v, --version    Show version information
+    --kubeconfig PATH       Kubeconfig file (default: $KUBECONFIG or ~/.kube/config)
+    --context NAME          Kubeconfig context to use instead of the current one
+    -n, --namespace NAME    Namespace to start in (default: the last one used)
+    -h, --help              Show this help message
+    -v, --version           Show version information
 
 KEYBOARD SHORTCUTS:
     Navigation:
@@ -67,6 +103,8 @@ KEYBOARD SHORTCUTS:
         r            Refresh data
         /            Search
         *            Toggle favorite
+        W            Toggle watch (background alerts)
+        b            Bookmarks
 
     Dashboard:
         L            Focus logs panel
@@ -84,6 +122,10 @@ KEYBOARD SHORTCUTS:
 CONFIGURATION:
     Config file: ~/.config/k9sight/config.json
 
+TELEMETRY:
+    Set OTEL_EXPORTER_OTLP_ENDPOINT (e.g. http://localhost:4318) to export
+    OTLP/HTTP traces and metrics for k9sight's own API calls and refreshes.
+
 For more information, visit: https://github.com/doganarif/k9sight
 `
 	fmt.Println(help)
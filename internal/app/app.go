@@ -2,19 +2,38 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/cache"
 	"github.com/doganarif/k9sight/internal/config"
 	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/logbackend"
+	"github.com/doganarif/k9sight/internal/notify"
+	"github.com/doganarif/k9sight/internal/registry"
+	"github.com/doganarif/k9sight/internal/telemetry"
+	"github.com/doganarif/k9sight/internal/tracing"
 	"github.com/doganarif/k9sight/internal/ui/components"
 	"github.com/doganarif/k9sight/internal/ui/keys"
 	"github.com/doganarif/k9sight/internal/ui/styles"
 	"github.com/doganarif/k9sight/internal/ui/views"
+	"golang.org/x/sync/errgroup"
+)
+
+// Pod details are cached briefly so bouncing between a pod and its list
+// doesn't refetch everything
+const (
+	detailCacheSize = 64
+	detailCacheTTL  = 15 * time.Second
+
+	// Scans are rerun on new images or every few hours, not per refresh
+	vulnReportTTL = 5 * time.Minute
 )
 
 type ViewState int
@@ -22,6 +41,8 @@ type ViewState int
 const (
 	ViewNavigator ViewState = iota
 	ViewDashboard
+	ViewWorkloadLogs // the merged logs of a workload's pods (L)
+	ViewWorkload     // the workload dashboard, before its pod list
 )
 
 type Model struct {
@@ -29,6 +50,7 @@ type Model struct {
 	config             *config.Config
 	navigator          components.Navigator
 	dashboard          views.Dashboard
+	workloadView       views.WorkloadDashboard
 	statusBar          components.StatusBar
 	help               components.HelpPanel
 	spinner            spinner.Model
@@ -38,7 +60,6 @@ type Model struct {
 	width              int
 	height             int
 	loading            bool
-	err                error
 	keys               keys.KeyMap
 	workload           *k8s.WorkloadInfo
 	pod                *k8s.PodInfo
@@ -47,60 +68,287 @@ type Model struct {
 	// State tracking for reactive log fetching
 	lastShowPrevious bool
 	lastLogContainer string
+	lastUseExternal  bool
+	lastTimeRange    time.Duration
+	lastFollowing    bool
+
+	// Optional external log store used instead of the kubelet API
+	logBackend logbackend.Backend
+
+	// Queries registries to explain image pull errors, nil when disabled
+	registryClient *registry.Client
+
+	// trivy-operator vulnerability reports by namespace, nil when disabled
+	vulnReports *cache.LRU[string, []k8s.VulnerabilityReport]
+
+	// Last observed state of watched pods/workloads, keyed by watch key
+	watchStates map[string]k8s.WatchState
+
+	// Recently viewed pods and their related resources, keyed by namespace/name;
+	// purged on manual refresh
+	podCache     *cache.LRU[string, *k8s.PodInfo]
+	relatedCache *cache.LRU[string, *k8s.RelatedResources]
+	listCache    *k8s.ListCache
+
+	// Non-fatal errors for the status bar badge and the error viewer
+	errorLog     []errorEntry
+	unseenErrors int
+
+	// Overlay for app-level output such as the error log and pod comparisons
+	resultViewer components.ResultViewer
+
+	// Context for loads started by the current view; cancelled on view changes
+	// so slow requests stop and their late results are dropped
+	loadCtx    context.Context
+	cancelLoad context.CancelFunc
+
+	// Watch keeping the navigator list live, see startListWatch
+	cancelListWatch context.CancelFunc
+	listWatchSeq    int
+
+	// Stream feeding new log lines to the dashboard, see startLogStream
+	cancelLogStream context.CancelFunc
+	logStreamSeq    int
+	logStreaming    bool
+
+	// Watch feeding new events to the dashboard, see startEventWatch
+	cancelEventWatch context.CancelFunc
+	eventWatchSeq    int
+	eventWatching    bool
+	eventWatchStart  time.Time
+
+	// Set while the next frame should ring the terminal bell, see ringBell
+	bell bool
+
+	// In-process port-forwards, the prompt starting them and the panel (F)
+	// listing them
+	forwards          *k8s.ForwardManager
+	portForwardPrompt components.PortForwardPrompt
+	portForwardPanel  components.PortForwardPanel
+
+	namespacePrompt components.NamespacePrompt
+
+	// Where to save YAML exported from the pod actions menu
+	savePathPrompt components.SavePathPrompt
+
+	// Free-form scaling, bounded by the HPA found when the scale menu opened
+	scalePrompt components.ScalePrompt
+	scaleHPA    *k8s.HPAInfo
+
+	// Rollouts followed in the background after a restart, scale or undo,
+	// keyed like watched items, and the toast with the latest outcome
+	rolloutWatches     map[string]bool
+	rolloutToast       string
+	rolloutToastFailed bool
+	rolloutToastUntil  time.Time
+
+	// Named namespace/type/selector/filter combinations (S saves, V recalls)
+	saveViewPrompt components.SaveViewPrompt
+	viewPalette    components.ViewPalette
+
+	// Bookmarks from the config (b picks one); startBookmark is the one
+	// `k9sight open` was given, opened by Init
+	bookmarkPalette components.ViewPalette
+	startBookmark   *config.Bookmark
+
+	// Merged logs of every pod of a workload, see openWorkloadLogs
+	workloadLogs       components.LogsPanel
+	workloadLogsTarget *k8s.WorkloadInfo
+	workloadLogPods    map[string]*workloadLogPod
+	workloadLogSeq     int
 }
 
 type loadedMsg struct {
-	workloads  []k8s.WorkloadInfo
+	resourceType k8s.ResourceType
+	workloads    []k8s.WorkloadInfo
+	namespaces   []string
+	err          error
+}
+
+type namespacesLoadedMsg struct {
 	namespaces []string
 	err        error
 }
 
 type podsLoadedMsg struct {
-	pods []k8s.PodInfo
-	err  error
+	pods        []k8s.PodInfo
+	statefulSet *k8s.StatefulSetStatus // set for StatefulSet pods
+	events      []k8s.EventInfo        // the workload's, its ReplicaSets' or Jobs' and its pods'
+	err         error
 }
 
-type dashboardDataMsg struct {
+// dashboardSectionMsg carries one section of dashboard data as soon as it is
+// fetched; the rest keep arriving on ch until it is closed, the last being a
+// "done" section with no data
+type dashboardSectionMsg struct {
+	podKey  string
+	section string
 	logs    []k8s.LogLine
 	events  []k8s.EventInfo
 	metrics *k8s.PodMetrics
 	related *k8s.RelatedResources
+	volumes []k8s.VolumeInfo
+	drift   []k8s.ConfigDrift
 	helpers []k8s.DebugHelper
+	lint    []k8s.DebugHelper
+	node    *k8s.NodeSummary
+	vulns   []k8s.ImageVulnerabilities
+	rollout []k8s.RolloutRevision
+	err     error
+	pod     *k8s.PodInfo
+	ch      <-chan dashboardSectionMsg
+}
+
+// dashboardSections are the sections one dashboard refresh sends, each at
+// most once
+var dashboardSections = []string{
+	"logs", "events", "helpers", "metrics", "pod", "related", "volumes",
+	"drift", "node", "rollout", "vulnerabilities", "done",
 }
 
 type logsUpdatedMsg struct {
 	logs []k8s.LogLine
+	err  error
 }
 
 type podDeletedMsg struct {
 	namespace string
 	podName   string
+	evicted   bool
 	err       error
 }
 
+// scaleRequest is the confirm dialog's data for a pending scale
+type scaleRequest struct {
+	workload *k8s.WorkloadInfo
+	replicas int32
+}
+
 type workloadActionMsg struct {
 	action       string
 	workloadName string
 	namespace    string
 	resourceType k8s.ResourceType
 	replicas     int32
+	revision     int64
+	job          string // Job created by a CronJob trigger
 	err          error
 }
 
 type tickMsg time.Time
 
-func New() (*Model, error) {
-	client, err := k8s.NewClient()
+type watchTickMsg time.Time
+
+type watchCheckedMsg struct {
+	states map[string]k8s.WatchState
+	alerts []string
+	bell   bool // an item started failing or recovered and watch_notify asks for the bell
+	err    error
+}
+
+// New builds the app for the cluster selected by opts. Without an explicit
+// namespace the one used last time is restored.
+func New(opts k8s.ClientOptions, bookmark string) (*Model, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+
+	// A bookmark picks the context and namespace unless the flags did
+	var startBookmark *config.Bookmark
+	if bookmark != "" {
+		b, err := findBookmark(cfg, bookmark)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Context == "" {
+			opts.Context = b.Context
+		}
+		if opts.Namespace == "" {
+			opts.Namespace = b.Namespace
+		}
+		b.Context, b.Namespace = "", ""
+		startBookmark = &b
+	}
+
+	client, err := k8s.NewClient(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	cfg, err := config.Load()
+	if opts.Namespace == "" && cfg.LastNamespace != "" {
+		client.SetNamespace(cfg.LastNamespace)
+	}
+
+	navigator := components.NewNavigator()
+	navigator.SetWatched(cfg.WatchedItems)
+	navigator.SetLoading(true)
+	if cfg.LastResourceType != "" {
+		navigator.SetResourceType(k8s.ResourceType(cfg.LastResourceType))
+	}
+	// A typo in the config is reported, not fatal
+	var warnings []string
+	resourceTypes, err := k8s.ParseResourceTypes(cfg.ResourceTypes)
 	if err != nil {
-		cfg = config.DefaultConfig()
+		warnings = append(warnings, "resource_types: "+err.Error())
+	}
+	navigator.SetResourceTypes(resourceTypes)
+	var columns []k8s.MetadataColumn
+	for i, c := range cfg.Columns {
+		col := k8s.MetadataColumn{Header: c.Header, Label: c.Label, Annotation: c.Annotation, Width: c.Width}
+		if err := col.Validate(); err != nil {
+			warnings = append(warnings, fmt.Sprintf("columns[%d] skipped: %v", i, err))
+			continue
+		}
+		columns = append(columns, col)
+	}
+	navigator.SetColumns(columns)
+
+	dashboard := views.NewDashboard()
+	// Without a valid pattern the dashboard runs without trace extraction
+	traceExtractor, err := tracing.NewExtractor(cfg.TraceIDPattern)
+	if err != nil {
+		warnings = append(warnings, "trace_id_pattern ignored: "+err.Error())
+	}
+	var traceLinks []tracing.Link
+	for _, l := range cfg.TraceLinks {
+		traceLinks = append(traceLinks, tracing.Link{Name: l.Name, URL: l.URL})
+	}
+	dashboard.SetTracing(traceExtractor, traceLinks)
+	dashboard.SetIntegration(components.ResolveIntegration(cfg.Integration))
+	dashboard.SetDebugImage(cfg.DebugImage)
+	dashboard.SetLogExportDir(cfg.LogExportDir)
+	dashboard.SetLogTimestamps(components.ParseTimestampMode(cfg.LogTimestamps))
+	dashboard.SetLogANSIColors(cfg.LogANSIColors)
+	dashboard.SetShowLint(cfg.PodLint)
+	dashboard.SetExternalTools(cfg.Pager, cfg.DiffTool)
+	dashboard.SetLogBudget(cfg.LogBudgetLines, cfg.LogBudgetMB<<20)
+	dashboard.SetLogGapThreshold(time.Duration(cfg.LogGapSeconds) * time.Second)
+
+	var logBackend logbackend.Backend
+	if cfg.LogBackend != nil {
+		// Without a valid backend, logs come from the kubelet only
+		logBackend, err = logbackend.New(*cfg.LogBackend)
+		if err != nil {
+			warnings = append(warnings, "log_backend ignored: "+err.Error())
+		} else {
+			dashboard.SetLogSource(logBackend.Name())
+		}
+	}
+
+	var registryClient *registry.Client
+	if cfg.RegistryLookup {
+		registryClient = registry.NewClient()
 	}
 
-	client.SetNamespace(cfg.LastNamespace)
+	var vulnReports *cache.LRU[string, []k8s.VulnerabilityReport]
+	if cfg.VulnerabilityReports {
+		vulnReports = cache.New[string, []k8s.VulnerabilityReport](detailCacheSize, vulnReportTTL)
+	}
+
+	warnings = append(warnings, checkBookmarks(cfg)...)
+
+	loadCtx, cancelLoad := context.WithCancel(context.Background())
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
@@ -109,85 +357,232 @@ func New() (*Model, error) {
 	return &Model{
 		k8sClient:          client,
 		config:             cfg,
-		navigator:          components.NewNavigator(),
-		dashboard:          views.NewDashboard(),
+		navigator:          navigator,
+		dashboard:          dashboard,
+		workloadView:       views.NewWorkloadDashboard(),
 		statusBar:          components.NewStatusBar(),
 		help:               components.NewHelpPanel(),
 		spinner:            s,
 		workloadActionMenu: components.NewWorkloadActionMenu(),
 		confirmDialog:      components.NewConfirmDialog(),
+		resultViewer:       components.NewResultViewer(),
+		forwards:           k8s.NewForwardManager(client),
+		portForwardPrompt:  components.NewPortForwardPrompt(),
+		portForwardPanel:   components.NewPortForwardPanel(),
+		namespacePrompt:    components.NewNamespacePrompt(),
+		savePathPrompt:     components.NewSavePathPrompt(),
+		scalePrompt:        components.NewScalePrompt(),
+		saveViewPrompt:     components.NewSaveViewPrompt(),
+		viewPalette:        components.NewViewPalette(),
+		bookmarkPalette:    newBookmarkPalette(),
+		startBookmark:      startBookmark,
+		statusMsg:          strings.Join(warnings, "; "),
 		view:               ViewNavigator,
-		loading:            true,
 		keys:      keys.DefaultKeyMap(),
+		watchStates:        make(map[string]k8s.WatchState),
+		logBackend:         logBackend,
+		registryClient:     registryClient,
+		vulnReports:        vulnReports,
+		podCache:           cache.New[string, *k8s.PodInfo](detailCacheSize, detailCacheTTL),
+		relatedCache:       cache.New[string, *k8s.RelatedResources](detailCacheSize, detailCacheTTL),
+		listCache:          k8s.NewListCache(detailCacheTTL),
+		loadCtx:            loadCtx,
+		cancelLoad:         cancelLoad,
 	}, nil
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.spinner.Tick,
+		m.loadNamespaces(),
 		m.loadInitialData(),
-	)
+		m.watchTickCmd(),
+	}
+	if b := m.startBookmark; b != nil {
+		cmds = append(cmds, func() tea.Msg { return openBookmarkMsg{bookmark: *b} })
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	if cmd, ok := m.handleListWatch(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleLogStream(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleEventWatch(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handlePortForward(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleExport(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleWorkloadLogs(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleWorkloadDetail(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleNamespace(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleCleanup(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleRollout(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleScale(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleBlastRadius(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleTriage(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleBookmarks(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleViews(msg); ok {
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.navigator.SetSize(msg.Width, msg.Height-2)
 		m.dashboard.SetSize(msg.Width, msg.Height-2)
+		m.workloadView.SetSize(msg.Width, msg.Height-2)
 		m.statusBar.SetWidth(msg.Width)
 		m.help.SetSize(msg.Width, msg.Height)
+		m.resultViewer.SetSize(msg.Width-4, msg.Height-4)
 		return m, nil
 
 	case spinner.TickMsg:
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
-	case loadedMsg:
-		m.loading = false
+	case namespacesLoadedMsg:
 		if msg.err != nil {
-			m.err = msg.err
+			m.recordError("namespaces", msg.err)
 			return m, nil
 		}
-		m.navigator.SetWorkloads(msg.workloads)
 		m.navigator.SetNamespaces(msg.namespaces)
 		return m, nil
 
-	case podsLoadedMsg:
+	case loadedMsg:
 		m.loading = false
+		m.navigator.SetLoading(false)
+		m.recordError("workloads", msg.err)
+		if msg.resourceType != "" {
+			m.navigator.SetResourceType(msg.resourceType)
+		}
+		// A failed list only disables its resource type, with the reason in its place
+		m.navigator.SetUnavailable(m.navigator.ResourceType(), k8s.ShortError(msg.err))
+		m.navigator.SetWorkloads(msg.workloads)
+		if msg.namespaces != nil {
+			m.navigator.SetNamespaces(msg.namespaces)
+		}
 		if msg.err != nil {
-			m.err = msg.err
 			return m, nil
 		}
+		return m, m.startListWatch()
+
+	case podsLoadedMsg:
+		m.loading = false
+		m.recordError("pods", msg.err)
+		m.navigator.SetPodsUnavailable(k8s.ShortError(msg.err))
+		m.navigator.SetStatefulSet(msg.statefulSet)
+		m.navigator.SetWorkloadEvents(msg.events)
 		m.navigator.SetPods(msg.pods)
 		m.navigator.SetMode(components.ModePods)
-		return m, nil
+		if msg.err != nil {
+			return m, nil
+		}
+		return m, m.startListWatch()
 
-	case dashboardDataMsg:
+	case dashboardSectionMsg:
+		if m.pod == nil || msg.podKey != m.pod.Namespace+"/"+m.pod.Name {
+			return m, nil // stale: the user moved to another pod
+		}
 		m.loading = false
-		m.dashboard.SetLogs(msg.logs)
-		m.dashboard.SetEvents(msg.events)
-		m.dashboard.SetMetrics(msg.metrics)
-		m.dashboard.SetRelated(msg.related)
-		m.dashboard.SetHelpers(msg.helpers)
-		return m, nil
+		if msg.section == "done" {
+			m.dashboard.RecordSnapshot()
+			return m, waitForSection(msg.ch)
+		}
+		m.applyDashboardSection(msg)
+		if msg.section == "logs" && msg.err == nil {
+			return m, tea.Batch(waitForSection(msg.ch), m.startLogStream())
+		}
+		if msg.section == "events" && msg.err == nil {
+			return m, tea.Batch(waitForSection(msg.ch), m.startEventWatch())
+		}
+		return m, waitForSection(msg.ch)
 
 	case logsUpdatedMsg:
-		m.dashboard.SetLogs(msg.logs)
+		m.recordError("logs", msg.err)
+		if msg.err != nil {
+			m.dashboard.SetLogs(nil)
+		} else {
+			m.dashboard.SetLogs(msg.logs)
+		}
+		m.dashboard.SetSectionError("logs", msg.err)
+		if msg.err == nil {
+			return m, m.startLogStream()
+		}
+		return m, nil
+
+	case components.PodLintMsg:
+		m.config.PodLint = msg.On
+		m.saveConfig()
+		return m, nil
+
+	case components.LogTimestampsMsg:
+		// Both log views show timestamps the same way
+		m.dashboard.SetLogTimestamps(msg.Mode)
+		m.workloadLogs.SetTimestampMode(msg.Mode)
+		m.config.LogTimestamps = msg.Mode.String()
+		m.saveConfig()
 		return m, nil
 
+	case views.CommandRunMsg:
+		// Debug containers are specific to one pod and not worth offering again
+		if len(msg.Item.Exec) > 0 && hasContainer(msg.Pod, msg.Item.Container) {
+			m.rememberCommand(msg.Pod, config.RecentCommand{Container: msg.Item.Container, Exec: msg.Item.Exec})
+		}
+		return m, nil
+
+	case views.ExecPodRequest:
+		c := m.k8sClient.ExecCommand(msg.Namespace, msg.PodName, msg.Container, msg.Command)
+		return m, tea.Exec(c, func(err error) tea.Msg {
+			return views.ExecFinishedMsg{Err: err}
+		})
+
 	case views.DeletePodRequest:
-		return m, m.deletePod(msg.Namespace, msg.PodName)
+		return m, m.deletePod(msg.Namespace, msg.PodName, msg.Evict)
 
 	case podDeletedMsg:
 		if msg.err != nil {
-			m.err = msg.err
+			if msg.evicted {
+				m.recordError("evict", msg.err)
+				m.statusMsg = "Evict failed: " + k8s.ShortError(msg.err)
+			} else {
+				m.recordError("delete", msg.err)
+				m.statusMsg = "Delete failed: " + k8s.ShortError(msg.err)
+			}
 		} else {
 			// Go back to navigator after deletion
+			m.podCache.Remove(msg.namespace + "/" + msg.podName)
+			m.relatedCache.Remove(msg.namespace + "/" + msg.podName)
+			m.cancelLoads()
 			m.view = ViewNavigator
 			m.pod = nil
 			if m.workload != nil {
@@ -198,14 +593,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case components.WorkloadActionMenuResult:
+		if m.view == ViewNavigator && m.navigator.Mode() == components.ModeNamespace {
+			return m, m.namespaceAction(msg.Item)
+		}
 		workload := m.navigator.SelectedWorkload()
 		if workload == nil {
 			return m, nil
 		}
 		switch msg.Item.Action {
 		case "scale":
-			m.loading = true
-			return m, m.scaleWorkload(workload, msg.Item.Replicas)
+			return m, m.confirmWithBlastRadius(workload, pendingConfirm{
+				title:   "Scale " + string(workload.Type),
+				message: fmt.Sprintf("Scale '%s' from %d to %d replicas?", workload.Name, workload.Replicas, msg.Item.Replicas),
+				command: msg.Item.Command,
+				action:  "scale",
+				data:    scaleRequest{workload: workload, replicas: msg.Item.Replicas},
+			})
+		case "scale-custom":
+			m.scalePrompt.Show(workload, m.scaleHPA)
+			return m, nil
+		case "undo":
+			m.confirmDialog.ShowCommand(
+				"Undo rollout",
+				fmt.Sprintf("Roll '%s' back to revision %d?", workload.Name, msg.Item.Revision),
+				msg.Item.Command,
+				"undo",
+				undoRequest{workload: workload, revision: msg.Item.Revision},
+			)
+			return m, nil
+		case "trigger":
+			m.confirmDialog.ShowCommand(
+				"Run CronJob",
+				fmt.Sprintf("Create a Job from '%s' now?", workload.Name),
+				msg.Item.Command,
+				"trigger",
+				workload,
+			)
+			return m, nil
+		case "runs":
+			return m, m.loadCronJobRuns(workload)
+		case "delete-finished":
+			return m, m.loadFinishedPods(workload.Namespace, workload)
+		case "suspend", "resume":
+			m.confirmDialog.ShowCommand(
+				msg.Item.Label+" CronJob",
+				fmt.Sprintf("%s the schedule of '%s'?", msg.Item.Label, workload.Name),
+				msg.Item.Command,
+				msg.Item.Action,
+				workload,
+			)
+			return m, nil
 		case "copy":
 			err := components.CopyToClipboard(msg.Item.Command)
 			if err == nil {
@@ -217,13 +654,55 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case components.ConfirmResult:
-		// Handle workload restart at app level
-		if msg.Confirmed && msg.Action == "restart" {
-			if workload, ok := msg.Data.(*k8s.WorkloadInfo); ok {
-				m.loading = true
-				m.statusMsg = "Restarting..."
-				return m, m.restartWorkload(workload)
+		// Handle workload restart and scale at app level
+		switch msg.Action {
+		case "restart", "scale", "undo", "trigger", "suspend", "resume", "delete-namespace", "delete-finished-pods":
+			switch {
+			case msg.Err != nil:
+				m.statusMsg = "Copy failed: " + msg.Err.Error()
+			case msg.Copied:
+				m.statusMsg = "Copied: " + msg.Command
+			case !msg.Confirmed:
+			case msg.Action == "restart":
+				if workload, ok := msg.Data.(*k8s.WorkloadInfo); ok {
+					m.loading = true
+					m.statusMsg = "Restarting..."
+					return m, m.restartWorkload(workload)
+				}
+			case msg.Action == "undo":
+				if req, ok := msg.Data.(undoRequest); ok {
+					m.loading = true
+					m.statusMsg = "Rolling back..."
+					return m, m.undoRollout(req)
+				}
+			case msg.Action == "trigger":
+				if workload, ok := msg.Data.(*k8s.WorkloadInfo); ok {
+					m.loading = true
+					m.statusMsg = "Creating job..."
+					return m, m.triggerCronJob(workload)
+				}
+			case msg.Action == "suspend" || msg.Action == "resume":
+				if workload, ok := msg.Data.(*k8s.WorkloadInfo); ok {
+					m.loading = true
+					return m, m.suspendCronJob(workload, msg.Action == "suspend")
+				}
+			case msg.Action == "delete-namespace":
+				if name, ok := msg.Data.(string); ok {
+					m.statusMsg = "Deleting namespace..."
+					return m, m.deleteNamespace(name)
+				}
+			case msg.Action == "delete-finished-pods":
+				if req, ok := msg.Data.(finishedPodsRequest); ok {
+					m.statusMsg = "Deleting finished pods..."
+					return m, m.deleteFinishedPods(req)
+				}
+			default:
+				if req, ok := msg.Data.(scaleRequest); ok {
+					m.loading = true
+					return m, m.scaleWorkload(req.workload, req.replicas)
+				}
 			}
+			return m, nil
 		}
 		// Forward other confirm results (exec, port-forward, delete) to dashboard
 		if m.view == ViewDashboard {
@@ -240,6 +719,128 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.dashboard, cmd = m.dashboard.Update(msg)
 			return m, cmd
 		}
+		m.recordError("diff tool", msg.Err)
+		return m, nil
+
+	case podCompareMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.recordError("compare", msg.err)
+			m.statusMsg = "Compare failed: " + k8s.ShortError(msg.err)
+			return m, nil
+		}
+		return m, m.showComparison(msg)
+
+	case daemonSetNodesMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.recordError("daemonset nodes", msg.err)
+			m.statusMsg = "Node breakdown failed: " + k8s.ShortError(msg.err)
+			return m, nil
+		}
+		m.resultViewer.Show(msg.name+" by node", k8s.FormatDaemonSetNodes(msg.rows), m.width-4, m.height-4)
+		return m, nil
+
+	case views.DebugPodRequest:
+		clientset := m.k8sClient.Clientset()
+		return m, func() tea.Msg {
+			container, err := k8s.AddDebugContainer(context.Background(), clientset, msg.Namespace, msg.PodName, msg.Image, msg.Target)
+			return views.DebugContainerMsg{Namespace: msg.Namespace, PodName: msg.PodName, Container: container, Err: err}
+		}
+
+	case views.DebugContainerMsg:
+		m.recordError("debug container", msg.Err)
+		if m.view == ViewDashboard {
+			var cmd tea.Cmd
+			m.dashboard, cmd = m.dashboard.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case views.ConfigDiffRequest:
+		return m, func() tea.Msg {
+			running, err := m.k8sClient.ReadMountedConfig(context.Background(), msg.Namespace, msg.PodName, msg.Drift)
+			return views.ConfigDiffMsg{Drift: msg.Drift, Running: running, Err: err}
+		}
+
+	case views.ConfigDiffMsg:
+		m.recordError("config diff", msg.Err)
+		if m.view == ViewDashboard {
+			var cmd tea.Cmd
+			m.dashboard, cmd = m.dashboard.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case views.RestartOwnerRequest:
+		return m, m.findRestartOwner(msg.Pod)
+
+	case restartOwnerMsg:
+		m.loading = false
+		w := msg.workload
+		if !k8s.HasRollout(w.Type) {
+			m.statusMsg = fmt.Sprintf("%s cannot be restarted", w.Type)
+			return m, nil
+		}
+		return m, m.confirmWithBlastRadius(w, pendingConfirm{
+			title:   "Restart " + string(w.Type),
+			message: "Are you sure you want to restart '" + w.Name + "'?",
+			command: components.RestartCommand(m.k8sClient.Kubectl(), w.Namespace, w.Name, string(w.Type)),
+			action:  "restart",
+			data:    w,
+		})
+
+	case cronJobRunsMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.recordError("cronjob runs", msg.err)
+			m.statusMsg = "Run history failed: " + k8s.ShortError(msg.err)
+			return m, nil
+		}
+		m.resultViewer.Show("Runs: "+msg.name, k8s.FormatCronJobRuns(msg.runs), m.width-4, m.height-4)
+		return m, nil
+
+	case views.ScheduleCheckRequest:
+		return m, m.simulateScheduling(msg.Namespace, msg.PodName)
+
+	case schedulingMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.recordError("scheduling", msg.err)
+			m.statusMsg = "Scheduling check failed: " + k8s.ShortError(msg.err)
+			return m, nil
+		}
+		m.resultViewer.Show("Scheduling: "+msg.podName, k8s.FormatScheduling(msg.fits), m.width-4, m.height-4)
+		return m, nil
+
+	case nodeDetailMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.recordError("node detail", msg.err)
+			m.statusMsg = "Node detail failed: " + k8s.ShortError(msg.err)
+			return m, nil
+		}
+		m.resultViewer.Show("Node: "+msg.detail.Name, k8s.FormatNodeDetail(msg.detail), m.width-4, m.height-4)
+		return m, nil
+
+	case components.ManifestYAMLRequest:
+		return m, m.fetchManifestYAML(msg)
+
+	case views.DescribePodRequest:
+		clientset := m.k8sClient.Clientset()
+		return m, func() tea.Msg {
+			content, err := k8s.DescribePod(context.Background(), clientset, msg.Namespace, msg.PodName)
+			return views.DescribeOutputMsg{Title: "Pod: " + msg.PodName, Content: content, Err: err}
+		}
+
+	case describeMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.recordError("describe", msg.err)
+			m.statusMsg = "Describe failed: " + k8s.ShortError(msg.err)
+			return m, nil
+		}
+		m.resultViewer.Show(msg.title, msg.content, m.width-4, m.height-4)
 		return m, nil
 
 	case views.DescribeOutputMsg:
@@ -254,6 +855,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case workloadActionMsg:
 		m.loading = false
 		if msg.err != nil {
+			m.recordError(msg.action, msg.err)
 			m.statusMsg = "Error: " + msg.err.Error()
 		} else {
 			switch msg.action {
@@ -261,21 +863,70 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.statusMsg = fmt.Sprintf("Scaled %s to %d replicas", msg.workloadName, msg.replicas)
 			case "restart":
 				m.statusMsg = fmt.Sprintf("Restart initiated for %s", msg.workloadName)
+			case "undo":
+				m.statusMsg = fmt.Sprintf("Rolling %s back to revision %d", msg.workloadName, msg.revision)
+			case "trigger":
+				m.statusMsg = fmt.Sprintf("Created job %s from %s", msg.job, msg.workloadName)
+			case "suspend":
+				m.statusMsg = fmt.Sprintf("Suspended %s", msg.workloadName)
+			case "resume":
+				m.statusMsg = fmt.Sprintf("Resumed %s", msg.workloadName)
+			}
+			// Follow the rollout the change started
+			var watch tea.Cmd
+			if msg.action == "scale" || msg.action == "restart" || msg.action == "undo" {
+				if watch = m.watchRollout(msg.namespace, msg.resourceType, msg.workloadName); watch != nil {
+					m.statusMsg += ", watching the rollout"
+				}
 			}
 			// Refresh workloads list
-			return m, m.loadWorkloads()
+			return m, tea.Batch(m.loadWorkloads(), watch)
 		}
 		return m, nil
 
 	case tickMsg:
 		if m.view == ViewDashboard && m.pod != nil {
+			// The cached copies are what the last refresh showed; a refresh
+			// needs the pod and its related resources as they are now
+			podKey := m.pod.Namespace + "/" + m.pod.Name
+			m.podCache.Remove(podKey)
+			m.relatedCache.Remove(podKey)
 			return m, tea.Batch(
 				m.loadDashboardData(m.pod),
 				m.tickCmd(),
 			)
 		}
+		if m.view == ViewWorkloadLogs {
+			return m, tea.Batch(m.syncWorkloadLogPods(), m.tickCmd())
+		}
+		if m.view == ViewWorkload && m.workload != nil {
+			return m, tea.Batch(m.loadWorkloadDetail(m.workload), m.tickCmd())
+		}
 		return m, m.tickCmd()
 
+	case watchTickMsg:
+		if len(m.config.WatchedItems) == 0 {
+			return m, m.watchTickCmd()
+		}
+		return m, tea.Batch(m.checkWatched(), m.watchTickCmd())
+
+	case watchCheckedMsg:
+		// Merged, so an item unwatched while the check ran is not brought
+		// back and one watched meanwhile keeps its state
+		for _, item := range m.config.WatchedItems {
+			if state, ok := msg.states[item]; ok {
+				m.watchStates[item] = state
+			}
+		}
+		m.recordError("watch", msg.err)
+		if len(msg.alerts) > 0 {
+			m.statusMsg = "Watch: " + msg.alerts[len(msg.alerts)-1]
+		}
+		if msg.bell {
+			return m, ringBell
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		// Confirm dialog takes highest priority
 		if m.confirmDialog.IsVisible() {
@@ -289,6 +940,51 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		if m.resultViewer.IsVisible() {
+			m.resultViewer, cmd = m.resultViewer.Update(msg)
+			return m, cmd
+		}
+
+		if m.portForwardPrompt.IsVisible() {
+			m.portForwardPrompt, cmd = m.portForwardPrompt.Update(msg)
+			return m, cmd
+		}
+
+		if m.portForwardPanel.IsVisible() {
+			m.portForwardPanel, cmd = m.portForwardPanel.Update(msg)
+			return m, cmd
+		}
+
+		if m.namespacePrompt.IsVisible() {
+			m.namespacePrompt, cmd = m.namespacePrompt.Update(msg)
+			return m, cmd
+		}
+
+		if m.savePathPrompt.IsVisible() {
+			m.savePathPrompt, cmd = m.savePathPrompt.Update(msg)
+			return m, cmd
+		}
+
+		if m.scalePrompt.IsVisible() {
+			m.scalePrompt, cmd = m.scalePrompt.Update(msg)
+			return m, cmd
+		}
+
+		if m.saveViewPrompt.IsVisible() {
+			m.saveViewPrompt, cmd = m.saveViewPrompt.Update(msg)
+			return m, cmd
+		}
+
+		if m.viewPalette.IsVisible() {
+			m.viewPalette, cmd = m.viewPalette.Update(msg)
+			return m, cmd
+		}
+
+		if m.bookmarkPalette.IsVisible() {
+			m.bookmarkPalette, cmd = m.bookmarkPalette.Update(msg)
+			return m, cmd
+		}
+
 		// Help overlay takes priority
 		if m.help.IsVisible() {
 			if msg.String() == "?" || msg.String() == "esc" {
@@ -315,6 +1011,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case "ctrl+c":
 				m.saveConfig()
+				m.forwards.StopAll()
 				return m, tea.Quit
 			default:
 				// Pass all other keys to navigator for search input
@@ -323,10 +1020,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Searching the merged workload logs takes every key but esc
+		if m.view == ViewWorkloadLogs && m.workloadLogs.IsSearching() {
+			m.workloadLogs, cmd = m.workloadLogs.Update(msg)
+			return m, cmd
+		}
+
 		// Normal key handling when not searching
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			m.saveConfig()
+			m.forwards.StopAll()
 			return m, tea.Quit
 
 		case key.Matches(msg, m.keys.Help):
@@ -336,6 +1040,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Refresh):
 			return m, m.refresh()
 
+		case key.Matches(msg, m.keys.Errors):
+			if m.view == ViewDashboard && (m.dashboard.IsLogsSearching() || m.dashboard.HasActiveOverlay()) {
+				break
+			}
+			m.showErrors()
+			return m, nil
+
+		case key.Matches(msg, m.keys.PortForwards):
+			if m.view == ViewDashboard && (m.dashboard.IsLogsSearching() || m.dashboard.HasActiveOverlay()) {
+				break
+			}
+			m.refreshPortForwards()
+			m.portForwardPanel.Show()
+			return m, nil
+
 		case key.Matches(msg, m.keys.Namespace):
 			if m.view == ViewNavigator {
 				m.navigator.SetMode(components.ModeNamespace)
@@ -367,21 +1086,104 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.navigator.SetMode(components.ModeResourceType)
 					return m, nil
 				}
+				if m.navigator.Mode() == components.ModeNamespace {
+					if key.Matches(msg, m.keys.CreateNamespace) {
+						m.namespacePrompt.Show()
+						return m, nil
+					}
+					if key.Matches(msg, m.keys.NamespaceActions) {
+						m.openNamespaceMenu()
+						return m, nil
+					}
+					if key.Matches(msg, m.keys.DeleteNamespace) {
+						if ns := m.navigator.SelectedNamespace(); ns != "" {
+							m.statusMsg = "Checking " + ns + " is empty..."
+							return m, m.checkNamespaceEmpty(ns)
+						}
+						return m, nil
+					}
+				}
+				if key.Matches(msg, m.keys.Context) {
+					contexts, _, err := m.k8sClient.ListContexts()
+					if err != nil {
+						m.recordError("contexts", err)
+						m.statusMsg = "Cannot read kubeconfig: " + k8s.ShortError(err)
+						return m, nil
+					}
+					m.navigator.SetMode(components.ModeContext)
+					m.navigator.SetContexts(contexts, m.k8sClient.Context())
+					return m, nil
+				}
 				// Scale action (only for scalable resource types)
 				if key.Matches(msg, m.keys.Scale) && m.navigator.Mode() == components.ModeWorkloads {
-					workload := m.navigator.SelectedWorkload()
-					if workload != nil {
-						rt := m.navigator.ResourceType()
-						if rt == k8s.ResourceDeployments || rt == k8s.ResourceStatefulSets {
-							items := components.ScaleActions(
-								m.k8sClient.Namespace(),
-								workload.Name,
-								string(rt),
-								workload.Replicas,
-							)
-							m.workloadActionMenu.Show("Scale "+workload.Name, items)
-							return m, nil
+					return m, m.openScaleMenu()
+				}
+				// Watch toggle for the selected workload or pod
+				if key.Matches(msg, m.keys.Watch) {
+					switch m.navigator.Mode() {
+					case components.ModeWorkloads:
+						if workload := m.navigator.SelectedWorkload(); workload != nil {
+							m.toggleWatch(k8s.WatchKey(workload.Namespace, workload.Type, workload.Name))
+						}
+						return m, nil
+					case components.ModePods:
+						if pod := m.navigator.SelectedPod(); pod != nil {
+							m.toggleWatch(k8s.WatchKey(pod.Namespace, k8s.ResourcePods, pod.Name))
 						}
+						return m, nil
+					}
+				}
+				if key.Matches(msg, m.keys.Nodes) {
+					if cmd := m.loadDaemonSetNodes(); cmd != nil {
+						return m, cmd
+					}
+				}
+				if key.Matches(msg, m.keys.PortForward) {
+					if cmd, ok := m.promptPortForward(); ok {
+						return m, cmd
+					}
+				}
+				if key.Matches(msg, m.keys.SaveView) {
+					m.openSaveView()
+					return m, nil
+				}
+				if key.Matches(msg, m.keys.Views) {
+					m.openViewPalette()
+					return m, nil
+				}
+				if key.Matches(msg, m.keys.Bookmarks) {
+					m.openBookmarkPalette()
+					return m, nil
+				}
+				if key.Matches(msg, m.keys.WorkloadActions) {
+					m.openWorkloadMenu()
+					return m, nil
+				}
+				if key.Matches(msg, m.keys.JumpToProblem) {
+					return m, m.jumpToProblem()
+				}
+				if key.Matches(msg, m.keys.RolloutHistory) {
+					if cmd := m.loadRolloutHistory(); cmd != nil {
+						return m, cmd
+					}
+				}
+				if key.Matches(msg, m.keys.Describe) {
+					if cmd := m.describeSelected(); cmd != nil {
+						return m, cmd
+					}
+				}
+				if key.Matches(msg, m.keys.WorkloadLogs) {
+					if w := m.selectedLogsWorkload(); w != nil {
+						return m, m.openWorkloadLogs(w)
+					}
+				}
+				if m.navigator.Mode() == components.ModePods {
+					if key.Matches(msg, m.keys.Mark) {
+						m.navigator.ToggleMark()
+						return m, nil
+					}
+					if key.Matches(msg, m.keys.Compare) {
+						return m, m.comparePods()
 					}
 				}
 				// Restart action
@@ -390,13 +1192,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if workload != nil {
 						rt := m.navigator.ResourceType()
 						if rt == k8s.ResourceDeployments || rt == k8s.ResourceStatefulSets || rt == k8s.ResourceDaemonSets {
-							m.confirmDialog.Show(
-								"Restart "+string(rt),
-								"Are you sure you want to restart '"+workload.Name+"'?",
-								"restart",
-								workload,
-							)
-							return m, nil
+							return m, m.confirmWithBlastRadius(workload, pendingConfirm{
+								title:   "Restart " + string(rt),
+								message: "Are you sure you want to restart '" + workload.Name + "'?",
+								command: components.RestartCommand(m.k8sClient.Kubectl(), m.k8sClient.Namespace(), workload.Name, string(rt)),
+								action:  "restart",
+								data:    workload,
+							})
 						}
 					}
 				}
@@ -405,7 +1207,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.navigator, cmd = m.navigator.Update(msg)
 		cmds = append(cmds, cmd)
 
+	case ViewWorkloadLogs:
+		m.workloadLogs, cmd = m.workloadLogs.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case ViewWorkload:
+		if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.keys.Watch) && m.workload != nil {
+			m.toggleWatch(k8s.WatchKey(m.workload.Namespace, m.workload.Type, m.workload.Name))
+			return m, nil
+		}
+
 	case ViewDashboard:
+		if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.keys.Watch) &&
+			m.pod != nil && !m.dashboard.IsLogsSearching() && !m.dashboard.HasActiveOverlay() {
+			m.toggleWatch(k8s.WatchKey(m.pod.Namespace, k8s.ResourcePods, m.pod.Name))
+			return m, nil
+		}
 		m.dashboard, cmd = m.dashboard.Update(msg)
 		cmds = append(cmds, cmd)
 
@@ -413,12 +1230,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.pod != nil {
 			currentShowPrevious := m.dashboard.LogsShowPrevious()
 			currentContainer := m.dashboard.LogsSelectedContainer()
+			currentUseExternal := m.dashboard.LogsUseExternalSource()
+			currentTimeRange := m.dashboard.LogsTimeRange()
+
+			// The external backend is queried server-side, so a wider time range needs a refetch
+			timeRangeChanged := currentUseExternal && currentTimeRange != m.lastTimeRange
 
-			if currentShowPrevious != m.lastShowPrevious || currentContainer != m.lastLogContainer {
+			currentFollowing := m.dashboard.LogsFollowing()
+
+			if currentShowPrevious != m.lastShowPrevious || currentContainer != m.lastLogContainer ||
+				currentUseExternal != m.lastUseExternal || timeRangeChanged || (currentFollowing && !m.lastFollowing) {
 				m.lastShowPrevious = currentShowPrevious
 				m.lastLogContainer = currentContainer
+				m.lastUseExternal = currentUseExternal
+				m.lastTimeRange = currentTimeRange
+				// The refetch restarts the stream for the new selection
+				m.stopLogStream()
 				cmds = append(cmds, m.loadLogsForState(m.pod, currentContainer, currentShowPrevious))
+			} else if !currentFollowing && m.lastFollowing {
+				// Not following means the view holds still; polling takes over
+				m.stopLogStream()
 			}
+			m.lastFollowing = currentFollowing
 		}
 	}
 
@@ -426,13 +1259,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
-	if m.err != nil {
-		return styles.StatusError.Render("Error: " + m.err.Error())
-	}
-
 	if m.loading {
 		// Center loading spinner
 		loadingMsg := m.spinner.View() + " Loading..."
+		if k8s.Retrying() {
+			loadingMsg = m.spinner.View() + " Loading... (retrying)"
+		}
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, loadingMsg)
 	}
 
@@ -440,11 +1272,19 @@ func (m Model) View() string {
 	m.statusBar.SetContext(m.k8sClient.Context())
 	m.statusBar.SetNamespace(m.k8sClient.Namespace())
 	m.statusBar.SetResource(string(m.navigator.ResourceType()))
+	m.statusBar.SetRetrying(k8s.Retrying())
+	m.statusBar.SetErrorCount(m.unseenErrors)
 	footerLine := m.statusBar.View()
+	if m.bell {
+		footerLine = "\a" + footerLine
+	}
 	if m.statusMsg != "" {
 		statusStyle := lipgloss.NewStyle().Foreground(styles.Success).Bold(true)
 		footerLine = footerLine + "  " + statusStyle.Render(m.statusMsg)
 	}
+	if toast := m.rolloutToastView(); toast != "" {
+		footerLine = footerLine + "  " + toast
+	}
 	footer := footerLine + "\n" + m.help.ShortHelp() + "  " + styles.Credit()
 	footerHeight := 2
 
@@ -457,6 +1297,10 @@ func (m Model) View() string {
 		content = m.navigator.View()
 	case ViewDashboard:
 		content = m.dashboard.View()
+	case ViewWorkloadLogs:
+		content = m.workloadLogsView()
+	case ViewWorkload:
+		content = m.workloadView.View()
 	}
 
 	// Render confirm dialog as overlay (highest priority)
@@ -472,6 +1316,32 @@ func (m Model) View() string {
 		)
 	}
 
+	if m.resultViewer.IsVisible() {
+		return lipgloss.Place(
+			m.width,
+			m.height,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.resultViewer.View(),
+			lipgloss.WithWhitespaceChars(" "),
+			lipgloss.WithWhitespaceForeground(styles.Background),
+		)
+	}
+
+	for _, overlay := range []string{m.portForwardPrompt.View(), m.portForwardPanel.View(), m.namespacePrompt.View(), m.savePathPrompt.View(), m.scalePrompt.View(), m.saveViewPrompt.View(), m.viewPalette.View(), m.bookmarkPalette.View()} {
+		if overlay != "" {
+			return lipgloss.Place(
+				m.width,
+				m.height,
+				lipgloss.Center,
+				lipgloss.Center,
+				overlay,
+				lipgloss.WithWhitespaceChars(" "),
+				lipgloss.WithWhitespaceForeground(styles.Background),
+			)
+		}
+	}
+
 	// Render workload action menu as overlay
 	if m.workloadActionMenu.IsVisible() {
 		return lipgloss.Place(
@@ -508,7 +1378,15 @@ func (m Model) View() string {
 
 func (m *Model) handleBack() (tea.Model, tea.Cmd) {
 	switch m.view {
+	case ViewWorkloadLogs:
+		m.cancelLoads()
+		m.view = ViewNavigator
+		m.workloadLogsTarget = nil
+		return m, m.startListWatch()
+
 	case ViewDashboard:
+		m.cancelLoads()
+		m.loading = false
 		m.view = ViewNavigator
 		m.pod = nil
 		if m.workload != nil {
@@ -516,18 +1394,31 @@ func (m *Model) handleBack() (tea.Model, tea.Cmd) {
 		} else {
 			m.navigator.SetMode(components.ModeWorkloads)
 		}
-		return m, nil
+		// The list watch stopped when the dashboard opened
+		return m, m.startListWatch()
+
+	case ViewWorkload:
+		m.cancelLoads()
+		m.loading = false
+		m.view = ViewNavigator
+		m.workload = nil
+		return m, m.loadWorkloads()
 
 	case ViewNavigator:
 		switch m.navigator.Mode() {
 		case components.ModePods:
+			if m.workload != nil && k8s.HasWorkloadDetail(m.workload.Type) {
+				m.navigator.SetMode(components.ModeWorkloads)
+				return m, m.openWorkloadDashboard(m.workload)
+			}
+			m.cancelLoads()
 			m.navigator.SetMode(components.ModeWorkloads)
 			m.workload = nil
 			return m, m.loadWorkloads()
 		case components.ModeNamespace:
 			m.navigator.SetMode(components.ModeWorkloads)
 			return m, nil
-		case components.ModeResourceType:
+		case components.ModeResourceType, components.ModeContext:
 			m.navigator.SetMode(components.ModeWorkloads)
 			return m, nil
 		}
@@ -537,40 +1428,41 @@ func (m *Model) handleBack() (tea.Model, tea.Cmd) {
 
 func (m *Model) handleEnter() (tea.Model, tea.Cmd) {
 	switch m.view {
+	case ViewWorkload:
+		if m.workload != nil {
+			m.cancelLoads()
+			m.view = ViewNavigator
+			m.loading = true
+			return m, m.loadPods(m.workload)
+		}
+
 	case ViewNavigator:
 		switch m.navigator.Mode() {
 		case components.ModeWorkloads:
 			workload := m.navigator.SelectedWorkload()
+			if workload != nil && workload.Type == k8s.ResourceNodes {
+				// Nodes have no pod list of their own; show the detail panel
+				return m, m.loadNodeDetail(workload.Name)
+			}
+			if workload != nil && k8s.HasWorkloadDetail(workload.Type) {
+				return m, m.openWorkloadDashboard(workload)
+			}
 			if workload != nil {
+				m.cancelLoads()
 				m.workload = workload
 				m.loading = true
 				return m, m.loadPods(workload)
 			}
 
 		case components.ModePods:
-			pod := m.navigator.SelectedPod()
-			if pod != nil {
-				m.pod = pod
-				m.view = ViewDashboard
-				m.dashboard.SetPod(pod)
-				m.dashboard.SetBreadcrumb(
-					m.k8sClient.Namespace(),
-					string(m.navigator.ResourceType()),
-					m.workload.Name,
-					pod.Name,
-				)
-				m.dashboard.SetContext(m.k8sClient.Context())
-				m.dashboard.SetNamespace(m.k8sClient.Namespace())
-				m.loading = true
-				return m, tea.Batch(
-					m.loadDashboardData(pod),
-					m.tickCmd(),
-				)
+			if pod := m.navigator.SelectedPod(); pod != nil {
+				return m, m.openPod(pod)
 			}
 
 		case components.ModeNamespace:
 			ns := m.navigator.SelectedNamespace()
 			if ns != "" {
+				m.cancelLoads()
 				m.k8sClient.SetNamespace(ns)
 				m.config.SetLastNamespace(ns)
 				m.navigator.SetMode(components.ModeWorkloads)
@@ -580,17 +1472,88 @@ func (m *Model) handleEnter() (tea.Model, tea.Cmd) {
 
 		case components.ModeResourceType:
 			rt := m.navigator.SelectedResourceType()
+			m.cancelLoads()
 			m.navigator.SetResourceType(rt)
 			m.config.SetLastResourceType(string(rt))
 			m.navigator.SetMode(components.ModeWorkloads)
 			m.loading = true
 			return m, m.loadWorkloads()
+
+		case components.ModeContext:
+			name := m.navigator.SelectedContext()
+			if name == "" || name == m.k8sClient.Context() {
+				m.navigator.SetMode(components.ModeWorkloads)
+				return m, nil
+			}
+			return m, m.switchContext(name)
 		}
 	}
 	return m, nil
 }
 
+// openPod shows the dashboard for one of m.workload's pods
+func (m *Model) openPod(pod *k8s.PodInfo) tea.Cmd {
+	m.cancelLoads()
+	// The list was just fetched, so it is at least as fresh as the cache
+	m.podCache.Put(pod.Namespace+"/"+pod.Name, pod)
+	m.pod = pod
+	m.view = ViewDashboard
+	m.dashboard.SetPod(pod)
+	m.refreshRecentCommands()
+	m.dashboard.SetBreadcrumb(
+		m.k8sClient.Namespace(),
+		string(m.workload.Type),
+		m.workload.Name,
+		pod.Name,
+	)
+	m.dashboard.SetKubectl(m.k8sClient.Kubectl())
+	m.dashboard.SetNamespace(m.k8sClient.Namespace())
+	m.lastFollowing = m.dashboard.LogsFollowing()
+	m.loading = true
+	return tea.Batch(
+		m.loadDashboardData(pod),
+		m.tickCmd(),
+	)
+}
+
+// switchContext moves the whole app to another cluster: everything cached or
+// in flight belongs to the old one
+func (m *Model) switchContext(name string) tea.Cmd {
+	if err := m.k8sClient.SwitchContext(name); err != nil {
+		m.recordError("context", err)
+		m.statusMsg = "Switch failed: " + k8s.ShortError(err)
+		return nil
+	}
+
+	m.cancelLoads()
+	m.podCache.Purge()
+	m.relatedCache.Purge()
+	m.listCache.Purge()
+	m.workload = nil
+	m.navigator.ClearMarks()
+	m.navigator.SetNamespaces(nil)
+	m.navigator.SetMode(components.ModeWorkloads)
+	m.statusMsg = "Switched to " + name
+	m.loading = true
+	return tea.Batch(m.loadWorkloads(), m.loadNamespaces())
+}
+
+// cancelLoads aborts loads started by the previous view and starts a fresh
+// context for the next one
+func (m *Model) cancelLoads() {
+	m.cancelLoad()
+	m.loadCtx, m.cancelLoad = context.WithCancel(context.Background())
+	m.listWatchSeq++ // the list watch was derived from the old context
+	m.stopLogStream()
+	m.stopEventWatch()
+}
+
 func (m *Model) refresh() tea.Cmd {
+	m.podCache.Purge()
+	m.relatedCache.Purge()
+	m.listCache.Purge()
+	m.k8sClient.InvalidateNamespaces()
+
 	switch m.view {
 	case ViewNavigator:
 		m.loading = true
@@ -600,120 +1563,398 @@ func (m *Model) refresh() tea.Cmd {
 			m.loading = true
 			return m.loadDashboardData(m.pod)
 		}
+	case ViewWorkloadLogs:
+		return m.syncWorkloadLogPods()
+	case ViewWorkload:
+		if m.workload != nil {
+			return m.loadWorkloadDetail(m.workload)
+		}
 	}
 	return nil
 }
 
+// loadInitialData fetches the first workload list. It runs alongside
+// loadNamespaces so the navigator fills in as soon as either answers.
 func (m *Model) loadInitialData() tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		namespaces, err := m.k8sClient.ListNamespaces(ctx)
-		if err != nil {
-			return loadedMsg{err: err}
-		}
+	parent := m.loadCtx
+	rt := m.navigator.ResourceType()
 
-		rt := k8s.ResourceType(m.config.LastResourceType)
-		if rt == "" {
-			rt = k8s.ResourceDeployments
-		}
-		m.navigator.SetResourceType(rt)
+	return func() tea.Msg {
+		ctx, span := telemetry.StartRefresh(parent, "initial")
+		defer span.End()
 
 		workloads, err := k8s.ListWorkloads(ctx, m.k8sClient.Clientset(), m.k8sClient.Namespace(), rt)
-		if err != nil {
-			return loadedMsg{err: err}
+		if ctx.Err() != nil {
+			return nil
 		}
 
 		return loadedMsg{
-			workloads:  workloads,
-			namespaces: namespaces,
+			resourceType: rt,
+			workloads:    workloads,
+			err:          err,
 		}
 	}
 }
 
+func (m *Model) loadNamespaces() tea.Cmd {
+	return func() tea.Msg {
+		namespaces, err := m.k8sClient.ListNamespaces(context.Background())
+		return namespacesLoadedMsg{namespaces: namespaces, err: err}
+	}
+}
+
 func (m *Model) loadWorkloads() tea.Cmd {
+	parent := m.loadCtx
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx, span := telemetry.StartRefresh(parent, "workloads")
+		defer span.End()
 		workloads, err := k8s.ListWorkloads(ctx, m.k8sClient.Clientset(), m.k8sClient.Namespace(), m.navigator.ResourceType())
-		if err != nil {
+		if ctx.Err() != nil {
+			return nil // the view changed; drop the stale result
+		}
+		if err != nil && !k8s.IsUnavailable(err) {
 			return loadedMsg{err: err}
 		}
 
 		namespaces, _ := m.k8sClient.ListNamespaces(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
 
 		return loadedMsg{
 			workloads:  workloads,
 			namespaces: namespaces,
+			err:        err,
 		}
 	}
 }
 
 func (m *Model) loadPods(workload *k8s.WorkloadInfo) tea.Cmd {
+	parent := m.loadCtx
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx, span := telemetry.StartRefresh(parent, "pods")
+		defer span.End()
 		pods, err := k8s.GetWorkloadPods(ctx, m.k8sClient.Clientset(), *workload)
+		if ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
 			return podsLoadedMsg{err: err}
 		}
-		return podsLoadedMsg{pods: pods}
+		msg := podsLoadedMsg{pods: pods}
+		// Without them the pods still list; events are often forbidden
+		msg.events, _ = k8s.GetWorkloadEvents(ctx, m.k8sClient.Clientset(), *workload, pods)
+		if workload.Type == k8s.ResourceStatefulSets {
+			// Without it the pods still list, just not by ordinal
+			msg.statefulSet, _ = k8s.GetStatefulSetStatus(ctx, m.k8sClient.Clientset(), workload.Namespace, workload.Name, pods)
+		}
+		return msg
 	}
 }
 
+// loadDashboardData fetches every dashboard section concurrently and delivers
+// each one as it completes, so a slow or forbidden call only affects its panel
 func (m *Model) loadDashboardData(pod *k8s.PodInfo) tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
+	container := m.dashboard.LogsSelectedContainer()
+	previous := m.dashboard.LogsShowPrevious()
+	external := m.dashboard.LogsUseExternalSource()
+	since := m.dashboard.LogsTimeRange()
+	streaming := m.logStreaming
+	workload := m.workload
+	clientset := m.k8sClient.Clientset()
+	podKey := pod.Namespace + "/" + pod.Name
+	parent := m.loadCtx
+	// The helpers and drift sections read the same ConfigMaps and Secrets
+	configObjects := k8s.NewConfigObjects(clientset, pod.Namespace)
+	// The node section passes the node's condition hints on to the helpers,
+	// saving the helpers a second read of the node
+	nodeHelpers := make(chan []k8s.DebugHelper, 1)
+
+	// Buffered for every section and the final "done" so producers never
+	// block on a stale load
+	ch := make(chan dashboardSectionMsg, len(dashboardSections))
+	send := func(msg dashboardSectionMsg) {
+		if parent.Err() != nil {
+			return // cancelled sections would only clobber the next view
+		}
+		msg.podKey = podKey
+		ch <- msg
+	}
 
-		logs, _ := k8s.GetAllContainerLogs(ctx, m.k8sClient.Clientset(), pod.Namespace, pod.Name, 200)
-		events, _ := k8s.GetPodEvents(ctx, m.k8sClient.Clientset(), pod.Namespace, pod.Name)
-		metrics, _ := k8s.GetPodMetrics(ctx, m.k8sClient.MetricsClient(), pod.Namespace, pod.Name)
-		related, _ := k8s.GetRelatedResources(ctx, m.k8sClient.Clientset(), *pod)
+	go func() {
+		ctx, span := telemetry.StartRefresh(parent, "dashboard")
+		defer span.End()
 
-		helpers := k8s.AnalyzePodIssues(pod, events)
+		// A plain group: one failing section must not cancel the others
+		var g errgroup.Group
 
-		return dashboardDataMsg{
-			logs:    logs,
-			events:  events,
-			metrics: metrics,
-			related: related,
-			helpers: helpers,
+		// A live log stream already delivers new lines
+		if !streaming {
+			g.Go(func() error {
+				logs, err := m.fetchLogs(ctx, pod, container, previous, external, since)
+				send(dashboardSectionMsg{section: "logs", logs: logs, err: err})
+				return err
+			})
 		}
-	}
+
+		g.Go(func() error {
+			events, err := k8s.GetPodAndOwnerEvents(ctx, clientset, pod.Object)
+			send(dashboardSectionMsg{section: "events", events: events, err: err})
+
+			helpers := k8s.AnalyzePodIssues(pod, events)
+			helpers = append(helpers, m.imagePullHelpers(ctx, pod)...)
+			helpers = append(helpers, k8s.GetConfigRefHelpers(ctx, configObjects, pod)...)
+			helpers = append(helpers, <-nodeHelpers...) // none when the node cannot be read
+			helpers = append(helpers, k8s.GetPreemptionHelpers(ctx, clientset, pod.Object, events)...)
+			helpers = append(helpers, k8s.GetPolicyHelpers(ctx, clientset, pod)...)
+			if workload != nil {
+				if siblings, err := k8s.GetWorkloadPods(ctx, clientset, *workload); err == nil {
+					helpers = append(helpers, k8s.AnalyzeDigestDrift(siblings)...)
+				}
+				helpers = append(helpers, k8s.GetHPAHelpers(ctx, clientset, *workload)...)
+			}
+			send(dashboardSectionMsg{section: "helpers", helpers: helpers, lint: k8s.LintPod(pod.Object, workload)})
+			return err
+		})
+
+		g.Go(func() error {
+			metrics, err := k8s.GetPodMetrics(ctx, m.k8sClient.MetricsClient(), pod.Namespace, pod.Name)
+			if errors.Is(err, k8s.ErrMetricsUnavailable) {
+				err = nil // shown as "metrics-server not available", not as a failure
+			}
+			send(dashboardSectionMsg{section: "metrics", metrics: metrics, err: err})
+			return err
+		})
+
+		g.Go(func() error {
+			fresh, ok := m.podCache.Get(podKey)
+			if !ok {
+				var err error
+				if fresh, err = k8s.GetPod(ctx, clientset, pod.Namespace, pod.Name); err != nil {
+					send(dashboardSectionMsg{section: "pod", err: err})
+					return err
+				}
+				m.podCache.Put(podKey, fresh)
+			}
+			send(dashboardSectionMsg{section: "pod", pod: fresh})
+			return nil
+		})
+
+		g.Go(func() error {
+			related, ok := m.relatedCache.Get(podKey)
+			if !ok {
+				var err error
+				if related, err = k8s.GetRelatedResources(ctx, clientset, m.listCache, *pod); err != nil {
+					send(dashboardSectionMsg{section: "related", err: err})
+					return err
+				}
+				m.relatedCache.Put(podKey, related)
+			}
+			send(dashboardSectionMsg{section: "related", related: related})
+			return nil
+		})
+
+		g.Go(func() error {
+			volumes := k8s.DescribeVolumes(pod.Object)
+			k8s.CheckVolumeSources(ctx, clientset, pod.Namespace, volumes)
+			send(dashboardSectionMsg{section: "volumes", volumes: volumes})
+			return nil
+		})
+
+		g.Go(func() error {
+			drift, err := k8s.FindConfigDrift(ctx, configObjects, pod.Object)
+			send(dashboardSectionMsg{section: "drift", drift: drift, err: err})
+			return err
+		})
+
+		g.Go(func() error {
+			if pod.Node == "" {
+				nodeHelpers <- nil
+				send(dashboardSectionMsg{section: "node"})
+				return nil
+			}
+			node, err := k8s.GetNodeSummary(ctx, clientset, pod.Node, pod.Object)
+			if err != nil {
+				nodeHelpers <- nil
+			} else {
+				nodeHelpers <- node.Helpers
+			}
+			send(dashboardSectionMsg{section: "node", node: node, err: err})
+			return err
+		})
+
+		g.Go(func() error {
+			// The workload the pod was opened from saves looking up its owner
+			var deployment string
+			if workload != nil && workload.Type == k8s.ResourceDeployments {
+				deployment = workload.Name
+			} else {
+				deployment = k8s.OwnerDeployment(ctx, clientset, pod)
+			}
+			if deployment == "" {
+				send(dashboardSectionMsg{section: "rollout"})
+				return nil
+			}
+			revisions, err := k8s.GetRolloutHistory(ctx, clientset, pod.Namespace, deployment)
+			send(dashboardSectionMsg{section: "rollout", rollout: revisions, err: err})
+			return err
+		})
+
+		if m.vulnReports != nil {
+			g.Go(func() error {
+				vulns, err := m.podVulnerabilities(ctx, pod)
+				send(dashboardSectionMsg{section: "vulnerabilities", vulns: vulns, err: err})
+				return err
+			})
+		}
+
+		if err := g.Wait(); err != nil {
+			span.SetError(err)
+		}
+		send(dashboardSectionMsg{section: "done"})
+		close(ch)
+	}()
+
+	return waitForSection(ch)
 }
 
-func (m *Model) loadLogsForState(pod *k8s.PodInfo, container string, previous bool) tea.Cmd {
+// waitForSection delivers the next dashboard section, or nothing once all have arrived
+func waitForSection(ch <-chan dashboardSectionMsg) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		var logs []k8s.LogLine
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		msg.ch = ch
+		return msg
+	}
+}
+
+// applyDashboardSection updates the panel for one section. On error the
+// panel keeps its previous data and shows the error in its header.
+func (m *Model) applyDashboardSection(msg dashboardSectionMsg) {
+	if msg.section != "helpers" {
+		m.dashboard.SetSectionError(msg.section, msg.err)
+	}
+	if msg.err != nil {
+		m.recordError(msg.section, msg.err)
+		return
+	}
+
+	switch msg.section {
+	case "pod":
+		m.pod = msg.pod
+		m.dashboard.RefreshPod(msg.pod)
+	case "logs":
+		m.dashboard.RefreshLogs(msg.logs)
+	case "events":
+		m.dashboard.SetEvents(msg.events)
+	case "metrics":
+		m.dashboard.SetMetrics(msg.metrics)
+	case "related":
+		m.dashboard.SetRelated(msg.related)
+	case "volumes":
+		m.dashboard.SetVolumes(msg.volumes)
+	case "drift":
+		m.dashboard.SetConfigDrift(msg.drift)
+	case "helpers":
+		m.dashboard.SetHelpers(msg.helpers)
+		m.dashboard.SetLint(msg.lint)
+	case "node":
+		m.dashboard.SetNode(msg.node)
+	case "rollout":
+		m.dashboard.SetRolloutHistory(msg.rollout)
+	case "vulnerabilities":
+		m.dashboard.SetVulnerabilities(msg.vulns)
+	}
+}
+
+// podVulnerabilities matches the pod's images against the namespace's
+// trivy-operator reports, listed at most once per vulnReportTTL
+func (m *Model) podVulnerabilities(ctx context.Context, pod *k8s.PodInfo) ([]k8s.ImageVulnerabilities, error) {
+	reports, ok := m.vulnReports.Get(pod.Namespace)
+	if !ok {
 		var err error
+		if reports, err = k8s.GetVulnerabilityReports(ctx, m.k8sClient.Clientset(), pod.Namespace); err != nil {
+			return nil, err
+		}
+		m.vulnReports.Put(pod.Namespace, reports)
+	}
+	return k8s.MatchVulnerabilities(pod, reports), nil
+}
 
-		if previous {
-			// Get previous logs for specific container or first container
-			targetContainer := container
-			if targetContainer == "" && len(pod.Containers) > 0 {
-				targetContainer = pod.Containers[0].Name
-			}
-			if targetContainer != "" {
-				logs, err = k8s.GetPreviousLogs(ctx, m.k8sClient.Clientset(), pod.Namespace, pod.Name, targetContainer, 200)
+// imagePullHelpers asks the registry about images that failed to pull, and
+// checks the pod's imagePullSecrets cover their registries
+func (m *Model) imagePullHelpers(ctx context.Context, pod *k8s.PodInfo) []k8s.DebugHelper {
+	var helpers []k8s.DebugHelper
+	var secrets []k8s.PullSecret
+	secretsRead := false
+	for _, c := range pod.Containers {
+		switch c.Reason {
+		case "ErrImagePull", "ImagePullBackOff":
+			if m.registryClient != nil {
+				helpers = append(helpers, registry.Helper(c.Name, m.registryClient.Lookup(ctx, c.Image)))
 			}
-		} else if container != "" {
-			// Get logs for specific container
-			opts := k8s.LogOptions{
-				Container:  container,
-				TailLines:  200,
-				Timestamps: true,
+			if !secretsRead {
+				secrets, secretsRead = k8s.GetPullSecrets(ctx, m.k8sClient.Clientset(), pod), true
 			}
-			logs, err = k8s.GetPodLogs(ctx, m.k8sClient.Clientset(), pod.Namespace, pod.Name, opts)
-		} else {
-			// Get all container logs
-			logs, err = k8s.GetAllContainerLogs(ctx, m.k8sClient.Clientset(), pod.Namespace, pod.Name, 200)
+			helpers = append(helpers, registry.PullSecretHelper(c.Name, c.Image, secrets))
 		}
+	}
+	return helpers
+}
 
-		if err != nil {
-			return logsUpdatedMsg{logs: []k8s.LogLine{{Content: "Error fetching logs: " + err.Error(), IsError: true}}}
+func (m *Model) loadLogsForState(pod *k8s.PodInfo, container string, previous bool) tea.Cmd {
+	external := m.dashboard.LogsUseExternalSource()
+	since := m.dashboard.LogsTimeRange()
+	parent := m.loadCtx
+
+	return func() tea.Msg {
+		ctx, span := telemetry.StartRefresh(parent, "logs")
+		defer span.End()
+
+		logs, err := m.fetchLogs(ctx, pod, container, previous, external, since)
+		if ctx.Err() != nil {
+			return nil
+		}
+		return logsUpdatedMsg{logs: logs, err: err}
+	}
+}
+
+// fetchLogs reads pod logs from the external backend when selected, otherwise from the kubelet
+func (m *Model) fetchLogs(ctx context.Context, pod *k8s.PodInfo, container string, previous, external bool, since time.Duration) ([]k8s.LogLine, error) {
+	if external && m.logBackend != nil {
+		// The backend keeps logs across restarts, so "previous" is just a wider range
+		return m.logBackend.Logs(ctx, logbackend.Query{
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Container: container,
+			Since:     since,
+			Limit:     m.config.LogLineLimit,
+		})
+	}
+
+	if previous {
+		// With all containers selected, the one crashing most
+		targetContainer := k8s.PreviousLogsContainer(pod, container)
+		if targetContainer == "" {
+			return nil, nil
 		}
+		return k8s.GetPreviousLogs(ctx, m.k8sClient.Clientset(), pod.Namespace, pod.Name, targetContainer, 200)
+	}
 
-		return logsUpdatedMsg{logs: logs}
+	if container != "" {
+		// Get logs for specific container
+		opts := k8s.LogOptions{
+			Container:  container,
+			TailLines:  200,
+			Timestamps: true,
+		}
+		return k8s.GetPodLogs(ctx, m.k8sClient.Clientset(), pod.Namespace, pod.Name, opts)
 	}
+
+	// Get all container logs
+	return k8s.GetAllContainerLogs(ctx, m.k8sClient.Clientset(), pod.Namespace, pod.Name, 200)
 }
 
 func (m *Model) tickCmd() tea.Cmd {
@@ -722,17 +1963,105 @@ func (m *Model) tickCmd() tea.Cmd {
 	})
 }
 
+func (m *Model) watchTickCmd() tea.Cmd {
+	return tea.Tick(time.Duration(m.config.RefreshInterval)*time.Second, func(t time.Time) tea.Msg {
+		return watchTickMsg(t)
+	})
+}
+
+func (m *Model) toggleWatch(key string) {
+	if m.config.ToggleWatch(key) {
+		m.statusMsg = "Watching " + key
+	} else {
+		m.statusMsg = "Stopped watching " + key
+		delete(m.watchStates, key)
+	}
+	m.navigator.SetWatched(m.config.WatchedItems)
+}
+
+// checkWatched polls every watched item and posts alerts to the configured
+// webhook. Items that start failing or recover also ring the bell or show a
+// desktop notification, as watch_notify asks.
+func (m *Model) checkWatched() tea.Cmd {
+	items := append([]string(nil), m.config.WatchedItems...)
+	prevStates := make(map[string]k8s.WatchState, len(m.watchStates))
+	for k, v := range m.watchStates {
+		prevStates[k] = v
+	}
+	webhookURL := m.config.WebhookURL
+	watchNotify := m.config.WatchNotify
+
+	return func() tea.Msg {
+		ctx, span := telemetry.StartRefresh(context.Background(), "watch")
+		defer span.End()
+		states := make(map[string]k8s.WatchState, len(items))
+		var alerts, transitions []string
+
+		for _, item := range items {
+			state, itemAlerts, err := k8s.CheckWatched(ctx, m.k8sClient.Clientset(), item, prevStates[item])
+			if err != nil {
+				states[item] = prevStates[item]
+				continue
+			}
+			states[item] = state
+			alerts = append(alerts, itemAlerts...)
+			if state.Changed(prevStates[item]) && len(itemAlerts) > 0 {
+				transitions = append(transitions, itemAlerts[0])
+			}
+		}
+
+		var err error
+		if webhookURL != "" {
+			for _, alert := range alerts {
+				if postErr := notify.PostWebhook(ctx, webhookURL, "k9sight: "+alert); postErr != nil {
+					err = fmt.Errorf("webhook failed: %w", postErr)
+				}
+			}
+		}
+
+		// Both failing must not hide the webhook's error
+		if notifyErr := notifyTransitions(watchNotify, transitions); notifyErr != nil {
+			err = errors.Join(err, fmt.Errorf("desktop notification failed: %w", notifyErr))
+		}
+
+		bell := len(transitions) > 0 && (watchNotify == "bell" || watchNotify == "both")
+		return watchCheckedMsg{states: states, alerts: alerts, bell: bell, err: err}
+	}
+}
+
+// notifyTransitions shows a desktop notification for watched items that
+// started failing or recovered when the watch_notify setting is "desktop" or
+// "both". The bell is rung by the watchCheckedMsg handler.
+func notifyTransitions(mode string, transitions []string) error {
+	if len(transitions) == 0 || (mode != "desktop" && mode != "both") {
+		return nil
+	}
+	var err error
+	for _, t := range transitions {
+		if notifyErr := notify.Desktop("k9sight", t); notifyErr != nil {
+			err = notifyErr
+		}
+	}
+	return err
+}
+
 func (m *Model) saveConfig() {
 	_ = m.config.Save()
 }
 
-func (m *Model) deletePod(namespace, podName string) tea.Cmd {
+func (m *Model) deletePod(namespace, podName string, evict bool) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		err := m.k8sClient.DeletePod(ctx, namespace, podName)
+		var err error
+		if evict {
+			err = m.k8sClient.EvictPod(ctx, namespace, podName)
+		} else {
+			err = m.k8sClient.DeletePod(ctx, namespace, podName)
+		}
 		return podDeletedMsg{
 			namespace: namespace,
 			podName:   podName,
+			evicted:   evict,
 			err:       err,
 		}
 	}
@@ -753,6 +2082,21 @@ func (m *Model) scaleWorkload(workload *k8s.WorkloadInfo, replicas int32) tea.Cm
 	}
 }
 
+// restartOwnerMsg carries the workload that owns a pod about to be restarted
+type restartOwnerMsg struct {
+	workload *k8s.WorkloadInfo
+}
+
+// findRestartOwner looks up the workload behind a pod so it can be restarted
+// from the dashboard
+func (m *Model) findRestartOwner(pod *k8s.PodInfo) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	m.loading = true
+	return func() tea.Msg {
+		return restartOwnerMsg{workload: k8s.WorkloadOwner(context.Background(), clientset, pod)}
+	}
+}
+
 func (m *Model) restartWorkload(workload *k8s.WorkloadInfo) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
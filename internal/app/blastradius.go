@@ -0,0 +1,61 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// pendingConfirm is a restart or scale confirm dialog held back until the
+// workload's blast radius is known
+type pendingConfirm struct {
+	title, message, command, action string
+	data                            interface{}
+}
+
+// blastRadiusMsg carries what the pending action would touch
+type blastRadiusMsg struct {
+	confirm pendingConfirm
+	radius  k8s.BlastRadius
+	err     error
+}
+
+// confirmWithBlastRadius looks up the pods, PDBs and HPA of the workload
+// before asking to restart or scale it, so the dialog can say what happens
+func (m *Model) confirmWithBlastRadius(w *k8s.WorkloadInfo, c pendingConfirm) tea.Cmd {
+	ctx, clientset := m.loadCtx, m.k8sClient.Clientset()
+	m.loading = true
+	return func() tea.Msg {
+		radius, err := k8s.GetBlastRadius(ctx, clientset, *w)
+		return blastRadiusMsg{confirm: c, radius: radius, err: err}
+	}
+}
+
+func (m *Model) handleBlastRadius(msg tea.Msg) (tea.Cmd, bool) {
+	br, ok := msg.(blastRadiusMsg)
+	if !ok {
+		return nil, false
+	}
+	m.loading = false
+	c := br.confirm
+
+	var impact []string
+	switch data := c.data.(type) {
+	case scaleRequest:
+		impact = br.radius.ScaleImpact(data.workload.Replicas, data.replicas)
+	default:
+		impact = br.radius.RestartImpact()
+	}
+	if br.err != nil {
+		// Still let the action go ahead, just without knowing its reach
+		m.recordError("blast radius", br.err)
+		impact = []string{"Could not list its pods: " + k8s.ShortError(br.err)}
+	}
+	message := c.message
+	if len(impact) > 0 {
+		message += "\n\n" + strings.Join(impact, "\n")
+	}
+	m.confirmDialog.ShowCommand(c.title, message, c.command, c.action, c.data)
+	return nil, true
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/config"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+)
+
+// bookmarkPaletteTitle tells the bookmark picker's results from the saved
+// view palette's
+const bookmarkPaletteTitle = "Bookmarks"
+
+// openBookmarkMsg opens a bookmark once the app has started, for
+// `k9sight open <bookmark>`
+type openBookmarkMsg struct {
+	bookmark config.Bookmark
+}
+
+func newBookmarkPalette() components.ViewPalette {
+	return components.NewPalette(bookmarkPaletteTitle, "No bookmarks yet: add them to bookmarks in the config file")
+}
+
+// findBookmark looks up the bookmark `k9sight open` was given
+func findBookmark(cfg *config.Config, name string) (config.Bookmark, error) {
+	b, ok := cfg.FindBookmark(name)
+	if !ok {
+		return b, fmt.Errorf("no bookmark named %q in the config", name)
+	}
+	if _, err := bookmarkResourceType(b); err != nil {
+		return b, err
+	}
+	return b, nil
+}
+
+// bookmarkResourceType is the resource type a bookmark switches to, empty
+// when it keeps the current one
+func bookmarkResourceType(b config.Bookmark) (k8s.ResourceType, error) {
+	if b.ResourceType == "" {
+		return "", nil
+	}
+	types, err := k8s.ParseResourceTypes([]string{b.ResourceType})
+	if err != nil {
+		return "", fmt.Errorf("bookmark %q: %w", b.Name, err)
+	}
+	return types[0], nil
+}
+
+// checkBookmarks reports the bookmarks of the config that cannot be opened,
+// to warn about at startup
+func checkBookmarks(cfg *config.Config) []string {
+	var warnings []string
+	for _, b := range cfg.Bookmarks {
+		if _, err := bookmarkResourceType(b); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	return warnings
+}
+
+// openBookmarkPalette lists the bookmarks to jump to one
+func (m *Model) openBookmarkPalette() {
+	items := make([]components.PaletteItem, 0, len(m.config.Bookmarks))
+	for _, b := range m.config.Bookmarks {
+		items = append(items, components.PaletteItem{Name: b.Name, Description: bookmarkTarget(b)})
+	}
+	m.bookmarkPalette.Show(items)
+}
+
+// bookmarkTarget describes where a bookmark leads, e.g.
+// "prod / payments / deployments / api"
+func bookmarkTarget(b config.Bookmark) string {
+	var parts []string
+	for _, p := range []string{b.Context, b.Namespace, b.ResourceType, b.Workload} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// applyBookmark switches context, namespace and resource type, then opens
+// the bookmarked workload's dashboard or, without one, the workload list
+func (m *Model) applyBookmark(b config.Bookmark) tea.Cmd {
+	bookmarkType, err := bookmarkResourceType(b)
+	if err != nil {
+		m.statusMsg = err.Error()
+		return nil
+	}
+	var cmds []tea.Cmd
+	if b.Context != "" && b.Context != m.k8sClient.Context() {
+		if m.switchContext(b.Context) == nil {
+			return nil // the reason is in the status bar
+		}
+		cmds = append(cmds, m.loadNamespaces())
+	}
+
+	m.cancelLoads()
+	m.view = ViewNavigator
+	m.pod = nil
+	m.workload = nil
+	if b.Namespace != "" {
+		m.k8sClient.SetNamespace(b.Namespace)
+		m.config.SetLastNamespace(b.Namespace)
+	}
+	rt := m.navigator.ResourceType()
+	if bookmarkType != "" {
+		rt = bookmarkType
+		m.navigator.SetResourceType(rt)
+		m.config.SetLastResourceType(string(rt))
+	}
+	m.navigator.SetMode(components.ModeWorkloads)
+	m.navigator.SetLabelFilter(nil)
+	m.statusMsg = "Bookmark: " + b.Name
+
+	if b.Workload != "" && k8s.HasWorkloadDetail(rt) {
+		m.navigator.SetFilter("")
+		w := &k8s.WorkloadInfo{Name: b.Workload, Namespace: m.k8sClient.Namespace(), Type: rt}
+		return tea.Batch(append(cmds, m.openWorkloadDashboard(w))...)
+	}
+	// Other types have no dashboard; narrow the list to the workload instead
+	m.navigator.SetFilter(b.Workload)
+	m.loading = true
+	return tea.Batch(append(cmds, m.loadWorkloads())...)
+}
+
+// handleBookmarks opens bookmarks picked in the palette or given on the
+// command line, returning false for any other message
+func (m *Model) handleBookmarks(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case openBookmarkMsg:
+		return m.applyBookmark(msg.bookmark), true
+
+	case components.ViewPaletteResult:
+		if msg.Palette != bookmarkPaletteTitle {
+			return nil, false
+		}
+		if msg.Remove {
+			m.config.RemoveBookmark(msg.Name)
+			m.saveConfig()
+			m.statusMsg = "Removed bookmark " + msg.Name
+			return nil, true
+		}
+		if b, ok := m.config.FindBookmark(msg.Name); ok {
+			return m.applyBookmark(b), true
+		}
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+)
+
+// finishedPodsMsg lists the finished pods of a namespace, or of a workload
+// when set, to preview them before deleting
+type finishedPodsMsg struct {
+	namespace string
+	workload  *k8s.WorkloadInfo
+	pods      []k8s.FinishedPod
+	err       error
+}
+
+type finishedPodsRequest struct {
+	namespace string
+	pods      []string
+}
+
+type finishedPodsDeletedMsg struct {
+	namespace string
+	deleted   int
+	total     int
+	err       error
+}
+
+// maxPreviewedPods is how many pods a cleanup confirmation lists before
+// "and N more"
+const maxPreviewedPods = 10
+
+// openWorkloadMenu offers the actions for the selected workload: a
+// CronJob's run now / suspend / resume / history, and for any workload
+// deleting its finished pods
+func (m *Model) openWorkloadMenu() {
+	if m.navigator.Mode() != components.ModeWorkloads {
+		return
+	}
+	workload := m.navigator.SelectedWorkload()
+	if workload == nil || workload.Type == k8s.ResourceNodes {
+		return
+	}
+	title := "Workload: " + workload.Name
+	if workload.Type == k8s.ResourceCronJobs {
+		title = "CronJob: " + workload.Name
+	}
+	m.workloadActionMenu.Show(title, components.WorkloadActions(m.k8sClient.Kubectl(), workload))
+}
+
+// loadFinishedPods looks up the Failed and Succeeded pods of the namespace,
+// or of the workload when not nil
+func (m *Model) loadFinishedPods(namespace string, workload *k8s.WorkloadInfo) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	m.statusMsg = "Looking for finished pods in " + cleanupScope(namespace, workload) + "..."
+	return func() tea.Msg {
+		pods, err := k8s.FinishedPods(context.Background(), clientset, namespace, workload)
+		return finishedPodsMsg{namespace: namespace, workload: workload, pods: pods, err: err}
+	}
+}
+
+func (m *Model) deleteFinishedPods(req finishedPodsRequest) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	return func() tea.Msg {
+		deleted, err := k8s.DeletePods(context.Background(), clientset, req.namespace, req.pods)
+		return finishedPodsDeletedMsg{namespace: req.namespace, deleted: deleted, total: len(req.pods), err: err}
+	}
+}
+
+// cleanupScope names what a cleanup covers, e.g. "'web' in 'shop'"
+func cleanupScope(namespace string, workload *k8s.WorkloadInfo) string {
+	if workload == nil {
+		return "'" + namespace + "'"
+	}
+	return fmt.Sprintf("'%s' in '%s'", workload.Name, namespace)
+}
+
+// handleCleanup previews the finished pods found and reports their
+// deletion, returning false for any other message
+func (m *Model) handleCleanup(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case finishedPodsMsg:
+		if msg.err != nil {
+			m.recordError("finished pods", msg.err)
+			m.statusMsg = "Cannot list pods: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		scope := cleanupScope(msg.namespace, msg.workload)
+		if len(msg.pods) == 0 {
+			m.statusMsg = "No finished pods in " + scope
+			return nil, true
+		}
+		names := make([]string, len(msg.pods))
+		for i, p := range msg.pods {
+			names[i] = p.Name
+		}
+		m.statusMsg = ""
+		m.confirmDialog.ShowCommand(
+			"Delete Finished Pods",
+			fmt.Sprintf("Delete %d finished pods in %s?\n\n%s", len(msg.pods), scope, k8s.FormatFinishedPods(msg.pods, maxPreviewedPods, time.Now())),
+			k8s.FinishedPodsCommand(m.k8sClient.Kubectl(), msg.namespace, msg.workload, msg.pods),
+			"delete-finished-pods",
+			finishedPodsRequest{namespace: msg.namespace, pods: names},
+		)
+		return nil, true
+
+	case finishedPodsDeletedMsg:
+		if msg.err != nil {
+			m.recordError("finished pods", msg.err)
+			m.statusMsg = fmt.Sprintf("Deleted %d of %d finished pods: %s", msg.deleted, msg.total, k8s.ShortError(msg.err))
+			return nil, true
+		}
+		m.statusMsg = fmt.Sprintf("Deleted %d finished pods in %s", msg.deleted, msg.namespace)
+		return nil, true
+	}
+	return nil, false
+}
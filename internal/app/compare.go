@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/views"
+	"golang.org/x/sync/errgroup"
+)
+
+// podCompareMsg carries the comparison text of the two marked pods
+type podCompareMsg struct {
+	leftName, rightName string
+	left, right         string
+	err                 error
+}
+
+// comparePods fetches both marked pods fresh, with their usage when metrics
+// are available, and renders them for a diff
+func (m *Model) comparePods() tea.Cmd {
+	marked := m.navigator.MarkedPods()
+	if len(marked) < 2 {
+		m.statusMsg = "Mark two pods with m to compare them"
+		return nil
+	}
+	left, right := marked[0], marked[1]
+	m.loading = true
+
+	return func() tea.Msg {
+		var texts [2]string
+		g, ctx := errgroup.WithContext(context.Background())
+		for i, p := range []k8s.PodInfo{left, right} {
+			i, p := i, p
+			g.Go(func() error {
+				pod, err := k8s.GetPod(ctx, m.k8sClient.Clientset(), p.Namespace, p.Name)
+				if err != nil {
+					return fmt.Errorf("%s: %w", p.Name, err)
+				}
+				// Usage is optional; without metrics-server the rest still compares
+				metrics, _ := k8s.GetPodMetrics(ctx, m.k8sClient.MetricsClient(), p.Namespace, p.Name)
+				texts[i] = k8s.PodComparisonText(pod.Object, metrics)
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return podCompareMsg{err: err}
+		}
+		return podCompareMsg{
+			leftName:  left.Name,
+			rightName: right.Name,
+			left:      texts[0],
+			right:     texts[1],
+		}
+	}
+}
+
+// showComparison opens the comparison in the configured diff tool, or as a
+// line diff in the result viewer
+func (m *Model) showComparison(msg podCompareMsg) tea.Cmd {
+	title := "Compare " + msg.leftName + " ↔ " + msg.rightName
+	cmd, content, err := views.Diff(m.config.DiffTool, msg.leftName, msg.left, msg.rightName, msg.right)
+	if err != nil {
+		m.recordError("diff tool", err)
+	}
+	if cmd != nil {
+		return cmd
+	}
+	if msg.left == msg.right {
+		content = "No differences in spec, env, digests, node or usage\n\n" + content
+	}
+	m.resultViewer.Show(title, content, m.width-4, m.height-4)
+	return nil
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// cronJobRunsMsg carries the Jobs a CronJob created
+type cronJobRunsMsg struct {
+	name string
+	runs []k8s.JobRun
+	err  error
+}
+
+// triggerCronJob creates a Job from the CronJob's template
+func (m *Model) triggerCronJob(workload *k8s.WorkloadInfo) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	return func() tea.Msg {
+		job, err := k8s.TriggerCronJob(context.Background(), clientset, workload.Namespace, workload.Name)
+		return workloadActionMsg{
+			action:       "trigger",
+			workloadName: workload.Name,
+			namespace:    workload.Namespace,
+			resourceType: workload.Type,
+			job:          job,
+			err:          err,
+		}
+	}
+}
+
+// suspendCronJob sets or clears the CronJob's spec.suspend
+func (m *Model) suspendCronJob(workload *k8s.WorkloadInfo, suspend bool) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	action := "resume"
+	if suspend {
+		action = "suspend"
+	}
+	return func() tea.Msg {
+		err := k8s.SetCronJobSuspend(context.Background(), clientset, workload.Namespace, workload.Name, suspend)
+		return workloadActionMsg{
+			action:       action,
+			workloadName: workload.Name,
+			namespace:    workload.Namespace,
+			resourceType: workload.Type,
+			err:          err,
+		}
+	}
+}
+
+// loadCronJobRuns lists the CronJob's recent runs with their durations
+func (m *Model) loadCronJobRuns(workload *k8s.WorkloadInfo) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	m.loading = true
+	return func() tea.Msg {
+		runs, err := k8s.GetCronJobRuns(context.Background(), clientset, workload.Namespace, workload.Name)
+		return cronJobRunsMsg{name: workload.Name, runs: runs, err: err}
+	}
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+)
+
+// daemonSetNodesMsg carries a DaemonSet's per-node breakdown
+type daemonSetNodesMsg struct {
+	name string
+	rows []k8s.DaemonSetNodeStatus
+	err  error
+}
+
+// loadDaemonSetNodes breaks down the selected DaemonSet, or the one whose
+// pods are listed, per node. It returns nil when no DaemonSet is in view.
+func (m *Model) loadDaemonSetNodes() tea.Cmd {
+	var ds *k8s.WorkloadInfo
+	switch m.navigator.Mode() {
+	case components.ModeWorkloads:
+		ds = m.navigator.SelectedWorkload()
+	case components.ModePods:
+		ds = m.workload
+	}
+	if ds == nil || ds.Type != k8s.ResourceDaemonSets {
+		return nil
+	}
+
+	namespace, name := ds.Namespace, ds.Name
+	clientset := m.k8sClient.Clientset()
+	m.loading = true
+	return func() tea.Msg {
+		rows, err := k8s.GetDaemonSetNodes(context.Background(), clientset, namespace, name)
+		return daemonSetNodesMsg{name: name, rows: rows, err: err}
+	}
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+)
+
+// describeMsg carries describe output for the result viewer
+type describeMsg struct {
+	title   string
+	content string
+	err     error
+}
+
+// describeSelected describes the workload or pod under the navigator's
+// cursor, or returns nil when nothing is selected
+func (m *Model) describeSelected() tea.Cmd {
+	var namespace, name string
+	var rt k8s.ResourceType
+	switch m.navigator.Mode() {
+	case components.ModeWorkloads:
+		w := m.navigator.SelectedWorkload()
+		if w == nil {
+			return nil
+		}
+		namespace, name, rt = w.Namespace, w.Name, w.Type
+	case components.ModePods:
+		p := m.navigator.SelectedPod()
+		if p == nil {
+			return nil
+		}
+		namespace, name, rt = p.Namespace, p.Name, k8s.ResourcePods
+	default:
+		return nil
+	}
+
+	clientset := m.k8sClient.Clientset()
+	m.loading = true
+	return func() tea.Msg {
+		content, err := k8s.DescribeWorkload(context.Background(), clientset, namespace, rt, name)
+		return describeMsg{title: "Describe " + string(rt) + "/" + name, content: content, err: err}
+	}
+}
+
+// fetchManifestYAML loads the YAML the manifest panel asked for, resolving a
+// pod's ReplicaSet or Job owner to the Deployment or CronJob above it
+func (m *Model) fetchManifestYAML(req components.ManifestYAMLRequest) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	return func() tea.Msg {
+		ctx := context.Background()
+		kind, name := req.Kind, req.Name
+		if kind != "Pod" {
+			kind, name = k8s.ResolveOwnerWorkload(ctx, clientset, req.Namespace, kind, name)
+		}
+		doc, err := k8s.GetManifestYAML(ctx, clientset, req.Namespace, kind, name)
+		return components.ManifestYAMLMsg{Request: req, Kind: kind, Name: name, YAML: doc, Err: err}
+	}
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// maxErrorLog is how many distinct errors the error viewer keeps
+const maxErrorLog = 50
+
+// errorEntry is one non-fatal error shown in the error viewer. Repeats of
+// the same error from the same source are folded into one entry.
+type errorEntry struct {
+	at     time.Time
+	source string
+	err    error
+	count  int
+}
+
+// recordError is the single place non-fatal load errors go: they bump the
+// status bar badge and are kept for the error viewer (E)
+func (m *Model) recordError(source string, err error) {
+	if err == nil {
+		return
+	}
+
+	if n := len(m.errorLog); n > 0 {
+		last := &m.errorLog[n-1]
+		if last.source == source && last.err.Error() == err.Error() {
+			last.at = time.Now()
+			last.count++
+			return
+		}
+	}
+
+	m.errorLog = append(m.errorLog, errorEntry{at: time.Now(), source: source, err: err, count: 1})
+	if len(m.errorLog) > maxErrorLog {
+		m.errorLog = m.errorLog[len(m.errorLog)-maxErrorLog:]
+	}
+	m.unseenErrors++
+}
+
+// showErrors opens the error viewer, newest first, and clears the badge
+func (m *Model) showErrors() {
+	var b strings.Builder
+	if len(m.errorLog) == 0 {
+		b.WriteString("No errors recorded\n")
+	}
+	for i := len(m.errorLog) - 1; i >= 0; i-- {
+		e := m.errorLog[i]
+		fmt.Fprintf(&b, "%s  %-10s %s", e.at.Format("15:04:05"), e.source, k8s.ShortError(e.err))
+		if e.count > 1 {
+			fmt.Fprintf(&b, " (x%d)", e.count)
+		}
+		fmt.Fprintf(&b, "\n          %s\n\n", e.err.Error())
+	}
+
+	m.unseenErrors = 0
+	m.resultViewer.Show(fmt.Sprintf("Errors (%d)", len(m.errorLog)), b.String(), m.width-4, m.height-4)
+}
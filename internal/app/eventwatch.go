@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// eventFlashDuration is how long the events panel flags a new warning
+const eventFlashDuration = 5 * time.Second
+
+// bellDuration keeps the bell in the rendered frame long enough for the
+// renderer, which draws at most 60 frames a second, to write it once
+const bellDuration = 100 * time.Millisecond
+
+// EventMsg carries an event of the dashboard pod as the watch sees it. seq
+// identifies the watch so events still queued from a replaced one are
+// ignored.
+type EventMsg struct {
+	seq   int
+	event k8s.EventInfo
+	ch    <-chan k8s.EventInfo
+}
+
+type eventWatchEndedMsg struct {
+	seq int
+	err error // the watch could not be opened
+}
+
+// eventFlashEndMsg re-renders the dashboard once a warning's flash is over
+type eventFlashEndMsg struct{}
+
+// bellMsg asks for the terminal bell; bellEndMsg takes it out of the frame
+type bellMsg struct{}
+
+type bellEndMsg struct{}
+
+// startEventWatch watches the dashboard pod's events, so new ones show up
+// between refreshes. It lives until the view changes.
+func (m *Model) startEventWatch() tea.Cmd {
+	if m.eventWatching || m.view != ViewDashboard || m.pod == nil || m.pod.Object == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(m.loadCtx)
+	m.cancelEventWatch = cancel
+	m.eventWatchSeq++
+	m.eventWatching = true
+	m.eventWatchStart = time.Now()
+	seq := m.eventWatchSeq
+
+	clientset := m.k8sClient.Clientset()
+	pod := m.pod.Object
+	return func() tea.Msg {
+		ch, err := k8s.WatchPodEvents(ctx, clientset, pod)
+		if err != nil {
+			return eventWatchEndedMsg{seq: seq, err: err}
+		}
+		return waitForEvent(seq, ch)()
+	}
+}
+
+// stopEventWatch ends the current watch; refreshes still list the events
+func (m *Model) stopEventWatch() {
+	if m.cancelEventWatch != nil {
+		m.cancelEventWatch()
+		m.cancelEventWatch = nil
+	}
+	m.eventWatchSeq++
+	m.eventWatching = false
+}
+
+func waitForEvent(seq int, ch <-chan k8s.EventInfo) tea.Cmd {
+	return func() tea.Msg {
+		e, ok := <-ch
+		if !ok {
+			return eventWatchEndedMsg{seq: seq}
+		}
+		return EventMsg{seq: seq, event: e, ch: ch}
+	}
+}
+
+// ringBell sounds the terminal bell. Writing it from a command would race
+// the renderer for the terminal, so View adds it to the next frame instead.
+func ringBell() tea.Msg {
+	return bellMsg{}
+}
+
+// handleEventWatch applies event watch messages, returning false for any
+// other message
+func (m *Model) handleEventWatch(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case EventMsg:
+		if msg.seq != m.eventWatchSeq {
+			return nil, true
+		}
+		next := waitForEvent(msg.seq, msg.ch)
+		e := msg.event
+		// The watch starts by replaying the events already listed
+		if !m.dashboard.ApplyEvent(e) || e.Type != "Warning" || !e.LastSeen.After(m.eventWatchStart) {
+			return next, true
+		}
+		m.dashboard.FlashEvent(e.Reason, eventFlashDuration)
+		m.statusMsg = "Warning: " + e.Reason + ": " + e.Message
+		cmds := []tea.Cmd{next, tea.Tick(eventFlashDuration, func(time.Time) tea.Msg { return eventFlashEndMsg{} })}
+		if m.config.EventBell {
+			cmds = append(cmds, ringBell)
+		}
+		return tea.Batch(cmds...), true
+
+	case eventWatchEndedMsg:
+		if msg.seq != m.eventWatchSeq {
+			return nil, true
+		}
+		// Watches expire server-side; the next events section restarts it
+		m.stopEventWatch()
+		m.recordError("event watch", msg.err)
+		return nil, true
+
+	case eventFlashEndMsg:
+		return nil, true
+
+	case bellMsg:
+		m.bell = true
+		return tea.Tick(bellDuration, func(time.Time) tea.Msg { return bellEndMsg{} }), true
+
+	case bellEndMsg:
+		m.bell = false
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+	"github.com/doganarif/k9sight/internal/ui/views"
+)
+
+// exportRefsMsg carries the objects to export once a ReplicaSet or Job owner
+// is resolved to its Deployment or CronJob, to name the file after
+type exportRefsMsg struct {
+	namespace string
+	refs      []k8s.ObjectRef
+}
+
+// exportRequest is what the save prompt saves once given a path
+type exportRequest struct {
+	namespace string
+	refs      []k8s.ObjectRef
+}
+
+type manifestsSavedMsg struct {
+	path    string
+	objects int
+	err     error
+}
+
+func (m *Model) resolveExportRefs(req views.SaveYAMLRequest) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	return func() tea.Msg {
+		refs := k8s.ResolveObjectRefs(context.Background(), clientset, req.Namespace, req.Objects)
+		return exportRefsMsg{namespace: req.Namespace, refs: refs}
+	}
+}
+
+func (m *Model) exportManifests(path string, req exportRequest) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	return func() tea.Msg {
+		doc, err := k8s.ExportManifests(context.Background(), clientset, req.namespace, req.refs)
+		if err == nil {
+			err = k8s.SaveExport(k8s.ExpandHome(path), doc)
+		}
+		return manifestsSavedMsg{path: path, objects: len(req.refs), err: err}
+	}
+}
+
+// exportSummary lists what an export holds for the save prompt, e.g.
+// "Deployment/web, Service/web"
+func exportSummary(refs []k8s.ObjectRef) string {
+	names := make([]string, len(refs))
+	for i, r := range refs {
+		names[i] = r.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// handleExport asks where to save exported YAML and saves it, returning
+// false for any other message
+func (m *Model) handleExport(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case views.SaveYAMLRequest:
+		return m.resolveExportRefs(msg), true
+
+	case exportRefsMsg:
+		m.savePathPrompt.Show(
+			"Save YAML",
+			exportSummary(msg.refs),
+			k8s.ManifestExportName(msg.refs),
+			exportRequest{namespace: msg.namespace, refs: msg.refs},
+		)
+		return nil, true
+
+	case components.SavePathResult:
+		req, ok := msg.Data.(exportRequest)
+		if !ok {
+			return nil, false
+		}
+		m.statusMsg = "Saving YAML..."
+		return m.exportManifests(msg.Path, req), true
+
+	case manifestsSavedMsg:
+		if msg.err != nil {
+			m.recordError("save YAML", msg.err)
+			m.statusMsg = "Save failed: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		m.statusMsg = fmt.Sprintf("Saved %d objects to %s", msg.objects, msg.path)
+		if msg.objects == 1 {
+			m.statusMsg = "Saved YAML to " + msg.path
+		}
+		return nil, true
+	}
+	return nil, false
+}
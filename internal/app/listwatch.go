@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+const (
+	// How long a deleted row stays dimmed before it is removed
+	deletedFadeDelay = 3 * time.Second
+	// Pause before re-listing when the server ends a watch
+	listWatchRestartDelay = 5 * time.Second
+)
+
+// Messages from the navigator's list watch. seq identifies the watch so
+// events still queued from a replaced one are ignored.
+type workloadEventMsg struct {
+	seq int
+	ev  k8s.WorkloadEvent
+	ch  <-chan k8s.WorkloadEvent
+}
+
+type podEventMsg struct {
+	seq int
+	ev  k8s.PodEvent
+	ch  <-chan k8s.PodEvent
+}
+
+type listWatchEndedMsg struct {
+	seq int
+	err error // the watch could not be opened
+}
+
+type listWatchRestartMsg struct {
+	seq int
+}
+
+type pruneDeletedMsg struct{}
+
+// startListWatch replaces the watch behind the navigator list: the pods of
+// the selected workload, or the workloads of the current type. It lives until
+// the next view change cancels loadCtx.
+func (m *Model) startListWatch() tea.Cmd {
+	m.stopListWatch()
+	ctx, cancel := context.WithCancel(m.loadCtx)
+	m.cancelListWatch = cancel
+	m.listWatchSeq++
+	seq := m.listWatchSeq
+
+	clientset := m.k8sClient.Clientset()
+	if m.workload != nil {
+		workload := *m.workload
+		return func() tea.Msg {
+			ch, err := k8s.WatchWorkloadPods(ctx, clientset, workload)
+			if err != nil {
+				return listWatchEndedMsg{seq: seq, err: err}
+			}
+			return waitForPodEvent(seq, ch)()
+		}
+	}
+
+	namespace := m.k8sClient.Namespace()
+	rt := m.navigator.ResourceType()
+	return func() tea.Msg {
+		ch, err := k8s.WatchWorkloads(ctx, clientset, namespace, rt)
+		if err != nil {
+			return listWatchEndedMsg{seq: seq, err: err}
+		}
+		return waitForWorkloadEvent(seq, ch)()
+	}
+}
+
+func (m *Model) stopListWatch() {
+	if m.cancelListWatch != nil {
+		m.cancelListWatch()
+		m.cancelListWatch = nil
+	}
+}
+
+func waitForWorkloadEvent(seq int, ch <-chan k8s.WorkloadEvent) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-ch
+		if !ok {
+			return listWatchEndedMsg{seq: seq}
+		}
+		return workloadEventMsg{seq: seq, ev: ev, ch: ch}
+	}
+}
+
+func waitForPodEvent(seq int, ch <-chan k8s.PodEvent) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-ch
+		if !ok {
+			return listWatchEndedMsg{seq: seq}
+		}
+		return podEventMsg{seq: seq, ev: ev, ch: ch}
+	}
+}
+
+// handleListWatch applies list watch messages, returning false for any other
+// message
+func (m *Model) handleListWatch(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case workloadEventMsg:
+		if msg.seq != m.listWatchSeq {
+			return nil, true
+		}
+		m.navigator.ApplyWorkloadEvent(msg.ev)
+		return tea.Batch(waitForWorkloadEvent(msg.seq, msg.ch), fadeDeleted(msg.ev.Deleted)), true
+
+	case podEventMsg:
+		if msg.seq != m.listWatchSeq {
+			return nil, true
+		}
+		m.navigator.ApplyPodEvent(msg.ev)
+		return tea.Batch(waitForPodEvent(msg.seq, msg.ch), fadeDeleted(msg.ev.Deleted)), true
+
+	case listWatchEndedMsg:
+		if msg.seq != m.listWatchSeq {
+			return nil, true
+		}
+		if msg.err != nil {
+			// Without watch permission the list still works with manual refresh
+			m.recordError("live updates", msg.err)
+			return nil, true
+		}
+		// Watches expire server-side; re-list so nothing missed in between is lost
+		return tea.Tick(listWatchRestartDelay, func(time.Time) tea.Msg {
+			return listWatchRestartMsg{seq: msg.seq}
+		}), true
+
+	case listWatchRestartMsg:
+		if msg.seq != m.listWatchSeq || m.view != ViewNavigator {
+			return nil, true
+		}
+		if m.workload != nil {
+			return m.loadPods(m.workload), true
+		}
+		return m.loadWorkloads(), true
+
+	case pruneDeletedMsg:
+		m.navigator.PruneDeleted()
+		return nil, true
+	}
+	return nil, false
+}
+
+func fadeDeleted(deleted bool) tea.Cmd {
+	if !deleted {
+		return nil
+	}
+	return tea.Tick(deletedFadeDelay, func(time.Time) tea.Msg {
+		return pruneDeletedMsg{}
+	})
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// logStreamBatch caps how many already-waiting lines one LogLineMsg carries,
+// so a chatty pod costs one render per batch instead of one per line
+const logStreamBatch = 500
+
+// LogLineMsg carries new lines from the dashboard's log stream. seq
+// identifies the stream so lines still queued from a replaced one are
+// ignored.
+type LogLineMsg struct {
+	seq   int
+	lines []k8s.LogLine
+	ch    <-chan k8s.LogLine
+}
+
+type logStreamEndedMsg struct {
+	seq int
+	err error // the stream could not be opened
+}
+
+// startLogStream follows the dashboard pod's logs from the newest line shown,
+// when the logs panel is following live kubelet logs and no stream runs yet.
+// It lives until the view changes or the log selection does.
+func (m *Model) startLogStream() tea.Cmd {
+	if m.logStreaming || m.view != ViewDashboard || m.pod == nil ||
+		!m.dashboard.LogsFollowing() || m.dashboard.LogsShowPrevious() || m.dashboard.LogsUseExternalSource() {
+		return nil
+	}
+
+	containers := []string{m.dashboard.LogsSelectedContainer()}
+	if containers[0] == "" {
+		containers = containers[:0]
+		for _, c := range m.pod.Containers {
+			containers = append(containers, c.Name)
+		}
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(m.loadCtx)
+	m.cancelLogStream = cancel
+	m.logStreamSeq++
+	m.logStreaming = true
+	m.dashboard.SetLogsLive(true)
+	seq := m.logStreamSeq
+
+	clientset := m.k8sClient.Clientset()
+	namespace, podName := m.pod.Namespace, m.pod.Name
+	after := m.dashboard.LogsNewest()
+	return func() tea.Msg {
+		ch, err := k8s.StreamPodLogs(ctx, clientset, namespace, podName, containers, after)
+		if err != nil {
+			return logStreamEndedMsg{seq: seq, err: err}
+		}
+		return waitForLogLines(seq, ch)()
+	}
+}
+
+// stopLogStream ends the current stream; polling resumes on the next tick
+func (m *Model) stopLogStream() {
+	if m.cancelLogStream != nil {
+		m.cancelLogStream()
+		m.cancelLogStream = nil
+	}
+	m.logStreamSeq++
+	m.logStreaming = false
+	m.dashboard.SetLogsLive(false)
+}
+
+// waitForLogLines delivers the next batch of streamed lines
+func waitForLogLines(seq int, ch <-chan k8s.LogLine) tea.Cmd {
+	return func() tea.Msg {
+		lines := readLogBatch(ch)
+		if lines == nil {
+			return logStreamEndedMsg{seq: seq}
+		}
+		return LogLineMsg{seq: seq, lines: lines, ch: ch}
+	}
+}
+
+// readLogBatch blocks for the next line, then takes whatever else is already
+// waiting. It returns nil once ch is closed.
+func readLogBatch(ch <-chan k8s.LogLine) []k8s.LogLine {
+	line, ok := <-ch
+	if !ok {
+		return nil
+	}
+	lines := []k8s.LogLine{line}
+	for len(lines) < logStreamBatch {
+		select {
+		case line, ok := <-ch:
+			if !ok {
+				// The end is picked up by the next read
+				return lines
+			}
+			lines = append(lines, line)
+		default:
+			return lines
+		}
+	}
+	return lines
+}
+
+// handleLogStream applies log stream messages, returning false for any other
+// message
+func (m *Model) handleLogStream(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case LogLineMsg:
+		if msg.seq != m.logStreamSeq {
+			return nil, true
+		}
+		m.dashboard.AppendLogs(msg.lines)
+		return waitForLogLines(msg.seq, msg.ch), true
+
+	case logStreamEndedMsg:
+		if msg.seq != m.logStreamSeq {
+			return nil, true
+		}
+		// The container exited or the stream broke: the next tick fetches
+		// logs again and starts a new stream from there
+		m.stopLogStream()
+		m.recordError("log stream", msg.err)
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+)
+
+type namespaceCreatedMsg struct {
+	name string
+	err  error
+}
+
+// namespaceContentsMsg reports what is left in a namespace about to be deleted
+type namespaceContentsMsg struct {
+	name     string
+	contents []string
+	err      error
+}
+
+type namespaceDeletedMsg struct {
+	name string
+	err  error
+}
+
+// namespaceReportMsg carries a namespace action's output for the result
+// viewer
+type namespaceReportMsg struct {
+	title   string
+	content string
+	err     error
+}
+
+// namespaceWarningWindow is how far back the namespace menu's warnings go
+const namespaceWarningWindow = time.Hour
+
+func (m *Model) createNamespace(req components.NamespacePromptResult) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	return func() tea.Msg {
+		err := k8s.CreateNamespace(context.Background(), clientset, req.Name, req.Labels)
+		return namespaceCreatedMsg{name: req.Name, err: err}
+	}
+}
+
+// checkNamespaceEmpty lists what the namespace holds; only an empty one is
+// offered for deletion
+func (m *Model) checkNamespaceEmpty(name string) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	return func() tea.Msg {
+		contents, err := k8s.NamespaceContents(context.Background(), clientset, name)
+		return namespaceContentsMsg{name: name, contents: contents, err: err}
+	}
+}
+
+func (m *Model) deleteNamespace(name string) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	return func() tea.Msg {
+		err := k8s.DeleteNamespace(context.Background(), clientset, name)
+		return namespaceDeletedMsg{name: name, err: err}
+	}
+}
+
+// openNamespaceMenu offers the quick actions for the namespace highlighted
+// in the namespace list
+func (m *Model) openNamespaceMenu() {
+	ns := m.navigator.SelectedNamespace()
+	if ns == "" {
+		return
+	}
+	m.workloadActionMenu.Show("Namespace: "+ns, components.NamespaceActions(ns))
+}
+
+// namespaceAction runs an item of the namespace menu against the
+// highlighted namespace
+func (m *Model) namespaceAction(item components.WorkloadActionItem) tea.Cmd {
+	ns := m.navigator.SelectedNamespace()
+	if ns == "" {
+		return nil
+	}
+	clientset := m.k8sClient.Clientset()
+	switch item.Action {
+	case "ns-warnings":
+		m.statusMsg = "Loading warnings in " + ns + "..."
+		return func() tea.Msg {
+			events, err := k8s.GetRecentWarnings(context.Background(), clientset, ns, namespaceWarningWindow)
+			return namespaceReportMsg{title: "Warnings in " + ns + " (last hour)", content: k8s.FormatWarningEvents(events), err: err}
+		}
+	case "ns-quotas":
+		m.statusMsg = "Loading quotas in " + ns + "..."
+		return func() tea.Msg {
+			quotas, err := k8s.ListResourceQuotas(context.Background(), clientset, ns)
+			return namespaceReportMsg{title: "Quotas in " + ns, content: k8s.FormatResourceQuotas(quotas), err: err}
+		}
+	case "delete-finished":
+		return m.loadFinishedPods(ns, nil)
+	case "copy":
+		if err := components.CopyToClipboard(item.Command); err != nil {
+			m.statusMsg = "Copy failed: " + err.Error()
+		} else {
+			m.statusMsg = "Copied: " + item.Command
+		}
+	}
+	return nil
+}
+
+// handleNamespace applies namespace create and delete messages and the
+// namespace menu's results, returning false for any other message
+func (m *Model) handleNamespace(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case components.NamespacePromptResult:
+		m.statusMsg = "Creating namespace " + msg.Name + "..."
+		return m.createNamespace(msg), true
+
+	case namespaceCreatedMsg:
+		if msg.err != nil {
+			m.recordError("create namespace", msg.err)
+			m.statusMsg = "Create failed: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		// Switch to the new namespace, as selecting it would
+		m.cancelLoads()
+		m.k8sClient.InvalidateNamespaces()
+		m.k8sClient.SetNamespace(msg.name)
+		m.config.SetLastNamespace(msg.name)
+		m.navigator.SetMode(components.ModeWorkloads)
+		m.statusMsg = "Created namespace " + msg.name
+		m.loading = true
+		return tea.Batch(m.loadWorkloads(), m.loadNamespaces()), true
+
+	case namespaceContentsMsg:
+		if msg.err != nil {
+			m.recordError("delete namespace", msg.err)
+			m.statusMsg = "Cannot check namespace: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		if len(msg.contents) > 0 {
+			m.statusMsg = fmt.Sprintf("Not deleting %s, it is not empty: %s", msg.name, strings.Join(msg.contents, ", "))
+			return nil, true
+		}
+		m.statusMsg = ""
+		m.confirmDialog.ShowCommand(
+			"Delete Namespace",
+			"Delete the empty namespace '"+msg.name+"'?",
+			m.k8sClient.Kubectl()+" delete namespace "+msg.name,
+			"delete-namespace",
+			msg.name,
+		)
+		return nil, true
+
+	case namespaceDeletedMsg:
+		if msg.err != nil {
+			m.recordError("delete namespace", msg.err)
+			m.statusMsg = "Delete failed: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		m.statusMsg = "Deleting namespace " + msg.name
+		m.k8sClient.InvalidateNamespaces()
+		return m.loadNamespaces(), true
+
+	case namespaceReportMsg:
+		if msg.err != nil {
+			m.recordError("namespace", msg.err)
+			m.statusMsg = "Failed: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		m.statusMsg = ""
+		m.resultViewer.Show(msg.title, msg.content, m.width-4, m.height-4)
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// nodeDetailMsg carries the node detail panel's content
+type nodeDetailMsg struct {
+	detail *k8s.NodeDetail
+	err    error
+}
+
+// loadNodeDetail reads a node, its capacity and the pods scheduled on it
+func (m *Model) loadNodeDetail(name string) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	m.loading = true
+	return func() tea.Msg {
+		detail, err := k8s.GetNodeDetail(context.Background(), clientset, name)
+		return nodeDetailMsg{detail: detail, err: err}
+	}
+}
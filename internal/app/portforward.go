@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/config"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+	"github.com/doganarif/k9sight/internal/ui/views"
+)
+
+// portForwardStartedMsg reports a forward that listens, or why it did not
+// start
+type portForwardStartedMsg struct {
+	forward k8s.PortForward
+	done    <-chan struct{}
+	err     error
+}
+
+// portForwardEndedMsg reports a forward that stopped on its own, e.g. when
+// its pod went away
+type portForwardEndedMsg struct {
+	id int
+}
+
+// servicePortsMsg carries a Service's ports to prefill the prompt
+type servicePortsMsg struct {
+	namespace string
+	service   string
+	ports     []int32
+	err       error
+}
+
+func (m *Model) startPortForward(req components.PortForwardPromptResult) tea.Cmd {
+	forwards := m.forwards
+	return func() tea.Msg {
+		start := forwards.Start
+		target := req.Pod
+		if req.Service != "" {
+			start, target = forwards.StartService, req.Service
+		}
+		f, done, err := start(req.Namespace, target, req.LocalPort, req.RemotePort)
+		return portForwardStartedMsg{forward: f, done: done, err: err}
+	}
+}
+
+// promptPortForward opens the port-forward prompt for the Service or pod
+// under the navigator's cursor, or returns false when neither is selected
+func (m *Model) promptPortForward() (tea.Cmd, bool) {
+	switch m.navigator.Mode() {
+	case components.ModeWorkloads:
+		w := m.navigator.SelectedWorkload()
+		if w == nil || w.Type != k8s.ResourceServices {
+			return nil, false
+		}
+		clientset := m.k8sClient.Clientset()
+		namespace, service := w.Namespace, w.Name
+		return func() tea.Msg {
+			ports, err := k8s.GetServicePorts(context.Background(), clientset, namespace, service)
+			return servicePortsMsg{namespace: namespace, service: service, ports: ports, err: err}
+		}, true
+	case components.ModePods:
+		p := m.navigator.SelectedPod()
+		if p == nil {
+			return nil, false
+		}
+		var ports []int32
+		for _, c := range p.Containers {
+			ports = append(ports, c.Ports...)
+		}
+		m.portForwardPrompt.Show(p.Namespace, p.Name, ports)
+		return nil, true
+	}
+	return nil, false
+}
+
+func waitForPortForward(id int, done <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		<-done
+		return portForwardEndedMsg{id: id}
+	}
+}
+
+// refreshPortForwards updates the panel and status bar from the manager
+func (m *Model) refreshPortForwards() {
+	list := m.forwards.List()
+	m.portForwardPanel.SetForwards(list, m.forwards.LastError())
+	running := 0
+	for _, f := range list {
+		if !f.Ended {
+			running++
+		}
+	}
+	m.statusBar.SetForwardCount(running)
+}
+
+// handlePortForward applies port-forward messages, returning false for any
+// other message
+func (m *Model) handlePortForward(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case views.PortForwardRequest:
+		m.portForwardPrompt.Show(msg.Namespace, msg.PodName, msg.Ports)
+		return nil, true
+
+	case servicePortsMsg:
+		if msg.err != nil {
+			m.recordError("port-forward", msg.err)
+			m.statusMsg = "Cannot read service: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		m.portForwardPrompt.ShowService(msg.namespace, msg.service, msg.ports)
+		return nil, true
+
+	case components.PortForwardPromptResult:
+		if m.pod != nil && msg.Service == "" && msg.Namespace == m.pod.Namespace && msg.Pod == m.pod.Name {
+			m.rememberCommand(m.pod, config.RecentCommand{LocalPort: msg.LocalPort, RemotePort: msg.RemotePort})
+		}
+		m.statusMsg = "Starting port-forward..."
+		return m.startPortForward(msg), true
+
+	case portForwardStartedMsg:
+		if msg.err != nil {
+			m.recordError("port-forward", msg.err)
+			m.statusMsg = "Port-forward failed: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		m.statusMsg = "Forwarding " + msg.forward.Target() + " (F to manage)"
+		m.refreshPortForwards()
+		return waitForPortForward(msg.forward.ID, msg.done), true
+
+	case portForwardEndedMsg:
+		// Stopped forwards are already gone from the list
+		if f, ok := m.forwards.Get(msg.id); ok && f.Err != nil {
+			m.recordError("port-forward", f.Err)
+			m.statusMsg = "Port-forward ended: " + f.Target()
+		}
+		m.refreshPortForwards()
+		return nil, true
+
+	case components.PortForwardStopMsg:
+		m.forwards.Stop(msg.ID)
+		m.refreshPortForwards()
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"github.com/doganarif/k9sight/internal/config"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+)
+
+// rememberCommand records an exec or port-forward run on pod under its
+// workload, so the workload's pods offer it again
+func (m *Model) rememberCommand(pod *k8s.PodInfo, rc config.RecentCommand) {
+	if pod == nil {
+		return
+	}
+	rc.Target = k8s.WorkloadKey(pod)
+	m.config.AddRecentCommand(rc)
+	m.saveConfig()
+	if m.pod != nil && k8s.WorkloadKey(m.pod) == rc.Target {
+		m.refreshRecentCommands()
+	}
+}
+
+func hasContainer(pod *k8s.PodInfo, name string) bool {
+	for _, c := range pod.Containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshRecentCommands offers the dashboard the recent commands of the
+// open pod's workload, aimed at the open pod
+func (m *Model) refreshRecentCommands() {
+	if m.pod == nil {
+		m.dashboard.SetRecentCommands(nil)
+		return
+	}
+	var items []components.PodActionItem
+	for _, rc := range m.config.RecentCommandsFor(k8s.WorkloadKey(m.pod)) {
+		if len(rc.Exec) > 0 {
+			items = append(items, components.RecentExecAction(m.k8sClient.Kubectl(), m.pod.Namespace, m.pod.Name, rc.Container, rc.Exec))
+		} else {
+			items = append(items, components.RecentPortForwardAction(m.pod.Namespace, m.pod.Name, rc.LocalPort, rc.RemotePort))
+		}
+	}
+	m.dashboard.SetRecentCommands(items)
+}
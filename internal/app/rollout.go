@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/notify"
+	"github.com/doganarif/k9sight/internal/ui/components"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+const (
+	rolloutPollInterval = 2 * time.Second
+	// StatefulSets and DaemonSets have no progress deadline to fail on
+	rolloutWatchLimit = 15 * time.Minute
+	// How long a rollout's outcome stays in the toast
+	rolloutToastDuration = 8 * time.Second
+)
+
+// rolloutWatch follows one workload's rollout in the background after a
+// restart, scale or undo
+type rolloutWatch struct {
+	key       string
+	name      string
+	namespace string
+	rt        k8s.ResourceType
+	started   time.Time
+}
+
+type rolloutPollMsg struct {
+	watch rolloutWatch
+}
+
+type rolloutStateMsg struct {
+	watch rolloutWatch
+	state k8s.RolloutState
+	err   error
+}
+
+// rolloutToastEndMsg re-renders once a rollout toast has expired
+type rolloutToastEndMsg struct{}
+
+// rolloutNotifiedMsg reports posting a rollout outcome to the webhook
+type rolloutNotifiedMsg struct {
+	err error
+}
+
+// rolloutHistoryMsg carries a Deployment's revisions for the undo menu
+type rolloutHistoryMsg struct {
+	workload  *k8s.WorkloadInfo
+	revisions []k8s.RolloutRevision
+	err       error
+}
+
+// undoRequest is the confirm dialog's data for a pending rollout undo
+type undoRequest struct {
+	workload *k8s.WorkloadInfo
+	revision int64
+}
+
+// loadRolloutHistory fetches the revisions of the selected Deployment, or
+// returns nil when the selection is not a Deployment
+func (m *Model) loadRolloutHistory() tea.Cmd {
+	if m.navigator.Mode() != components.ModeWorkloads {
+		return nil
+	}
+	workload := m.navigator.SelectedWorkload()
+	if workload == nil || workload.Type != k8s.ResourceDeployments {
+		return nil
+	}
+
+	clientset := m.k8sClient.Clientset()
+	m.loading = true
+	return func() tea.Msg {
+		revisions, err := k8s.GetRolloutHistory(context.Background(), clientset, workload.Namespace, workload.Name)
+		return rolloutHistoryMsg{workload: workload, revisions: revisions, err: err}
+	}
+}
+
+func (m *Model) undoRollout(req undoRequest) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	workload := req.workload
+	return func() tea.Msg {
+		err := k8s.UndoDeployment(context.Background(), clientset, workload.Namespace, workload.Name, req.revision)
+		return workloadActionMsg{
+			action:       "undo",
+			workloadName: workload.Name,
+			namespace:    workload.Namespace,
+			resourceType: workload.Type,
+			revision:     req.revision,
+			err:          err,
+		}
+	}
+}
+
+// handleRollout shows a Deployment's history as a menu of revisions to undo to
+func (m *Model) handleRollout(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case rolloutHistoryMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.recordError("rollout history", msg.err)
+			m.statusMsg = "Rollout history failed: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		items := components.UndoActions(m.k8sClient.Kubectl(), msg.workload.Namespace, msg.workload.Name, msg.revisions)
+		if len(items) == 1 {
+			m.statusMsg = "No earlier revision of " + msg.workload.Name + " to undo to"
+			return nil, true
+		}
+		m.workloadActionMenu.Show(fmt.Sprintf("Rollout history: %s", msg.workload.Name), items)
+		return nil, true
+
+	case rolloutPollMsg:
+		clientset := m.k8sClient.Clientset()
+		w := msg.watch
+		return func() tea.Msg {
+			state, err := k8s.GetRolloutState(context.Background(), clientset, w.namespace, w.rt, w.name)
+			return rolloutStateMsg{watch: w, state: state, err: err}
+		}, true
+
+	case rolloutStateMsg:
+		w := msg.watch
+		switch {
+		case msg.err != nil:
+			m.recordError("rollout watch", msg.err)
+			return m.finishRolloutWatch(w, "stopped watching: "+k8s.ShortError(msg.err), true), true
+		case msg.state.Done:
+			return m.finishRolloutWatch(w, "complete", false), true
+		case msg.state.Failed:
+			return m.finishRolloutWatch(w, "failed, "+msg.state.Message, true), true
+		case w.rt != k8s.ResourceDeployments && time.Since(w.started) > rolloutWatchLimit:
+			return m.finishRolloutWatch(w, "not done after "+k8s.FormatDuration(rolloutWatchLimit)+", "+msg.state.Message, true), true
+		}
+		return pollRollout(w), true
+
+	case rolloutNotifiedMsg:
+		m.recordError("webhook", msg.err)
+		return nil, true
+
+	case rolloutToastEndMsg:
+		return nil, true
+	}
+	return nil, false
+}
+
+// watchRollout follows the rollout a restart, scale or undo of the workload
+// started, and reports when it completes or fails
+func (m *Model) watchRollout(namespace string, rt k8s.ResourceType, name string) tea.Cmd {
+	if !k8s.HasRollout(rt) {
+		return nil
+	}
+	key := k8s.WatchKey(namespace, rt, name)
+	if m.rolloutWatches[key] {
+		return nil
+	}
+	if m.rolloutWatches == nil {
+		m.rolloutWatches = make(map[string]bool)
+	}
+	m.rolloutWatches[key] = true
+	return pollRollout(rolloutWatch{key: key, name: name, namespace: namespace, rt: rt, started: time.Now()})
+}
+
+func pollRollout(w rolloutWatch) tea.Cmd {
+	return tea.Tick(rolloutPollInterval, func(time.Time) tea.Msg {
+		return rolloutPollMsg{watch: w}
+	})
+}
+
+// finishRolloutWatch shows the outcome in a toast, whichever view is open,
+// and posts it to the configured webhook
+func (m *Model) finishRolloutWatch(w rolloutWatch, outcome string, failed bool) tea.Cmd {
+	delete(m.rolloutWatches, w.key)
+	text := fmt.Sprintf("Rollout of %s/%s %s", w.namespace, w.name, outcome)
+	m.rolloutToast = text
+	m.rolloutToastFailed = failed
+	m.rolloutToastUntil = time.Now().Add(rolloutToastDuration)
+	cmds := []tea.Cmd{tea.Tick(rolloutToastDuration, func(time.Time) tea.Msg { return rolloutToastEndMsg{} })}
+	if webhookURL := m.config.WebhookURL; webhookURL != "" {
+		cmds = append(cmds, func() tea.Msg {
+			return rolloutNotifiedMsg{err: notify.PostWebhook(context.Background(), webhookURL, "k9sight: "+text)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// rolloutToastView renders the latest rollout outcome while it is fresh,
+// "" once it has expired
+func (m Model) rolloutToastView() string {
+	if m.rolloutToast == "" || time.Now().After(m.rolloutToastUntil) {
+		return ""
+	}
+	style := styles.StatusRunning
+	if m.rolloutToastFailed {
+		style = styles.StatusError
+	}
+	return style.Reverse(true).Render(" " + m.rolloutToast + " ")
+}
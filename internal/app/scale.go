@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+)
+
+// scaleMenuMsg carries the HPA targeting a workload about to be scaled
+type scaleMenuMsg struct {
+	workload *k8s.WorkloadInfo
+	hpa      *k8s.HPAInfo
+}
+
+// openScaleMenu looks up the selected workload's HPA before offering replica
+// counts, or explains why the selection cannot be scaled
+func (m *Model) openScaleMenu() tea.Cmd {
+	workload := m.navigator.SelectedWorkload()
+	if workload == nil {
+		return nil
+	}
+	if !k8s.IsScalable(workload.Type) {
+		m.statusMsg = fmt.Sprintf("%s cannot be scaled", workload.Type)
+		return nil
+	}
+
+	clientset := m.k8sClient.Clientset()
+	m.loading = true
+	return func() tea.Msg {
+		// Without permission to list HPAs, scale without their bounds
+		hpa, _ := k8s.FindHPA(context.Background(), clientset, workload.Namespace, workload.Type, workload.Name)
+		return scaleMenuMsg{workload: workload, hpa: hpa}
+	}
+}
+
+func (m *Model) handleScale(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case scaleMenuMsg:
+		m.loading = false
+		m.scaleHPA = msg.hpa
+		w := msg.workload
+		title := "Scale " + w.Name
+		if msg.hpa != nil {
+			title += fmt.Sprintf(" (HPA %d-%d)", msg.hpa.MinReplicas, msg.hpa.MaxReplicas)
+		}
+		m.workloadActionMenu.Show(title, components.ScaleActions(m.k8sClient.Kubectl(), w.Namespace, w.Name, string(w.Type), w.Replicas, msg.hpa))
+		return nil, true
+
+	case components.ScalePromptResult:
+		w := msg.Workload
+		return m.confirmWithBlastRadius(w, pendingConfirm{
+			title:   "Scale " + string(w.Type),
+			message: fmt.Sprintf("Scale '%s' from %d to %d replicas?", w.Name, w.Replicas, msg.Replicas),
+			command: fmt.Sprintf("%s scale %s/%s -n %s --replicas=%d", m.k8sClient.Kubectl(), w.Type, w.Name, w.Namespace, msg.Replicas),
+			action:  "scale",
+			data:    scaleRequest{workload: w, replicas: msg.Replicas},
+		}), true
+	}
+	return nil, false
+}
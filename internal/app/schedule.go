@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// schedulingMsg carries a pending pod's per-node scheduling check
+type schedulingMsg struct {
+	podName string
+	fits    []k8s.NodeFit
+	err     error
+}
+
+// simulateScheduling checks which nodes could take a pending pod
+func (m *Model) simulateScheduling(namespace, name string) tea.Cmd {
+	clientset := m.k8sClient.Clientset()
+	m.loading = true
+	return func() tea.Msg {
+		fits, err := k8s.SimulateScheduling(context.Background(), clientset, namespace, name)
+		return schedulingMsg{podName: name, fits: fits, err: err}
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+)
+
+// podProblemMsg carries the unhealthiest pod in scope, its workload and that
+// workload's pods for the list behind the dashboard
+type podProblemMsg struct {
+	scope    string
+	problem  *k8s.PodProblem
+	workload *k8s.WorkloadInfo
+	pods     []k8s.PodInfo
+	err      error
+}
+
+// jumpToProblem finds the unhealthiest pod of the listed workload's pods, the
+// namespace highlighted in the namespace list, or the current namespace
+func (m *Model) jumpToProblem() tea.Cmd {
+	namespace := m.k8sClient.Namespace()
+	var scope *k8s.WorkloadInfo
+	switch m.navigator.Mode() {
+	case components.ModePods:
+		scope = m.workload
+	case components.ModeNamespace:
+		if ns := m.navigator.SelectedNamespace(); ns != "" {
+			namespace = ns
+		}
+	}
+	scopeName := namespace
+	if scope != nil {
+		scopeName = scope.Name
+	}
+
+	clientset := m.k8sClient.Clientset()
+	m.loading = true
+	m.statusMsg = "Looking for problems in " + scopeName + "..."
+	return func() tea.Msg {
+		ctx := context.Background()
+		problems, err := k8s.FindPodProblems(ctx, clientset, namespace, scope)
+		if err != nil || len(problems) == 0 {
+			return podProblemMsg{scope: scopeName, err: err}
+		}
+		worst := &problems[0]
+		workload := k8s.WorkloadOwner(ctx, clientset, &worst.Pod)
+		pods, err := k8s.GetWorkloadPods(ctx, clientset, *workload)
+		if err != nil {
+			pods = []k8s.PodInfo{worst.Pod}
+		}
+		return podProblemMsg{scope: scopeName, problem: worst, workload: workload, pods: pods}
+	}
+}
+
+func (m *Model) handleTriage(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case podProblemMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.recordError("problems", msg.err)
+			m.statusMsg = "Problem search failed: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		if msg.problem == nil {
+			m.statusMsg = "No unhealthy pods in " + msg.scope
+			return nil, true
+		}
+
+		pod := msg.problem.Pod
+		if pod.Namespace != m.k8sClient.Namespace() {
+			m.k8sClient.SetNamespace(pod.Namespace)
+			m.config.SetLastNamespace(pod.Namespace)
+		}
+		m.workload = msg.workload
+		m.navigator.SetPodsUnavailable("")
+		m.navigator.SetStatefulSet(nil)
+		m.navigator.SetPods(msg.pods)
+		m.navigator.SetMode(components.ModePods)
+		cmd := m.openPod(&pod)
+		m.statusMsg = pod.Name + ": " + strings.Join(msg.problem.Reasons, ", ")
+		return cmd, true
+	}
+	return nil, false
+}
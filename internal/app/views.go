@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/config"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+)
+
+// currentViewScope describes what saving a view now would capture
+func (m *Model) currentViewScope() string {
+	scope := m.k8sClient.Namespace() + " / " + string(m.navigator.ResourceType())
+	if q := m.navigator.SearchQuery(); q != "" {
+		scope += " / filter " + q
+	}
+	return scope
+}
+
+// openSaveView asks for a name to save the workload list under
+func (m *Model) openSaveView() {
+	if m.navigator.Mode() != components.ModeWorkloads {
+		return
+	}
+	m.saveViewPrompt.Show(m.currentViewScope(), m.navigator.LabelFilter().String())
+}
+
+// openViewPalette lists the saved views to recall one
+func (m *Model) openViewPalette() {
+	items := make([]components.PaletteItem, 0, len(m.config.SavedViews))
+	for _, v := range m.config.SavedViews {
+		desc := []string{v.Namespace, v.ResourceType}
+		if v.LabelSelector != "" {
+			desc = append(desc, "-l "+v.LabelSelector)
+		}
+		if v.Filter != "" {
+			desc = append(desc, "/"+v.Filter)
+		}
+		items = append(items, components.PaletteItem{Name: v.Name, Description: strings.Join(desc, " ")})
+	}
+	m.viewPalette.Show(items)
+}
+
+// applyView switches namespace and resource type and sets the filters of a
+// saved view, then reloads the workload list
+func (m *Model) applyView(v config.SavedView) tea.Cmd {
+	filter, err := k8s.ParseLabelFilter(v.LabelSelector)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("View %s has a bad label selector: %v", v.Name, err)
+		return nil
+	}
+
+	m.cancelLoads()
+	m.view = ViewNavigator
+	m.pod = nil
+	m.workload = nil
+	if v.Namespace != "" {
+		m.k8sClient.SetNamespace(v.Namespace)
+		m.config.SetLastNamespace(v.Namespace)
+	}
+	if v.ResourceType != "" {
+		rt := k8s.ResourceType(v.ResourceType)
+		m.navigator.SetResourceType(rt)
+		m.config.SetLastResourceType(v.ResourceType)
+	}
+	m.navigator.SetMode(components.ModeWorkloads)
+	m.navigator.SetLabelFilter(filter)
+	m.navigator.SetFilter(v.Filter)
+	m.statusMsg = "View: " + v.Name
+	m.loading = true
+	return m.loadWorkloads()
+}
+
+// handleViews saves, recalls and removes saved views
+func (m *Model) handleViews(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case components.SaveViewResult:
+		filter, _ := k8s.ParseLabelFilter(msg.LabelSelector)
+		m.navigator.SetLabelFilter(filter)
+		m.config.SaveView(config.SavedView{
+			Name:          msg.Name,
+			Namespace:     m.k8sClient.Namespace(),
+			ResourceType:  string(m.navigator.ResourceType()),
+			LabelSelector: filter.String(),
+			Filter:        m.navigator.SearchQuery(),
+		})
+		m.saveConfig()
+		m.statusMsg = "Saved view " + msg.Name
+		return nil, true
+
+	case components.ViewPaletteResult:
+		if msg.Remove {
+			m.config.RemoveView(msg.Name)
+			m.saveConfig()
+			m.statusMsg = "Removed view " + msg.Name
+			return nil, true
+		}
+		for _, v := range m.config.SavedViews {
+			if v.Name == msg.Name {
+				return m.applyView(v), true
+			}
+		}
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/telemetry"
+)
+
+// workloadDetailMsg carries a fresh state of the workload dashboard's workload
+type workloadDetailMsg struct {
+	detail *k8s.WorkloadDetail
+	err    error
+}
+
+// openWorkloadDashboard shows w's replica status, rollout, conditions, pods
+// and events before its pod list
+func (m *Model) openWorkloadDashboard(w *k8s.WorkloadInfo) tea.Cmd {
+	m.cancelLoads()
+	m.workload = w
+	m.loading = true
+	return m.loadWorkloadDetail(w)
+}
+
+func (m *Model) loadWorkloadDetail(w *k8s.WorkloadInfo) tea.Cmd {
+	parent := m.loadCtx
+	clientset := m.k8sClient.Clientset()
+	workload := *w
+	return func() tea.Msg {
+		ctx, span := telemetry.StartRefresh(parent, "workload")
+		defer span.End()
+		detail, err := k8s.GetWorkloadDetail(ctx, clientset, workload)
+		if ctx.Err() != nil {
+			return nil // left for another view
+		}
+		return workloadDetailMsg{detail: detail, err: err}
+	}
+}
+
+// handleWorkloadDetail applies workload dashboard messages, returning false
+// for any other message
+func (m *Model) handleWorkloadDetail(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case workloadDetailMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.recordError("workload", msg.err)
+			m.statusMsg = "Cannot load workload: " + k8s.ShortError(msg.err)
+			if m.view != ViewWorkload {
+				// It never opened; fall back to the list, which a bookmark
+				// may have switched away from before it loaded
+				m.workload = nil
+				m.loading = true
+				m.navigator.SetLoading(true)
+				return m.loadWorkloads(), true
+			}
+			return nil, true
+		}
+		m.view = ViewWorkload
+		m.workloadView.SetDetail(msg.detail)
+		return nil, true
+	}
+	return nil, false
+}
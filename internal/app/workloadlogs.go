@@ -0,0 +1,194 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+// workloadLogTail is how many lines of each container a newly followed pod
+// starts with
+const workloadLogTail = 50
+
+// workloadLogPod is one pod's place in the merged workload logs
+type workloadLogPod struct {
+	following bool
+	ended     time.Time // when its last stream ended, to pick up from there
+}
+
+// workloadPodsMsg carries the workload's pods, to follow the new ones
+type workloadPodsMsg struct {
+	seq  int
+	pods []k8s.PodInfo
+	err  error
+}
+
+// workloadLogLinesMsg carries new lines from one pod's stream
+type workloadLogLinesMsg struct {
+	seq   int
+	pod   string
+	lines []k8s.LogLine
+	ch    <-chan k8s.LogLine
+}
+
+type workloadLogEndedMsg struct {
+	seq int
+	pod string
+	err error
+}
+
+// openWorkloadLogs shows the logs of every pod of w merged into one panel,
+// each line labelled with its pod and container. Pods that appear later,
+// as in a rollout, are followed from the next tick.
+func (m *Model) openWorkloadLogs(w *k8s.WorkloadInfo) tea.Cmd {
+	m.cancelLoads()
+	m.view = ViewWorkloadLogs
+	m.workloadLogsTarget = w
+	m.workloadLogPods = make(map[string]*workloadLogPod)
+	m.workloadLogSeq++
+
+	panel := components.NewLogsPanel()
+	panel.SetBudget(m.config.LogBudgetLines, m.config.LogBudgetMB<<20)
+	panel.SetGapThreshold(time.Duration(m.config.LogGapSeconds) * time.Second)
+	panel.SetLive(true)
+	panel.SetTimestampMode(components.ParseTimestampMode(m.config.LogTimestamps))
+	panel.SetANSIColors(m.config.LogANSIColors)
+	panel.SetSize(m.width-4, m.height-8)
+	m.workloadLogs = panel
+
+	return tea.Batch(m.syncWorkloadLogPods(), m.tickCmd())
+}
+
+// selectedLogsWorkload is the workload L merges the pod logs of: the one
+// under the cursor, or the one whose pods are listed
+func (m *Model) selectedLogsWorkload() *k8s.WorkloadInfo {
+	switch m.navigator.Mode() {
+	case components.ModeWorkloads:
+		if w := m.navigator.SelectedWorkload(); w != nil && w.Type != k8s.ResourceNodes {
+			return w
+		}
+	case components.ModePods:
+		return m.workload
+	}
+	return nil
+}
+
+// syncWorkloadLogPods lists the workload's pods
+func (m *Model) syncWorkloadLogPods() tea.Cmd {
+	if m.workloadLogsTarget == nil {
+		return nil
+	}
+	ctx, clientset := m.loadCtx, m.k8sClient.Clientset()
+	w, seq := *m.workloadLogsTarget, m.workloadLogSeq
+	return func() tea.Msg {
+		pods, err := k8s.GetWorkloadPods(ctx, clientset, w)
+		return workloadPodsMsg{seq: seq, pods: pods, err: err}
+	}
+}
+
+// followWorkloadPod starts streaming one pod into the merged logs
+func (m *Model) followWorkloadPod(pod k8s.PodInfo, after time.Time) tea.Cmd {
+	ctx, clientset, seq := m.loadCtx, m.k8sClient.Clientset(), m.workloadLogSeq
+	return func() tea.Msg {
+		ch, err := k8s.StreamWorkloadPodLogs(ctx, clientset, &pod, workloadLogTail, after)
+		if err != nil {
+			return workloadLogEndedMsg{seq: seq, pod: pod.Name, err: err}
+		}
+		return waitForWorkloadLogLines(seq, pod.Name, ch)()
+	}
+}
+
+func waitForWorkloadLogLines(seq int, pod string, ch <-chan k8s.LogLine) tea.Cmd {
+	return func() tea.Msg {
+		lines := readLogBatch(ch)
+		if lines == nil {
+			return workloadLogEndedMsg{seq: seq, pod: pod}
+		}
+		return workloadLogLinesMsg{seq: seq, pod: pod, lines: lines, ch: ch}
+	}
+}
+
+// handleWorkloadLogs applies merged workload log messages, returning false
+// for any other message
+func (m *Model) handleWorkloadLogs(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case workloadPodsMsg:
+		if msg.seq != m.workloadLogSeq {
+			return nil, true
+		}
+		if msg.err != nil {
+			m.recordError("workload logs", msg.err)
+			m.statusMsg = "Cannot list pods: " + k8s.ShortError(msg.err)
+			return nil, true
+		}
+		var cmds []tea.Cmd
+		var sources []string
+		for _, pod := range msg.pods {
+			for _, c := range pod.Containers {
+				sources = append(sources, k8s.PodLogLabel(pod.Name, c.Name))
+			}
+			state, ok := m.workloadLogPods[pod.Name]
+			if !ok {
+				state = &workloadLogPod{}
+				m.workloadLogPods[pod.Name] = state
+			}
+			if state.following || !k8s.LogsFollowable(&pod) {
+				continue
+			}
+			state.following = true
+			cmds = append(cmds, m.followWorkloadPod(pod, state.ended))
+		}
+		sort.Strings(sources)
+		// Setting them resets the [ ] selection, so only when pods come or go
+		if !slices.Equal(sources, m.workloadLogs.Containers()) {
+			m.workloadLogs.SetContainers(sources)
+		}
+		return tea.Batch(cmds...), true
+
+	case workloadLogLinesMsg:
+		if msg.seq != m.workloadLogSeq {
+			return nil, true
+		}
+		m.workloadLogs.InsertLogs(msg.lines)
+		return waitForWorkloadLogLines(msg.seq, msg.pod, msg.ch), true
+
+	case workloadLogEndedMsg:
+		if msg.seq != m.workloadLogSeq {
+			return nil, true
+		}
+		// The pod is gone or its container exited; if it is still around the
+		// next sync picks it up again from here
+		if state, ok := m.workloadLogPods[msg.pod]; ok {
+			state.following = false
+			state.ended = time.Now()
+		}
+		m.recordError("workload logs", msg.err)
+		return nil, true
+	}
+	return nil, false
+}
+
+// workloadLogsView renders the merged logs under a title naming the
+// workload and how many pods are followed
+func (m Model) workloadLogsView() string {
+	following := 0
+	for _, state := range m.workloadLogPods {
+		if state.following {
+			following++
+		}
+	}
+	w := m.workloadLogsTarget
+	title := styles.TitleStyle.Render(fmt.Sprintf("%s/%s", w.Type, w.Name)) +
+		styles.HelpDescStyle.Render(fmt.Sprintf("  logs of %d pods  [ ] pick a pod/container, esc back", following))
+
+	m.workloadLogs.SetSize(m.width-4, m.height-8)
+	panel := styles.ActivePanelStyle.Width(m.width - 4).Height(m.height - 8).Render(m.workloadLogs.View())
+	return lipgloss.JoinVertical(lipgloss.Left, title, panel)
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUEviction(t *testing.T) {
+	c := New[string, int](2, time.Minute)
+	c.Put("a", 1)
+	c.Put("b", 2)
+
+	// Touch a so b becomes the least recently used
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %d, %v; want 1, true", v, ok)
+	}
+	c.Put("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		if v, ok := c.Get(key); !ok || v != want {
+			t.Errorf("Get(%s) = %d, %v; want %d, true", key, v, ok, want)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+}
+
+func TestLRUExpiry(t *testing.T) {
+	now := time.Now()
+	c := New[string, int](4, 10*time.Second)
+	c.now = func() time.Time { return now }
+
+	c.Put("a", 1)
+	now = now.Add(5 * time.Second)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("a should still be fresh")
+	}
+
+	// Put resets the expiry
+	c.Put("a", 2)
+	now = now.Add(8 * time.Second)
+	if v, ok := c.Get("a"); !ok || v != 2 {
+		t.Fatalf("Get(a) = %d, %v; want 2, true", v, ok)
+	}
+
+	now = now.Add(3 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("a should have expired")
+	}
+	if c.Len() != 0 {
+		t.Errorf("expired entry should be dropped, Len() = %d", c.Len())
+	}
+}
+
+func TestLRURemoveAndPurge(t *testing.T) {
+	c := New[string, int](4, time.Minute)
+	c.Put("a", 1)
+	c.Put("b", 2)
+
+	c.Remove("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("a should be removed")
+	}
+
+	c.Purge()
+	if _, ok := c.Get("b"); ok || c.Len() != 0 {
+		t.Error("Purge should drop every entry")
+	}
+}
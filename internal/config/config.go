@@ -4,16 +4,109 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 )
 
 type Config struct {
-	LastNamespace    string   `json:"last_namespace"`
-	LastContext      string   `json:"last_context"`
-	LastResourceType string   `json:"last_resource_type"`
-	FavoriteItems    []string `json:"favorite_items"`
-	LogLineLimit     int      `json:"log_line_limit"`
-	RefreshInterval  int      `json:"refresh_interval_seconds"`
-	Theme            string   `json:"theme"`
+	LastNamespace        string            `json:"last_namespace"`
+	LastContext          string            `json:"last_context"`
+	LastResourceType     string            `json:"last_resource_type"`
+	ResourceTypes        []string          `json:"resource_types,omitempty"` // types offered by t, in order; empty for all
+	FavoriteItems        []string          `json:"favorite_items"`
+	LogLineLimit         int               `json:"log_line_limit"`
+	RefreshInterval      int               `json:"refresh_interval_seconds"`
+	Theme                string            `json:"theme"`
+	WatchedItems         []string          `json:"watched_items"`
+	WebhookURL           string            `json:"webhook_url"`
+	TraceIDPattern       string            `json:"trace_id_pattern"`
+	TraceLinks           []TraceLink       `json:"trace_links"`
+	LogBackend           *LogBackendConfig `json:"log_backend,omitempty"`
+	Integration          string            `json:"integration"`
+	Pager                string            `json:"pager"`
+	DiffTool             string            `json:"diff_tool"`
+	RegistryLookup       bool              `json:"registry_lookup"`
+	VulnerabilityReports bool              `json:"vulnerability_reports"` // show trivy-operator CVE counts
+	LogBudgetLines       int               `json:"log_budget_lines"`
+	LogBudgetMB          int               `json:"log_budget_mb"`
+	LogGapSeconds        int               `json:"log_gap_seconds"` // mark silences longer than this in the logs, 0 for never
+	SavedViews           []SavedView       `json:"saved_views,omitempty"`
+	Bookmarks            []Bookmark        `json:"bookmarks,omitempty"`
+	DebugImage           string            `json:"debug_image"`     // image of the Debug pod action's ephemeral container
+	LogExportDir         string            `json:"log_export_dir"`  // where s in the logs panel saves logs, the working directory when empty
+	LogTimestamps        string            `json:"log_timestamps"`  // clock, full, relative or off
+	LogANSIColors        bool              `json:"log_ansi_colors"` // show applications' own log colors instead of level colors
+	EventBell            bool              `json:"event_bell"`      // ring the terminal bell on new warning events in the dashboard
+	PodLint              bool              `json:"pod_lint"`        // show best practice hints about the pod spec
+	WatchNotify          string            `json:"watch_notify"`    // bell, desktop or both when a watched item starts failing or recovers
+	RecentCommands       []RecentCommand   `json:"recent_commands,omitempty"`
+	Columns              []Column          `json:"columns,omitempty"` // extra list columns from labels and annotations
+}
+
+// maxRecentCommands is how many execs and port-forwards are remembered per
+// workload
+const maxRecentCommands = 5
+
+// RecentCommand is an exec or port-forward run against a pod, remembered
+// for the pod's workload so its next pods offer it again. Exec is set for an
+// exec, the ports for a port-forward.
+type RecentCommand struct {
+	Target     string   `json:"target"` // namespace/Kind/name of the workload
+	Container  string   `json:"container,omitempty"`
+	Exec       []string `json:"exec,omitempty"`
+	LocalPort  int      `json:"local_port,omitempty"`
+	RemotePort int      `json:"remote_port,omitempty"`
+}
+
+// SavedView is a named workload list to come back to, e.g. "payments prod
+// errors": a namespace, resource type, label selector and search filter
+type SavedView struct {
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace"`
+	ResourceType  string `json:"resource_type"`
+	LabelSelector string `json:"label_selector,omitempty"`
+	Filter        string `json:"filter,omitempty"`
+}
+
+// Bookmark is a named debugging target to jump to, with `k9sight open
+// <name>` or the in-app picker: a context, namespace and resource type, and
+// optionally one workload of that type. Empty fields keep the current value.
+type Bookmark struct {
+	Name         string `json:"name"`
+	Context      string `json:"context,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	Workload     string `json:"workload,omitempty"`
+}
+
+// LogBackendConfig points the logs panel at an external log store so logs
+// survive pod restarts. Type is "loki" or "elasticsearch".
+type LogBackendConfig struct {
+	Type          string `json:"type"`
+	URL           string `json:"url"`
+	Query         string `json:"query,omitempty"`
+	Index         string `json:"index,omitempty"`
+	TenantID      string `json:"tenant_id,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	BearerToken   string `json:"bearer_token,omitempty"`
+	LookbackHours int    `json:"lookback_hours,omitempty"`
+}
+
+// Column adds a navigator column showing a label or annotation of each
+// workload and pod, e.g. {"label": "team"} or
+// {"header": "SHA", "annotation": "example.com/git-sha", "width": 8}
+type Column struct {
+	Header     string `json:"header,omitempty"`
+	Label      string `json:"label,omitempty"`
+	Annotation string `json:"annotation,omitempty"`
+	Width      int    `json:"width,omitempty"`
+}
+
+// TraceLink is a tracing backend URL template, e.g.
+// {"name": "Jaeger", "url": "http://jaeger:16686/trace/{trace_id}"}
+type TraceLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 func DefaultConfig() *Config {
@@ -23,6 +116,12 @@ func DefaultConfig() *Config {
 		LogLineLimit:     500,
 		RefreshInterval:  5,
 		Theme:            "default",
+		Integration:      "auto",
+		LogBudgetLines:   50000,
+		LogBudgetMB:      64,
+		LogGapSeconds:    30,
+		DebugImage:       "busybox",
+		LogTimestamps:    "clock",
 	}
 }
 
@@ -112,3 +211,113 @@ func (c *Config) IsFavorite(item string) bool {
 	}
 	return false
 }
+
+func (c *Config) AddWatch(item string) {
+	for _, w := range c.WatchedItems {
+		if w == item {
+			return
+		}
+	}
+	c.WatchedItems = append(c.WatchedItems, item)
+}
+
+func (c *Config) RemoveWatch(item string) {
+	for i, w := range c.WatchedItems {
+		if w == item {
+			c.WatchedItems = append(c.WatchedItems[:i], c.WatchedItems[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *Config) IsWatched(item string) bool {
+	for _, w := range c.WatchedItems {
+		if w == item {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleWatch flips the watch flag for item and returns the new state.
+func (c *Config) ToggleWatch(item string) bool {
+	if c.IsWatched(item) {
+		c.RemoveWatch(item)
+		return false
+	}
+	c.AddWatch(item)
+	return true
+}
+
+// AddRecentCommand puts rc first among its target's recent commands, moving
+// it there if it was run before and dropping the oldest beyond
+// maxRecentCommands
+func (c *Config) AddRecentCommand(rc RecentCommand) {
+	kept := []RecentCommand{rc}
+	count := 1
+	for _, r := range c.RecentCommands {
+		if r.Target == rc.Target {
+			if sameCommand(r, rc) || count == maxRecentCommands {
+				continue
+			}
+			count++
+		}
+		kept = append(kept, r)
+	}
+	c.RecentCommands = kept
+}
+
+// RecentCommandsFor lists target's recent commands, most recent first
+func (c *Config) RecentCommandsFor(target string) []RecentCommand {
+	var recent []RecentCommand
+	for _, r := range c.RecentCommands {
+		if r.Target == target {
+			recent = append(recent, r)
+		}
+	}
+	return recent
+}
+
+func sameCommand(a, b RecentCommand) bool {
+	return a.Container == b.Container && slices.Equal(a.Exec, b.Exec) &&
+		a.LocalPort == b.LocalPort && a.RemotePort == b.RemotePort
+}
+
+// SaveView stores v, replacing any saved view with the same name
+func (c *Config) SaveView(v SavedView) {
+	for i, saved := range c.SavedViews {
+		if saved.Name == v.Name {
+			c.SavedViews[i] = v
+			return
+		}
+	}
+	c.SavedViews = append(c.SavedViews, v)
+}
+
+// FindBookmark returns the bookmark called name
+func (c *Config) FindBookmark(name string) (Bookmark, bool) {
+	for _, b := range c.Bookmarks {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return Bookmark{}, false
+}
+
+func (c *Config) RemoveBookmark(name string) {
+	for i, b := range c.Bookmarks {
+		if b.Name == name {
+			c.Bookmarks = append(c.Bookmarks[:i], c.Bookmarks[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *Config) RemoveView(name string) {
+	for i, saved := range c.SavedViews {
+		if saved.Name == name {
+			c.SavedViews = append(c.SavedViews[:i], c.SavedViews[i+1:]...)
+			return
+		}
+	}
+}
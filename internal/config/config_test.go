@@ -20,6 +20,10 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("DefaultConfig().LogLineLimit = %d, should be positive", cfg.LogLineLimit)
 	}
 
+	if cfg.LogBudgetLines <= 0 || cfg.LogBudgetMB <= 0 {
+		t.Errorf("DefaultConfig() log budget = %d lines / %d MB, should be positive", cfg.LogBudgetLines, cfg.LogBudgetMB)
+	}
+
 	if cfg.RefreshInterval <= 0 {
 		t.Errorf("DefaultConfig().RefreshInterval = %d, should be positive", cfg.RefreshInterval)
 	}
@@ -133,3 +137,101 @@ func TestSetters(t *testing.T) {
 		t.Errorf("After SetLastResourceType, LastResourceType = %q, want %q", cfg.LastResourceType, "statefulsets")
 	}
 }
+
+func TestToggleWatch(t *testing.T) {
+	cfg := DefaultConfig()
+
+	if !cfg.ToggleWatch("default/deployments/api") {
+		t.Errorf("ToggleWatch on unwatched item should return true")
+	}
+	if !cfg.IsWatched("default/deployments/api") {
+		t.Errorf("IsWatched should be true after ToggleWatch")
+	}
+
+	cfg.AddWatch("default/deployments/api")
+	if len(cfg.WatchedItems) != 1 {
+		t.Errorf("After duplicate AddWatch, len(WatchedItems) = %d, want 1", len(cfg.WatchedItems))
+	}
+
+	if cfg.ToggleWatch("default/deployments/api") {
+		t.Errorf("ToggleWatch on watched item should return false")
+	}
+	if len(cfg.WatchedItems) != 0 {
+		t.Errorf("After second ToggleWatch, len(WatchedItems) = %d, want 0", len(cfg.WatchedItems))
+	}
+}
+
+func TestSavedViews(t *testing.T) {
+	cfg := DefaultConfig()
+
+	cfg.SaveView(SavedView{Name: "payments prod errors", Namespace: "payments", ResourceType: "deployments", Filter: "error"})
+	cfg.SaveView(SavedView{Name: "jobs", Namespace: "batch", ResourceType: "jobs"})
+	cfg.SaveView(SavedView{Name: "payments prod errors", Namespace: "payments", ResourceType: "pods", LabelSelector: "app=api"})
+	if len(cfg.SavedViews) != 2 {
+		t.Fatalf("After saving a view twice, len(SavedViews) = %d, want 2", len(cfg.SavedViews))
+	}
+	if v := cfg.SavedViews[0]; v.ResourceType != "pods" || v.LabelSelector != "app=api" || v.Filter != "" {
+		t.Errorf("Saving an existing name should replace it in place, got %+v", v)
+	}
+
+	cfg.RemoveView("payments prod errors")
+	if len(cfg.SavedViews) != 1 || cfg.SavedViews[0].Name != "jobs" {
+		t.Errorf("After RemoveView, SavedViews = %+v", cfg.SavedViews)
+	}
+	cfg.RemoveView("missing")
+	if len(cfg.SavedViews) != 1 {
+		t.Errorf("RemoveView of an unknown name should do nothing")
+	}
+}
+
+func TestBookmarks(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Bookmarks = []Bookmark{
+		{Name: "payments api", Context: "prod", Namespace: "payments", ResourceType: "deployments", Workload: "api"},
+		{Name: "batch", Namespace: "batch", ResourceType: "jobs"},
+	}
+
+	if b, ok := cfg.FindBookmark("payments api"); !ok || b.Context != "prod" || b.Workload != "api" {
+		t.Errorf("FindBookmark(payments api) = %+v, %v", b, ok)
+	}
+	if _, ok := cfg.FindBookmark("missing"); ok {
+		t.Error("FindBookmark of an unknown name should report false")
+	}
+
+	cfg.RemoveBookmark("payments api")
+	if len(cfg.Bookmarks) != 1 || cfg.Bookmarks[0].Name != "batch" {
+		t.Errorf("After RemoveBookmark, Bookmarks = %+v", cfg.Bookmarks)
+	}
+}
+
+func TestRecentCommands(t *testing.T) {
+	cfg := DefaultConfig()
+	shell := RecentCommand{Target: "prod/Deployment/web", Container: "app", Exec: []string{"sh"}}
+	forward := RecentCommand{Target: "prod/Deployment/web", LocalPort: 8080, RemotePort: 80}
+	other := RecentCommand{Target: "prod/StatefulSet/db", Container: "db", Exec: []string{"bash"}}
+
+	cfg.AddRecentCommand(shell)
+	cfg.AddRecentCommand(other)
+	cfg.AddRecentCommand(forward)
+	// Running it again moves it to the front instead of repeating it
+	cfg.AddRecentCommand(shell)
+
+	recent := cfg.RecentCommandsFor("prod/Deployment/web")
+	if len(recent) != 2 || !sameCommand(recent[0], shell) || !sameCommand(recent[1], forward) {
+		t.Errorf("RecentCommandsFor(web) = %+v", recent)
+	}
+	if recent := cfg.RecentCommandsFor("prod/StatefulSet/db"); len(recent) != 1 {
+		t.Errorf("RecentCommandsFor(db) = %+v", recent)
+	}
+
+	for port := 1; port <= maxRecentCommands+2; port++ {
+		cfg.AddRecentCommand(RecentCommand{Target: "prod/Deployment/web", LocalPort: port, RemotePort: port})
+	}
+	recent = cfg.RecentCommandsFor("prod/Deployment/web")
+	if len(recent) != maxRecentCommands || recent[0].LocalPort != maxRecentCommands+2 {
+		t.Errorf("after overflow RecentCommandsFor(web) = %+v", recent)
+	}
+	if recent := cfg.RecentCommandsFor("prod/StatefulSet/db"); len(recent) != 1 {
+		t.Errorf("other workloads must keep their commands, got %+v", recent)
+	}
+}
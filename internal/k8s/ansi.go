@@ -0,0 +1,89 @@
+package k8s
+
+import "strings"
+
+// Applications that detect a terminal, or are told to, color their logs with
+// ANSI escape sequences. Only SGR sequences (colors and text attributes) are
+// safe to show; cursor movement, screen clearing and OSC titles or links
+// would garble the panel.
+
+const esc = 0x1b
+
+// StripANSI removes escape sequences and control characters other than tabs,
+// leaving the text as it reads
+func StripANSI(s string) string {
+	return scanANSI(s, false)
+}
+
+// SanitizeANSI keeps the SGR color and attribute sequences of s and drops
+// every other escape sequence and control character. A reset is appended
+// when any color was kept, so it does not bleed into what follows.
+func SanitizeANSI(s string) string {
+	return scanANSI(s, true)
+}
+
+func scanANSI(s string, keepSGR bool) string {
+	if !hasControl(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	kept := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == esc && i+1 < len(s) && s[i+1] == '[':
+			// CSI: parameter and intermediate bytes, then a final byte
+			end := i + 2
+			for end < len(s) && (s[end] < 0x40 || s[end] > 0x7e) {
+				end++
+			}
+			if end == len(s) {
+				return b.String() // cut off mid-sequence
+			}
+			if keepSGR && s[end] == 'm' && sgrParams(s[i+2:end]) {
+				b.WriteString(s[i : end+1])
+				kept = true
+			}
+			i = end
+		case c == esc && i+1 < len(s) && s[i+1] == ']':
+			// OSC, ended by BEL or ESC \
+			end := i + 2
+			for end < len(s) && s[end] != 0x07 && !(s[end] == esc && end+1 < len(s) && s[end+1] == '\\') {
+				end++
+			}
+			if end < len(s) && s[end] == esc {
+				end++
+			}
+			i = end
+		case c == esc:
+			i++ // a two-byte sequence
+		case c == '\t' || c >= 0x20 && c != 0x7f:
+			b.WriteByte(c)
+		}
+	}
+	if kept {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// sgrParams reports whether a CSI sequence's parameters are the digits and
+// separators of SGR, not private or intermediate bytes
+func sgrParams(params string) bool {
+	for i := 0; i < len(params); i++ {
+		if c := params[i]; (c < '0' || c > '9') && c != ';' && c != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+func hasControl(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < 0x20 && c != '\t') || c == 0x7f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package k8s
+
+import "testing"
+
+func TestANSI(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		wantStrip string
+		wantSafe  string
+	}{
+		{"plain", "GET /healthz 200", "GET /healthz 200", "GET /healthz 200"},
+		{"colored level", "\x1b[31mERROR\x1b[0m db down", "ERROR db down", "\x1b[31mERROR\x1b[0m db down\x1b[0m"},
+		{"256 colors", "\x1b[38;5;208mwarn\x1b[m", "warn", "\x1b[38;5;208mwarn\x1b[m\x1b[0m"},
+		{"cursor and clear dropped", "\x1b[2K\x1b[1Aprogress 50%\r", "progress 50%", "progress 50%"},
+		{"osc title and link", "\x1b]0;title\x07see \x1b]8;;http://x\x1b\\docs\x1b]8;;\x1b\\", "see docs", "see docs"},
+		{"private mode", "\x1b[?25lhidden cursor", "hidden cursor", "hidden cursor"},
+		{"tabs kept", "a\tb\x00", "a\tb", "a\tb"},
+		{"cut off", "ok \x1b[3", "ok ", "ok "},
+	}
+	for _, tt := range tests {
+		if got := StripANSI(tt.in); got != tt.wantStrip {
+			t.Errorf("%s: StripANSI() = %q, want %q", tt.name, got, tt.wantStrip)
+		}
+		if got := SanitizeANSI(tt.in); got != tt.wantSafe {
+			t.Errorf("%s: SanitizeANSI() = %q, want %q", tt.name, got, tt.wantSafe)
+		}
+	}
+
+	if got := ClassifyLogLevel("\x1b[31mERROR\x1b[0m db down"); got != LevelError {
+		t.Errorf("ClassifyLogLevel() of a colored line = %v, want error", got)
+	}
+}
@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// BlastRadius is what a restart or scale of a workload touches: its pods,
+// the PodDisruptionBudgets covering them and the HPA managing its replicas
+type BlastRadius struct {
+	Pods int
+	PDBs []PDBInfo
+	HPA  *HPAInfo
+}
+
+// PDBInfo is a PodDisruptionBudget covering a workload's pods
+type PDBInfo struct {
+	Name               string
+	Budget             string // "minAvailable 2" or "maxUnavailable 25%"
+	DisruptionsAllowed int32
+	CurrentHealthy     int32
+	DesiredHealthy     int32
+}
+
+// GetBlastRadius counts the workload's pods and finds the PDBs and HPA that
+// apply to it. Without permission to list PDBs or HPAs those are left out.
+func GetBlastRadius(ctx context.Context, clientset *kubernetes.Clientset, w WorkloadInfo) (BlastRadius, error) {
+	pods, err := GetWorkloadPods(ctx, clientset, w)
+	if err != nil {
+		return BlastRadius{}, err
+	}
+	b := BlastRadius{Pods: len(pods)}
+
+	// PDBs select pods, so match them against a running pod's labels when
+	// there is one, which carry more than the workload's selector
+	podLabels := w.Labels
+	if len(pods) > 0 {
+		podLabels = pods[0].Labels
+	}
+	if pdbs, err := clientset.PolicyV1().PodDisruptionBudgets(w.Namespace).List(ctx, metav1.ListOptions{}); err == nil {
+		b.PDBs = matchPDBs(pdbs.Items, podLabels)
+	}
+	if IsScalable(w.Type) {
+		b.HPA, _ = FindHPA(ctx, clientset, w.Namespace, w.Type, w.Name)
+	}
+	return b, nil
+}
+
+func matchPDBs(pdbs []policyv1.PodDisruptionBudget, podLabels map[string]string) []PDBInfo {
+	var matched []PDBInfo
+	for _, pdb := range pdbs {
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(podLabels)) {
+			continue
+		}
+		info := PDBInfo{
+			Name:               pdb.Name,
+			DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+			CurrentHealthy:     pdb.Status.CurrentHealthy,
+			DesiredHealthy:     pdb.Status.DesiredHealthy,
+		}
+		switch {
+		case pdb.Spec.MinAvailable != nil:
+			info.Budget = "minAvailable " + pdb.Spec.MinAvailable.String()
+		case pdb.Spec.MaxUnavailable != nil:
+			info.Budget = "maxUnavailable " + pdb.Spec.MaxUnavailable.String()
+		}
+		matched = append(matched, info)
+	}
+	return matched
+}
+
+// RestartImpact describes what restarting the workload does
+func (b BlastRadius) RestartImpact() []string {
+	lines := []string{fmt.Sprintf("Replaces all %s", pluralPods(b.Pods))}
+	for _, pdb := range b.PDBs {
+		lines = append(lines, fmt.Sprintf("PDB %s (%s) allows %d disruption(s) now", pdb.Name, pdb.Budget, pdb.DisruptionsAllowed))
+	}
+	return lines
+}
+
+// ScaleImpact describes what scaling the workload from one replica count to
+// another does. PDBs only guard evictions, so scaling down goes past them.
+func (b BlastRadius) ScaleImpact(from, to int32) []string {
+	var lines []string
+	switch {
+	case to == 0:
+		lines = append(lines, fmt.Sprintf("Stops all %s", pluralPods(b.Pods)))
+	case to < from:
+		lines = append(lines, fmt.Sprintf("Removes %d of %s", from-to, pluralPods(b.Pods)))
+	case to > from:
+		lines = append(lines, fmt.Sprintf("Adds %d to %s", to-from, pluralPods(b.Pods)))
+	}
+	if to < from {
+		for _, pdb := range b.PDBs {
+			lines = append(lines, fmt.Sprintf("PDB %s (%s) does not hold back scaling down", pdb.Name, pdb.Budget))
+		}
+	}
+	if hpa := b.HPA; hpa != nil {
+		switch {
+		case to == 0:
+			lines = append(lines, fmt.Sprintf("HPA %s stops autoscaling until scaled back up", hpa.Name))
+		case to < hpa.MinReplicas || to > hpa.MaxReplicas:
+			lines = append(lines, fmt.Sprintf("HPA %s will scale it back within %d-%d", hpa.Name, hpa.MinReplicas, hpa.MaxReplicas))
+		default:
+			lines = append(lines, fmt.Sprintf("HPA %s may change it again (%d-%d)", hpa.Name, hpa.MinReplicas, hpa.MaxReplicas))
+		}
+	}
+	return lines
+}
+
+func pluralPods(n int) string {
+	if n == 1 {
+		return "1 running pod"
+	}
+	return fmt.Sprintf("%d running pods", n)
+}
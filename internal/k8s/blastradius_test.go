@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestMatchPDBs(t *testing.T) {
+	two := intstr.FromInt(2)
+	quarter := intstr.FromString("25%")
+	pdbs := []policyv1.PodDisruptionBudget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				MinAvailable: &two,
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+				MaxUnavailable: &quarter,
+			},
+		},
+		{
+			// An empty selector matches nothing for a policy/v1 PDB
+			ObjectMeta: metav1.ObjectMeta{Name: "empty"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{}},
+		},
+	}
+
+	got := matchPDBs(pdbs, map[string]string{"app": "web", "pod-template-hash": "abc"})
+	if len(got) != 1 || got[0].Name != "web" || got[0].Budget != "minAvailable 2" || got[0].DisruptionsAllowed != 1 {
+		t.Errorf("app=web: got %+v", got)
+	}
+	if got := matchPDBs(pdbs, map[string]string{"app": "api"}); len(got) != 1 || got[0].Budget != "maxUnavailable 25%" {
+		t.Errorf("app=api: got %+v", got)
+	}
+	if got := matchPDBs(pdbs, map[string]string{"app": "db"}); len(got) != 0 {
+		t.Errorf("app=db: got %+v, want none", got)
+	}
+}
+
+func TestScaleImpact(t *testing.T) {
+	pdb := PDBInfo{Name: "web", Budget: "minAvailable 2"}
+	hpa := &HPAInfo{Name: "web", MinReplicas: 2, MaxReplicas: 10}
+	tests := []struct {
+		name     string
+		radius   BlastRadius
+		from, to int32
+		want     []string
+	}{
+		{"to zero", BlastRadius{Pods: 3, PDBs: []PDBInfo{pdb}}, 3, 0, []string{"Stops all 3 running pods", "PDB web (minAvailable 2) does not hold back"}},
+		{"down", BlastRadius{Pods: 1}, 1, 0, []string{"Stops all 1 running pod"}},
+		{"down with hpa", BlastRadius{Pods: 3, HPA: hpa}, 3, 1, []string{"Removes 2 of 3 running pods", "HPA web will scale it back within 2-10"}},
+		{"up", BlastRadius{Pods: 2, PDBs: []PDBInfo{pdb}, HPA: hpa}, 2, 4, []string{"Adds 2 to 2 running pods", "HPA web may change it again (2-10)"}},
+	}
+	for _, tt := range tests {
+		got := tt.radius.ScaleImpact(tt.from, tt.to)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %q", tt.name, got)
+			continue
+		}
+		for i, want := range tt.want {
+			if !strings.HasPrefix(got[i], want) {
+				t.Errorf("%s: line %d = %q, want prefix %q", tt.name, i, got[i], want)
+			}
+		}
+	}
+}
+
+func TestRestartImpact(t *testing.T) {
+	b := BlastRadius{Pods: 4, PDBs: []PDBInfo{{Name: "web", Budget: "maxUnavailable 1", DisruptionsAllowed: 0}}}
+	got := strings.Join(b.RestartImpact(), "\n")
+	if got != "Replaces all 4 running pods\nPDB web (maxUnavailable 1) allows 0 disruption(s) now" {
+		t.Errorf("RestartImpact() = %q", got)
+	}
+}
@@ -0,0 +1,150 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FinishedPod is a pod that will not run again, Failed or Succeeded, and
+// stays around until deleted
+type FinishedPod struct {
+	Name     string
+	Phase    string
+	Reason   string    // Evicted, Completed, Error, OOMKilled...
+	Finished time.Time // when its last container terminated
+}
+
+// FinishedPods lists the Failed and Succeeded pods of a workload, or of the
+// whole namespace when workload is nil, sorted by name. A CronJob's pods are
+// those of the Jobs it created.
+func FinishedPods(ctx context.Context, clientset *kubernetes.Clientset, namespace string, workload *WorkloadInfo) ([]FinishedPod, error) {
+	opts := metav1.ListOptions{}
+	var jobs map[string]bool
+	if workload != nil {
+		switch {
+		case workload.Type == ResourcePods:
+			opts.FieldSelector = "metadata.name=" + workload.Name
+		case workload.Type == ResourceCronJobs:
+			runs, err := GetCronJobRuns(ctx, clientset, namespace, workload.Name)
+			if err != nil {
+				return nil, err
+			}
+			jobs = make(map[string]bool, len(runs))
+			for _, r := range runs {
+				jobs[r.Name] = true
+			}
+		case len(workload.Labels) == 0:
+			return nil, nil // an empty selector would match every pod
+		default:
+			opts.LabelSelector = labels.SelectorFromSet(workload.Labels).String()
+		}
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	var finished []FinishedPod
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if p.Status.Phase != corev1.PodFailed && p.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		if jobs != nil && !ownedByJobIn(p.OwnerReferences, jobs) {
+			continue
+		}
+		finished = append(finished, finishedPod(p))
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].Name < finished[j].Name })
+	return finished, nil
+}
+
+func ownedByJobIn(refs []metav1.OwnerReference, jobs map[string]bool) bool {
+	for _, ref := range refs {
+		if ref.Kind == "Job" && jobs[ref.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// finishedPod reads why a pod finished: the pod's own reason, as for an
+// evicted pod, else its first terminated container's
+func finishedPod(p *corev1.Pod) FinishedPod {
+	f := FinishedPod{Name: p.Name, Phase: string(p.Status.Phase), Reason: p.Status.Reason, Finished: p.CreationTimestamp.Time}
+	for _, cs := range p.Status.ContainerStatuses {
+		t := cs.State.Terminated
+		if t == nil {
+			continue
+		}
+		if f.Reason == "" {
+			f.Reason = t.Reason
+		}
+		if t.FinishedAt.After(f.Finished) {
+			f.Finished = t.FinishedAt.Time
+		}
+	}
+	return f
+}
+
+// FormatFinishedPods previews the pods a cleanup deletes, one per line with
+// its phase, reason and age, listing at most limit of them
+func FormatFinishedPods(pods []FinishedPod, limit int, now time.Time) string {
+	var b strings.Builder
+	for i, p := range pods {
+		if limit > 0 && i == limit {
+			fmt.Fprintf(&b, "  ... and %d more\n", len(pods)-limit)
+			break
+		}
+		state := p.Phase
+		if p.Reason != "" && p.Reason != p.Phase {
+			state += "/" + p.Reason
+		}
+		fmt.Fprintf(&b, "  %s  %s  %s ago\n", p.Name, state, FormatDuration(now.Sub(p.Finished)))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// FinishedPodsCommand is the kubectl equivalent of deleting the finished
+// pods: by phase and the workload's selector, or by name for the pods of a
+// CronJob's Jobs, which share no selector. kubectl is the invocation
+// addressing the cluster, see KubectlFor.
+func FinishedPodsCommand(kubectl, namespace string, workload *WorkloadInfo, pods []FinishedPod) string {
+	if workload != nil && (workload.Type == ResourceCronJobs || workload.Type == ResourcePods) {
+		names := make([]string, len(pods))
+		for i, p := range pods {
+			names[i] = p.Name
+		}
+		return fmt.Sprintf("%s delete pods -n %s %s", kubectl, namespace, strings.Join(names, " "))
+	}
+	cmd := fmt.Sprintf("%s delete pods -n %s --field-selector status.phase!=Running,status.phase!=Pending,status.phase!=Unknown", kubectl, namespace)
+	if workload != nil {
+		cmd += " -l " + labels.SelectorFromSet(workload.Labels).String()
+	}
+	return cmd
+}
+
+// DeletePods deletes the named pods, carrying on past failures, and returns
+// how many were deleted with the first error
+func DeletePods(ctx context.Context, clientset *kubernetes.Clientset, namespace string, names []string) (int, error) {
+	deleted := 0
+	var firstErr error
+	for _, name := range names {
+		if err := DeletePod(ctx, clientset, namespace, name); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		deleted++
+	}
+	return deleted, firstErr
+}
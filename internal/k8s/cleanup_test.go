@@ -0,0 +1,81 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFinishedPod(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	terminated := func(reason string, at time.Time) corev1.ContainerStatus {
+		return corev1.ContainerStatus{State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Reason: reason, FinishedAt: metav1.NewTime(at)},
+		}}
+	}
+
+	tests := []struct {
+		name         string
+		status       corev1.PodStatus
+		wantReason   string
+		wantFinished time.Time
+	}{
+		{"evicted", corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"}, "Evicted", created},
+		{"completed", corev1.PodStatus{
+			Phase:             corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{terminated("Completed", created.Add(time.Minute))},
+		}, "Completed", created.Add(time.Minute)},
+		{"latest container", corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{
+				terminated("OOMKilled", created.Add(2*time.Minute)),
+				terminated("Error", created.Add(3*time.Minute)),
+			},
+		}, "OOMKilled", created.Add(3 * time.Minute)},
+	}
+	for _, tt := range tests {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "p", CreationTimestamp: metav1.NewTime(created)}, Status: tt.status}
+		got := finishedPod(p)
+		if got.Reason != tt.wantReason || !got.Finished.Equal(tt.wantFinished) {
+			t.Errorf("%s: reason %q finished %v, want %q %v", tt.name, got.Reason, got.Finished, tt.wantReason, tt.wantFinished)
+		}
+	}
+}
+
+func TestFormatFinishedPods(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pods := []FinishedPod{
+		{Name: "a", Phase: "Failed", Reason: "Evicted", Finished: now.Add(-2 * time.Hour)},
+		{Name: "b", Phase: "Succeeded", Reason: "Completed", Finished: now.Add(-time.Minute)},
+		{Name: "c", Phase: "Failed", Finished: now},
+	}
+	got := FormatFinishedPods(pods, 2, now)
+	want := "  a  Failed/Evicted  2h0m ago\n  b  Succeeded/Completed  1m0s ago\n  ... and 1 more"
+	if got != want {
+		t.Errorf("FormatFinishedPods() =\n%s\nwant\n%s", got, want)
+	}
+	if got := FormatFinishedPods(pods[2:], 0, now); got != "  c  Failed  0s ago" {
+		t.Errorf("without reason = %q", got)
+	}
+}
+
+func TestFinishedPodsCommand(t *testing.T) {
+	pods := []FinishedPod{{Name: "job-1-abc"}, {Name: "job-2-def"}}
+	tests := []struct {
+		name     string
+		workload *WorkloadInfo
+		want     string
+	}{
+		{"namespace", nil, "kubectl --context prod delete pods -n ns --field-selector status.phase!=Running,status.phase!=Pending,status.phase!=Unknown"},
+		{"deployment", &WorkloadInfo{Type: ResourceDeployments, Labels: map[string]string{"app": "web"}}, "--field-selector status.phase!=Running,status.phase!=Pending,status.phase!=Unknown -l app=web"},
+		{"cronjob", &WorkloadInfo{Type: ResourceCronJobs}, "kubectl --context prod delete pods -n ns job-1-abc job-2-def"},
+	}
+	for _, tt := range tests {
+		if got := FinishedPodsCommand("kubectl --context prod", "ns", tt.workload, pods); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: command = %q, want it to contain %q", tt.name, got, tt.want)
+		}
+	}
+}
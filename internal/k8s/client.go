@@ -3,29 +3,63 @@ package k8s
 import (
 	"context"
 	"fmt"
-	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/doganarif/k9sight/internal/telemetry"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
 	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
+// namespaceTTL is how long the namespace list is served as-is; after that it
+// is still served while a refresh runs in the background
+const namespaceTTL = 5 * time.Minute
+
+// ClientOptions select the kubeconfig, context and namespace to start with;
+// empty fields fall back to kubectl's defaults
+type ClientOptions struct {
+	Kubeconfig string // path, instead of $KUBECONFIG or ~/.kube/config
+	Context    string
+	Namespace  string
+}
+
 type Client struct {
+	mu            sync.RWMutex // guards the fields SwitchContext replaces
+	kubeconfig    string
 	clientset     *kubernetes.Clientset
 	metricsClient *metricsv.Clientset
+	scaleClient   scale.ScalesGetter
+	scaleErr      error
 	config        *rest.Config
 	context       string
 	namespace     string
+	namespaces    namespaceCache
+	metricsOnce   sync.Once
+	scaleOnce     sync.Once
+}
+
+type namespaceCache struct {
+	mu         sync.Mutex
+	names      []string
+	forbidden  bool // listing is not allowed; only the current namespace is offered
+	fetched    time.Time
+	refreshing bool
 }
 
-func NewClient() (*Client, error) {
-	kubeconfig := filepath.Join(homedir.HomeDir(), ".kube", "config")
+func NewClient(opts ClientOptions) (*Client, error) {
+	clientConfig := loadClientConfig(opts.Kubeconfig, opts.Context)
 
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	config, err := clientConfig.ClientConfig()
 	if err != nil {
+		// Running inside a pod without a kubeconfig
+		if opts.Kubeconfig != "" || opts.Context != "" {
+			return nil, fmt.Errorf("failed to create kubernetes config: %w", err)
+		}
 		config, err = rest.InClusterConfig()
 		if err != nil {
 			return nil, fmt.Errorf("failed to create kubernetes config: %w", err)
@@ -33,56 +67,217 @@ func NewClient() (*Client, error) {
 	}
 
 	config.Timeout = 30 * time.Second
+	config.Wrap(telemetry.WrapTransport)
+	config.Wrap(WrapRetry) // outermost, so every attempt gets its own span
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
-	metricsClient, _ := metricsv.NewForConfig(config)
-
-	rawConfig, _ := clientcmd.NewDefaultClientConfigLoadingRules().Load()
-	currentContext := ""
-	if rawConfig != nil {
+	currentContext := opts.Context
+	if rawConfig, err := clientConfig.RawConfig(); err == nil && currentContext == "" {
 		currentContext = rawConfig.CurrentContext
 	}
 
+	// Without --namespace, start in the context's default namespace like kubectl
+	namespace := opts.Namespace
+	if namespace == "" {
+		if ns, _, err := clientConfig.Namespace(); err == nil && ns != "" {
+			namespace = ns
+		} else {
+			namespace = "default"
+		}
+	}
+
 	return &Client{
-		clientset:     clientset,
-		metricsClient: metricsClient,
-		config:        config,
-		context:       currentContext,
-		namespace:     "default",
+		kubeconfig: opts.Kubeconfig,
+		clientset:  clientset,
+		config:     config,
+		context:    currentContext,
+		namespace:  namespace,
 	}, nil
 }
 
+// loadClientConfig resolves kubeconfig files the way kubectl does, with an
+// optional explicit path and context override
+func loadClientConfig(kubeconfig, context string) clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules,
+		&clientcmd.ConfigOverrides{CurrentContext: context})
+}
+
 func (c *Client) Clientset() *kubernetes.Clientset {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.clientset
 }
 
+// restClients returns the config and clientset of the current context
+// together, for work that must stay on one cluster even if the context
+// switches meanwhile
+func (c *Client) restClients() (*rest.Config, *kubernetes.Clientset) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.config, c.clientset
+}
+
+// MetricsClient builds the metrics-server client on first use, keeping it
+// off the startup path
 func (c *Client) MetricsClient() *metricsv.Clientset {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.metricsOnce.Do(func() {
+		c.metricsClient, _ = metricsv.NewForConfig(c.config)
+	})
 	return c.metricsClient
 }
 
+// ScaleClient builds the /scale client on first use; its discovery of the
+// cluster's resources is only needed once something is scaled
+func (c *Client) ScaleClient() (scale.ScalesGetter, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.scaleOnce.Do(func() {
+		c.scaleClient, c.scaleErr = newScaleClient(c.config, c.clientset.Discovery())
+	})
+	return c.scaleClient, c.scaleErr
+}
+
+// Kubectl is the kubectl invocation that addresses the client's cluster, for
+// commands k9sight runs or offers to copy
+func (c *Client) Kubectl() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return KubectlFor(c.context, c.kubeconfig)
+}
+
+// KubectlFor is kubectl with the flags that select a kubeconfig and context,
+// e.g. "kubectl --context prod", so a command goes to the cluster shown
+// rather than to kubectl's current context
+func KubectlFor(context, kubeconfig string) string {
+	kubectl := "kubectl"
+	if kubeconfig != "" {
+		kubectl += " --kubeconfig " + shellArg(kubeconfig)
+	}
+	if context != "" {
+		kubectl += " --context " + shellArg(context)
+	}
+	return kubectl
+}
+
 func (c *Client) Context() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.context
 }
 
+// SwitchContext points the client at another kubeconfig context, rebuilding
+// the clientset and dropping the metrics client and namespace cache. The
+// namespace becomes the context's default namespace.
+func (c *Client) SwitchContext(name string) error {
+	clientConfig := loadClientConfig(c.kubeconfig, name)
+
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load context %s: %w", name, err)
+	}
+	config.Timeout = 30 * time.Second
+	config.Wrap(telemetry.WrapTransport)
+	config.Wrap(WrapRetry)
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil || namespace == "" {
+		namespace = "default"
+	}
+
+	c.mu.Lock()
+	c.clientset = clientset
+	c.config = config
+	c.context = name
+	c.namespace = namespace
+	c.metricsClient = nil
+	c.metricsOnce = sync.Once{}
+	c.scaleClient, c.scaleErr = nil, nil
+	c.scaleOnce = sync.Once{}
+	c.mu.Unlock()
+
+	c.InvalidateNamespaces()
+	return nil
+}
+
 func (c *Client) Namespace() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.namespace
 }
 
 func (c *Client) SetNamespace(ns string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.namespace = ns
 }
 
+// ListNamespaces returns the cached namespace list, fetching it on first use
+// and refreshing it in the background once it is older than namespaceTTL.
+// When listing is forbidden it falls back to the current namespace.
 func (c *Client) ListNamespaces(ctx context.Context) ([]string, error) {
-	return ListNamespaces(ctx, c.clientset)
+	nc := &c.namespaces
+	nc.mu.Lock()
+	cached := !nc.fetched.IsZero()
+	names, forbidden := nc.names, nc.forbidden
+	if cached && time.Since(nc.fetched) >= namespaceTTL && !nc.refreshing {
+		nc.refreshing = true
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			c.fetchNamespaces(ctx)
+		}()
+	}
+	nc.mu.Unlock()
+
+	if !cached {
+		var err error
+		if names, forbidden, err = c.fetchNamespaces(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if forbidden {
+		return []string{c.Namespace()}, nil
+	}
+	return names, nil
+}
+
+// InvalidateNamespaces forces the next ListNamespaces to fetch a fresh list
+func (c *Client) InvalidateNamespaces() {
+	c.namespaces.mu.Lock()
+	c.namespaces.fetched = time.Time{}
+	c.namespaces.mu.Unlock()
+}
+
+func (c *Client) fetchNamespaces(ctx context.Context) ([]string, bool, error) {
+	names, err := ListNamespaces(ctx, c.Clientset())
+	forbidden := apierrors.IsForbidden(err)
+
+	nc := &c.namespaces
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	nc.refreshing = false
+	if err != nil && !forbidden {
+		return nil, false, err // keep serving the previous list, retry next time
+	}
+	nc.names, nc.forbidden, nc.fetched = names, forbidden, time.Now()
+	return names, forbidden, nil
 }
 
 func (c *Client) ListContexts() ([]string, string, error) {
-	rules := clientcmd.NewDefaultClientConfigLoadingRules()
-	config, err := rules.Load()
+	config, err := loadClientConfig(c.kubeconfig, "").RawConfig()
 	if err != nil {
 		return nil, "", err
 	}
@@ -91,32 +286,38 @@ func (c *Client) ListContexts() ([]string, string, error) {
 	for name := range config.Contexts {
 		contexts = append(contexts, name)
 	}
+	sort.Strings(contexts)
 	return contexts, config.CurrentContext, nil
 }
 
 func (c *Client) DeletePod(ctx context.Context, namespace, name string) error {
-	return DeletePod(ctx, c.clientset, namespace, name)
+	return DeletePod(ctx, c.Clientset(), namespace, name)
+}
+
+func (c *Client) EvictPod(ctx context.Context, namespace, name string) error {
+	return EvictPod(ctx, c.Clientset(), namespace, name)
 }
 
 func (c *Client) ScaleWorkload(ctx context.Context, namespace, name string, resourceType ResourceType, replicas int32) error {
-	switch resourceType {
-	case ResourceDeployments:
-		return ScaleDeployment(ctx, c.clientset, namespace, name, replicas)
-	case ResourceStatefulSets:
-		return ScaleStatefulSet(ctx, c.clientset, namespace, name, replicas)
-	default:
-		return nil // DaemonSets, Jobs, CronJobs cannot be scaled
+	resource, err := scaleResource(resourceType)
+	if err != nil {
+		return err
+	}
+	scales, err := c.ScaleClient()
+	if err != nil {
+		return fmt.Errorf("failed to create scale client: %w", err)
 	}
+	return Scale(ctx, scales, resource, namespace, name, replicas)
 }
 
 func (c *Client) RestartWorkload(ctx context.Context, namespace, name string, resourceType ResourceType) error {
 	switch resourceType {
 	case ResourceDeployments:
-		return RestartDeployment(ctx, c.clientset, namespace, name)
+		return RestartDeployment(ctx, c.Clientset(), namespace, name)
 	case ResourceStatefulSets:
-		return RestartStatefulSet(ctx, c.clientset, namespace, name)
+		return RestartStatefulSet(ctx, c.Clientset(), namespace, name)
 	case ResourceDaemonSets:
-		return RestartDaemonSet(ctx, c.clientset, namespace, name)
+		return RestartDaemonSet(ctx, c.Clientset(), namespace, name)
 	default:
 		return nil // Jobs and CronJobs don't have restart concept
 	}
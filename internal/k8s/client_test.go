@@ -0,0 +1,18 @@
+package k8s
+
+import "testing"
+
+func TestKubectlFor(t *testing.T) {
+	tests := []struct {
+		context, kubeconfig, want string
+	}{
+		{"", "", "kubectl"},
+		{"prod-eu", "", "kubectl --context prod-eu"},
+		{"arn:aws:eks:eu-west-1:123:cluster/prod", "/home/me/.kube/eks config", "kubectl --kubeconfig '/home/me/.kube/eks config' --context arn:aws:eks:eu-west-1:123:cluster/prod"},
+	}
+	for _, tt := range tests {
+		if got := KubectlFor(tt.context, tt.kubeconfig); got != tt.want {
+			t.Errorf("KubectlFor(%q, %q) = %q, want %q", tt.context, tt.kubeconfig, got, tt.want)
+		}
+	}
+}
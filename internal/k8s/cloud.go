@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ConsoleLink is a deep link to a node's instance page in a cloud console
+type ConsoleLink struct {
+	Provider string
+	URL      string
+}
+
+// CloudConsoleLink builds the console URL for a node from its provider ID.
+// Supports AWS (EKS), GCE (GKE) and Azure (AKS) provider IDs.
+func CloudConsoleLink(providerID string) (ConsoleLink, bool) {
+	scheme, rest, found := strings.Cut(providerID, "://")
+	if !found {
+		return ConsoleLink{}, false
+	}
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+
+	switch scheme {
+	case "aws":
+		// aws:///<zone>/<instance-id>
+		if len(parts) != 2 || !strings.HasPrefix(parts[1], "i-") {
+			return ConsoleLink{}, false
+		}
+		region := awsRegion(parts[0])
+		return ConsoleLink{
+			Provider: "AWS",
+			URL: fmt.Sprintf("https://console.aws.amazon.com/ec2/home?region=%s#InstanceDetails:instanceId=%s",
+				region, parts[1]),
+		}, true
+
+	case "gce":
+		// gce://<project>/<zone>/<instance-name>
+		if len(parts) != 3 {
+			return ConsoleLink{}, false
+		}
+		return ConsoleLink{
+			Provider: "GCP",
+			URL: fmt.Sprintf("https://console.cloud.google.com/compute/instancesDetail/zones/%s/instances/%s?project=%s",
+				parts[1], parts[2], url.QueryEscape(parts[0])),
+		}, true
+
+	case "azure":
+		// azure:///subscriptions/<sub>/resourceGroups/<rg>/providers/Microsoft.Compute/...
+		if len(parts) < 8 || !strings.EqualFold(parts[0], "subscriptions") {
+			return ConsoleLink{}, false
+		}
+		return ConsoleLink{
+			Provider: "Azure",
+			URL:      "https://portal.azure.com/#resource/" + strings.Join(parts, "/"),
+		}, true
+	}
+
+	return ConsoleLink{}, false
+}
+
+// awsRegion strips the availability zone suffix, e.g. us-east-1a -> us-east-1
+func awsRegion(zone string) string {
+	if n := len(zone); n > 0 && zone[n-1] >= 'a' && zone[n-1] <= 'z' {
+		return zone[:n-1]
+	}
+	return zone
+}
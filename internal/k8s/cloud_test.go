@@ -0,0 +1,68 @@
+package k8s
+
+import "testing"
+
+func TestCloudConsoleLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		providerID string
+		wantOK     bool
+		provider   string
+		url        string
+	}{
+		{
+			name:       "aws",
+			providerID: "aws:///us-east-1a/i-0abc123",
+			wantOK:     true,
+			provider:   "AWS",
+			url:        "https://console.aws.amazon.com/ec2/home?region=us-east-1#InstanceDetails:instanceId=i-0abc123",
+		},
+		{
+			name:       "gce",
+			providerID: "gce://my-project/europe-west1-b/gke-pool-1-abcd",
+			wantOK:     true,
+			provider:   "GCP",
+			url:        "https://console.cloud.google.com/compute/instancesDetail/zones/europe-west1-b/instances/gke-pool-1-abcd?project=my-project",
+		},
+		{
+			name:       "azure vmss",
+			providerID: "azure:///subscriptions/sub-1/resourceGroups/mc_rg/providers/Microsoft.Compute/virtualMachineScaleSets/aks-pool/virtualMachines/0",
+			wantOK:     true,
+			provider:   "Azure",
+			url:        "https://portal.azure.com/#resource/subscriptions/sub-1/resourceGroups/mc_rg/providers/Microsoft.Compute/virtualMachineScaleSets/aks-pool/virtualMachines/0",
+		},
+		{
+			name:       "kind",
+			providerID: "kind://docker/kind/kind-control-plane",
+			wantOK:     false,
+		},
+		{
+			name:       "empty",
+			providerID: "",
+			wantOK:     false,
+		},
+		{
+			name:       "malformed aws",
+			providerID: "aws:///us-east-1a",
+			wantOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, ok := CloudConsoleLink(tt.providerID)
+			if ok != tt.wantOK {
+				t.Fatalf("CloudConsoleLink(%q) ok = %v, want %v", tt.providerID, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if link.Provider != tt.provider {
+				t.Errorf("Provider = %q, want %q", link.Provider, tt.provider)
+			}
+			if link.URL != tt.url {
+				t.Errorf("URL = %q, want %q", link.URL, tt.url)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package k8s
+
+import (
+	"errors"
+	"strings"
+)
+
+// defaultColumnWidth fits a short git SHA or a semver with a suffix
+const defaultColumnWidth = 12
+
+// MetadataColumn is an extra list column showing one label or annotation of
+// each workload and pod, such as the owning team or the deployed version
+type MetadataColumn struct {
+	Header     string
+	Label      string
+	Annotation string
+	Width      int
+}
+
+// Validate checks that the column reads exactly one key
+func (c MetadataColumn) Validate() error {
+	switch {
+	case c.Label == "" && c.Annotation == "":
+		return errors.New("column needs a label or an annotation")
+	case c.Label != "" && c.Annotation != "":
+		return errors.New("column has both a label and an annotation")
+	case c.Width < 0:
+		return errors.New("column width is negative")
+	}
+	return nil
+}
+
+// Title is the header, or the key's name uppercased without its prefix,
+// e.g. VERSION for app.kubernetes.io/version
+func (c MetadataColumn) Title() string {
+	if c.Header != "" {
+		return c.Header
+	}
+	key := c.Label + c.Annotation
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		key = key[i+1:]
+	}
+	return strings.ToUpper(key)
+}
+
+// ColumnWidth is the configured width, or the default widened to fit the title
+func (c MetadataColumn) ColumnWidth() int {
+	if c.Width > 0 {
+		return c.Width
+	}
+	return max(defaultColumnWidth, len(c.Title()))
+}
+
+// Value reads the column's key from an object's labels or annotations, "-"
+// when the object does not set it
+func (c MetadataColumn) Value(labels, annotations map[string]string) string {
+	var v string
+	if c.Label != "" {
+		v = labels[c.Label]
+	} else {
+		v = annotations[c.Annotation]
+	}
+	if v == "" {
+		return "-"
+	}
+	return v
+}
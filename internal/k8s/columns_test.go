@@ -0,0 +1,42 @@
+package k8s
+
+import "testing"
+
+func TestMetadataColumn(t *testing.T) {
+	labels := map[string]string{"team": "payments", "app.kubernetes.io/version": "1.4.2"}
+	annotations := map[string]string{"example.com/git-sha": "3f9c2a1b7d"}
+
+	tests := []struct {
+		name      string
+		col       MetadataColumn
+		wantTitle string
+		wantWidth int
+		wantValue string
+	}{
+		{"label", MetadataColumn{Label: "team"}, "TEAM", 12, "payments"},
+		{"prefixed label", MetadataColumn{Label: "app.kubernetes.io/version"}, "VERSION", 12, "1.4.2"},
+		{"annotation with header", MetadataColumn{Header: "SHA", Annotation: "example.com/git-sha", Width: 7}, "SHA", 7, "3f9c2a1b7d"},
+		{"missing", MetadataColumn{Label: "example.com/cost-center-identifier"}, "COST-CENTER-IDENTIFIER", 22, "-"},
+		{"label key not read as annotation", MetadataColumn{Label: "example.com/git-sha"}, "GIT-SHA", 12, "-"},
+	}
+	for _, tt := range tests {
+		if err := tt.col.Validate(); err != nil {
+			t.Errorf("%s: Validate() = %v", tt.name, err)
+		}
+		if got := tt.col.Title(); got != tt.wantTitle {
+			t.Errorf("%s: Title() = %q, want %q", tt.name, got, tt.wantTitle)
+		}
+		if got := tt.col.ColumnWidth(); got != tt.wantWidth {
+			t.Errorf("%s: ColumnWidth() = %d, want %d", tt.name, got, tt.wantWidth)
+		}
+		if got := tt.col.Value(labels, annotations); got != tt.wantValue {
+			t.Errorf("%s: Value() = %q, want %q", tt.name, got, tt.wantValue)
+		}
+	}
+
+	for _, bad := range []MetadataColumn{{}, {Label: "a", Annotation: "b"}, {Label: "a", Width: -1}} {
+		if bad.Validate() == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodComparisonText renders the parts of a pod that explain why one replica
+// behaves differently from another: node, spec, env, image digests and
+// resource usage. Names, UIDs and timestamps are left out, and everything is
+// sorted, so a line diff of two replicas shows only real differences.
+func PodComparisonText(pod *corev1.Pod, metrics *PodMetrics) string {
+	if pod == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Node:            %s\n", pod.Spec.NodeName)
+	fmt.Fprintf(&b, "Status:          %s\n", getPodStatus(pod))
+	fmt.Fprintf(&b, "QoS:             %s\n", pod.Status.QOSClass)
+	fmt.Fprintf(&b, "ServiceAccount:  %s\n", pod.Spec.ServiceAccountName)
+	if pod.Spec.PriorityClassName != "" {
+		fmt.Fprintf(&b, "PriorityClass:   %s\n", pod.Spec.PriorityClassName)
+	}
+	for _, k := range sortedKeys(pod.Spec.NodeSelector) {
+		fmt.Fprintf(&b, "NodeSelector:    %s=%s\n", k, pod.Spec.NodeSelector[k])
+	}
+
+	b.WriteString("Labels:\n")
+	for _, k := range sortedKeys(pod.Labels) {
+		fmt.Fprintf(&b, "  %s=%s\n", k, pod.Labels[k])
+	}
+
+	b.WriteString("Volumes:\n")
+	for _, v := range pod.Spec.Volumes {
+		kind, source, _ := volumeKind(v)
+		fmt.Fprintf(&b, "  %s: %s\n", v.Name, strings.TrimSpace(kind+" "+source))
+	}
+
+	statuses := make(map[string]corev1.ContainerStatus, len(pod.Status.ContainerStatuses))
+	for _, cs := range pod.Status.ContainerStatuses {
+		statuses[cs.Name] = cs
+	}
+	usage := make(map[string]ContainerMetrics)
+	if metrics != nil {
+		for _, cm := range metrics.Containers {
+			usage[cm.Name] = cm
+		}
+	}
+
+	for _, c := range pod.Spec.Containers {
+		fmt.Fprintf(&b, "\nContainer %s\n", c.Name)
+		fmt.Fprintf(&b, "  Image:     %s\n", c.Image)
+
+		if cs, ok := statuses[c.Name]; ok {
+			fmt.Fprintf(&b, "  Digest:    %s\n", ImageDigest(cs.ImageID))
+			fmt.Fprintf(&b, "  State:     %s\n", containerStateText(cs.State))
+			fmt.Fprintf(&b, "  Ready:     %v\n", cs.Ready)
+			fmt.Fprintf(&b, "  Restarts:  %d\n", cs.RestartCount)
+			if t := cs.LastTerminationState.Terminated; t != nil {
+				fmt.Fprintf(&b, "  LastExit:  %d %s\n", t.ExitCode, t.Reason)
+			}
+		}
+
+		if len(c.Command) > 0 {
+			fmt.Fprintf(&b, "  Command:   %s\n", strings.Join(c.Command, " "))
+		}
+		if len(c.Args) > 0 {
+			fmt.Fprintf(&b, "  Args:      %s\n", strings.Join(c.Args, " "))
+		}
+		fmt.Fprintf(&b, "  Requests:  cpu=%s memory=%s\n", c.Resources.Requests.Cpu(), c.Resources.Requests.Memory())
+		fmt.Fprintf(&b, "  Limits:    cpu=%s memory=%s\n", c.Resources.Limits.Cpu(), c.Resources.Limits.Memory())
+		if cm, ok := usage[c.Name]; ok {
+			fmt.Fprintf(&b, "  Usage:     cpu=%s memory=%s\n", cm.CPUUsage, cm.MemoryUsage)
+		}
+
+		if len(c.Env) > 0 || len(c.EnvFrom) > 0 {
+			b.WriteString("  Env:\n")
+			env := make([]string, 0, len(c.Env)+len(c.EnvFrom))
+			for _, e := range c.Env {
+				env = append(env, e.Name+"="+envValue(e))
+			}
+			for _, src := range c.EnvFrom {
+				switch {
+				case src.ConfigMapRef != nil:
+					env = append(env, src.Prefix+"* <configmap "+src.ConfigMapRef.Name+">")
+				case src.SecretRef != nil:
+					env = append(env, src.Prefix+"* <secret "+src.SecretRef.Name+">")
+				}
+			}
+			sort.Strings(env)
+			for _, e := range env {
+				fmt.Fprintf(&b, "    %s\n", e)
+			}
+		}
+
+		if len(c.VolumeMounts) > 0 {
+			b.WriteString("  Mounts:\n")
+			for _, m := range c.VolumeMounts {
+				ro := ""
+				if m.ReadOnly {
+					ro = " (ro)"
+				}
+				fmt.Fprintf(&b, "    %s <- %s%s\n", m.MountPath, m.Name, ro)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// envValue shows literal values as-is and references by their source, since
+// the referenced values are not in the pod
+func envValue(e corev1.EnvVar) string {
+	if e.ValueFrom == nil {
+		return e.Value
+	}
+	switch src := e.ValueFrom; {
+	case src.SecretKeyRef != nil:
+		return fmt.Sprintf("<secret %s/%s>", src.SecretKeyRef.Name, src.SecretKeyRef.Key)
+	case src.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("<configmap %s/%s>", src.ConfigMapKeyRef.Name, src.ConfigMapKeyRef.Key)
+	case src.FieldRef != nil:
+		return "<field " + src.FieldRef.FieldPath + ">"
+	case src.ResourceFieldRef != nil:
+		return "<resource " + src.ResourceFieldRef.Resource + ">"
+	}
+	return "<ref>"
+}
+
+func containerStateText(s corev1.ContainerState) string {
+	switch {
+	case s.Running != nil:
+		return "Running"
+	case s.Waiting != nil:
+		return "Waiting " + s.Waiting.Reason
+	case s.Terminated != nil:
+		return "Terminated " + s.Terminated.Reason
+	}
+	return "Unknown"
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
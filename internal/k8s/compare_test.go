@@ -0,0 +1,61 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestPodComparisonText(t *testing.T) {
+	replica := func(name, node, imageID string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID("uid-" + name)},
+			Spec: corev1.PodSpec{
+				NodeName: node,
+				Containers: []corev1.Container{{
+					Name:  "app",
+					Image: "web:1.0",
+					Env: []corev1.EnvVar{
+						{Name: "ZONE", Value: "a"},
+						{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+								Key:                  "password",
+							},
+						}},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", ImageID: "web@" + imageID}},
+			},
+		}
+	}
+
+	healthy := PodComparisonText(replica("web-1", "node-a", "sha256:aaa"), nil)
+	failing := PodComparisonText(replica("web-2", "node-b", "sha256:bbb"), nil)
+
+	if strings.Contains(healthy, "web-1") || strings.Contains(healthy, "uid-") {
+		t.Errorf("comparison text should leave out names and UIDs:\n%s", healthy)
+	}
+	if !strings.Contains(healthy, "PASSWORD=<secret db/password>") {
+		t.Errorf("secret env should show its reference:\n%s", healthy)
+	}
+	if strings.Index(healthy, "PASSWORD=") > strings.Index(healthy, "ZONE=") {
+		t.Errorf("env should be sorted:\n%s", healthy)
+	}
+
+	var changed []string
+	for _, line := range strings.Split(SimpleDiff(healthy, failing), "\n") {
+		if strings.HasPrefix(line, "+ ") {
+			changed = append(changed, strings.TrimSpace(line[2:]))
+		}
+	}
+	want := []string{"Node:            node-b", "Digest:    sha256:bbb"}
+	if strings.Join(changed, "|") != strings.Join(want, "|") {
+		t.Errorf("diff additions = %q, want %q", changed, want)
+	}
+}
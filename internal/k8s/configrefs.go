@@ -0,0 +1,166 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ConfigRef is a reference from a pod spec to a ConfigMap or Secret, and to
+// one of its keys when only that key is used
+type ConfigRef struct {
+	Kind string // ConfigMap or Secret
+	Name string
+	Key  string // empty when the whole object is used
+	Use  string // e.g. "env DB_PASSWORD of app", "envFrom of app", "imagePullSecrets"
+}
+
+// podConfigRefs lists the required ConfigMap and Secret references of the
+// pod's env, envFrom and imagePullSecrets, init containers included.
+// References marked optional are left out: they cannot keep the pod from
+// starting. Volumes are left to CheckVolumeSources, which flags their
+// missing objects and keys in the volumes view.
+func podConfigRefs(pod *corev1.Pod) []ConfigRef {
+	var refs []ConfigRef
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, c := range containers {
+		for _, e := range c.EnvFrom {
+			use := "envFrom of " + c.Name
+			switch {
+			case e.ConfigMapRef != nil && !isTrue(e.ConfigMapRef.Optional):
+				refs = append(refs, ConfigRef{Kind: "ConfigMap", Name: e.ConfigMapRef.Name, Use: use})
+			case e.SecretRef != nil && !isTrue(e.SecretRef.Optional):
+				refs = append(refs, ConfigRef{Kind: "Secret", Name: e.SecretRef.Name, Use: use})
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			use := fmt.Sprintf("env %s of %s", e.Name, c.Name)
+			switch r := e.ValueFrom; {
+			case r.ConfigMapKeyRef != nil && !isTrue(r.ConfigMapKeyRef.Optional):
+				refs = append(refs, ConfigRef{Kind: "ConfigMap", Name: r.ConfigMapKeyRef.Name, Key: r.ConfigMapKeyRef.Key, Use: use})
+			case r.SecretKeyRef != nil && !isTrue(r.SecretKeyRef.Optional):
+				refs = append(refs, ConfigRef{Kind: "Secret", Name: r.SecretKeyRef.Name, Key: r.SecretKeyRef.Key, Use: use})
+			}
+		}
+	}
+
+	for _, s := range pod.Spec.ImagePullSecrets {
+		refs = append(refs, ConfigRef{Kind: "Secret", Name: s.Name, Use: "imagePullSecrets"})
+	}
+	return refs
+}
+
+// ConfigRefHelpers flags references to ConfigMaps and Secrets that do not
+// exist, and to keys they do not have, each once with every use of it.
+// objects maps "Kind/name" to the object's keys, nil for an object that does
+// not exist; objects that could not be read are absent and not checked.
+func ConfigRefHelpers(refs []ConfigRef, objects map[string]map[string]bool) []DebugHelper {
+	// The missing objects and keys, in the order first referenced
+	var missing []ConfigRef
+	uses := make(map[ConfigRef][]string)
+	for _, ref := range refs {
+		keys, read := objects[ref.Kind+"/"+ref.Name]
+		switch {
+		case !read:
+			continue
+		case keys == nil:
+			ref.Key = "" // the object is missing, whatever key was wanted
+		case ref.Key == "" || keys[ref.Key]:
+			continue
+		}
+		use := ref.Use
+		ref.Use = ""
+		if _, ok := uses[ref]; !ok {
+			missing = append(missing, ref)
+		}
+		uses[ref] = append(uses[ref], use)
+	}
+
+	var helpers []DebugHelper
+	for _, ref := range missing {
+		usedBy := " (" + strings.Join(uses[ref], ", ") + ")"
+		keys := objects[ref.Kind+"/"+ref.Name]
+		if keys == nil {
+			helpers = append(helpers, DebugHelper{
+				Issue:    fmt.Sprintf("%s %s does not exist", ref.Kind, ref.Name) + usedBy,
+				Severity: "High",
+				Suggestions: []string{
+					missingRefEffect(uses[ref]),
+					fmt.Sprintf("Create the %s, or fix the name if it is a typo", ref.Kind),
+					"Mark the reference optional: true if the pod can run without it",
+				},
+			})
+			continue
+		}
+		helpers = append(helpers, DebugHelper{
+			Issue:    fmt.Sprintf("%s %s has no key %q", ref.Kind, ref.Name, ref.Key) + usedBy,
+			Severity: "High",
+			Suggestions: []string{
+				"The kubelet cannot set the variable or file, so the container stays in CreateContainerConfigError or ContainerCreating",
+				"Keys it has: " + joinKeys(keys),
+				"Add the key, or fix the name in the pod spec",
+			},
+		})
+	}
+	return helpers
+}
+
+// missingRefEffect says what a missing object does to the pod, by its
+// first use
+func missingRefEffect(uses []string) string {
+	switch {
+	case uses[0] == "imagePullSecrets":
+		return "Images from private registries are pulled without credentials and fail"
+	}
+	return "The container cannot start and stays in CreateContainerConfigError"
+}
+
+func joinKeys(keys map[string]bool) string {
+	if len(keys) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// GetConfigRefHelpers checks that the ConfigMaps and Secrets the pod refers
+// to exist and have the keys it uses. Objects that cannot be read, e.g.
+// Secrets the user may not get, are not checked.
+func GetConfigRefHelpers(ctx context.Context, configObjects *ConfigObjects, pod *PodInfo) []DebugHelper {
+	if pod.Object == nil {
+		return nil
+	}
+	refs := podConfigRefs(pod.Object)
+	objects := make(map[string]map[string]bool)
+	tried := make(map[string]bool)
+	for _, ref := range refs {
+		id := ref.Kind + "/" + ref.Name
+		if tried[id] {
+			continue
+		}
+		tried[id] = true
+		obj, err := configObjects.get(ctx, ref.Kind, ref.Name)
+		switch {
+		case apierrors.IsNotFound(err):
+			objects[id] = nil
+		case err == nil:
+			keys := make(map[string]bool, len(obj.data))
+			for k := range obj.data {
+				keys[k] = true
+			}
+			objects[id] = keys
+		}
+	}
+	return ConfigRefHelpers(refs, objects)
+}
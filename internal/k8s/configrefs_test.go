@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestConfigRefHelpers(t *testing.T) {
+	optional := true
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		// Volumes are checked by CheckVolumeSources, not here
+		Volumes: []corev1.Volume{
+			{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "app-tls"}}},
+		},
+		InitContainers: []corev1.Container{{
+			Name:    "migrate",
+			EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "db-creds"}}}},
+		}},
+		Containers: []corev1.Container{{
+			Name: "app",
+			Env: []corev1.EnvVar{
+				{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "db-creds"}, Key: "password",
+				}}},
+				{Name: "LOG_LEVEL", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}, Key: "log-level",
+				}}},
+				{Name: "MODE", Value: "prod"},
+			},
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "extra"}, Optional: &optional,
+			}}},
+		}},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+	}}
+
+	refs := podConfigRefs(pod)
+	if len(refs) != 4 {
+		t.Fatalf("podConfigRefs() = %d refs, want 4 without the volume and the optional one: %+v", len(refs), refs)
+	}
+
+	tests := []struct {
+		name    string
+		objects map[string]map[string]bool
+		want    []string
+	}{
+		{
+			name: "all present",
+			objects: map[string]map[string]bool{
+				"ConfigMap/app-config": {"app.yaml": true, "log-level": true},
+				"Secret/app-tls":       {"tls.crt": true},
+				"Secret/db-creds":      {"password": true},
+				"Secret/registry":      {".dockerconfigjson": true},
+			},
+		},
+		{
+			name: "missing objects and keys",
+			objects: map[string]map[string]bool{
+				"ConfigMap/app-config": {"app.yaml": true, "loglevel": true},
+				"Secret/app-tls":       {"tls.crt": true},
+				"Secret/db-creds":      nil,
+				"Secret/registry":      nil,
+			},
+			want: []string{
+				`Secret db-creds does not exist (envFrom of migrate, env DB_PASSWORD of app)`,
+				`ConfigMap app-config has no key "log-level" (env LOG_LEVEL of app)`,
+				`Secret registry does not exist (imagePullSecrets)`,
+			},
+		},
+		{
+			name: "unreadable objects are not checked",
+			objects: map[string]map[string]bool{
+				"ConfigMap/app-config": {"app.yaml": true, "log-level": true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConfigRefHelpers(refs, tt.objects)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d helpers, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if got[i].Issue != want || got[i].Severity != "High" {
+					t.Errorf("helper %d = %s %q, want High %q", i, got[i].Severity, got[i].Issue, want)
+				}
+			}
+		})
+	}
+
+	missingKey := ConfigRefHelpers(refs, map[string]map[string]bool{"ConfigMap/app-config": {"app.yaml": true, "loglevel": true}})
+	if len(missingKey) != 1 || !strings.Contains(strings.Join(missingKey[0].Suggestions, "\n"), "Keys it has: app.yaml, loglevel") {
+		t.Errorf("a missing key should list the keys there are, got %+v", missingKey)
+	}
+}
@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNextCronRun(t *testing.T) {
+	// A Wednesday
+	after := time.Date(2024, 5, 1, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		schedule string
+		want     string
+	}{
+		{"*/15 * * * *", "2024-05-01 10:15"},
+		{"0 * * * *", "2024-05-01 11:00"},
+		{"@daily", "2024-05-02 00:00"},
+		{"30 9 * * 1-5", "2024-05-02 09:30"},
+		{"0 0 * * SUN", "2024-05-05 00:00"},
+		{"0 0 * * 7", "2024-05-05 00:00"},
+		{"0 12 15 * *", "2024-05-15 12:00"},
+		{"0 0 1 jan *", "2025-01-01 00:00"},
+		{"5/20 10 * * *", "2024-05-01 10:25"},
+		{"0 6 13 * 5", "2024-05-03 06:00"},
+		{"0 0 30 2 *", ""},
+	}
+	for _, tt := range tests {
+		got, err := NextCronRun(tt.schedule, "UTC", after)
+		if err != nil {
+			t.Errorf("NextCronRun(%q): %v", tt.schedule, err)
+			continue
+		}
+		var s string
+		if !got.IsZero() {
+			s = got.Format("2006-01-02 15:04")
+		}
+		if s != tt.want {
+			t.Errorf("NextCronRun(%q) = %q, want %q", tt.schedule, s, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "0 0 * * funday"} {
+		if _, err := NextCronRun(bad, "UTC", after); err == nil {
+			t.Errorf("NextCronRun(%q) should fail", bad)
+		}
+	}
+}
+
+func TestJobFromCronJob(t *testing.T) {
+	cj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("b", 60), Namespace: "jobs", UID: "uid-1"},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "backup"}},
+			},
+		},
+	}
+	job := jobFromCronJob(cj, time.Unix(1714557600, 0))
+
+	if len(job.Name) > 63 || !strings.HasSuffix(job.Name, "-manual-1714557600") {
+		t.Errorf("name = %q", job.Name)
+	}
+	if job.Namespace != "jobs" || job.Labels["app"] != "backup" {
+		t.Errorf("metadata = %+v", job.ObjectMeta)
+	}
+	if job.Annotations["cronjob.kubernetes.io/instantiate"] != "manual" {
+		t.Errorf("annotations = %v", job.Annotations)
+	}
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].UID != "uid-1" || !*job.OwnerReferences[0].Controller {
+		t.Errorf("owner = %+v", job.OwnerReferences)
+	}
+}
@@ -0,0 +1,314 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DaemonSetNodeStatus is one node's share of a DaemonSet: whether it should
+// run a pod, the pod it has, and the node's own condition
+type DaemonSetNodeStatus struct {
+	Node      string
+	NodeState string // e.g. "Ready", "NotReady, MemoryPressure"
+	NodeReady bool
+	Eligible  bool   // the DaemonSet's selector, affinity and tolerations allow the node
+	Skipped   string // why an ineligible node gets no pod
+	Pod       string
+	PodStatus string
+	Ready     bool
+}
+
+// Healthy reports whether the node has what the DaemonSet wants there: a
+// ready pod when eligible, nothing otherwise
+func (s DaemonSetNodeStatus) Healthy() bool {
+	if s.Pod != "" {
+		return s.Ready
+	}
+	return !s.Eligible
+}
+
+// Taints the DaemonSet controller tolerates on every daemon pod
+var daemonSetTolerations = []corev1.Toleration{
+	{Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists},
+	{Key: "node.kubernetes.io/unreachable", Operator: corev1.TolerationOpExists},
+	{Key: "node.kubernetes.io/disk-pressure", Operator: corev1.TolerationOpExists},
+	{Key: "node.kubernetes.io/memory-pressure", Operator: corev1.TolerationOpExists},
+	{Key: "node.kubernetes.io/pid-pressure", Operator: corev1.TolerationOpExists},
+	{Key: "node.kubernetes.io/unschedulable", Operator: corev1.TolerationOpExists},
+}
+
+// GetDaemonSetNodes breaks a DaemonSet down per node
+func GetDaemonSetNodes(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) ([]DaemonSetNodeStatus, error) {
+	ds, err := GetDaemonSet(ctx, clientset, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return DaemonSetNodeStatuses(ds, nodes.Items, pods.Items), nil
+}
+
+// DaemonSetNodeStatuses matches the DaemonSet's pods to nodes. Unhealthy
+// nodes come first, then nodes with pods, then skipped nodes.
+func DaemonSetNodeStatuses(ds *appsv1.DaemonSet, nodes []corev1.Node, pods []corev1.Pod) []DaemonSetNodeStatus {
+	podsByNode := make(map[string]*corev1.Pod)
+	for i := range pods {
+		p := &pods[i]
+		if ref := metav1.GetControllerOf(p); ref == nil || ref.UID != ds.UID {
+			continue
+		}
+		node := podNodeName(p)
+		// Keep the newest pod when a replacement overlaps the old one
+		if prev, ok := podsByNode[node]; !ok || p.CreationTimestamp.After(prev.CreationTimestamp.Time) {
+			podsByNode[node] = p
+		}
+	}
+
+	tolerations := append(append([]corev1.Toleration{}, ds.Spec.Template.Spec.Tolerations...), daemonSetTolerations...)
+	rows := make([]DaemonSetNodeStatus, 0, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		row := DaemonSetNodeStatus{Node: node.Name}
+		row.NodeState, row.NodeReady = nodeState(node)
+		row.Skipped = nodeMismatch(&ds.Spec.Template.Spec, tolerations, node)
+		row.Eligible = row.Skipped == ""
+		if p, ok := podsByNode[node.Name]; ok {
+			row.Pod = p.Name
+			row.PodStatus = getPodStatus(p)
+			row.Ready = isPodReady(p)
+		}
+		rows = append(rows, row)
+	}
+
+	rank := func(s DaemonSetNodeStatus) int {
+		switch {
+		case !s.Healthy():
+			return 0
+		case s.Pod != "":
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if ri, rj := rank(rows[i]), rank(rows[j]); ri != rj {
+			return ri < rj
+		}
+		return rows[i].Node < rows[j].Node
+	})
+	return rows
+}
+
+// FormatDaemonSetNodes renders the breakdown as a table with a summary line
+func FormatDaemonSetNodes(rows []DaemonSetNodeStatus) string {
+	eligible, ready, skipped := 0, 0, 0
+	for _, r := range rows {
+		if r.Eligible {
+			eligible++
+		} else {
+			skipped++
+		}
+		if r.Eligible && r.Ready {
+			ready++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d/%d eligible nodes have a ready pod", ready, eligible)
+	if skipped > 0 {
+		fmt.Fprintf(&b, ", %d nodes skipped", skipped)
+	}
+	b.WriteString("\n\n")
+
+	nodeWidth := len("NODE")
+	for _, r := range rows {
+		nodeWidth = max(nodeWidth, len(r.Node))
+	}
+	format := "%s%-" + strconv.Itoa(nodeWidth) + "s  %-24s  %-9s  %-5s  %s\n"
+	fmt.Fprintf(&b, format, "  ", "NODE", "NODE STATE", "SCHEDULED", "READY", "POD")
+	for _, r := range rows {
+		marker := "  "
+		if !r.Healthy() {
+			marker = "✗ "
+		}
+		scheduled, ready, pod := "no", "-", "-"
+		if r.Pod != "" {
+			scheduled, ready, pod = "yes", strconv.FormatBool(r.Ready), r.Pod+" ("+r.PodStatus+")"
+		}
+		if !r.Eligible {
+			pod = "skipped: " + r.Skipped
+		}
+		fmt.Fprintf(&b, format, marker, r.Node, r.NodeState, scheduled, ready, pod)
+	}
+	return b.String()
+}
+
+// podNodeName is the node a pod runs on or, for daemon pods not yet
+// scheduled, the node the controller pinned it to
+func podNodeName(p *corev1.Pod) string {
+	if p.Spec.NodeName != "" {
+		return p.Spec.NodeName
+	}
+	if a := p.Spec.Affinity; a != nil && a.NodeAffinity != nil && a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		for _, term := range a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			for _, f := range term.MatchFields {
+				if f.Key == "metadata.name" && len(f.Values) == 1 {
+					return f.Values[0]
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// nodeState summarizes the node's Ready condition, any pressure and cordoning
+func nodeState(node *corev1.Node) (string, bool) {
+	ready := "Unknown"
+	isReady := false
+	var problems []string
+	for _, c := range node.Status.Conditions {
+		switch c.Type {
+		case corev1.NodeReady:
+			isReady = c.Status == corev1.ConditionTrue
+			ready = "NotReady"
+			if isReady {
+				ready = "Ready"
+			} else if c.Status == corev1.ConditionUnknown {
+				ready = "Unknown"
+			}
+		case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable:
+			if c.Status == corev1.ConditionTrue {
+				problems = append(problems, string(c.Type))
+			}
+		}
+	}
+	if node.Spec.Unschedulable {
+		problems = append(problems, "SchedulingDisabled")
+	}
+	return strings.Join(append([]string{ready}, problems...), ", "), isReady
+}
+
+// nodeMismatch explains why a pod with this spec cannot run on the node:
+// its node selector, required node affinity or an untolerated taint.
+// It returns "" when the node fits.
+func nodeMismatch(spec *corev1.PodSpec, tolerations []corev1.Toleration, node *corev1.Node) string {
+	for _, k := range sortedKeys(spec.NodeSelector) {
+		if v := spec.NodeSelector[k]; node.Labels[k] != v {
+			return fmt.Sprintf("nodeSelector %s=%s", k, v)
+		}
+	}
+
+	if a := spec.Affinity; a != nil && a.NodeAffinity != nil && a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		terms := a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		matched := false
+		for _, term := range terms {
+			if nodeMatchesTerm(node, term) {
+				matched = true
+				break
+			}
+		}
+		if !matched && len(terms) > 0 {
+			return "required node affinity"
+		}
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !toleratesTaint(tolerations, taint) {
+			return "taint " + taint.ToString()
+		}
+	}
+	return ""
+}
+
+func nodeMatchesTerm(node *corev1.Node, term corev1.NodeSelectorTerm) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false // an empty term matches no objects
+	}
+	for _, req := range term.MatchExpressions {
+		if !requirementMatches(req, node.Labels) {
+			return false
+		}
+	}
+	for _, req := range term.MatchFields {
+		if req.Key == "metadata.name" && !requirementMatches(req, map[string]string{req.Key: node.Name}) {
+			return false
+		}
+	}
+	return true
+}
+
+func requirementMatches(req corev1.NodeSelectorRequirement, labels map[string]string) bool {
+	value, ok := labels[req.Key]
+	switch req.Operator {
+	case corev1.NodeSelectorOpIn:
+		return ok && containsString(req.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !ok || !containsString(req.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return ok
+	case corev1.NodeSelectorOpDoesNotExist:
+		return !ok
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if !ok || len(req.Values) != 1 {
+			return false
+		}
+		have, err1 := strconv.ParseInt(value, 10, 64)
+		want, err2 := strconv.ParseInt(req.Values[0], 10, 64)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		if req.Operator == corev1.NodeSelectorOpGt {
+			return have > want
+		}
+		return have < want
+	}
+	return false
+}
+
+func toleratesTaint(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPodReady(p *corev1.Pod) bool {
+	for _, c := range p.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
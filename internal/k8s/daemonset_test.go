@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestDaemonSetNodeStatuses(t *testing.T) {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", UID: types.UID("ds-uid")},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+			}},
+		},
+	}
+	isController := true
+	owner := []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", UID: ds.UID, Controller: &isController}}
+
+	node := func(name, os string, ready corev1.ConditionStatus, taints ...corev1.Taint) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/os": os}},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+		}
+	}
+	pod := func(name, nodeName string, ready corev1.ConditionStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, OwnerReferences: owner},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	nodes := []corev1.Node{
+		node("a", "linux", corev1.ConditionTrue),
+		node("b", "linux", corev1.ConditionFalse, corev1.Taint{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoExecute}),
+		node("c", "linux", corev1.ConditionTrue, corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}),
+		node("d", "windows", corev1.ConditionTrue),
+		node("e", "linux", corev1.ConditionTrue),
+	}
+	pods := []corev1.Pod{
+		pod("agent-a", "a", corev1.ConditionTrue),
+		pod("agent-b", "b", corev1.ConditionFalse),
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Spec: corev1.PodSpec{NodeName: "e"}},
+	}
+
+	rows := DaemonSetNodeStatuses(ds, nodes, pods)
+	if len(rows) != 5 {
+		t.Fatalf("got %d rows, want 5", len(rows))
+	}
+
+	var order []string
+	for _, r := range rows {
+		order = append(order, r.Node)
+	}
+	if got := strings.Join(order, ","); got != "b,e,a,c,d" {
+		t.Errorf("order = %s, want unhealthy nodes first", got)
+	}
+
+	byNode := map[string]DaemonSetNodeStatus{}
+	for _, r := range rows {
+		byNode[r.Node] = r
+	}
+	if r := byNode["b"]; !r.Eligible || r.Pod != "agent-b" || r.Ready || r.NodeState != "NotReady" {
+		t.Errorf("node b = %+v", r)
+	}
+	if r := byNode["c"]; r.Eligible || !strings.Contains(r.Skipped, "dedicated=gpu") {
+		t.Errorf("tainted node c = %+v", r)
+	}
+	if r := byNode["d"]; r.Eligible || r.Skipped != "nodeSelector kubernetes.io/os=linux" {
+		t.Errorf("windows node d = %+v", r)
+	}
+	if r := byNode["e"]; r.Pod != "" || r.Healthy() {
+		t.Errorf("node e should be missing its pod: %+v", r)
+	}
+
+	out := FormatDaemonSetNodes(rows)
+	if !strings.HasPrefix(out, "1/3 eligible nodes have a ready pod, 2 nodes skipped") {
+		t.Errorf("summary = %q", strings.SplitN(out, "\n", 2)[0])
+	}
+}
+
+func TestRequirementMatches(t *testing.T) {
+	labels := map[string]string{"zone": "a", "cores": "8"}
+	tests := []struct {
+		req  corev1.NodeSelectorRequirement
+		want bool
+	}{
+		{corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a", "b"}}, true},
+		{corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"a"}}, false},
+		{corev1.NodeSelectorRequirement{Key: "gpu", Operator: corev1.NodeSelectorOpDoesNotExist}, true},
+		{corev1.NodeSelectorRequirement{Key: "cores", Operator: corev1.NodeSelectorOpGt, Values: []string{"4"}}, true},
+		{corev1.NodeSelectorRequirement{Key: "cores", Operator: corev1.NodeSelectorOpLt, Values: []string{"4"}}, false},
+	}
+	for _, tt := range tests {
+		if got := requirementMatches(tt.req, labels); got != tt.want {
+			t.Errorf("requirementMatches(%s %s %v) = %v, want %v", tt.req.Key, tt.req.Operator, tt.req.Values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultDebugImage is the debug container image when none is configured
+const DefaultDebugImage = "busybox"
+
+// How long to wait for an injected debug container to start; pulling the
+// image is usually most of it
+const debugStartTimeout = 2 * time.Minute
+
+// AddDebugContainer injects an ephemeral container running image into the
+// pod, sharing the process namespace of target when it is set, and waits for
+// it to start. It returns the container's name, to exec into. Ephemeral
+// containers cannot be removed; they stay until the pod is deleted.
+func AddDebugContainer(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName, image, target string) (string, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	container := debugContainer(pod, image, target)
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, container)
+	if _, err := clientset.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{}); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, debugStartTimeout)
+	defer cancel()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		if done, err := debugContainerStarted(pod, container.Name); done {
+			return container.Name, err
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("debug container %s did not start within %s", container.Name, debugStartTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// debugContainer builds an interactive shell container with a name not yet
+// used in the pod: debugger, debugger-2, ...
+func debugContainer(pod *corev1.Pod, image, target string) corev1.EphemeralContainer {
+	used := make(map[string]bool)
+	for _, c := range pod.Spec.Containers {
+		used[c.Name] = true
+	}
+	for _, c := range pod.Spec.InitContainers {
+		used[c.Name] = true
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		used[c.Name] = true
+	}
+	name := "debugger"
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("debugger-%d", i)
+	}
+
+	if image == "" {
+		image = DefaultDebugImage
+	}
+	return corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:  name,
+			Image: image,
+			// An interactive shell keeps the container running to exec into
+			Command:                  []string{"sh"},
+			Stdin:                    true,
+			TTY:                      true,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: target,
+	}
+}
+
+// debugContainerStarted reports whether the named ephemeral container is
+// running, or has failed in a way waiting will not fix
+func debugContainerStarted(pod *corev1.Pod, name string) (bool, error) {
+	for _, s := range pod.Status.EphemeralContainerStatuses {
+		if s.Name != name {
+			continue
+		}
+		switch {
+		case s.State.Running != nil:
+			return true, nil
+		case s.State.Terminated != nil:
+			return true, fmt.Errorf("debug container exited: %s", s.State.Terminated.Reason)
+		case s.State.Waiting != nil:
+			switch s.State.Waiting.Reason {
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerError":
+				return true, fmt.Errorf("debug container %s: %s", s.State.Waiting.Reason, s.State.Waiting.Message)
+			}
+		}
+	}
+	return false, nil
+}
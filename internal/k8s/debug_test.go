@@ -0,0 +1,54 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDebugContainer(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "app"}},
+	}}
+	c := debugContainer(pod, "", "app")
+	if c.Name != "debugger" || c.Image != DefaultDebugImage || c.TargetContainerName != "app" {
+		t.Errorf("first debug container = %s %s target %s", c.Name, c.Image, c.TargetContainerName)
+	}
+	if !c.Stdin || !c.TTY {
+		t.Error("debug container should be interactive to stay running")
+	}
+
+	pod.Spec.EphemeralContainers = []corev1.EphemeralContainer{
+		{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger"}},
+		{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger-2"}},
+	}
+	if c := debugContainer(pod, "nicolaka/netshoot", ""); c.Name != "debugger-3" || c.Image != "nicolaka/netshoot" {
+		t.Errorf("third debug container = %s %s", c.Name, c.Image)
+	}
+}
+
+func TestDebugContainerStarted(t *testing.T) {
+	status := func(state corev1.ContainerState) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{
+			EphemeralContainerStatuses: []corev1.ContainerStatus{{Name: "debugger", State: state}},
+		}}
+	}
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		done    bool
+		wantErr bool
+	}{
+		{"no status yet", &corev1.Pod{}, false, false},
+		{"creating", status(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}), false, false},
+		{"running", status(corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}), true, false},
+		{"bad image", status(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}), true, true},
+		{"exited", status(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error"}}), true, true},
+	}
+	for _, tt := range tests {
+		done, err := debugContainerStarted(tt.pod, "debugger")
+		if done != tt.done || (err != nil) != tt.wantErr {
+			t.Errorf("%s: done=%v err=%v, want done=%v err=%v", tt.name, done, err, tt.done, tt.wantErr)
+		}
+	}
+}
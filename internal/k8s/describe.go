@@ -0,0 +1,567 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DescribePod renders kubectl describe-style output for a pod from the API,
+// so it needs no kubectl and always uses the in-app context
+func DescribePod(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) (string, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	// Events are left out rather than failing the description
+	events, _ := GetPodEvents(ctx, clientset, namespace, name)
+	return FormatPodDescription(pod, events), nil
+}
+
+// DescribeWorkload renders kubectl describe-style output for a workload,
+// service or node
+func DescribeWorkload(ctx context.Context, clientset *kubernetes.Clientset, namespace string, resourceType ResourceType, name string) (string, error) {
+	var w describeWriter
+	switch resourceType {
+	case ResourcePods:
+		return DescribePod(ctx, clientset, namespace, name)
+	case ResourceNodes:
+		d, err := GetNodeDetail(ctx, clientset, name)
+		if err != nil {
+			return "", err
+		}
+		return FormatNodeDetail(d), nil
+	case ResourceDeployments:
+		d, err := GetDeployment(ctx, clientset, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		describeDeployment(&w, d)
+	case ResourceStatefulSets:
+		s, err := GetStatefulSet(ctx, clientset, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		describeStatefulSet(&w, s)
+	case ResourceDaemonSets:
+		d, err := GetDaemonSet(ctx, clientset, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		describeDaemonSet(&w, d)
+	case ResourceJobs:
+		j, err := GetJob(ctx, clientset, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		describeJob(&w, j)
+	case ResourceCronJobs:
+		cj, err := clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		describeCronJob(&w, cj)
+	case ResourceServices:
+		svc, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		describeService(&w, svc)
+	default:
+		return "", fmt.Errorf("cannot describe %s", resourceType)
+	}
+
+	// GetPodEvents matches on the involved object's name, whatever its kind
+	events, _ := GetPodEvents(ctx, clientset, namespace, name)
+	describeEvents(&w, events)
+	return w.String(), nil
+}
+
+// FormatPodDescription lays out a pod the way kubectl describe does
+func FormatPodDescription(pod *corev1.Pod, events []EventInfo) string {
+	var w describeWriter
+	describeMeta(&w, &pod.ObjectMeta)
+	if pod.Spec.Priority != nil {
+		w.line(0, "Priority", *pod.Spec.Priority)
+	}
+	w.line(0, "Service Account", pod.Spec.ServiceAccountName)
+	node := "<none>"
+	if pod.Spec.NodeName != "" {
+		node = pod.Spec.NodeName + "/" + pod.Status.HostIP
+	}
+	w.line(0, "Node", node)
+	if pod.Status.StartTime != nil {
+		w.line(0, "Start Time", pod.Status.StartTime.Time.Format(time.RFC1123Z))
+	}
+	w.line(0, "Status", getPodStatus(pod))
+	if pod.Status.Reason != "" {
+		w.line(0, "Reason", pod.Status.Reason)
+	}
+	if pod.Status.Message != "" {
+		w.line(0, "Message", pod.Status.Message)
+	}
+	w.line(0, "IP", pod.Status.PodIP)
+	if ref := metav1.GetControllerOf(pod); ref != nil {
+		w.line(0, "Controlled By", ref.Kind+"/"+ref.Name)
+	}
+
+	probes := DescribeProbes(pod)
+	if len(pod.Spec.InitContainers) > 0 {
+		w.line(0, "Init Containers", "")
+		describeContainers(&w, pod.Spec.InitContainers, pod.Status.InitContainerStatuses, probes)
+	}
+	w.line(0, "Containers", "")
+	describeContainers(&w, pod.Spec.Containers, pod.Status.ContainerStatuses, probes)
+
+	if len(pod.Status.Conditions) > 0 {
+		w.line(0, "Conditions", "")
+		w.row(1, "Type", "Status")
+		for _, c := range pod.Status.Conditions {
+			w.row(1, string(c.Type), string(c.Status))
+		}
+	}
+	describeVolumes(&w, DescribeVolumes(pod))
+	w.line(0, "QoS Class", string(pod.Status.QOSClass))
+	w.line(0, "Node-Selectors", mapText(pod.Spec.NodeSelector))
+	w.line(0, "Tolerations", tolerationsText(pod.Spec.Tolerations))
+	describeEvents(&w, events)
+	return w.String()
+}
+
+func describeDeployment(w *describeWriter, d *appsv1.Deployment) {
+	describeMeta(w, &d.ObjectMeta)
+	w.line(0, "Selector", selectorText(d.Spec.Selector))
+	w.line(0, "Replicas", fmt.Sprintf("%d desired | %d updated | %d total | %d available | %d unavailable",
+		replicasOrOne(d.Spec.Replicas), d.Status.UpdatedReplicas, d.Status.Replicas,
+		d.Status.AvailableReplicas, d.Status.UnavailableReplicas))
+	w.line(0, "StrategyType", string(d.Spec.Strategy.Type))
+	w.line(0, "MinReadySeconds", d.Spec.MinReadySeconds)
+	if ru := d.Spec.Strategy.RollingUpdate; ru != nil {
+		w.line(0, "RollingUpdateStrategy", fmt.Sprintf("%s max unavailable, %s max surge", ru.MaxUnavailable, ru.MaxSurge))
+	}
+	describeTemplate(w, &d.Spec.Template)
+	describeConditions(w, len(d.Status.Conditions), func(row func(typ, status, reason string)) {
+		for _, c := range d.Status.Conditions {
+			row(string(c.Type), string(c.Status), c.Reason)
+		}
+	})
+}
+
+func describeStatefulSet(w *describeWriter, s *appsv1.StatefulSet) {
+	describeMeta(w, &s.ObjectMeta)
+	w.line(0, "Selector", selectorText(s.Spec.Selector))
+	w.line(0, "Replicas", fmt.Sprintf("%d desired | %d total", replicasOrOne(s.Spec.Replicas), s.Status.Replicas))
+	w.line(0, "Update Strategy", string(s.Spec.UpdateStrategy.Type))
+	if ru := s.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		w.line(1, "Partition", *ru.Partition)
+	}
+	w.line(0, "Pods Status", fmt.Sprintf("%d Ready / %d Current / %d Updated",
+		s.Status.ReadyReplicas, s.Status.CurrentReplicas, s.Status.UpdatedReplicas))
+	describeTemplate(w, &s.Spec.Template)
+	if len(s.Spec.VolumeClaimTemplates) > 0 {
+		w.line(0, "Volume Claims", "")
+		for _, t := range s.Spec.VolumeClaimTemplates {
+			w.line(1, "Name", t.Name)
+			w.line(1, "StorageClass", stringOrNone(t.Spec.StorageClassName))
+			if size, ok := t.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+				w.line(1, "Capacity", size.String())
+			}
+		}
+	}
+}
+
+func describeDaemonSet(w *describeWriter, d *appsv1.DaemonSet) {
+	describeMeta(w, &d.ObjectMeta)
+	w.line(0, "Selector", selectorText(d.Spec.Selector))
+	w.line(0, "Node-Selector", mapText(d.Spec.Template.Spec.NodeSelector))
+	w.line(0, "Desired Number of Nodes Scheduled", d.Status.DesiredNumberScheduled)
+	w.line(0, "Current Number of Nodes Scheduled", d.Status.CurrentNumberScheduled)
+	w.line(0, "Number of Nodes Scheduled with Up-to-date Pods", d.Status.UpdatedNumberScheduled)
+	w.line(0, "Number of Nodes Scheduled with Available Pods", d.Status.NumberAvailable)
+	w.line(0, "Number of Nodes Misscheduled", d.Status.NumberMisscheduled)
+	w.line(0, "Pods Status", fmt.Sprintf("%d Ready / %d Unavailable", d.Status.NumberReady, d.Status.NumberUnavailable))
+	describeTemplate(w, &d.Spec.Template)
+}
+
+func describeJob(w *describeWriter, j *batchv1.Job) {
+	describeMeta(w, &j.ObjectMeta)
+	w.line(0, "Selector", selectorText(j.Spec.Selector))
+	w.line(0, "Parallelism", replicasOrOne(j.Spec.Parallelism))
+	w.line(0, "Completions", replicasOrOne(j.Spec.Completions))
+	if j.Spec.BackoffLimit != nil {
+		w.line(0, "Backoff Limit", *j.Spec.BackoffLimit)
+	}
+	if j.Status.StartTime != nil {
+		w.line(0, "Start Time", j.Status.StartTime.Time.Format(time.RFC1123Z))
+	}
+	if j.Status.CompletionTime != nil {
+		w.line(0, "Completed At", j.Status.CompletionTime.Time.Format(time.RFC1123Z))
+	}
+	w.line(0, "Pods Statuses", fmt.Sprintf("%d Active / %d Succeeded / %d Failed",
+		j.Status.Active, j.Status.Succeeded, j.Status.Failed))
+	describeTemplate(w, &j.Spec.Template)
+	describeConditions(w, len(j.Status.Conditions), func(row func(typ, status, reason string)) {
+		for _, c := range j.Status.Conditions {
+			row(string(c.Type), string(c.Status), c.Reason)
+		}
+	})
+}
+
+func describeCronJob(w *describeWriter, cj *batchv1.CronJob) {
+	describeMeta(w, &cj.ObjectMeta)
+	w.line(0, "Schedule", cj.Spec.Schedule)
+	w.line(0, "Concurrency Policy", string(cj.Spec.ConcurrencyPolicy))
+	suspend := false
+	if cj.Spec.Suspend != nil {
+		suspend = *cj.Spec.Suspend
+	}
+	w.line(0, "Suspend", suspend)
+	if cj.Status.LastScheduleTime != nil {
+		w.line(0, "Last Schedule Time", cj.Status.LastScheduleTime.Time.Format(time.RFC1123Z))
+	}
+	var active []string
+	for _, ref := range cj.Status.Active {
+		active = append(active, ref.Name)
+	}
+	w.line(0, "Active Jobs", listText(active))
+	describeTemplate(w, &cj.Spec.JobTemplate.Spec.Template)
+}
+
+func describeService(w *describeWriter, svc *corev1.Service) {
+	describeMeta(w, &svc.ObjectMeta)
+	w.line(0, "Selector", mapText(svc.Spec.Selector))
+	w.line(0, "Type", string(svc.Spec.Type))
+	w.line(0, "IP", stringOrNone(&svc.Spec.ClusterIP))
+	if svc.Spec.ExternalName != "" {
+		w.line(0, "External Name", svc.Spec.ExternalName)
+	}
+	for _, ing := range svc.Status.LoadBalancer.Ingress {
+		w.line(0, "LoadBalancer Ingress", ing.IP+ing.Hostname)
+	}
+	for _, p := range svc.Spec.Ports {
+		name := p.Name
+		if name == "" {
+			name = "<unset>"
+		}
+		w.line(0, "Port", fmt.Sprintf("%s  %d/%s", name, p.Port, p.Protocol))
+		w.line(0, "TargetPort", fmt.Sprintf("%s/%s", p.TargetPort.String(), p.Protocol))
+		if p.NodePort != 0 {
+			w.line(0, "NodePort", fmt.Sprintf("%s  %d/%s", name, p.NodePort, p.Protocol))
+		}
+	}
+	w.line(0, "Session Affinity", string(svc.Spec.SessionAffinity))
+}
+
+func describeMeta(w *describeWriter, meta *metav1.ObjectMeta) {
+	w.line(0, "Name", meta.Name)
+	if meta.Namespace != "" {
+		w.line(0, "Namespace", meta.Namespace)
+	}
+	w.line(0, "CreationTimestamp", meta.CreationTimestamp.Time.Format(time.RFC1123Z))
+	w.multi(0, "Labels", sortedPairs(meta.Labels))
+	// last-applied-configuration repeats the whole object
+	annotations := make(map[string]string, len(meta.Annotations))
+	for k, v := range meta.Annotations {
+		if k != corev1.LastAppliedConfigAnnotation {
+			annotations[k] = v
+		}
+	}
+	w.multi(0, "Annotations", sortedPairs(annotations))
+}
+
+func describeTemplate(w *describeWriter, t *corev1.PodTemplateSpec) {
+	w.line(0, "Pod Template", "")
+	w.multi(1, "Labels", sortedPairs(t.Labels))
+	if t.Spec.ServiceAccountName != "" {
+		w.line(1, "Service Account", t.Spec.ServiceAccountName)
+	}
+	probes := DescribeProbes(&corev1.Pod{Spec: t.Spec})
+	if len(t.Spec.InitContainers) > 0 {
+		w.line(1, "Init Containers", "")
+		w.indent++
+		describeContainers(w, t.Spec.InitContainers, nil, probes)
+		w.indent--
+	}
+	w.line(1, "Containers", "")
+	w.indent++
+	describeContainers(w, t.Spec.Containers, nil, probes)
+	w.indent--
+	w.indent++
+	describeVolumes(w, DescribeVolumes(&corev1.Pod{Spec: t.Spec}))
+	w.indent--
+}
+
+func describeContainers(w *describeWriter, containers []corev1.Container, statuses []corev1.ContainerStatus, probes []ProbeInfo) {
+	byName := make(map[string]corev1.ContainerStatus, len(statuses))
+	for _, s := range statuses {
+		byName[s.Name] = s
+	}
+	for _, c := range containers {
+		w.line(1, c.Name, "")
+		status, hasStatus := byName[c.Name]
+		if hasStatus && status.ContainerID != "" {
+			w.line(2, "Container ID", status.ContainerID)
+		}
+		w.line(2, "Image", c.Image)
+		if hasStatus && status.ImageID != "" {
+			w.line(2, "Image ID", status.ImageID)
+		}
+		var ports []string
+		for _, p := range c.Ports {
+			ports = append(ports, fmt.Sprintf("%d/%s", p.ContainerPort, p.Protocol))
+		}
+		w.line(2, "Port", listText(ports))
+		if len(c.Command) > 0 {
+			w.line(2, "Command", strings.Join(c.Command, " "))
+		}
+		if len(c.Args) > 0 {
+			w.line(2, "Args", strings.Join(c.Args, " "))
+		}
+		if hasStatus {
+			describeContainerState(w, "State", status.State)
+			if status.LastTerminationState != (corev1.ContainerState{}) {
+				describeContainerState(w, "Last State", status.LastTerminationState)
+			}
+			w.line(2, "Ready", status.Ready)
+			w.line(2, "Restart Count", status.RestartCount)
+		}
+		w.multi(2, "Limits", resourcePairs(c.Resources.Limits))
+		w.multi(2, "Requests", resourcePairs(c.Resources.Requests))
+		for _, p := range probes {
+			if p.Container == c.Name {
+				w.line(2, strings.ToUpper(p.Kind[:1])+p.Kind[1:], p.Target()+" "+p.Timing())
+			}
+		}
+		var env []string
+		for _, e := range c.Env {
+			env = append(env, e.Name+": "+envValueText(e))
+		}
+		for _, from := range c.EnvFrom {
+			switch {
+			case from.ConfigMapRef != nil:
+				env = append(env, from.Prefix+"* from ConfigMap "+from.ConfigMapRef.Name)
+			case from.SecretRef != nil:
+				env = append(env, from.Prefix+"* from Secret "+from.SecretRef.Name)
+			}
+		}
+		w.multi(2, "Environment", env)
+		var mounts []string
+		for _, m := range c.VolumeMounts {
+			mode := "rw"
+			if m.ReadOnly {
+				mode = "ro"
+			}
+			mount := fmt.Sprintf("%s from %s (%s)", m.MountPath, m.Name, mode)
+			if m.SubPath != "" {
+				mount += " subPath " + m.SubPath
+			}
+			mounts = append(mounts, mount)
+		}
+		w.multi(2, "Mounts", mounts)
+	}
+}
+
+func describeContainerState(w *describeWriter, label string, state corev1.ContainerState) {
+	switch {
+	case state.Running != nil:
+		w.line(2, label, "Running")
+		w.line(3, "Started", state.Running.StartedAt.Time.Format(time.RFC1123Z))
+	case state.Waiting != nil:
+		w.line(2, label, "Waiting")
+		w.line(3, "Reason", state.Waiting.Reason)
+		if state.Waiting.Message != "" {
+			w.line(3, "Message", state.Waiting.Message)
+		}
+	case state.Terminated != nil:
+		t := state.Terminated
+		w.line(2, label, "Terminated")
+		w.line(3, "Reason", t.Reason)
+		if t.Message != "" {
+			w.line(3, "Message", t.Message)
+		}
+		w.line(3, "Exit Code", t.ExitCode)
+		w.line(3, "Started", t.StartedAt.Time.Format(time.RFC1123Z))
+		w.line(3, "Finished", t.FinishedAt.Time.Format(time.RFC1123Z))
+	default:
+		w.line(2, label, "Waiting")
+	}
+}
+
+func describeVolumes(w *describeWriter, volumes []VolumeInfo) {
+	if len(volumes) == 0 {
+		w.line(0, "Volumes", "<none>")
+		return
+	}
+	w.line(0, "Volumes", "")
+	for _, v := range volumes {
+		w.line(1, v.Name, "")
+		w.line(2, "Type", v.Type)
+		if v.Source != "" {
+			w.line(2, "Source", v.Source)
+		}
+	}
+}
+
+// describeConditions writes a Type/Status/Reason table; rows feeds it
+func describeConditions(w *describeWriter, n int, rows func(row func(typ, status, reason string))) {
+	if n == 0 {
+		return
+	}
+	w.line(0, "Conditions", "")
+	w.row(1, "Type", "Status", "Reason")
+	rows(func(typ, status, reason string) {
+		w.row(1, typ, status, reason)
+	})
+}
+
+func describeEvents(w *describeWriter, events []EventInfo) {
+	if len(events) == 0 {
+		w.line(0, "Events", "<none>")
+		return
+	}
+	// Oldest first, as kubectl prints them
+	sorted := append([]EventInfo(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].LastSeen.Before(sorted[j].LastSeen) })
+	w.line(0, "Events", "")
+	w.row(1, "Type", "Reason", "Age", "From", "Message")
+	for _, e := range sorted {
+		age := e.Age
+		if e.Count > 1 {
+			age = fmt.Sprintf("%s (x%d)", e.Age, e.Count)
+		}
+		w.row(1, e.Type, e.Reason, age, e.Source, e.Message)
+	}
+}
+
+func envValueText(e corev1.EnvVar) string {
+	from := e.ValueFrom
+	switch {
+	case from == nil:
+		return e.Value
+	case from.SecretKeyRef != nil:
+		return fmt.Sprintf("<set to the key '%s' in secret '%s'>", from.SecretKeyRef.Key, from.SecretKeyRef.Name)
+	case from.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("<set to the key '%s' of config map '%s'>", from.ConfigMapKeyRef.Key, from.ConfigMapKeyRef.Name)
+	case from.FieldRef != nil:
+		return fmt.Sprintf("(%s:%s)", from.FieldRef.APIVersion, from.FieldRef.FieldPath)
+	case from.ResourceFieldRef != nil:
+		return fmt.Sprintf("%s (%s)", from.ResourceFieldRef.Resource, from.ResourceFieldRef.ContainerName)
+	}
+	return ""
+}
+
+func resourcePairs(list corev1.ResourceList) []string {
+	var pairs []string
+	for _, name := range sortedResourceNames(list) {
+		q := list[name]
+		pairs = append(pairs, fmt.Sprintf("%s: %s", name, q.String()))
+	}
+	return pairs
+}
+
+func sortedPairs(m map[string]string) []string {
+	pairs := make([]string, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		pairs = append(pairs, k+"="+m[k])
+	}
+	return pairs
+}
+
+func mapText(m map[string]string) string {
+	return listText(sortedPairs(m))
+}
+
+func listText(items []string) string {
+	if len(items) == 0 {
+		return "<none>"
+	}
+	return strings.Join(items, ", ")
+}
+
+func tolerationsText(tolerations []corev1.Toleration) string {
+	var items []string
+	for _, t := range tolerations {
+		s := t.Key
+		if t.Operator == corev1.TolerationOpEqual || t.Value != "" {
+			s += "=" + t.Value
+		}
+		if t.Effect != "" {
+			s += ":" + string(t.Effect)
+		}
+		if t.Operator == corev1.TolerationOpExists && t.Key != "" {
+			s += " op=Exists"
+		}
+		if t.TolerationSeconds != nil {
+			s += fmt.Sprintf(" for %ds", *t.TolerationSeconds)
+		}
+		items = append(items, s)
+	}
+	return listText(items)
+}
+
+func selectorText(s *metav1.LabelSelector) string {
+	selector, err := metav1.LabelSelectorAsSelector(s)
+	if err != nil || selector.Empty() {
+		return "<none>"
+	}
+	return selector.String()
+}
+
+func replicasOrOne(n *int32) int32 {
+	if n == nil {
+		return 1
+	}
+	return *n
+}
+
+func stringOrNone(s *string) string {
+	if s == nil || *s == "" {
+		return "<none>"
+	}
+	return *s
+}
+
+// describeWriter aligns "Label:  value" lines the way kubectl describe does:
+// values line up within each run of lines at the same depth
+type describeWriter struct {
+	b      strings.Builder
+	indent int
+}
+
+func (w *describeWriter) line(level int, label string, value any) {
+	fmt.Fprintf(&w.b, "%s%s:\t%v\n", strings.Repeat("  ", level+w.indent), label, value)
+}
+
+// multi writes one item per line, the first beside the label
+func (w *describeWriter) multi(level int, label string, items []string) {
+	if len(items) == 0 {
+		w.line(level, label, "<none>")
+		return
+	}
+	w.line(level, label, items[0])
+	for _, item := range items[1:] {
+		fmt.Fprintf(&w.b, "%s\t%s\n", strings.Repeat("  ", level+w.indent), item)
+	}
+}
+
+// row writes tab-separated columns of a table
+func (w *describeWriter) row(level int, cells ...string) {
+	fmt.Fprintf(&w.b, "%s%s\n", strings.Repeat("  ", level+w.indent), strings.Join(cells, "\t"))
+}
+
+func (w *describeWriter) String() string {
+	var out strings.Builder
+	tw := tabwriter.NewWriter(&out, 0, 8, 2, ' ', 0)
+	fmt.Fprint(tw, w.b.String())
+	tw.Flush()
+	return out.String()
+}
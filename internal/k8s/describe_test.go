@@ -0,0 +1,100 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFormatPodDescription(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-0",
+			Namespace: "shop",
+			Labels:    map[string]string{"app": "web", "tier": "front"},
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: "{...}",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: "web:v2",
+				Ports: []corev1.ContainerPort{{ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
+				Env: []corev1.EnvVar{
+					{Name: "MODE", Value: "prod"},
+					{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}, Key: "token"},
+					}},
+				},
+				Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:  corev1.PodRunning,
+			HostIP: "10.0.0.1",
+			PodIP:  "10.1.0.7",
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "app",
+				Ready:        true,
+				RestartCount: 2,
+				State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason: "OOMKilled", ExitCode: 137,
+				}},
+			}},
+		},
+	}
+	events := []EventInfo{
+		{Type: "Warning", Reason: "BackOff", Message: "Back-off restarting", Source: "kubelet", Age: "1m", Count: 3, LastSeen: time.Now()},
+		{Type: "Normal", Reason: "Scheduled", Message: "Assigned", Source: "scheduler", Age: "5m", LastSeen: time.Now().Add(-5 * time.Minute)},
+	}
+
+	out := FormatPodDescription(pod, events)
+	for _, want := range []string{
+		"Name:",
+		"Node:",
+		"node-1/10.0.0.1",
+		"app=web",
+		"tier=front",
+		"Image:",
+		"8080/TCP",
+		"Last State:",
+		"OOMKilled",
+		"Restart Count:",
+		"memory: 256Mi",
+		"TOKEN: <set to the key 'token' in secret 'creds'>",
+		"1m (x3)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("description missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, corev1.LastAppliedConfigAnnotation) {
+		t.Error("last-applied-configuration should be left out")
+	}
+	if strings.Index(out, "Scheduled") > strings.Index(out, "BackOff") {
+		t.Error("events should be listed oldest first")
+	}
+
+	// Values line up within a block
+	name := strings.Index(lineWith(out, "Name:"), "web-0")
+	namespace := strings.Index(lineWith(out, "Namespace:"), "shop")
+	if name != namespace {
+		t.Errorf("values not aligned: %d vs %d", name, namespace)
+	}
+}
+
+func lineWith(s, prefix string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(line, prefix) {
+			return line
+		}
+	}
+	return ""
+}
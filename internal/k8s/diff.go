@@ -0,0 +1,49 @@
+package k8s
+
+import "strings"
+
+// SimpleDiff returns a line diff of a and b, prefixing removed lines with
+// "- ", added lines with "+ " and unchanged lines with "  "
+func SimpleDiff(a, b string) string {
+	left := strings.Split(strings.TrimRight(a, "\n"), "\n")
+	right := strings.Split(strings.TrimRight(b, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of left[i:] and right[j:]
+	lcs := make([][]int, len(left)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(right)+1)
+	}
+	for i := len(left) - 1; i >= 0; i-- {
+		for j := len(right) - 1; j >= 0; j-- {
+			if left[i] == right[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(left) && j < len(right) {
+		switch {
+		case left[i] == right[j]:
+			out.WriteString("  " + left[i] + "\n")
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out.WriteString("- " + left[i] + "\n")
+			i++
+		default:
+			out.WriteString("+ " + right[j] + "\n")
+			j++
+		}
+	}
+	for ; i < len(left); i++ {
+		out.WriteString("- " + left[i] + "\n")
+	}
+	for ; j < len(right); j++ {
+		out.WriteString("+ " + right[j] + "\n")
+	}
+	return out.String()
+}
@@ -0,0 +1,39 @@
+package k8s
+
+import "testing"
+
+func TestSimpleDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{
+			name: "identical",
+			a:    "a\nb\n",
+			b:    "a\nb\n",
+			want: "  a\n  b\n",
+		},
+		{
+			name: "changed line",
+			a:    "image: api:1.0\nreplicas: 2",
+			b:    "image: api:1.1\nreplicas: 2",
+			want: "- image: api:1.0\n+ image: api:1.1\n  replicas: 2\n",
+		},
+		{
+			name: "added and removed",
+			a:    "a\nb\nc",
+			b:    "b\nc\nd",
+			want: "- a\n  b\n  c\n+ d\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SimpleDiff(tt.a, tt.b); got != tt.want {
+				t.Errorf("SimpleDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ImageDigest extracts the digest from a container status imageID such as
+// "docker-pullable://nginx@sha256:..." or "sha256:..."
+func ImageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	if strings.HasPrefix(imageID, "sha256:") {
+		return imageID
+	}
+	return ""
+}
+
+// ShortImage drops the registry host from an image reference, keeping the
+// repository and tag, e.g. "ghcr.io/acme/web:v1.2" becomes "acme/web:v1.2"
+func ShortImage(image string) string {
+	host, rest, ok := strings.Cut(image, "/")
+	if !ok || !(strings.ContainsAny(host, ".:") || host == "localhost") {
+		return image
+	}
+	return rest
+}
+
+// ShortDigest abbreviates a digest for display, e.g. sha256:1a2b3c4d5e6f
+func ShortDigest(digest string) string {
+	algo, hex, found := strings.Cut(digest, ":")
+	if !found || len(hex) <= 12 {
+		return digest
+	}
+	return algo + ":" + hex[:12]
+}
+
+// AnalyzeDigestDrift flags containers whose tag resolved to different image
+// digests across pods of the same workload, e.g. a mutable :latest tag
+func AnalyzeDigestDrift(pods []PodInfo) []DebugHelper {
+	type key struct{ container, image string }
+	digests := make(map[key]map[string][]string)
+	var order []key
+
+	for _, pod := range pods {
+		for _, c := range pod.Containers {
+			if c.Digest == "" || strings.Contains(c.Image, "@") {
+				continue // not running yet, or pinned by digest so drift is impossible
+			}
+			k := key{c.Name, c.Image}
+			if _, ok := digests[k]; !ok {
+				digests[k] = make(map[string][]string)
+				order = append(order, k)
+			}
+			digests[k][c.Digest] = append(digests[k][c.Digest], pod.Name)
+		}
+	}
+
+	var helpers []DebugHelper
+	for _, k := range order {
+		byDigest := digests[k]
+		if len(byDigest) < 2 {
+			continue
+		}
+
+		var list []string
+		for d := range byDigest {
+			list = append(list, d)
+		}
+		sort.Strings(list)
+
+		var suggestions []string
+		for _, d := range list {
+			suggestions = append(suggestions, fmt.Sprintf("%s on %d pod(s): %s",
+				ShortDigest(d), len(byDigest[d]), strings.Join(byDigest[d], ", ")))
+		}
+		suggestions = append(suggestions, "Pin the image by digest or use immutable tags")
+
+		helpers = append(helpers, DebugHelper{
+			Issue:       fmt.Sprintf("Image digest drift for %s (%s)", k.container, k.image),
+			Severity:    "Warning",
+			Suggestions: suggestions,
+		})
+	}
+	return helpers
+}
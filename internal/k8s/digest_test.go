@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImageDigest(t *testing.T) {
+	tests := []struct {
+		imageID string
+		want    string
+	}{
+		{"docker-pullable://nginx@sha256:abc123", "sha256:abc123"},
+		{"docker.io/library/nginx@sha256:abc123", "sha256:abc123"},
+		{"sha256:abc123", "sha256:abc123"},
+		{"", ""},
+		{"docker://nginx:1.25", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ImageDigest(tt.imageID); got != tt.want {
+			t.Errorf("ImageDigest(%q) = %q, want %q", tt.imageID, got, tt.want)
+		}
+	}
+}
+
+func TestShortDigest(t *testing.T) {
+	if got := ShortDigest("sha256:0123456789abcdef0123"); got != "sha256:0123456789ab" {
+		t.Errorf("ShortDigest() = %q", got)
+	}
+	if got := ShortDigest("sha256:abc"); got != "sha256:abc" {
+		t.Errorf("ShortDigest() = %q", got)
+	}
+}
+
+func TestAnalyzeDigestDrift(t *testing.T) {
+	pod := func(name, image, digest string) PodInfo {
+		return PodInfo{Name: name, Containers: []ContainerInfo{{Name: "app", Image: image, Digest: digest}}}
+	}
+
+	tests := []struct {
+		name      string
+		pods      []PodInfo
+		wantCount int
+		contains  string
+	}{
+		{
+			name:      "same digest",
+			pods:      []PodInfo{pod("a", "api:latest", "sha256:1"), pod("b", "api:latest", "sha256:1")},
+			wantCount: 0,
+		},
+		{
+			name:      "drift",
+			pods:      []PodInfo{pod("a", "api:latest", "sha256:1"), pod("b", "api:latest", "sha256:2"), pod("c", "api:latest", "sha256:2")},
+			wantCount: 1,
+			contains:  "sha256:2 on 2 pod(s): b, c",
+		},
+		{
+			name:      "different spec images are not drift",
+			pods:      []PodInfo{pod("a", "api:1.0", "sha256:1"), pod("b", "api:1.1", "sha256:2")},
+			wantCount: 0,
+		},
+		{
+			name:      "pending pods ignored",
+			pods:      []PodInfo{pod("a", "api:latest", "sha256:1"), pod("b", "api:latest", "")},
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helpers := AnalyzeDigestDrift(tt.pods)
+			if len(helpers) != tt.wantCount {
+				t.Fatalf("AnalyzeDigestDrift() returned %d helpers, want %d", len(helpers), tt.wantCount)
+			}
+			if tt.contains != "" && !strings.Contains(strings.Join(helpers[0].Suggestions, "\n"), tt.contains) {
+				t.Errorf("suggestions %v missing %q", helpers[0].Suggestions, tt.contains)
+			}
+		})
+	}
+}
+
+func TestShortImage(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"nginx:1.25", "nginx:1.25"},
+		{"acme/web:v1", "acme/web:v1"},
+		{"ghcr.io/acme/web:v1.2", "acme/web:v1.2"},
+		{"localhost:5000/web:dev", "web:dev"},
+		{"localhost/web", "web"},
+	}
+	for _, tt := range tests {
+		if got := ShortImage(tt.image); got != tt.want {
+			t.Errorf("ShortImage(%q) = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+}
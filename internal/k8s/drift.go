@@ -0,0 +1,424 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ConfigDrift is a ConfigMap or Secret that changed after a container started
+// using it, so the container may be running with the old version
+type ConfigDrift struct {
+	Kind      string // ConfigMap or Secret
+	Name      string
+	Container string
+	Via       string // env, envFrom, volume or subPath
+	ChangedAt time.Time
+	StartedAt time.Time
+	// Targets maps what the container sees, an environment variable or a
+	// file path, to the key it comes from
+	Targets map[string]string
+	// Current holds the object's data now under the same names as Targets;
+	// Secret values are replaced by a hash
+	Current map[string]string
+}
+
+// Stale reports whether the container keeps the old data until it restarts.
+// The kubelet refreshes plain volume mounts in place, though an app that reads
+// its config only at startup still needs a restart.
+func (d ConfigDrift) Stale() bool {
+	return d.Via != "volume"
+}
+
+// Format renders values named like Targets for a diff: one NAME=value line
+// per environment variable, or each file's path followed by its content
+func (d ConfigDrift) Format(values map[string]string) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		if d.Via == "env" || d.Via == "envFrom" {
+			fmt.Fprintf(&b, "%s=%s\n", name, values[name])
+			continue
+		}
+		fmt.Fprintf(&b, "== %s ==\n%s\n", name, strings.TrimRight(values[name], "\n"))
+	}
+	return b.String()
+}
+
+// configObject is the part of a ConfigMap or Secret drift detection needs
+type configObject struct {
+	changed time.Time
+	data    map[string]string
+}
+
+// configUse is one way a container reads a ConfigMap or Secret. targets
+// maps the object's keys to what the container sees.
+type configUse struct {
+	kind, name, container, via string
+	targets                    func(keys []string) map[string]string
+}
+
+// ConfigObjects reads the ConfigMaps and Secrets of one namespace at most
+// once each, so the checks of one refresh that need the same objects, drift
+// and missing references, share the requests. It is safe for concurrent use.
+type ConfigObjects struct {
+	clientset *kubernetes.Clientset
+	namespace string
+
+	mu      sync.Mutex
+	entries map[string]*configEntry
+}
+
+type configEntry struct {
+	once sync.Once
+	obj  *configObject
+	err  error
+}
+
+func NewConfigObjects(clientset *kubernetes.Clientset, namespace string) *ConfigObjects {
+	return &ConfigObjects{clientset: clientset, namespace: namespace, entries: make(map[string]*configEntry)}
+}
+
+// get reads the object on first use; later and concurrent calls for it wait
+// for and share that result
+func (c *ConfigObjects) get(ctx context.Context, kind, name string) (*configObject, error) {
+	c.mu.Lock()
+	e, ok := c.entries[kind+"/"+name]
+	if !ok {
+		e = &configEntry{}
+		c.entries[kind+"/"+name] = e
+	}
+	c.mu.Unlock()
+	e.once.Do(func() {
+		e.obj, e.err = getConfigObject(ctx, c.clientset, c.namespace, kind, name)
+	})
+	return e.obj, e.err
+}
+
+// FindConfigDrift lists the ConfigMaps and Secrets the pod's containers use
+// through env, envFrom or volumes that changed after the container started.
+// Objects that are gone or cannot be read are skipped.
+func FindConfigDrift(ctx context.Context, objects *ConfigObjects, pod *corev1.Pod) ([]ConfigDrift, error) {
+	if pod == nil {
+		return nil, nil
+	}
+	var lookupErr error
+	lookup := func(kind, name string) *configObject {
+		obj, err := objects.get(ctx, kind, name)
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) && lookupErr == nil {
+			lookupErr = err
+		}
+		return obj
+	}
+	drift := configDrift(pod, lookup)
+	return drift, lookupErr
+}
+
+func getConfigObject(ctx context.Context, clientset *kubernetes.Clientset, namespace, kind, name string) (*configObject, error) {
+	if kind == "Secret" {
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data := make(map[string]string, len(secret.Data))
+		for k, v := range secret.Data {
+			data[k] = secretHash(string(v))
+		}
+		return &configObject{changed: lastChange(secret.ObjectMeta), data: data}, nil
+	}
+
+	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]string, len(cm.Data)+len(cm.BinaryData))
+	for k, v := range cm.Data {
+		data[k] = v
+	}
+	for k, v := range cm.BinaryData {
+		data[k] = fmt.Sprintf("<binary, %d bytes>", len(v))
+	}
+	return &configObject{changed: lastChange(cm.ObjectMeta), data: data}, nil
+}
+
+// lastChange is when an object's data was last written, from the managed
+// fields entries that own some of it, or its creation when none do. An
+// entry for labels or annotations alone, e.g. a controller's, says nothing
+// about the data.
+func lastChange(meta metav1.ObjectMeta) time.Time {
+	changed := meta.CreationTimestamp.Time
+	for _, f := range meta.ManagedFields {
+		if f.Time != nil && f.Time.After(changed) && ownsData(f.FieldsV1) {
+			changed = f.Time.Time
+		}
+	}
+	return changed
+}
+
+// ownsData reports whether a managed fields entry covers a ConfigMap's or
+// Secret's data, binaryData or stringData
+func ownsData(fields *metav1.FieldsV1) bool {
+	if fields == nil {
+		return false
+	}
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(fields.Raw, &top); err != nil {
+		return false
+	}
+	for _, key := range []string{"f:data", "f:binaryData", "f:stringData"} {
+		if _, ok := top[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// secretHash stands in for a Secret value so a diff shows which values
+// changed without showing them
+func secretHash(value string) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(value)))[:19]
+}
+
+func configDrift(pod *corev1.Pod, lookup func(kind, name string) *configObject) []ConfigDrift {
+	var drift []ConfigDrift
+	for _, use := range configUses(pod) {
+		started := containerStartedAt(pod, use.container)
+		obj := lookup(use.kind, use.name)
+		if obj == nil || started.IsZero() || !obj.changed.After(started) {
+			continue
+		}
+
+		keys := make([]string, 0, len(obj.data))
+		for k := range obj.data {
+			keys = append(keys, k)
+		}
+		targets := use.targets(keys)
+		if len(targets) == 0 {
+			continue
+		}
+		current := make(map[string]string, len(targets))
+		for target, key := range targets {
+			if v, ok := obj.data[key]; ok {
+				current[target] = v
+			}
+		}
+		drift = append(drift, ConfigDrift{
+			Kind:      use.kind,
+			Name:      use.name,
+			Container: use.container,
+			Via:       use.via,
+			ChangedAt: obj.changed,
+			StartedAt: started,
+			Targets:   targets,
+			Current:   current,
+		})
+	}
+	return drift
+}
+
+// containerStartedAt is when the running container started, which is when
+// it last read its environment, or the pod's start without one
+func containerStartedAt(pod *corev1.Pod, container string) time.Time {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == container && cs.State.Running != nil {
+			return cs.State.Running.StartedAt.Time
+		}
+	}
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time
+	}
+	return time.Time{}
+}
+
+// configSource is a ConfigMap or Secret behind a volume; items nil means
+// every key as a file named after it
+type configSource struct {
+	kind, name string
+	items      map[string]string // key -> path in the volume
+}
+
+func configUses(pod *corev1.Pod) []configUse {
+	volumes := make(map[string][]configSource)
+	for _, v := range pod.Spec.Volumes {
+		switch {
+		case v.ConfigMap != nil:
+			volumes[v.Name] = []configSource{{"ConfigMap", v.ConfigMap.Name, itemPaths(v.ConfigMap.Items)}}
+		case v.Secret != nil:
+			volumes[v.Name] = []configSource{{"Secret", v.Secret.SecretName, itemPaths(v.Secret.Items)}}
+		case v.Projected != nil:
+			for _, p := range v.Projected.Sources {
+				switch {
+				case p.ConfigMap != nil:
+					volumes[v.Name] = append(volumes[v.Name], configSource{"ConfigMap", p.ConfigMap.Name, itemPaths(p.ConfigMap.Items)})
+				case p.Secret != nil:
+					volumes[v.Name] = append(volumes[v.Name], configSource{"Secret", p.Secret.Name, itemPaths(p.Secret.Items)})
+				}
+			}
+		}
+	}
+
+	var uses []configUse
+	for _, c := range pod.Spec.Containers {
+		// Variables set from single keys, grouped by object
+		envKeys := make(map[[2]string]map[string]string)
+		var envOrder [][2]string
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			var ref [2]string
+			var key string
+			switch {
+			case e.ValueFrom.ConfigMapKeyRef != nil:
+				ref, key = [2]string{"ConfigMap", e.ValueFrom.ConfigMapKeyRef.Name}, e.ValueFrom.ConfigMapKeyRef.Key
+			case e.ValueFrom.SecretKeyRef != nil:
+				ref, key = [2]string{"Secret", e.ValueFrom.SecretKeyRef.Name}, e.ValueFrom.SecretKeyRef.Key
+			default:
+				continue
+			}
+			if envKeys[ref] == nil {
+				envKeys[ref] = make(map[string]string)
+				envOrder = append(envOrder, ref)
+			}
+			envKeys[ref][e.Name] = key
+		}
+		for _, ref := range envOrder {
+			vars := envKeys[ref]
+			uses = append(uses, configUse{ref[0], ref[1], c.Name, "env", func([]string) map[string]string { return vars }})
+		}
+
+		for _, e := range c.EnvFrom {
+			kind, name := "ConfigMap", ""
+			switch {
+			case e.ConfigMapRef != nil:
+				name = e.ConfigMapRef.Name
+			case e.SecretRef != nil:
+				kind, name = "Secret", e.SecretRef.Name
+			default:
+				continue
+			}
+			prefix := e.Prefix
+			uses = append(uses, configUse{kind, name, c.Name, "envFrom", func(keys []string) map[string]string {
+				vars := make(map[string]string, len(keys))
+				for _, k := range keys {
+					vars[prefix+k] = k
+				}
+				return vars
+			}})
+		}
+
+		for _, m := range c.VolumeMounts {
+			for _, src := range volumes[m.Name] {
+				uses = append(uses, mountUse(c.Name, m, src))
+			}
+		}
+	}
+	return uses
+}
+
+// mountUse maps a volume's keys to the files a mount shows; a subPath mount
+// shows just the one file it selects
+func mountUse(container string, m corev1.VolumeMount, src configSource) configUse {
+	relPath := func(key string) (string, bool) {
+		if src.items == nil {
+			return key, true
+		}
+		p, ok := src.items[key]
+		return p, ok
+	}
+
+	if m.SubPath != "" {
+		return configUse{src.kind, src.name, container, "subPath", func(keys []string) map[string]string {
+			for _, k := range keys {
+				if p, ok := relPath(k); ok && p == m.SubPath {
+					return map[string]string{m.MountPath: k}
+				}
+			}
+			return nil
+		}}
+	}
+	return configUse{src.kind, src.name, container, "volume", func(keys []string) map[string]string {
+		files := make(map[string]string, len(keys))
+		for _, k := range keys {
+			if p, ok := relPath(k); ok {
+				files[path.Join(m.MountPath, p)] = k
+			}
+		}
+		return files
+	}}
+}
+
+func itemPaths(items []corev1.KeyToPath) map[string]string {
+	if len(items) == 0 {
+		return nil
+	}
+	paths := make(map[string]string, len(items))
+	for _, item := range items {
+		paths[item.Key] = item.Path
+	}
+	return paths
+}
+
+// ReadMountedConfig reads what the container actually sees for a drifted
+// object, its environment or its files, by exec'ing env or cat in it. Images
+// without those tools cannot be read this way.
+func (c *Client) ReadMountedConfig(ctx context.Context, namespace, pod string, d ConfigDrift) (map[string]string, error) {
+	c.mu.RLock()
+	config, clientset := c.config, c.clientset
+	c.mu.RUnlock()
+
+	run := func(command ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		err := ExecIntoPod(ctx, config, clientset, namespace, pod, d.Container, command, nil, &stdout, &stderr)
+		if err != nil && stderr.Len() > 0 {
+			err = fmt.Errorf("%s: %s", strings.Join(command, " "), strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), err
+	}
+
+	values := make(map[string]string, len(d.Targets))
+	if d.Via == "env" || d.Via == "envFrom" {
+		out, err := run("env")
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range strings.Split(out, "\n") {
+			name, value, ok := strings.Cut(line, "=")
+			if _, wanted := d.Targets[name]; ok && wanted {
+				values[name] = value
+			}
+		}
+	} else {
+		for file := range d.Targets {
+			out, err := run("cat", file)
+			if err != nil {
+				return nil, err
+			}
+			values[file] = out
+		}
+	}
+
+	if d.Kind == "Secret" {
+		for name, v := range values {
+			values[name] = secretHash(v)
+		}
+	}
+	return values, nil
+}
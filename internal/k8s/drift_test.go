@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfigDrift(t *testing.T) {
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"},
+				}}},
+				{Name: "nginx", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "nginx-config"},
+					Items:                []corev1.KeyToPath{{Key: "default", Path: "default.conf"}},
+				}}},
+			},
+			Containers: []corev1.Container{{
+				Name: "app",
+				Env: []corev1.EnvVar{{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password"},
+				}}},
+				EnvFrom: []corev1.EnvFromSource{{Prefix: "APP_", ConfigMapRef: &corev1.ConfigMapEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"},
+				}}},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "config", MountPath: "/etc/app"},
+					{Name: "nginx", MountPath: "/etc/nginx/conf.d/default.conf", SubPath: "default.conf"},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "app",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(started)}},
+			}},
+		},
+	}
+	objects := map[string]*configObject{
+		"ConfigMap/app-config":   {changed: started.Add(time.Hour), data: map[string]string{"mode": "fast", "level": "debug"}},
+		"ConfigMap/nginx-config": {changed: started.Add(time.Minute), data: map[string]string{"default": "server {}"}},
+		// Unchanged since the container started
+		"Secret/db": {changed: started.Add(-time.Hour), data: map[string]string{"password": "sha256:0123"}},
+	}
+
+	drift := configDrift(pod, func(kind, name string) *configObject { return objects[kind+"/"+name] })
+
+	var got []string
+	for _, d := range drift {
+		var targets []string
+		for target, key := range d.Targets {
+			targets = append(targets, target+"<"+key)
+		}
+		sort.Strings(targets)
+		got = append(got, d.Via+" "+d.Kind+"/"+d.Name+" "+strings.Join(targets, ","))
+	}
+	want := []string{
+		"envFrom ConfigMap/app-config APP_level<level,APP_mode<mode",
+		"volume ConfigMap/app-config /etc/app/level<level,/etc/app/mode<mode",
+		"subPath ConfigMap/nginx-config /etc/nginx/conf.d/default.conf<default",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("drift =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if drift[1].Stale() || !drift[2].Stale() {
+		t.Errorf("only the plain volume mount is refreshed in place")
+	}
+	if got := drift[0].Format(drift[0].Current); got != "APP_level=debug\nAPP_mode=fast\n" {
+		t.Errorf("Format(env) = %q", got)
+	}
+	if got := drift[2].Format(drift[2].Current); got != "== /etc/nginx/conf.d/default.conf ==\nserver {}\n" {
+		t.Errorf("Format(file) = %q", got)
+	}
+}
+
+func TestLastChange(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	updated := metav1.NewTime(created.Add(time.Hour))
+	labelled := metav1.NewTime(created.Add(2 * time.Hour))
+	meta := metav1.ObjectMeta{
+		CreationTimestamp: metav1.NewTime(created),
+		ManagedFields: []metav1.ManagedFieldsEntry{
+			{Manager: "kubectl-client-side-apply", Time: &updated, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:app.yaml":{}}}`)}},
+			{Manager: "helm"},
+			// A later label change is not a data change
+			{Manager: "kubectl-label", Time: &labelled, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:team":{}}}}`)}},
+		},
+	}
+	if got := lastChange(meta); !got.Equal(updated.Time) {
+		t.Errorf("lastChange = %v, want %v", got, updated.Time)
+	}
+	if got := secretHash("hunter2"); !strings.HasPrefix(got, "sha256:") || len(got) != 19 {
+		t.Errorf("secretHash = %q", got)
+	}
+}
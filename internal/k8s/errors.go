@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// IsUnavailable reports whether err means one API is off-limits or not
+// installed (forbidden, missing group or resource), as opposed to the cluster
+// being unreachable. Such errors should only disable the affected view.
+func IsUnavailable(err error) bool {
+	return apierrors.IsForbidden(err) || apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err)
+}
+
+// ShortError condenses an API error into a few words for a panel header,
+// e.g. "forbidden" instead of the full RBAC message
+func ShortError(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case apierrors.IsForbidden(err):
+		return "forbidden"
+	case apierrors.IsUnauthorized(err):
+		return "unauthorized"
+	case apierrors.IsNotFound(err):
+		return "not found"
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return "timed out"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case apierrors.IsTooManyRequests(err):
+		return "throttled"
+	case apierrors.IsServiceUnavailable(err):
+		return "unavailable"
+	}
+	return TruncateString(err.Error(), 60)
+}
@@ -0,0 +1,62 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsUnavailable(t *testing.T) {
+	cronjobs := schema.GroupResource{Group: "batch", Resource: "cronjobs"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"forbidden", apierrors.NewForbidden(cronjobs, "", errors.New("no")), true},
+		{"missing api group", apierrors.NewNotFound(cronjobs, ""), true},
+		{"method not supported", apierrors.NewMethodNotSupported(cronjobs, "list"), true},
+		{"unauthorized", apierrors.NewUnauthorized("token expired"), false},
+		{"network", errors.New("dial tcp: connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUnavailable(tt.err); got != tt.want {
+				t.Errorf("IsUnavailable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortError(t *testing.T) {
+	events := schema.GroupResource{Resource: "events"}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"forbidden", apierrors.NewForbidden(events, "", errors.New("user cannot list events")), "forbidden"},
+		{"wrapped forbidden", fmt.Errorf("failed: %w", apierrors.NewForbidden(events, "", errors.New("no"))), "forbidden"},
+		{"not found", apierrors.NewNotFound(events, "x"), "not found"},
+		{"deadline", fmt.Errorf("get: %w", context.DeadlineExceeded), "timed out"},
+		{"unavailable", apierrors.NewServiceUnavailable("metrics"), "unavailable"},
+		{"other", errors.New("connection refused"), "connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShortError(tt.err); got != tt.want {
+				t.Errorf("ShortError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
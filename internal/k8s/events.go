@@ -3,14 +3,20 @@ package k8s
 import (
 	"context"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 )
 
 type EventInfo struct {
+	UID       string
 	Type      string
 	Reason    string
 	Message   string
@@ -33,31 +39,127 @@ func GetPodEvents(ctx context.Context, clientset *kubernetes.Clientset, namespac
 	return eventsToEventInfo(events.Items), nil
 }
 
-func GetWorkloadEvents(ctx context.Context, clientset *kubernetes.Clientset, workload WorkloadInfo) ([]EventInfo, error) {
-	events, err := clientset.CoreV1().Events(workload.Namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
+// podEventObjects names the objects whose events concern a pod as
+// "Kind/name": the pod itself, its controller, e.g. a ReplicaSet that fails
+// to create pods, and the workload above that, e.g. the Deployment scaling it
+func podEventObjects(ctx context.Context, clientset *kubernetes.Clientset, pod *corev1.Pod) []string {
+	objects := []string{"Pod/" + pod.Name}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller == nil || !*owner.Controller {
+			continue
+		}
+		objects = append(objects, owner.Kind+"/"+owner.Name)
+		kind, name := ResolveOwnerWorkload(ctx, clientset, pod.Namespace, owner.Kind, owner.Name)
+		if kind != owner.Kind || name != owner.Name {
+			objects = append(objects, kind+"/"+name)
+		}
 	}
+	return objects
+}
 
-	var filtered []corev1.Event
-	for _, e := range events.Items {
-		if e.InvolvedObject.Name == workload.Name {
-			filtered = append(filtered, e)
+// eventSelector selects the events of a "Kind/name" object. A pod's events
+// match on the name alone, as GetPodEvents does.
+func eventSelector(object string) string {
+	kind, name, _ := strings.Cut(object, "/")
+	if kind == "Pod" {
+		return fields.OneTermEqualSelector("involvedObject.name", name).String()
+	}
+	return fields.Set{"involvedObject.kind": kind, "involvedObject.name": name}.String()
+}
+
+// GetPodAndOwnerEvents lists the events of the pod, of its controller and of
+// the workload above that, newest first. The owners' events are left out
+// when they cannot be listed; the pod's own must be.
+func GetPodAndOwnerEvents(ctx context.Context, clientset *kubernetes.Clientset, pod *corev1.Pod) ([]EventInfo, error) {
+	var all []corev1.Event
+	for i, object := range podEventObjects(ctx, clientset, pod) {
+		events, err := clientset.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: eventSelector(object),
+		})
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
 			continue
 		}
+		all = append(all, events.Items...)
+	}
+	return eventsToEventInfo(all), nil
+}
+
+// workloadKinds maps the listed resource types to the kind events name them by
+var workloadKinds = map[ResourceType]string{
+	ResourceDeployments:  "Deployment",
+	ResourceStatefulSets: "StatefulSet",
+	ResourceDaemonSets:   "DaemonSet",
+	ResourceJobs:         "Job",
+	ResourceCronJobs:     "CronJob",
+	ResourceServices:     "Service",
+	ResourcePods:         "Pod",
+}
+
+// GetWorkloadEvents lists the events of a workload, of the ReplicaSets or
+// Jobs it created and of its pods, newest first. pods are the workload's
+// pods as the caller already listed them, so they are not listed again.
+func GetWorkloadEvents(ctx context.Context, clientset *kubernetes.Clientset, workload WorkloadInfo, pods []PodInfo) ([]EventInfo, error) {
+	events, err := clientset.CoreV1().Events(workload.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
 
-		if workload.Labels != nil {
-			pods, _ := GetWorkloadPods(ctx, clientset, workload)
-			for _, pod := range pods {
-				if e.InvolvedObject.Name == pod.Name {
-					filtered = append(filtered, e)
-					break
+	var owned []string
+	switch workload.Type {
+	case ResourceDeployments:
+		// A ReplicaSet that cannot create pods (FailedCreate) has none to
+		// find it by, so list them
+		if len(workload.Labels) > 0 {
+			rsList, err := clientset.AppsV1().ReplicaSets(workload.Namespace).List(ctx, metav1.ListOptions{
+				LabelSelector: labels.SelectorFromSet(workload.Labels).String(),
+			})
+			if err == nil {
+				for _, rs := range rsList.Items {
+					if isOwnedBy(rs.OwnerReferences, "Deployment", workload.Name) {
+						owned = append(owned, "ReplicaSet/"+rs.Name)
+					}
 				}
 			}
 		}
+	case ResourceCronJobs:
+		if runs, err := GetCronJobRuns(ctx, clientset, workload.Namespace, workload.Name); err == nil {
+			for _, r := range runs {
+				owned = append(owned, "Job/"+r.Name)
+			}
+		}
 	}
 
-	return eventsToEventInfo(filtered), nil
+	objects := workloadObjects(workload, pods, owned)
+	return eventsToEventInfo(filterEvents(events.Items, objects)), nil
+}
+
+// workloadObjects keys the workload, the objects it owns and its pods as
+// "Kind/name", the way events name what they are about
+func workloadObjects(workload WorkloadInfo, pods []PodInfo, owned []string) map[string]bool {
+	objects := make(map[string]bool, len(pods)+len(owned)+1)
+	if kind := workloadKinds[workload.Type]; kind != "" {
+		objects[kind+"/"+workload.Name] = true
+	}
+	for _, o := range owned {
+		objects[o] = true
+	}
+	for _, p := range pods {
+		objects["Pod/"+p.Name] = true
+	}
+	return objects
+}
+
+func filterEvents(events []corev1.Event, objects map[string]bool) []corev1.Event {
+	var filtered []corev1.Event
+	for _, e := range events {
+		if objects[e.InvolvedObject.Kind+"/"+e.InvolvedObject.Name] {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
 }
 
 func GetNamespaceEvents(ctx context.Context, clientset *kubernetes.Clientset, namespace string, limit int) ([]EventInfo, error) {
@@ -93,6 +195,7 @@ func eventsToEventInfo(events []corev1.Event) []EventInfo {
 		}
 
 		result = append(result, EventInfo{
+			UID:       string(e.UID),
 			Type:      e.Type,
 			Reason:    e.Reason,
 			Message:   e.Message,
@@ -112,6 +215,78 @@ func eventsToEventInfo(events []corev1.Event) []EventInfo {
 	return result
 }
 
+// WatchPodEvents streams the events GetPodAndOwnerEvents lists as they are
+// recorded or repeated, starting with the ones that already exist. A field
+// selector matches one object, so each gets its own watch. The channel
+// closes when ctx is cancelled or the server ends any of them.
+func WatchPodEvents(ctx context.Context, clientset *kubernetes.Clientset, pod *corev1.Pod) (<-chan EventInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	var streams []<-chan EventInfo
+	for i, object := range podEventObjects(ctx, clientset, pod) {
+		w, err := clientset.CoreV1().Events(pod.Namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector: eventSelector(object),
+		})
+		if err != nil {
+			if i == 0 {
+				cancel()
+				return nil, err
+			}
+			continue
+		}
+		streams = append(streams, streamEvents(ctx, w, func(ev watch.Event) (EventInfo, bool) {
+			e, ok := ev.Object.(*corev1.Event)
+			if !ok || ev.Type == watch.Deleted {
+				return EventInfo{}, false
+			}
+			return eventsToEventInfo([]corev1.Event{*e})[0], true
+		}))
+	}
+
+	out := make(chan EventInfo, listWatchBuffer)
+	var wg sync.WaitGroup
+	for _, stream := range streams {
+		wg.Add(1)
+		go func(stream <-chan EventInfo) {
+			defer wg.Done()
+			defer cancel() // one ending ends all, so they restart together
+			for e := range stream {
+				select {
+				case out <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(stream)
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(out)
+	}()
+	return out, nil
+}
+
+// UpsertEvent adds a watched event to a newest-first list, or replaces the
+// earlier version of it, returning a new list and whether it was new or
+// seen again
+func UpsertEvent(events []EventInfo, e EventInfo) ([]EventInfo, bool) {
+	result := make([]EventInfo, 0, len(events)+1)
+	for _, existing := range events {
+		if existing.UID != e.UID || e.UID == "" {
+			result = append(result, existing)
+			continue
+		}
+		if existing.Count == e.Count && existing.LastSeen.Equal(e.LastSeen) {
+			return events, false
+		}
+	}
+	result = append(result, e)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LastSeen.After(result[j].LastSeen)
+	})
+	return result, true
+}
+
 func IsWarningEvent(e EventInfo) bool {
 	return e.Type == "Warning"
 }
@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestUpsertEvent(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	events := []EventInfo{
+		{UID: "b", Reason: "Pulled", LastSeen: base.Add(time.Minute), Count: 1},
+		{UID: "a", Reason: "Scheduled", LastSeen: base, Count: 1},
+	}
+
+	// The watch replays existing events first; those change nothing
+	got, changed := UpsertEvent(events, events[1])
+	if changed || len(got) != 2 {
+		t.Errorf("replayed event: changed = %v, %d events", changed, len(got))
+	}
+
+	backoff := EventInfo{UID: "c", Type: "Warning", Reason: "BackOff", LastSeen: base.Add(2 * time.Minute), Count: 1}
+	got, changed = UpsertEvent(events, backoff)
+	if !changed || len(got) != 3 || got[0].UID != "c" {
+		t.Fatalf("new event: changed = %v, events %+v", changed, got)
+	}
+	if len(events) != 2 {
+		t.Error("the original list was modified")
+	}
+
+	// Seen again: the count goes up and it stays a single entry
+	backoff.Count, backoff.LastSeen = 2, base.Add(3*time.Minute)
+	got, changed = UpsertEvent(got, backoff)
+	if !changed || len(got) != 3 || got[0].Count != 2 {
+		t.Errorf("repeated event: changed = %v, events %+v", changed, got)
+	}
+}
+
+func TestFilterWorkloadEvents(t *testing.T) {
+	event := func(kind, name string) corev1.Event {
+		return corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name}, Reason: kind + "/" + name}
+	}
+	events := []corev1.Event{
+		event("Deployment", "web"),
+		event("Service", "web"), // same name, another object
+		event("ReplicaSet", "web-7d9f"),
+		event("Pod", "web-7d9f-abc"),
+		event("Pod", "api-5c4b-xyz"),
+	}
+	workload := WorkloadInfo{Name: "web", Type: ResourceDeployments}
+	objects := workloadObjects(workload, []PodInfo{{Name: "web-7d9f-abc"}}, []string{"ReplicaSet/web-7d9f"})
+
+	var got []string
+	for _, e := range filterEvents(events, objects) {
+		got = append(got, e.Reason)
+	}
+	want := "Deployment/web ReplicaSet/web-7d9f Pod/web-7d9f-abc"
+	if strings.Join(got, " ") != want {
+		t.Errorf("filtered = %v, want %s", got, want)
+	}
+}
+
+func TestEventSelector(t *testing.T) {
+	tests := map[string]string{
+		"Pod/web-1":          "involvedObject.name=web-1",
+		"ReplicaSet/web-7d9": "involvedObject.kind=ReplicaSet,involvedObject.name=web-7d9",
+		"Deployment/web":     "involvedObject.kind=Deployment,involvedObject.name=web",
+	}
+	for object, want := range tests {
+		if got := eventSelector(object); got != want {
+			t.Errorf("eventSelector(%q) = %q, want %q", object, got, want)
+		}
+	}
+}
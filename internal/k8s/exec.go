@@ -0,0 +1,160 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// How often an exec session checks the terminal for a resize
+const execResizePoll = 250 * time.Millisecond
+
+// ExecCommand runs a command in a container through the API server with the
+// client's own credentials, so neither kubectl nor its current context is
+// involved. It satisfies bubbletea's ExecCommand, which hands it the
+// terminal while the UI is suspended.
+type ExecCommand struct {
+	config    *rest.Config
+	clientset *kubernetes.Clientset
+	namespace string
+	pod       string
+	container string
+	command   []string
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// ExecCommand prepares an interactive exec into a pod's container
+func (c *Client) ExecCommand(namespace, pod, container string, command []string) *ExecCommand {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &ExecCommand{
+		config:    c.config,
+		clientset: c.clientset,
+		namespace: namespace,
+		pod:       pod,
+		container: container,
+		command:   command,
+	}
+}
+
+func (e *ExecCommand) SetStdin(r io.Reader)  { e.stdin = r }
+func (e *ExecCommand) SetStdout(w io.Writer) { e.stdout = w }
+func (e *ExecCommand) SetStderr(w io.Writer) { e.stderr = w }
+
+// Run execs into the pod until the command exits
+func (e *ExecCommand) Run() error {
+	return ExecIntoPod(context.Background(), e.config, e.clientset, e.namespace, e.pod, e.container, e.command, e.stdin, e.stdout, e.stderr)
+}
+
+// ExecIntoPod runs command in the container, streaming stdin, stdout and
+// stderr. When stdin is a terminal it is put in raw mode and the session
+// gets a TTY that follows the terminal's size, like kubectl exec -it.
+func ExecIntoPod(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, pod, container string, command []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	tty := false
+	var sizes *terminalSizeQueue
+	if f, ok := stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return err
+		}
+		defer term.Restore(int(f.Fd()), state)
+		tty = true
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		sizes = newTerminalSizeQueue(ctx, int(f.Fd()))
+	}
+
+	opts := &corev1.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdin:     stdin != nil,
+		Stdout:    stdout != nil,
+		Stderr:    stderr != nil && !tty, // a TTY merges stderr into stdout
+		TTY:       tty,
+	}
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(opts, scheme.ParameterCodec)
+
+	// The client's request timeout would end an interactive session
+	streamConfig := rest.CopyConfig(config)
+	streamConfig.Timeout = 0
+	executor, err := remotecommand.NewSPDYExecutor(streamConfig, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Tty:    tty,
+	}
+	if opts.Stderr {
+		streamOpts.Stderr = stderr
+	}
+	if sizes != nil {
+		streamOpts.TerminalSizeQueue = sizes
+	}
+	return executor.StreamWithContext(ctx, streamOpts)
+}
+
+// terminalSizeQueue reports the terminal's size at the start of a session
+// and whenever it changes. It polls rather than waiting for SIGWINCH, which
+// does not exist on every platform.
+type terminalSizeQueue struct {
+	sizes chan remotecommand.TerminalSize
+}
+
+func newTerminalSizeQueue(ctx context.Context, fd int) *terminalSizeQueue {
+	q := &terminalSizeQueue{sizes: make(chan remotecommand.TerminalSize, 1)}
+	go func() {
+		defer close(q.sizes)
+		var last remotecommand.TerminalSize
+		ticker := time.NewTicker(execResizePoll)
+		defer ticker.Stop()
+		for {
+			if w, h, err := term.GetSize(fd); err == nil {
+				size := remotecommand.TerminalSize{Width: uint16(w), Height: uint16(h)}
+				if size != last {
+					last = size
+					select {
+					case q.sizes <- size:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return q
+}
+
+// Next blocks for the next size; nil ends the resize stream
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
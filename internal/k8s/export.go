@@ -0,0 +1,141 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// ObjectRef names a namespaced object to export, e.g. {"Deployment", "web"}
+type ObjectRef struct {
+	Kind string
+	Name string
+}
+
+func (r ObjectRef) String() string {
+	return r.Kind + "/" + r.Name
+}
+
+// clusterFields are what the cluster fills in on an object; applying them
+// elsewhere fails or ties the copy to objects that do not exist there
+var clusterFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+	{"metadata", "ownerReferences"},
+	{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"},
+	{"metadata", "annotations", "deployment.kubernetes.io/revision"},
+	{"metadata", "annotations", "pv.kubernetes.io/bind-completed"},
+	{"metadata", "annotations", "pv.kubernetes.io/bound-by-controller"},
+	{"status"},
+}
+
+// kindClusterFields are the spec fields assigned per kind: a Service's
+// addresses, a pod's node and a claim's bound volume
+var kindClusterFields = map[string][][]string{
+	"Service":               {{"spec", "clusterIP"}, {"spec", "clusterIPs"}},
+	"Pod":                   {{"spec", "nodeName"}},
+	"PersistentVolumeClaim": {{"spec", "volumeName"}},
+}
+
+// ResolveObjectRefs replaces a ReplicaSet or Job owned by a Deployment or
+// CronJob with its owner, as ResolveOwnerWorkload does, dropping duplicates
+func ResolveObjectRefs(ctx context.Context, clientset *kubernetes.Clientset, namespace string, refs []ObjectRef) []ObjectRef {
+	seen := make(map[ObjectRef]bool, len(refs))
+	resolved := make([]ObjectRef, 0, len(refs))
+	for _, r := range refs {
+		kind, name := ResolveOwnerWorkload(ctx, clientset, namespace, r.Kind, r.Name)
+		ref := ObjectRef{Kind: kind, Name: name}
+		if !seen[ref] {
+			seen[ref] = true
+			resolved = append(resolved, ref)
+		}
+	}
+	return resolved
+}
+
+// ExportManifests fetches each object and renders them as one multi-document
+// YAML file, cleaned for applying to another cluster
+func ExportManifests(ctx context.Context, clientset *kubernetes.Clientset, namespace string, refs []ObjectRef) (string, error) {
+	docs := make([]string, 0, len(refs))
+	for _, r := range refs {
+		obj, gvk, err := getObject(ctx, clientset, namespace, r.Kind, r.Name)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", r, err)
+		}
+		doc, err := CleanManifestYAML(obj, gvk)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", r, err)
+		}
+		docs = append(docs, doc)
+	}
+	return strings.Join(docs, "---\n"), nil
+}
+
+// CleanManifestYAML serializes obj like ManifestYAML, also leaving out its
+// status and the fields the cluster assigned, so that kubectl apply creates
+// the same object in another cluster
+func CleanManifestYAML(obj runtime.Object, gvk schema.GroupVersionKind) (string, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.DeepCopyObject())
+	if err != nil {
+		return "", err
+	}
+	u["apiVersion"], u["kind"] = gvk.GroupVersion().String(), gvk.Kind
+	for _, field := range append(clusterFields, kindClusterFields[gvk.Kind]...) {
+		unstructured.RemoveNestedField(u, field...)
+	}
+	if annotations, ok, _ := unstructured.NestedMap(u, "metadata", "annotations"); ok && len(annotations) == 0 {
+		unstructured.RemoveNestedField(u, "metadata", "annotations")
+	}
+	dropNullTimestamps(u)
+
+	out, err := yaml.Marshal(u)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// dropNullTimestamps removes the "creationTimestamp: null" pod and job
+// templates serialize with
+func dropNullTimestamps(m map[string]interface{}) {
+	for k, v := range m {
+		switch v := v.(type) {
+		case nil:
+			if k == "creationTimestamp" {
+				delete(m, k)
+			}
+		case map[string]interface{}:
+			dropNullTimestamps(v)
+		case []interface{}:
+			for _, item := range v {
+				if item, ok := item.(map[string]interface{}); ok {
+					dropNullTimestamps(item)
+				}
+			}
+		}
+	}
+}
+
+// ManifestExportName is the file an export is offered to be saved as, named
+// after its first object, e.g. "deployment-web.yaml", or
+// "deployment-web-bundle.yaml" along with related objects
+func ManifestExportName(refs []ObjectRef) string {
+	if len(refs) == 0 {
+		return "manifests.yaml"
+	}
+	name := strings.ToLower(refs[0].Kind) + "-" + refs[0].Name
+	if len(refs) > 1 {
+		name += "-bundle"
+	}
+	return name + ".yaml"
+}
@@ -0,0 +1,96 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCleanManifestYAML(t *testing.T) {
+	isController := true
+	meta := metav1.ObjectMeta{
+		Name:              "web",
+		Namespace:         "shop",
+		UID:               "uid-1",
+		ResourceVersion:   "42",
+		Generation:        3,
+		CreationTimestamp: metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)),
+		Labels:            map[string]string{"app": "web"},
+		Annotations:       map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+		OwnerReferences:   []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-abc", Controller: &isController}},
+		ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+	}
+
+	tests := []struct {
+		name    string
+		doc     func() (string, error)
+		want    []string
+		notWant []string
+	}{
+		{
+			"service",
+			func() (string, error) {
+				svc := &corev1.Service{ObjectMeta: meta, Spec: corev1.ServiceSpec{ClusterIP: "10.0.0.5", ClusterIPs: []string{"10.0.0.5"}}}
+				return CleanManifestYAML(svc, corev1.SchemeGroupVersion.WithKind("Service"))
+			},
+			[]string{"apiVersion: v1\n", "kind: Service\n", "name: web\n", "app: web\n"},
+			[]string{"uid", "resourceVersion", "generation", "creationTimestamp", "ownerReferences", "managedFields", "annotations", "clusterIP", "status"},
+		},
+		{
+			"deployment template",
+			func() (string, error) {
+				dep := &appsv1.Deployment{ObjectMeta: meta, Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}}},
+				}}
+				return CleanManifestYAML(dep, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+			},
+			[]string{"apiVersion: apps/v1\n", "kind: Deployment\n", "template:"},
+			[]string{"creationTimestamp", "status"},
+		},
+		{
+			"pod keeps other annotations",
+			func() (string, error) {
+				pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Annotations: map[string]string{"team": "shop"}}, Spec: corev1.PodSpec{NodeName: "node-1"}}
+				return CleanManifestYAML(pod, corev1.SchemeGroupVersion.WithKind("Pod"))
+			},
+			[]string{"team: shop\n"},
+			[]string{"nodeName"},
+		},
+	}
+	for _, tt := range tests {
+		doc, err := tt.doc()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(doc, want) {
+				t.Errorf("%s: YAML lacks %q:\n%s", tt.name, want, doc)
+			}
+		}
+		for _, notWant := range tt.notWant {
+			if strings.Contains(doc, notWant) {
+				t.Errorf("%s: YAML still has %q:\n%s", tt.name, notWant, doc)
+			}
+		}
+	}
+}
+
+func TestManifestExportName(t *testing.T) {
+	tests := []struct {
+		refs []ObjectRef
+		want string
+	}{
+		{nil, "manifests.yaml"},
+		{[]ObjectRef{{"Pod", "web-1"}}, "pod-web-1.yaml"},
+		{[]ObjectRef{{"Deployment", "web"}, {"Service", "web"}}, "deployment-web-bundle.yaml"},
+	}
+	for _, tt := range tests {
+		if got := ManifestExportName(tt.refs); got != tt.want {
+			t.Errorf("ManifestExportName(%v) = %q, want %q", tt.refs, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// HPAInfo is the HorizontalPodAutoscaler that targets a workload
+type HPAInfo struct {
+	Name            string
+	MinReplicas     int32
+	MaxReplicas     int32
+	CurrentReplicas int32
+	DesiredReplicas int32
+	Metrics         []HPAMetric
+}
+
+// HPAMetric is one metric an HPA scales on, with its current value and
+// target worded like kubectl get hpa, e.g. "cpu" "45%" "80%"
+type HPAMetric struct {
+	Name    string
+	Current string // "<unknown>" until the HPA has read it
+	Target  string
+}
+
+// scaleKind is the kind an HPA's scaleTargetRef names a workload of a
+// scalable type by, or "" for a type that cannot be scaled
+func scaleKind(resourceType ResourceType) string {
+	if !IsScalable(resourceType) {
+		return ""
+	}
+	return workloadKinds[resourceType]
+}
+
+// FindHPA returns the HPA targeting a workload, or nil when there is none
+func FindHPA(ctx context.Context, clientset *kubernetes.Clientset, namespace string, resourceType ResourceType, name string) (*HPAInfo, error) {
+	hpas, err := FindHPAs(ctx, clientset, namespace, resourceType, name)
+	if err != nil || len(hpas) == 0 {
+		return nil, err
+	}
+	return &hpas[0], nil
+}
+
+// FindHPAs returns every HPA targeting a workload. More than one is a
+// misconfiguration: they fight over its replica count.
+func FindHPAs(ctx context.Context, clientset *kubernetes.Clientset, namespace string, resourceType ResourceType, name string) ([]HPAInfo, error) {
+	hpas, err := clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return matchHPAs(hpas.Items, scaleKind(resourceType), name), nil
+}
+
+func matchHPAs(hpas []autoscalingv2.HorizontalPodAutoscaler, kind, name string) []HPAInfo {
+	var matched []HPAInfo
+	for _, hpa := range hpas {
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind != kind || ref.Name != name {
+			continue
+		}
+		info := HPAInfo{
+			Name:            hpa.Name,
+			MinReplicas:     1,
+			MaxReplicas:     hpa.Spec.MaxReplicas,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+			Metrics:         hpaMetrics(&hpa),
+		}
+		if hpa.Spec.MinReplicas != nil {
+			info.MinReplicas = *hpa.Spec.MinReplicas
+		}
+		matched = append(matched, info)
+	}
+	return matched
+}
+
+// hpaMetrics pairs each metric of the spec with its current value in the
+// status, which the HPA does not keep in the same order
+func hpaMetrics(hpa *autoscalingv2.HorizontalPodAutoscaler) []HPAMetric {
+	current := make(map[string]string, len(hpa.Status.CurrentMetrics))
+	for _, m := range hpa.Status.CurrentMetrics {
+		name, value := metricStatus(m)
+		current[name] = value
+	}
+	metrics := make([]HPAMetric, 0, len(hpa.Spec.Metrics))
+	for _, m := range hpa.Spec.Metrics {
+		name, target := metricSpec(m)
+		value, ok := current[name]
+		if !ok || value == "" {
+			value = "<unknown>"
+		}
+		metrics = append(metrics, HPAMetric{Name: name, Current: value, Target: target})
+	}
+	return metrics
+}
+
+func metricSpec(m autoscalingv2.MetricSpec) (name, target string) {
+	switch {
+	case m.Resource != nil:
+		return string(m.Resource.Name), metricTarget(m.Resource.Target)
+	case m.ContainerResource != nil:
+		return fmt.Sprintf("%s (container %s)", m.ContainerResource.Name, m.ContainerResource.Container), metricTarget(m.ContainerResource.Target)
+	case m.Pods != nil:
+		return "pods/" + m.Pods.Metric.Name, metricTarget(m.Pods.Target)
+	case m.Object != nil:
+		return fmt.Sprintf("%s on %s/%s", m.Object.Metric.Name, m.Object.DescribedObject.Kind, m.Object.DescribedObject.Name), metricTarget(m.Object.Target)
+	case m.External != nil:
+		return "external/" + m.External.Metric.Name, metricTarget(m.External.Target)
+	}
+	return string(m.Type), ""
+}
+
+func metricStatus(m autoscalingv2.MetricStatus) (name, value string) {
+	switch {
+	case m.Resource != nil:
+		return string(m.Resource.Name), metricValue(m.Resource.Current)
+	case m.ContainerResource != nil:
+		return fmt.Sprintf("%s (container %s)", m.ContainerResource.Name, m.ContainerResource.Container), metricValue(m.ContainerResource.Current)
+	case m.Pods != nil:
+		return "pods/" + m.Pods.Metric.Name, metricValue(m.Pods.Current)
+	case m.Object != nil:
+		return fmt.Sprintf("%s on %s/%s", m.Object.Metric.Name, m.Object.DescribedObject.Kind, m.Object.DescribedObject.Name), metricValue(m.Object.Current)
+	case m.External != nil:
+		return "external/" + m.External.Metric.Name, metricValue(m.External.Current)
+	}
+	return string(m.Type), ""
+}
+
+func metricTarget(t autoscalingv2.MetricTarget) string {
+	switch {
+	case t.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *t.AverageUtilization)
+	case t.AverageValue != nil:
+		return t.AverageValue.String()
+	case t.Value != nil:
+		return t.Value.String()
+	}
+	return ""
+}
+
+func metricValue(v autoscalingv2.MetricValueStatus) string {
+	switch {
+	case v.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *v.AverageUtilization)
+	case v.AverageValue != nil:
+		return v.AverageValue.String()
+	case v.Value != nil:
+		return v.Value.String()
+	}
+	return ""
+}
+
+// FormatHPAMetrics lists an HPA's metrics as current/target, e.g.
+// "cpu 45%/80%, memory <unknown>/75%"
+func FormatHPAMetrics(metrics []HPAMetric) string {
+	parts := make([]string, len(metrics))
+	for i, m := range metrics {
+		parts[i] = fmt.Sprintf("%s %s/%s", m.Name, m.Current, m.Target)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// HPAHelpers warns when autoscalers will undo a manual scale of a workload:
+// replicas set to something other than what its HPA wants, or several HPAs
+// fighting over it. kind is the workload's kind, e.g. "Deployment".
+func HPAHelpers(kind, name string, replicas int32, hpas []HPAInfo) []DebugHelper {
+	if len(hpas) > 1 {
+		names := make([]string, len(hpas))
+		for i, h := range hpas {
+			names[i] = h.Name
+		}
+		return []DebugHelper{{
+			Issue:    fmt.Sprintf("%d HPAs target %s %s: %s", len(hpas), kind, name, strings.Join(names, ", ")),
+			Severity: "High",
+			Suggestions: []string{
+				"Each HPA sets the replica count on its own, so they keep overriding each other",
+				"Delete all but one, or merge their metrics into a single HPA",
+			},
+		}}
+	}
+	if len(hpas) == 0 || hpas[0].DesiredReplicas == 0 || replicas == hpas[0].DesiredReplicas {
+		return nil
+	}
+	hpa := hpas[0]
+	return []DebugHelper{{
+		Issue: fmt.Sprintf("%s %s is scaled to %d but HPA %s wants %d (%d-%d)",
+			kind, name, replicas, hpa.Name, hpa.DesiredReplicas, hpa.MinReplicas, hpa.MaxReplicas),
+		Severity: "Medium",
+		Suggestions: []string{
+			"The HPA resets the replica count on its next sync, so a manual scale will not stick",
+			"Change the HPA's bounds instead: kubectl edit hpa " + hpa.Name,
+		},
+	}}
+}
+
+// GetHPAHelpers looks up the HPAs of a Deployment or StatefulSet and its
+// replica count, for HPAHelpers
+func GetHPAHelpers(ctx context.Context, clientset *kubernetes.Clientset, w WorkloadInfo) []DebugHelper {
+	if !IsScalable(w.Type) {
+		return nil
+	}
+	hpas, err := FindHPAs(ctx, clientset, w.Namespace, w.Type, w.Name)
+	if err != nil || len(hpas) == 0 {
+		return nil
+	}
+	var replicas *int32
+	switch w.Type {
+	case ResourceDeployments:
+		d, err := GetDeployment(ctx, clientset, w.Namespace, w.Name)
+		if err != nil {
+			return nil
+		}
+		replicas = d.Spec.Replicas
+	case ResourceStatefulSets:
+		s, err := GetStatefulSet(ctx, clientset, w.Namespace, w.Name)
+		if err != nil {
+			return nil
+		}
+		replicas = s.Spec.Replicas
+	}
+	return HPAHelpers(scaleKind(w.Type), w.Name, desiredReplicas(replicas), hpas)
+}
+
+// ParseReplicas validates a typed replica count. With an HPA the count must
+// stay within its bounds, since the HPA would scale it straight back.
+func ParseReplicas(input string, hpa *HPAInfo) (int32, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(input), 10, 32)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is not a replica count", input)
+	}
+	replicas := int32(n)
+	if hpa != nil && (replicas < hpa.MinReplicas || replicas > hpa.MaxReplicas) {
+		return 0, fmt.Errorf("HPA %s keeps replicas between %d and %d", hpa.Name, hpa.MinReplicas, hpa.MaxReplicas)
+	}
+	return replicas, nil
+}
@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchHPAs(t *testing.T) {
+	two := int32(2)
+	hpas := []autoscalingv2.HorizontalPodAutoscaler{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "db"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "StatefulSet", Name: "web"},
+				MaxReplicas:    4,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+				MinReplicas:    &two,
+				MaxReplicas:    10,
+			},
+		},
+	}
+
+	got := matchHPAs(hpas, scaleKind(ResourceDeployments), "web")
+	if len(got) != 1 || got[0].Name != "web" || got[0].MinReplicas != 2 || got[0].MaxReplicas != 10 {
+		t.Errorf("Deployment web: got %+v", got)
+	}
+	if got := matchHPAs(hpas, scaleKind(ResourceStatefulSets), "web"); len(got) != 1 || got[0].MinReplicas != 1 {
+		t.Errorf("minReplicas should default to 1, got %+v", got)
+	}
+	if got := matchHPAs(hpas, "Deployment", "api"); len(got) != 0 {
+		t.Errorf("Deployment api: got %+v, want none", got)
+	}
+	if kind := scaleKind(ResourceDaemonSets); kind != "" {
+		t.Errorf("scaleKind(daemonsets) = %q, want none", kind)
+	}
+}
+
+func TestParseReplicas(t *testing.T) {
+	hpa := &HPAInfo{Name: "web", MinReplicas: 2, MaxReplicas: 10}
+	tests := []struct {
+		input   string
+		hpa     *HPAInfo
+		want    int32
+		wantErr bool
+	}{
+		{"7", nil, 7, false},
+		{" 0 ", nil, 0, false},
+		{"-1", nil, 0, true},
+		{"three", nil, 0, true},
+		{"", nil, 0, true},
+		{"10", hpa, 10, false},
+		{"1", hpa, 0, true},
+		{"11", hpa, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseReplicas(tt.input, tt.hpa)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseReplicas(%q) = %d, %v; want %d, err %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestHPAMetrics(t *testing.T) {
+	eighty, fortyFive := int32(80), int32(45)
+	qps := resource.MustParse("100")
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{Metrics: []autoscalingv2.MetricSpec{
+			{Type: autoscalingv2.ResourceMetricSourceType, Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU, Target: autoscalingv2.MetricTarget{AverageUtilization: &eighty},
+			}},
+			{Type: autoscalingv2.PodsMetricSourceType, Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "http_requests"}, Target: autoscalingv2.MetricTarget{AverageValue: &qps},
+			}},
+		}},
+		// The status lists them in another order, and not yet the pods metric
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{CurrentMetrics: []autoscalingv2.MetricStatus{
+			{Type: autoscalingv2.ResourceMetricSourceType, Resource: &autoscalingv2.ResourceMetricStatus{
+				Name: corev1.ResourceCPU, Current: autoscalingv2.MetricValueStatus{AverageUtilization: &fortyFive},
+			}},
+		}},
+	}
+
+	got := FormatHPAMetrics(hpaMetrics(hpa))
+	if want := "cpu 45%/80%, pods/http_requests <unknown>/100"; got != want {
+		t.Errorf("FormatHPAMetrics() = %q, want %q", got, want)
+	}
+}
+
+func TestHPAHelpers(t *testing.T) {
+	web := HPAInfo{Name: "web", MinReplicas: 2, MaxReplicas: 10, CurrentReplicas: 3, DesiredReplicas: 3}
+	tests := []struct {
+		name     string
+		replicas int32
+		hpas     []HPAInfo
+		want     string
+		severity string
+	}{
+		{"no hpa", 5, nil, "", ""},
+		{"in step", 3, []HPAInfo{web}, "", ""},
+		{"not synced yet", 5, []HPAInfo{{Name: "web", MinReplicas: 2, MaxReplicas: 10}}, "", ""},
+		{"manual scale", 5, []HPAInfo{web}, "Deployment api is scaled to 5 but HPA web wants 3 (2-10)", "Medium"},
+		{"two hpas", 3, []HPAInfo{web, {Name: "web-memory"}}, "2 HPAs target Deployment api: web, web-memory", "High"},
+	}
+	for _, tt := range tests {
+		got := HPAHelpers("Deployment", "api", tt.replicas, tt.hpas)
+		if tt.want == "" {
+			if len(got) != 0 {
+				t.Errorf("%s: got %+v, want no hint", tt.name, got)
+			}
+			continue
+		}
+		if len(got) != 1 || got[0].Issue != tt.want || got[0].Severity != tt.severity {
+			t.Errorf("%s: got %+v, want %q (%s)", tt.name, got, tt.want, tt.severity)
+		}
+	}
+}
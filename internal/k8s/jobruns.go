@@ -0,0 +1,201 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// minRunsForP95 is how many earlier finished runs a run is judged against;
+// with fewer the percentile means little
+const minRunsForP95 = 5
+
+// JobRun is one Job a CronJob created and how long it took
+type JobRun struct {
+	Name     string
+	Start    time.Time
+	Duration time.Duration // so far, for a run still active
+	Status   string        // Succeeded, Failed or Running
+	// SlowerThan is the p95 of the earlier finished runs when this run took
+	// longer, zero otherwise
+	SlowerThan time.Duration
+}
+
+// JobRunStats summarizes the finished runs of a CronJob
+type JobRunStats struct {
+	Finished, Failed int
+	Min, Avg, Max    time.Duration
+	P95              time.Duration
+}
+
+// FailureRate is the share of finished runs that failed
+func (s JobRunStats) FailureRate() float64 {
+	if s.Finished == 0 {
+		return 0
+	}
+	return float64(s.Failed) / float64(s.Finished)
+}
+
+// GetCronJobRuns lists the Jobs a CronJob still keeps in its history, oldest
+// first, with runs slower than the p95 of the runs before them flagged
+func GetCronJobRuns(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) ([]JobRun, error) {
+	jobs, err := clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var runs []JobRun
+	now := time.Now()
+	for i := range jobs.Items {
+		if isOwnedBy(jobs.Items[i].OwnerReferences, "CronJob", name) {
+			runs = append(runs, jobRun(&jobs.Items[i], now))
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Start.Before(runs[j].Start) })
+	flagSlowRuns(runs)
+	return runs, nil
+}
+
+func isOwnedBy(refs []metav1.OwnerReference, kind, name string) bool {
+	for _, ref := range refs {
+		if ref.Kind == kind && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func jobRun(j *batchv1.Job, now time.Time) JobRun {
+	run := JobRun{Name: j.Name, Status: "Running", Start: j.CreationTimestamp.Time}
+	if j.Status.StartTime != nil {
+		run.Start = j.Status.StartTime.Time
+	}
+	end := now
+	for _, c := range j.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			run.Status = "Succeeded"
+			end = c.LastTransitionTime.Time
+		case batchv1.JobFailed:
+			run.Status = "Failed"
+			end = c.LastTransitionTime.Time
+		}
+	}
+	if run.Status == "Succeeded" && j.Status.CompletionTime != nil {
+		end = j.Status.CompletionTime.Time
+	}
+	if end.After(run.Start) {
+		run.Duration = end.Sub(run.Start)
+	}
+	return run
+}
+
+// flagSlowRuns marks each run, finished or still active, that took longer
+// than the p95 of the finished runs before it
+func flagSlowRuns(runs []JobRun) {
+	var earlier []time.Duration
+	for i := range runs {
+		if len(earlier) >= minRunsForP95 {
+			if p95 := percentile(earlier, 95); runs[i].Duration > p95 {
+				runs[i].SlowerThan = p95
+			}
+		}
+		if runs[i].Status != "Running" {
+			earlier = append(earlier, runs[i].Duration)
+		}
+	}
+}
+
+// percentile returns the nearest-rank percentile of durations
+func percentile(durations []time.Duration, p int) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// JobRunStatistics computes duration statistics over the finished runs
+func JobRunStatistics(runs []JobRun) JobRunStats {
+	var s JobRunStats
+	var total time.Duration
+	var durations []time.Duration
+	for _, r := range runs {
+		if r.Status == "Running" {
+			continue
+		}
+		if r.Status == "Failed" {
+			s.Failed++
+		}
+		if s.Finished == 0 || r.Duration < s.Min {
+			s.Min = r.Duration
+		}
+		s.Max = max(s.Max, r.Duration)
+		total += r.Duration
+		durations = append(durations, r.Duration)
+		s.Finished++
+	}
+	if s.Finished > 0 {
+		s.Avg = total / time.Duration(s.Finished)
+		s.P95 = percentile(durations, 95)
+	}
+	return s
+}
+
+// FormatCronJobRuns renders the statistics and the run table, newest first
+func FormatCronJobRuns(runs []JobRun) string {
+	if len(runs) == 0 {
+		return "No runs in the CronJob's history (see successfulJobsHistoryLimit and failedJobsHistoryLimit)\n"
+	}
+
+	var b strings.Builder
+	s := JobRunStatistics(runs)
+	if s.Finished > 0 {
+		fmt.Fprintf(&b, "%d finished runs: min %s, avg %s, max %s, p95 %s\n",
+			s.Finished, FormatDuration(s.Min), FormatDuration(s.Avg), FormatDuration(s.Max), FormatDuration(s.P95))
+		fmt.Fprintf(&b, "Failure rate: %.0f%% (%d of %d)\n", s.FailureRate()*100, s.Failed, s.Finished)
+	}
+	slow := 0
+	for _, r := range runs {
+		if r.SlowerThan > 0 {
+			slow++
+		}
+	}
+	if slow > 0 {
+		fmt.Fprintf(&b, "%d runs slower than the p95 of the runs before them\n", slow)
+	}
+	b.WriteString("\n")
+
+	nameWidth := len("JOB")
+	for _, r := range runs {
+		nameWidth = max(nameWidth, len(r.Name))
+	}
+	format := fmt.Sprintf("%%s%%-%ds  %%-19s  %%-9s  %%-9s  %%s\n", nameWidth)
+	fmt.Fprintf(&b, format, "  ", "JOB", "STARTED", "STATUS", "DURATION", "")
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := runs[i]
+		marker, note := "  ", ""
+		if r.SlowerThan > 0 {
+			marker, note = "⚠ ", "slower than p95 "+FormatDuration(r.SlowerThan)
+		} else if r.Status == "Failed" {
+			marker = "✗ "
+		}
+		started := "-"
+		if !r.Start.IsZero() {
+			started = r.Start.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(&b, format, marker, r.Name, started, r.Status, FormatDuration(r.Duration), note)
+	}
+	return b.String()
+}
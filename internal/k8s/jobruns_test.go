@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJobRun(t *testing.T) {
+	start := time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup-1", CreationTimestamp: metav1.NewTime(start.Add(-time.Second))},
+		Status: batchv1.JobStatus{
+			StartTime: &metav1.Time{Time: start},
+			Conditions: []batchv1.JobCondition{{
+				Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(start.Add(90 * time.Second)),
+			}},
+		},
+	}
+	run := jobRun(job, start.Add(time.Hour))
+	if run.Status != "Failed" || run.Duration != 90*time.Second {
+		t.Errorf("failed run = %+v", run)
+	}
+
+	job.Status.Conditions = nil
+	run = jobRun(job, start.Add(time.Hour))
+	if run.Status != "Running" || run.Duration != time.Hour {
+		t.Errorf("active run = %+v", run)
+	}
+}
+
+func TestJobRunStatistics(t *testing.T) {
+	minutes := func(status string, durations ...int) []JobRun {
+		var runs []JobRun
+		for _, d := range durations {
+			runs = append(runs, JobRun{Status: status, Duration: time.Duration(d) * time.Minute})
+		}
+		return runs
+	}
+	runs := minutes("Succeeded", 10, 11, 10, 12, 11)
+	runs = append(runs, minutes("Failed", 30)...)
+	runs = append(runs, minutes("Succeeded", 12)...)
+	runs = append(runs, minutes("Running", 45)...)
+	flagSlowRuns(runs)
+
+	var flagged []int
+	for i, r := range runs {
+		if r.SlowerThan > 0 {
+			flagged = append(flagged, i)
+		}
+	}
+	// The 30m failure beats the first five; the running one beats everything
+	if len(flagged) != 2 || flagged[0] != 5 || flagged[1] != 7 {
+		t.Errorf("flagged runs = %v", flagged)
+	}
+	if runs[7].SlowerThan != 30*time.Minute {
+		t.Errorf("running run judged against %s, want 30m", runs[7].SlowerThan)
+	}
+
+	s := JobRunStatistics(runs)
+	if s.Finished != 7 || s.Failed != 1 || s.Min != 10*time.Minute || s.Max != 30*time.Minute || s.P95 != 30*time.Minute {
+		t.Errorf("stats = %+v", s)
+	}
+	if s.Avg != 96*time.Minute/7 {
+		t.Errorf("avg = %s", s.Avg)
+	}
+
+	out := FormatCronJobRuns(runs)
+	if !strings.Contains(out, "Failure rate: 14% (1 of 7)") || !strings.Contains(out, "2 runs slower than the p95") {
+		t.Errorf("FormatCronJobRuns() =\n%s", out)
+	}
+}
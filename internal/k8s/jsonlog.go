@@ -0,0 +1,158 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Keys structured loggers use for the level, message and time of a line
+var (
+	jsonLevelKeys   = []string{"level", "lvl", "severity", "log.level", "loglevel"}
+	jsonMessageKeys = []string{"msg", "message", "log", "event"}
+	jsonTimeKeys    = map[string]bool{"ts": true, "time": true, "timestamp": true, "@timestamp": true, "t": true}
+)
+
+// JSONLog is a log line written as a JSON object, with nested objects
+// flattened into dotted keys
+type JSONLog struct {
+	Level   string // lower-cased, empty when the line has none
+	Message string
+	Fields  map[string]string
+	// Extra lists the fields other than level, message and time, sorted
+	Extra []string
+}
+
+// ParseJSONLog parses a log line that is a JSON object
+func ParseJSONLog(content string) (JSONLog, bool) {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "{") || !strings.HasSuffix(content, "}") {
+		return JSONLog{}, false
+	}
+	dec := json.NewDecoder(strings.NewReader(content))
+	dec.UseNumber()
+	var obj map[string]interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return JSONLog{}, false
+	}
+
+	l := JSONLog{Fields: make(map[string]string, len(obj))}
+	flattenJSON("", obj, l.Fields)
+
+	used := make(map[string]bool)
+	for _, k := range jsonLevelKeys {
+		if v, ok := l.Fields[k]; ok {
+			l.Level = strings.ToLower(v)
+			used[k] = true
+			break
+		}
+	}
+	for _, k := range jsonMessageKeys {
+		if v, ok := l.Fields[k]; ok {
+			l.Message = v
+			used[k] = true
+			break
+		}
+	}
+	for k := range l.Fields {
+		if !used[k] && !jsonTimeKeys[k] {
+			l.Extra = append(l.Extra, k)
+		}
+	}
+	sort.Strings(l.Extra)
+	return l, true
+}
+
+func flattenJSON(prefix string, v interface{}, out map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if prefix != "" {
+				k = prefix + "." + k
+			}
+			flattenJSON(k, child, out)
+		}
+	case nil:
+		out[prefix] = "null"
+	case string:
+		out[prefix] = v
+	case []interface{}:
+		b, _ := json.Marshal(v)
+		out[prefix] = string(b)
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+// FieldCondition is one key=value or key!=value term of a field filter
+type FieldCondition struct {
+	Key    string
+	Value  string // "*" matches any value the key has
+	Negate bool
+}
+
+// FieldFilter selects JSON log lines by field values; every condition must
+// hold. Values compare case-insensitively.
+type FieldFilter struct {
+	Conditions []FieldCondition
+}
+
+// ParseFieldFilter parses space-separated conditions like
+// "level=error trace_id=abc status!=200". "level" and "msg" also match the
+// level and message under whatever key the logger used.
+func ParseFieldFilter(expr string) (*FieldFilter, error) {
+	var f FieldFilter
+	for _, term := range strings.Fields(expr) {
+		key, value, ok := strings.Cut(term, "=")
+		if !ok || key == "" || key == "!" {
+			return nil, fmt.Errorf("%q: want key=value or key!=value", term)
+		}
+		cond := FieldCondition{Key: key, Value: value}
+		if k, neg := strings.CutSuffix(key, "!"); neg {
+			cond.Key, cond.Negate = k, true
+		}
+		f.Conditions = append(f.Conditions, cond)
+	}
+	if len(f.Conditions) == 0 {
+		return nil, nil
+	}
+	return &f, nil
+}
+
+// Match reports whether the line satisfies every condition
+func (f *FieldFilter) Match(l JSONLog) bool {
+	if f == nil {
+		return true
+	}
+	for _, c := range f.Conditions {
+		v, ok := l.Fields[c.Key]
+		switch c.Key {
+		case "level":
+			v, ok = l.Level, l.Level != ""
+		case "msg":
+			v, ok = l.Message, l.Message != ""
+		}
+		matches := ok && (c.Value == "*" || strings.EqualFold(v, c.Value))
+		if matches == c.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the filter back as its expression
+func (f *FieldFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	terms := make([]string, len(f.Conditions))
+	for i, c := range f.Conditions {
+		op := "="
+		if c.Negate {
+			op = "!="
+		}
+		terms[i] = c.Key + op + c.Value
+	}
+	return strings.Join(terms, " ")
+}
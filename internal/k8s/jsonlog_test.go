@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseJSONLog(t *testing.T) {
+	l, ok := ParseJSONLog(`{"ts":"2024-05-01T12:00:00Z","level":"ERROR","msg":"payment failed","trace_id":"abc","http":{"status":502,"retry":true},"tags":["a","b"],"user":null}`)
+	if !ok {
+		t.Fatal("ParseJSONLog() should parse a JSON object")
+	}
+	if l.Level != "error" || l.Message != "payment failed" {
+		t.Errorf("level/msg = %q/%q", l.Level, l.Message)
+	}
+	if got := strings.Join(l.Extra, ","); got != "http.retry,http.status,tags,trace_id,user" {
+		t.Errorf("Extra = %s", got)
+	}
+	if l.Fields["http.status"] != "502" || l.Fields["tags"] != `["a","b"]` || l.Fields["user"] != "null" {
+		t.Errorf("Fields = %v", l.Fields)
+	}
+
+	l, ok = ParseJSONLog(`{"severity":"warning","message":"slow query"}`)
+	if !ok || l.Level != "warning" || l.Message != "slow query" {
+		t.Errorf("alternate keys = %+v", l)
+	}
+
+	for _, line := range []string{"plain text", `{"broken":`, `["array"]`, ""} {
+		if _, ok := ParseJSONLog(line); ok {
+			t.Errorf("ParseJSONLog(%q) should fail", line)
+		}
+	}
+}
+
+func TestFieldFilter(t *testing.T) {
+	line, _ := ParseJSONLog(`{"lvl":"error","msg":"boom","trace_id":"abc","status":500}`)
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"level=error", true},
+		{"level=ERROR trace_id=abc", true},
+		{"level=error trace_id=xyz", false},
+		{"status!=200", true},
+		{"status!=500", false},
+		{"trace_id=*", true},
+		{"user=*", false},
+		{"user!=*", true},
+		{"msg=boom", true},
+	}
+	for _, tt := range tests {
+		f, err := ParseFieldFilter(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseFieldFilter(%q): %v", tt.expr, err)
+		}
+		if got := f.Match(line); got != tt.want {
+			t.Errorf("%q.Match() = %v, want %v", tt.expr, got, tt.want)
+		}
+		if f.String() != tt.expr {
+			t.Errorf("String() = %q, want %q", f.String(), tt.expr)
+		}
+	}
+
+	for _, bad := range []string{"level", "=error", "!=x"} {
+		if _, err := ParseFieldFilter(bad); err == nil {
+			t.Errorf("ParseFieldFilter(%q) should fail", bad)
+		}
+	}
+	if f, err := ParseFieldFilter("  "); f != nil || err != nil {
+		t.Errorf("an empty expression should give no filter")
+	}
+}
@@ -0,0 +1,148 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// StartupPhase is one step of a pod's way from creation to ready
+type StartupPhase struct {
+	Name     string
+	Duration time.Duration
+	Done     bool   // false for the phase the pod is still in
+	Note     string // e.g. "2 pulled, 1 cached"
+}
+
+// pullDurationRe matches the kubelet's `Successfully pulled image "x" in
+// 1.234s (1.5s including waiting)`
+var pullDurationRe = regexp.MustCompile(`^Successfully pulled image "([^"]+)" in ([0-9.]+[a-zµ]+)`)
+
+// BuildStartupTiming splits the time from creation to ready into scheduling,
+// init containers, image pulls, container start and readiness, using the
+// pod's condition transitions, container start times and pull events. A
+// pod that is not ready yet ends with the phase it is in. After a restart the
+// later phases describe the latest start rather than the first.
+func BuildStartupTiming(pod *corev1.Pod, events []EventInfo, now time.Time) []StartupPhase {
+	if pod == nil || pod.CreationTimestamp.IsZero() {
+		return nil
+	}
+
+	conditions := make(map[corev1.PodConditionType]time.Time)
+	for _, c := range pod.Status.Conditions {
+		if c.Status == corev1.ConditionTrue {
+			conditions[c.Type] = c.LastTransitionTime.Time
+		}
+	}
+
+	var started time.Time
+	allStarted := len(pod.Status.ContainerStatuses) > 0
+	for _, cs := range pod.Status.ContainerStatuses {
+		var at time.Time
+		switch {
+		case cs.State.Running != nil:
+			at = cs.State.Running.StartedAt.Time
+		case cs.State.Terminated != nil:
+			at = cs.State.Terminated.StartedAt.Time
+		}
+		if at.IsZero() {
+			allStarted = false
+		} else if at.After(started) {
+			started = at
+		}
+	}
+	if !allStarted {
+		started = time.Time{}
+	}
+
+	var phases []StartupPhase
+	from := pod.CreationTimestamp.Time
+	// step closes the phase ending at end, or opens the one in progress
+	step := func(name string, end time.Time) bool {
+		if end.IsZero() {
+			if pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodRunning {
+				phases = append(phases, StartupPhase{Name: name, Duration: nonNegative(now.Sub(from))})
+			}
+			return false
+		}
+		phases = append(phases, StartupPhase{Name: name, Duration: nonNegative(end.Sub(from)), Done: true})
+		from = end
+		return true
+	}
+
+	if !step("Scheduling", conditions[corev1.PodScheduled]) {
+		return phases
+	}
+	initialized := conditions[corev1.PodInitialized]
+	if len(pod.Spec.InitContainers) > 0 {
+		if !step("Init containers", initialized) {
+			return phases
+		}
+	} else if !initialized.IsZero() {
+		from = initialized
+	}
+
+	if started.IsZero() {
+		step("Container start", time.Time{})
+		return phases
+	}
+	pull, note := imagePullTime(pod, events)
+	if pull > 0 || note != "" {
+		phases = append(phases, StartupPhase{Name: "Image pull", Duration: pull, Done: true, Note: note})
+	}
+	phases = append(phases, StartupPhase{Name: "Container start", Duration: nonNegative(started.Sub(from) - pull), Done: true})
+	from = started
+
+	if pod.Status.Phase == corev1.PodRunning || pod.Status.Phase == corev1.PodPending {
+		step("Readiness", conditions[corev1.PodReady])
+	}
+	return phases
+}
+
+// imagePullTime adds up the pulls of the main containers' images reported in
+// the pod's events; init container pulls count towards the init phase
+func imagePullTime(pod *corev1.Pod, events []EventInfo) (time.Duration, string) {
+	images := make(map[string]bool)
+	for _, c := range pod.Spec.Containers {
+		images[c.Image] = true
+	}
+	for _, c := range pod.Spec.InitContainers {
+		delete(images, c.Image)
+	}
+
+	var total time.Duration
+	pulled, cached := 0, 0
+	for _, e := range events {
+		if m := pullDurationRe.FindStringSubmatch(e.Message); m != nil && images[m[1]] {
+			if d, err := time.ParseDuration(m[2]); err == nil {
+				total += d
+				pulled++
+			}
+			continue
+		}
+		if strings.HasSuffix(e.Message, "already present on machine") {
+			for image := range images {
+				if strings.Contains(e.Message, `"`+image+`"`) {
+					cached++
+					break
+				}
+			}
+		}
+	}
+
+	var parts []string
+	if pulled > 0 {
+		parts = append(parts, fmt.Sprintf("%d pulled", pulled))
+	}
+	if cached > 0 {
+		parts = append(parts, fmt.Sprintf("%d cached", cached))
+	}
+	return total, strings.Join(parts, ", ")
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	return max(d, 0)
+}
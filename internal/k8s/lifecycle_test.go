@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildStartupTiming(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(after time.Duration) metav1.Time { return metav1.NewTime(created.Add(after)) }
+	condition := func(typ corev1.PodConditionType, after time.Duration) corev1.PodCondition {
+		return corev1.PodCondition{Type: typ, Status: corev1.ConditionTrue, LastTransitionTime: at(after)}
+	}
+	running := func(after time.Duration) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{StartedAt: at(after)},
+		}}
+	}
+	spec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "migrate", Image: "acme/migrate:v1"}},
+		Containers:     []corev1.Container{{Name: "app", Image: "acme/web:v2"}, {Name: "proxy", Image: "envoy:1.29"}},
+	}
+	events := []EventInfo{
+		{Reason: "Pulled", Message: `Successfully pulled image "acme/migrate:v1" in 3s (3s including waiting)`},
+		{Reason: "Pulled", Message: `Successfully pulled image "acme/web:v2" in 12.5s (12.5s including waiting)`},
+		{Reason: "Pulled", Message: `Container image "envoy:1.29" already present on machine`},
+	}
+
+	tests := []struct {
+		name   string
+		pod    *corev1.Pod
+		events []EventInfo
+		want   []StartupPhase
+	}{
+		{
+			name: "ready pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: at(0)},
+				Spec:       spec,
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						condition(corev1.PodScheduled, 2*time.Second),
+						condition(corev1.PodInitialized, 10*time.Second),
+						condition(corev1.PodReady, 40*time.Second),
+					},
+					ContainerStatuses: []corev1.ContainerStatus{running(25 * time.Second)},
+				},
+			},
+			events: events,
+			want: []StartupPhase{
+				{Name: "Scheduling", Duration: 2 * time.Second, Done: true},
+				{Name: "Init containers", Duration: 8 * time.Second, Done: true},
+				{Name: "Image pull", Duration: 12500 * time.Millisecond, Done: true, Note: "1 pulled, 1 cached"},
+				{Name: "Container start", Duration: 2500 * time.Millisecond, Done: true},
+				{Name: "Readiness", Duration: 15 * time.Second, Done: true},
+			},
+		},
+		{
+			name: "still scheduling",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: at(0)},
+				Status:     corev1.PodStatus{Phase: corev1.PodPending},
+			},
+			want: []StartupPhase{{Name: "Scheduling", Duration: time.Minute}},
+		},
+		{
+			name: "waiting to become ready, without init containers",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: at(0)},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "acme/web:v2"}}},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{
+						condition(corev1.PodScheduled, time.Second),
+						condition(corev1.PodInitialized, time.Second),
+					},
+					ContainerStatuses: []corev1.ContainerStatus{running(5 * time.Second)},
+				},
+			},
+			want: []StartupPhase{
+				{Name: "Scheduling", Duration: time.Second, Done: true},
+				{Name: "Container start", Duration: 4 * time.Second, Done: true},
+				{Name: "Readiness", Duration: 55 * time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildStartupTiming(tt.pod, tt.events, created.Add(time.Minute))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("phase %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
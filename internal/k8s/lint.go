@@ -0,0 +1,129 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxGracePeriod is the longest termination grace period that does not
+// hold up node drains and rollouts noticeably
+const maxGracePeriod = 5 * time.Minute
+
+// LintPod checks the pod spec against common best practices: probes, resource
+// requests, replicas of its workload spread across nodes and a sensible
+// termination grace period. Nothing it finds is broken, so every finding is
+// an Info hint, shown apart from the debug hints. workload is the pod's
+// workload, nil when it has none.
+func LintPod(pod *corev1.Pod, workload *WorkloadInfo) []DebugHelper {
+	if pod == nil {
+		return nil
+	}
+	var helpers []DebugHelper
+	for _, c := range pod.Spec.Containers {
+		helpers = append(helpers, lintContainer(c)...)
+	}
+
+	if workload != nil && IsScalable(workload.Type) {
+		kind := scaleKind(workload.Type)
+		switch {
+		case workload.Replicas == 1:
+			helpers = append(helpers, DebugHelper{
+				Issue:    fmt.Sprintf("%s %s runs a single replica", kind, workload.Name),
+				Severity: "Info",
+				Suggestions: []string{
+					"A node drain, eviction or crash takes it down until the pod is replaced",
+					"Run 2 or more replicas, with a PodDisruptionBudget, if it serves traffic",
+				},
+			})
+		case workload.Replicas > 1 && !spreadsReplicas(pod.Spec):
+			helpers = append(helpers, DebugHelper{
+				Issue:    fmt.Sprintf("Replicas of %s %s may all land on one node", kind, workload.Name),
+				Severity: "Info",
+				Suggestions: []string{
+					"The pod has no pod anti-affinity or topology spread constraints, so one node failure can take every replica",
+					"Add a topologySpreadConstraint on kubernetes.io/hostname, or a preferred podAntiAffinity",
+				},
+			})
+		}
+	}
+
+	if g := pod.Spec.TerminationGracePeriodSeconds; g != nil {
+		grace := time.Duration(*g) * time.Second
+		switch {
+		case grace == 0:
+			helpers = append(helpers, DebugHelper{
+				Issue:    "terminationGracePeriodSeconds is 0",
+				Severity: "Info",
+				Suggestions: []string{
+					"Containers are killed at once, without a chance to finish requests or flush data",
+					"Leave the default 30s unless the pod is known to be safe to kill",
+				},
+			})
+		case grace > maxGracePeriod:
+			helpers = append(helpers, DebugHelper{
+				Issue:    fmt.Sprintf("terminationGracePeriodSeconds is %d (%s)", *g, FormatDuration(grace)),
+				Severity: "Info",
+				Suggestions: []string{
+					"A pod that hangs on shutdown holds up node drains and rollouts that long",
+					"Make shutdown finish within a few minutes, or make sure the wait is intended",
+				},
+			})
+		}
+	}
+	return helpers
+}
+
+func lintContainer(c corev1.Container) []DebugHelper {
+	var helpers []DebugHelper
+	var probes []string
+	if c.ReadinessProbe == nil {
+		probes = append(probes, "readiness")
+	}
+	if c.LivenessProbe == nil {
+		probes = append(probes, "liveness")
+	}
+	if len(probes) > 0 {
+		helpers = append(helpers, DebugHelper{
+			Issue:    fmt.Sprintf("Container %s has no %s probe", c.Name, strings.Join(probes, " or ")),
+			Severity: "Info",
+			Suggestions: []string{
+				"Without a readiness probe the pod gets traffic as soon as it starts, ready or not",
+				"Without a liveness probe a hung process is never restarted",
+			},
+		})
+	}
+
+	var requests []string
+	if _, ok := c.Resources.Requests[corev1.ResourceCPU]; !ok {
+		requests = append(requests, "CPU")
+	}
+	if _, ok := c.Resources.Requests[corev1.ResourceMemory]; !ok {
+		requests = append(requests, "memory")
+	}
+	if len(requests) > 0 {
+		helpers = append(helpers, DebugHelper{
+			Issue:    fmt.Sprintf("Container %s has no %s request", c.Name, strings.Join(requests, " or ")),
+			Severity: "Info",
+			Suggestions: []string{
+				"The scheduler places the pod as if it needed nothing, so nodes get overcommitted",
+				"Pods without requests are the first evicted under node pressure",
+			},
+		})
+	}
+	return helpers
+}
+
+// spreadsReplicas reports whether the pod spec keeps its replicas apart,
+// with pod anti-affinity or topology spread constraints
+func spreadsReplicas(spec corev1.PodSpec) bool {
+	if len(spec.TopologySpreadConstraints) > 0 {
+		return true
+	}
+	a := spec.Affinity
+	return a != nil && a.PodAntiAffinity != nil &&
+		(len(a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) > 0 ||
+			len(a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) > 0)
+}
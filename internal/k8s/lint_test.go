@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestLintPod(t *testing.T) {
+	good := corev1.Container{
+		Name:           "app",
+		ReadinessProbe: &corev1.Probe{},
+		LivenessProbe:  &corev1.Probe{},
+		Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		}},
+	}
+	spread := []corev1.TopologySpreadConstraint{{TopologyKey: "kubernetes.io/hostname"}}
+	grace := func(s int64) *int64 { return &s }
+	deployment := func(replicas int32) *WorkloadInfo {
+		return &WorkloadInfo{Name: "api", Type: ResourceDeployments, Replicas: replicas}
+	}
+
+	tests := []struct {
+		name     string
+		spec     corev1.PodSpec
+		workload *WorkloadInfo
+		want     []string
+	}{
+		{
+			name:     "follows the practices",
+			spec:     corev1.PodSpec{Containers: []corev1.Container{good}, TopologySpreadConstraints: spread, TerminationGracePeriodSeconds: grace(30)},
+			workload: deployment(3),
+		},
+		{
+			name: "bare container",
+			spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			want: []string{"Container app has no readiness or liveness probe", "Container app has no CPU or memory request"},
+		},
+		{
+			name:     "single replica",
+			spec:     corev1.PodSpec{Containers: []corev1.Container{good}},
+			workload: deployment(1),
+			want:     []string{"Deployment api runs a single replica"},
+		},
+		{
+			name:     "replicas not spread",
+			spec:     corev1.PodSpec{Containers: []corev1.Container{good}},
+			workload: deployment(3),
+			want:     []string{"Replicas of Deployment api may all land on one node"},
+		},
+		{
+			name: "anti-affinity spreads replicas",
+			spec: corev1.PodSpec{Containers: []corev1.Container{good}, Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{Weight: 100}},
+			}}},
+			workload: deployment(3),
+		},
+		{
+			name:     "daemonsets are not judged by replicas",
+			spec:     corev1.PodSpec{Containers: []corev1.Container{good}},
+			workload: &WorkloadInfo{Name: "agent", Type: ResourceDaemonSets, Replicas: 1},
+		},
+		{
+			name: "no grace period",
+			spec: corev1.PodSpec{Containers: []corev1.Container{good}, TerminationGracePeriodSeconds: grace(0)},
+			want: []string{"terminationGracePeriodSeconds is 0"},
+		},
+		{
+			name: "long grace period",
+			spec: corev1.PodSpec{Containers: []corev1.Container{good}, TerminationGracePeriodSeconds: grace(3600)},
+			want: []string{"terminationGracePeriodSeconds is 3600 (1h0m)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LintPod(&corev1.Pod{Spec: tt.spec}, tt.workload)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d hints, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(got[i].Issue, want) || got[i].Severity != "Info" {
+					t.Errorf("hint %d = %s %q, want Info %q", i, got[i].Severity, got[i].Issue, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/doganarif/k9sight/internal/cache"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const listCacheNamespaces = 16
+
+// ListCache keeps namespace-wide service and ingress lists for a short time,
+// so related-resource lookups for pods in one namespace share a single List.
+// A nil *ListCache lists directly.
+type ListCache struct {
+	services  *cache.LRU[string, []corev1.Service]
+	ingresses *cache.LRU[string, []networkingv1.Ingress]
+}
+
+func NewListCache(ttl time.Duration) *ListCache {
+	return &ListCache{
+		services:  cache.New[string, []corev1.Service](listCacheNamespaces, ttl),
+		ingresses: cache.New[string, []networkingv1.Ingress](listCacheNamespaces, ttl),
+	}
+}
+
+// Services lists the services in namespace
+func (c *ListCache) Services(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]corev1.Service, error) {
+	if c != nil {
+		if svcs, ok := c.services.Get(namespace); ok {
+			return svcs, nil
+		}
+	}
+	list, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if c != nil {
+		c.services.Put(namespace, list.Items)
+	}
+	return list.Items, nil
+}
+
+// Ingresses lists the ingresses in namespace
+func (c *ListCache) Ingresses(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]networkingv1.Ingress, error) {
+	if c != nil {
+		if ings, ok := c.ingresses.Get(namespace); ok {
+			return ings, nil
+		}
+	}
+	list, err := clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if c != nil {
+		c.ingresses.Put(namespace, list.Items)
+	}
+	return list.Items, nil
+}
+
+// Purge drops all cached lists
+func (c *ListCache) Purge() {
+	if c == nil {
+		return
+	}
+	c.services.Purge()
+	c.ingresses.Purge()
+}
@@ -0,0 +1,158 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Buffered so a burst of changes (e.g. a rollout) does not stall the watch
+const listWatchBuffer = 64
+
+// WorkloadEvent is one incremental change to a workload list: the workload
+// was added or modified, or removed when Deleted is set
+type WorkloadEvent struct {
+	Workload WorkloadInfo
+	Deleted  bool
+}
+
+// PodEvent is one incremental change to a pod list
+type PodEvent struct {
+	Pod     PodInfo
+	Deleted bool
+}
+
+// WatchWorkloads streams changes to the workloads of one type in a namespace.
+// The channel closes when ctx is cancelled or the server ends the watch.
+func WatchWorkloads(ctx context.Context, clientset *kubernetes.Clientset, namespace string, resourceType ResourceType) (<-chan WorkloadEvent, error) {
+	opts := metav1.ListOptions{}
+
+	var w watch.Interface
+	var err error
+	switch resourceType {
+	case ResourceDeployments:
+		w, err = clientset.AppsV1().Deployments(namespace).Watch(ctx, opts)
+	case ResourceStatefulSets:
+		w, err = clientset.AppsV1().StatefulSets(namespace).Watch(ctx, opts)
+	case ResourceDaemonSets:
+		w, err = clientset.AppsV1().DaemonSets(namespace).Watch(ctx, opts)
+	case ResourceJobs:
+		w, err = clientset.BatchV1().Jobs(namespace).Watch(ctx, opts)
+	case ResourceCronJobs:
+		w, err = clientset.BatchV1().CronJobs(namespace).Watch(ctx, opts)
+	case ResourcePods:
+		w, err = clientset.CoreV1().Pods(namespace).Watch(ctx, opts)
+	case ResourceServices:
+		w, err = clientset.CoreV1().Services(namespace).Watch(ctx, opts)
+	case ResourceNodes:
+		w, err = clientset.CoreV1().Nodes().Watch(ctx, opts)
+	default:
+		return nil, fmt.Errorf("unknown resource type: %s", resourceType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return streamEvents(ctx, w, func(ev watch.Event) (WorkloadEvent, bool) {
+		workload, ok := workloadFromObject(ev.Object)
+		return WorkloadEvent{Workload: workload, Deleted: ev.Type == watch.Deleted}, ok
+	}), nil
+}
+
+// WatchWorkloadPods streams changes to the pods GetWorkloadPods would list
+func WatchWorkloadPods(ctx context.Context, clientset *kubernetes.Clientset, workload WorkloadInfo) (<-chan PodEvent, error) {
+	opts := metav1.ListOptions{}
+	switch {
+	case workload.Type == ResourcePods:
+		opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", workload.Name).String()
+	case len(workload.Labels) == 0:
+		// Nothing can change, e.g. for a Service without a selector
+		ch := make(chan PodEvent)
+		go func() {
+			<-ctx.Done()
+			close(ch)
+		}()
+		return ch, nil
+	default:
+		opts.LabelSelector = labels.SelectorFromSet(workload.Labels).String()
+	}
+
+	w, err := clientset.CoreV1().Pods(workload.Namespace).Watch(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamEvents(ctx, w, func(ev watch.Event) (PodEvent, bool) {
+		pod, ok := ev.Object.(*corev1.Pod)
+		if !ok {
+			return PodEvent{}, false
+		}
+		return PodEvent{Pod: podToPodInfo(pod), Deleted: ev.Type == watch.Deleted}, true
+	}), nil
+}
+
+// workloadFromObject converts a watched object with the same rules the list
+// functions use, so live rows match freshly loaded ones
+func workloadFromObject(obj runtime.Object) (WorkloadInfo, bool) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return deploymentToWorkload(o), true
+	case *appsv1.StatefulSet:
+		return statefulSetToWorkload(o), true
+	case *appsv1.DaemonSet:
+		return daemonSetToWorkload(o), true
+	case *batchv1.Job:
+		return jobToWorkload(o), true
+	case *batchv1.CronJob:
+		return cronJobToWorkload(o), true
+	case *corev1.Pod:
+		return podToWorkload(o), true
+	case *corev1.Service:
+		return serviceToWorkload(o), true
+	case *corev1.Node:
+		return nodeToWorkload(o), true
+	}
+	return WorkloadInfo{}, false
+}
+
+// streamEvents forwards converted events from w until ctx is done or the
+// watch ends. Error and bookmark events, and objects convert rejects, are dropped.
+func streamEvents[T any](ctx context.Context, w watch.Interface, convert func(watch.Event) (T, bool)) <-chan T {
+	out := make(chan T, listWatchBuffer)
+	go func() {
+		defer close(out)
+		defer w.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-w.ResultChan():
+				if !ok {
+					return
+				}
+				if ev.Type != watch.Added && ev.Type != watch.Modified && ev.Type != watch.Deleted {
+					continue
+				}
+				item, ok := convert(ev)
+				if !ok {
+					continue
+				}
+				select {
+				case out <- item:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
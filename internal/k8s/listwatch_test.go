@@ -0,0 +1,147 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestWorkloadFromObject(t *testing.T) {
+	tests := []struct {
+		name       string
+		obj        runtime.Object
+		wantOK     bool
+		wantType   ResourceType
+		wantReady  string
+		wantStatus string
+		wantImage  string
+	}{
+		{
+			name: "deployment",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{},
+					Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+						{Name: "app", Image: "ghcr.io/acme/web:v1.2"},
+						{Name: "proxy", Image: "envoy:v1.29"},
+					}}},
+				},
+				Status: appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 2},
+			},
+			wantOK:     true,
+			wantType:   ResourceDeployments,
+			wantReady:  "2/3",
+			wantStatus: "Progressing",
+			wantImage:  "ghcr.io/acme/web:v1.2 +1",
+		},
+		{
+			name: "pod",
+			obj: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:v2"}}},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{Ready: true}},
+				},
+			},
+			wantOK:     true,
+			wantType:   ResourcePods,
+			wantReady:  "1/1",
+			wantStatus: "Running",
+			wantImage:  "web:v2",
+		},
+		{
+			name: "service without selector",
+			obj: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "external-db"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName},
+			},
+			wantOK:     true,
+			wantType:   ResourceServices,
+			wantReady:  "ExternalName",
+			wantStatus: "No selector",
+		},
+		{
+			name: "cordoned node",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+				Spec:       corev1.NodeSpec{Unschedulable: true},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+					NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: "v1.29.1"},
+				},
+			},
+			wantOK:     true,
+			wantType:   ResourceNodes,
+			wantReady:  "v1.29.1",
+			wantStatus: "Cordoned",
+		},
+		{
+			name:   "unrelated object",
+			obj:    &corev1.ConfigMap{},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, ok := workloadFromObject(tt.obj)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if w.Type != tt.wantType || w.Ready != tt.wantReady || w.Status != tt.wantStatus {
+				t.Errorf("got %s %s %s, want %s %s %s",
+					w.Type, w.Ready, w.Status, tt.wantType, tt.wantReady, tt.wantStatus)
+			}
+			if w.Image != tt.wantImage {
+				t.Errorf("Image = %q, want %q", w.Image, tt.wantImage)
+			}
+		})
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	fake := watch.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := streamEvents(ctx, fake, func(ev watch.Event) (string, bool) {
+		pod, ok := ev.Object.(*corev1.Pod)
+		if !ok {
+			return "", false
+		}
+		return string(ev.Type) + " " + pod.Name, true
+	})
+
+	go func() {
+		fake.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a"}})
+		fake.Add(&corev1.ConfigMap{}) // not convertible, dropped
+		fake.Error(&metav1.Status{})  // error events are dropped
+		fake.Delete(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a"}})
+		fake.Stop()
+	}()
+
+	var got []string
+	for item := range out {
+		got = append(got, item)
+	}
+
+	want := []string{"ADDED a", "DELETED a"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FormatLogExport renders log lines for saving to a file. Raw output is each
+// line as the container wrote it; formatted output prefixes it with its
+// RFC 3339 timestamp and, when the lines come from more than one container,
+// the container name.
+func FormatLogExport(lines []LogLine, raw bool) string {
+	containers := make(map[string]bool)
+	for _, l := range lines {
+		containers[l.Container] = true
+	}
+
+	var b strings.Builder
+	for _, l := range lines {
+		if !raw {
+			if !l.Timestamp.IsZero() {
+				b.WriteString(l.Timestamp.UTC().Format(time.RFC3339Nano))
+				b.WriteString(" ")
+			}
+			if len(containers) > 1 && l.Container != "" {
+				b.WriteString("[" + l.Container + "] ")
+			}
+		}
+		b.WriteString(l.Content)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// LogExportPath is where logs are saved: a file in dir named after the pod,
+// the container ("all" for every container) and the time, e.g.
+// "web-7d9f-app-20240501-120000.log". A leading ~ in dir is the home
+// directory and an empty dir the working directory.
+func LogExportPath(dir, pod, container string, at time.Time) string {
+	if container == "" {
+		container = "all"
+	}
+	return filepath.Join(ExpandHome(dir), pod+"-"+container+"-"+at.Format("20060102-150405")+".log")
+}
+
+// ExpandHome replaces a leading ~ in path with the home directory
+func ExpandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + rest
+		}
+	}
+	return path
+}
+
+// SaveExport writes content to path, creating its directory
+func SaveExport(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
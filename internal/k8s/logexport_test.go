@@ -0,0 +1,40 @@
+package k8s
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFormatLogExport(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 500000000, time.UTC)
+	lines := []LogLine{
+		{Timestamp: ts, Container: "app", Content: "started"},
+		{Container: "proxy", Content: "  indented"},
+	}
+	tests := []struct {
+		lines []LogLine
+		raw   bool
+		want  string
+	}{
+		{lines, true, "started\n  indented\n"},
+		{lines, false, "2024-05-01T12:00:00.5Z [app] started\n[proxy]   indented\n"},
+		{lines[:1], false, "2024-05-01T12:00:00.5Z started\n"},
+		{nil, false, ""},
+	}
+	for _, tt := range tests {
+		if got := FormatLogExport(tt.lines, tt.raw); got != tt.want {
+			t.Errorf("FormatLogExport(raw=%v) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestLogExportPath(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 3, 4, 0, time.Local)
+	if got := LogExportPath("logs", "web-1", "app", at); got != filepath.Join("logs", "web-1-app-20240501-120304.log") {
+		t.Errorf("LogExportPath() = %q", got)
+	}
+	if got := LogExportPath("", "web-1", "", at); got != "web-1-all-20240501-120304.log" {
+		t.Errorf("LogExportPath() without dir or container = %q", got)
+	}
+}
@@ -0,0 +1,116 @@
+package k8s
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LogLevel is the severity a log line was written at
+type LogLevel int
+
+const (
+	LevelUnknown LogLevel = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+var logLevelNames = map[LogLevel]string{
+	LevelUnknown: "",
+	LevelDebug:   "debug",
+	LevelInfo:    "info",
+	LevelWarn:    "warn",
+	LevelError:   "error",
+	LevelFatal:   "fatal",
+}
+
+func (l LogLevel) String() string {
+	return logLevelNames[l]
+}
+
+// ParseLogLevel maps a level name as loggers spell it, in any case, to a
+// LogLevel
+func ParseLogLevel(name string) LogLevel {
+	switch strings.ToLower(name) {
+	case "trace", "debug", "dbug", "dbg", "d", "finer", "finest", "fine":
+		return LevelDebug
+	case "info", "inf", "i", "notice", "information", "informational":
+		return LevelInfo
+	case "warn", "warning", "wrn", "w":
+		return LevelWarn
+	case "error", "err", "eror", "e", "severe", "dpanic":
+		return LevelError
+	case "fatal", "ftl", "f", "panic", "critical", "crit", "alert", "emerg", "emergency":
+		return LevelFatal
+	}
+	return LevelUnknown
+}
+
+var (
+	// logrus and other logfmt loggers: level=error
+	logfmtLevel = regexp.MustCompile(`(?:^|\s)(?:level|lvl|severity)=["']?([A-Za-z]+)`)
+	// klog: E0501 12:00:00.000000
+	klogHeader = regexp.MustCompile(`^([IWEF])\d{4} \d{2}:\d{2}:\d{2}\.\d{6}\s`)
+	// zap console encoder: the level is a tab-separated column
+	zapLevel = regexp.MustCompile(`\t(DEBUG|INFO|WARN|ERROR|DPANIC|PANIC|FATAL)\t`)
+)
+
+// Lines that mean the process is dying, whatever the logger
+var fatalPrefixes = []string{"panic: ", "fatal error: ", "Traceback (most recent call last)", "Exception in thread "}
+
+// logLevelTokens is how many leading words of a line may hold a bare
+// severity, which leaves room for a timestamp and a component name
+const logLevelTokens = 4
+
+// ClassifyLogLevel works out the level of a log line from JSON level fields,
+// logfmt level=, klog and zap headers, or a severity word near the start
+// such as ERROR, [warn] or error:. Lines that merely mention a level, like
+// "0 errors", stay unknown.
+func ClassifyLogLevel(content string) LogLevel {
+	content = strings.TrimSpace(StripANSI(content))
+	if parsed, ok := ParseJSONLog(content); ok {
+		return ParseLogLevel(parsed.Level)
+	}
+	for _, prefix := range fatalPrefixes {
+		if strings.HasPrefix(content, prefix) {
+			return LevelFatal
+		}
+	}
+	if m := klogHeader.FindStringSubmatch(content); m != nil {
+		return ParseLogLevel(m[1])
+	}
+	if m := zapLevel.FindStringSubmatch(content); m != nil {
+		return ParseLogLevel(m[1])
+	}
+	if m := logfmtLevel.FindStringSubmatch(content); m != nil {
+		return ParseLogLevel(m[1])
+	}
+
+	for i, word := range strings.Fields(content) {
+		if i == logLevelTokens {
+			break
+		}
+		if level := severityWord(word); level != LevelUnknown {
+			return level
+		}
+	}
+	return LevelUnknown
+}
+
+// severityWord reads a level from a word that is one: an upper-case word,
+// or one set off by brackets or a colon. Single letters never count.
+func severityWord(word string) LogLevel {
+	trimmed := strings.TrimLeft(word, "[<(")
+	if end := strings.IndexAny(trimmed, "]>):"); end >= 0 {
+		trimmed = trimmed[:end]
+	}
+	if len(trimmed) < 3 {
+		return LevelUnknown
+	}
+	if trimmed != word || trimmed == strings.ToUpper(trimmed) {
+		return ParseLogLevel(trimmed)
+	}
+	return LevelUnknown
+}
@@ -0,0 +1,38 @@
+package k8s
+
+import "testing"
+
+func TestClassifyLogLevel(t *testing.T) {
+	tests := []struct {
+		line string
+		want LogLevel
+	}{
+		{`{"level":"error","msg":"payment failed"}`, LevelError},
+		{`{"severity":"WARNING","message":"slow query"}`, LevelWarn},
+		{`{"msg":"no level here"}`, LevelUnknown},
+		{`time="2024-05-01T12:00:00Z" level=warning msg="disk almost full"`, LevelWarn},
+		{`ts=2024-05-01T12:00:00Z lvl=debug msg=tick`, LevelDebug},
+		{"E0501 12:00:00.123456       1 controller.go:42] sync failed", LevelError},
+		{"I0501 12:00:00.123456       1 main.go:10] Starting", LevelInfo},
+		{"2024-05-01T12:00:00.000Z\tERROR\tserver/handler.go:12\trequest failed", LevelError},
+		{"2024-05-01T12:00:00.000Z\tINFO\tstarted", LevelInfo},
+		{"2024-05-01 12:00:00 ERROR [main] connection refused", LevelError},
+		{"[warn] deprecated flag --foo", LevelWarn},
+		{"2024/05/01 12:00:00 [error] 29#29: upstream timed out", LevelError},
+		{"ERROR:root:could not connect", LevelError},
+		{"error: unable to open file", LevelError},
+		{"panic: runtime error: invalid memory address", LevelFatal},
+		{"Traceback (most recent call last):", LevelFatal},
+		// Mentions of a level are not a level
+		{"Processed 120 records, 0 errors", LevelUnknown},
+		{"retrying after error from upstream", LevelUnknown},
+		{"Error budget is healthy", LevelUnknown},
+		{"GET /healthz 200", LevelUnknown},
+		{"", LevelUnknown},
+	}
+	for _, tt := range tests {
+		if got := ClassifyLogLevel(tt.line); got != tt.want {
+			t.Errorf("ClassifyLogLevel(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
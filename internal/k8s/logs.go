@@ -3,12 +3,17 @@ package k8s
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -17,7 +22,8 @@ type LogLine struct {
 	Timestamp time.Time
 	Container string
 	Content   string
-	IsError   bool
+	Level     LogLevel
+	IsError   bool // Level is error or fatal
 }
 
 type LogOptions struct {
@@ -70,83 +76,234 @@ func parseLogStream(reader io.Reader, container string, hasTimestamps bool) ([]L
 	scanner.Buffer(buf, 1024*1024)
 
 	for scanner.Scan() {
-		line := scanner.Text()
-		logLine := LogLine{
-			Container: container,
-			Content:   line,
+		lines = append(lines, parseLogLine(scanner.Text(), container, hasTimestamps))
+	}
+
+	return lines, scanner.Err()
+}
+
+func parseLogLine(line, container string, hasTimestamps bool) LogLine {
+	logLine := LogLine{
+		Container: container,
+		Content:   line,
+	}
+
+	if hasTimestamps && len(line) > 30 {
+		if ts, err := time.Parse(time.RFC3339Nano, line[:30]); err == nil {
+			logLine.Timestamp = ts
+			logLine.Content = strings.TrimSpace(line[31:])
+		} else if ts, err := time.Parse(time.RFC3339, line[:20]); err == nil {
+			logLine.Timestamp = ts
+			logLine.Content = strings.TrimSpace(line[21:])
 		}
+	}
 
-		if hasTimestamps && len(line) > 30 {
-			if ts, err := time.Parse(time.RFC3339Nano, line[:30]); err == nil {
-				logLine.Timestamp = ts
-				logLine.Content = strings.TrimSpace(line[31:])
-			} else if ts, err := time.Parse(time.RFC3339, line[:20]); err == nil {
-				logLine.Timestamp = ts
-				logLine.Content = strings.TrimSpace(line[21:])
-			}
+	logLine.Level = ClassifyLogLevel(logLine.Content)
+	logLine.IsError = logLine.Level >= LevelError
+	return logLine
+}
+
+// logStreamBuffer is how many streamed lines may wait for the UI
+const logStreamBuffer = 256
+
+// StreamPodLogs follows the logs of the given containers and sends each new
+// line as it is written. Only lines newer than after are sent, so the stream
+// continues where a fetch left off; a zero after starts from now. The
+// channel closes once every container's stream has ended or ctx is done.
+func StreamPodLogs(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName string, containers []string, after time.Time) (<-chan LogLine, error) {
+	streams := make([]io.ReadCloser, 0, len(containers))
+	for _, container := range containers {
+		opts := &corev1.PodLogOptions{
+			Container:  container,
+			Follow:     true,
+			Timestamps: true,
+		}
+		if after.IsZero() {
+			var none int64
+			opts.TailLines = &none
+		} else {
+			// sinceTime has second precision; finer duplicates are dropped below
+			since := metav1.NewTime(after)
+			opts.SinceTime = &since
 		}
 
-		logLine.IsError = isErrorLine(logLine.Content)
-		lines = append(lines, logLine)
+		stream, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+		if err != nil {
+			for _, s := range streams {
+				s.Close()
+			}
+			return nil, fmt.Errorf("failed to follow logs: %w", err)
+		}
+		streams = append(streams, stream)
 	}
-
-	return lines, scanner.Err()
+	return mergeLogStreams(ctx, streams, containers, after), nil
 }
 
-func isErrorLine(content string) bool {
-	lower := strings.ToLower(content)
-	errorIndicators := []string{
-		"error", "err:", "fatal", "panic", "exception",
-		"failed", "failure", "crash", "critical",
+// mergeLogStreams follows each stream, labelling its lines with the label of
+// the same index, into one channel that closes once all have ended
+func mergeLogStreams(ctx context.Context, streams []io.ReadCloser, labels []string, after time.Time) <-chan LogLine {
+	ch := make(chan LogLine, logStreamBuffer)
+	var wg sync.WaitGroup
+	for i, stream := range streams {
+		wg.Add(1)
+		go func(label string, stream io.ReadCloser) {
+			defer wg.Done()
+			defer stream.Close()
+			followLogStream(ctx, stream, label, after, ch)
+		}(labels[i], stream)
 	}
-	for _, indicator := range errorIndicators {
-		if strings.Contains(lower, indicator) {
-			return true
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+	return ch
+}
+
+// followLogStream sends the stream's lines newer than after until it ends or
+// ctx is done, with container as their Container
+func followLogStream(ctx context.Context, stream io.Reader, container string, after time.Time, ch chan<- LogLine) {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := parseLogLine(scanner.Text(), container, true)
+		if !after.IsZero() && !line.Timestamp.After(after) {
+			continue
+		}
+		select {
+		case ch <- line:
+		case <-ctx.Done():
+			return
 		}
 	}
-	return false
 }
 
+// NewLogLine builds a LogLine from a line obtained outside the kubelet API
+func NewLogLine(container, content string, ts time.Time) LogLine {
+	level := ClassifyLogLevel(content)
+	return LogLine{
+		Timestamp: ts,
+		Container: container,
+		Content:   content,
+		Level:     level,
+		IsError:   level >= LevelError,
+	}
+}
+
+// maxLogFetchWorkers bounds concurrent log streams per pod
+const maxLogFetchWorkers = 4
+
 func GetAllContainerLogs(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName string, tailLines int64) ([]LogLine, error) {
 	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	var allLogs []LogLine
 	linesPerContainer := tailLines / int64(len(pod.Spec.Containers))
 	if linesPerContainer < 10 {
 		linesPerContainer = 10
 	}
 
-	for _, container := range pod.Spec.Containers {
-		opts := LogOptions{
-			Container:  container.Name,
-			TailLines:  linesPerContainer,
-			Timestamps: true,
-		}
+	// Fetch containers concurrently; each keeps its own slot so the merge
+	// doesn't depend on which stream finishes first
+	results := make([][]LogLine, len(pod.Spec.Containers))
+	var g errgroup.Group
+	g.SetLimit(maxLogFetchWorkers)
+	for i, container := range pod.Spec.Containers {
+		i, name := i, container.Name
+		g.Go(func() error {
+			logs, err := GetPodLogs(ctx, clientset, namespace, podName, LogOptions{
+				Container:  name,
+				TailLines:  linesPerContainer,
+				Timestamps: true,
+			})
+			if err == nil {
+				results[i] = logs
+			}
+			return nil // one failing container shouldn't hide the others
+		})
+	}
+	g.Wait()
 
-		logs, err := GetPodLogs(ctx, clientset, namespace, podName, opts)
-		if err != nil {
-			continue
+	// The kubelet returns each container's lines in order; sorting is only
+	// a fallback for clock steps inside a container
+	for _, logs := range results {
+		if !sort.SliceIsSorted(logs, func(i, j int) bool { return LogLineBefore(logs[i], logs[j]) }) {
+			sortLogsByTime(logs)
 		}
-		allLogs = append(allLogs, logs...)
 	}
-
-	sortLogsByTime(allLogs)
-	return allLogs, nil
+	return mergeSortedLogs(results), nil
 }
 
+// sortLogsByTime orders one container's lines by time, keeping the order of
+// lines with equal times
 func sortLogsByTime(logs []LogLine) {
-	for i := 0; i < len(logs)-1; i++ {
-		for j := i + 1; j < len(logs); j++ {
-			if logs[j].Timestamp.Before(logs[i].Timestamp) {
-				logs[i], logs[j] = logs[j], logs[i]
+	sort.SliceStable(logs, func(i, j int) bool { return LogLineBefore(logs[i], logs[j]) })
+}
+
+// mergeSortedLogs merges per-container lines, each already in time order,
+// into one time-ordered slice. A pod has only a few containers, so the next
+// line is picked by scanning the heads of all of them.
+func mergeSortedLogs(runs [][]LogLine) []LogLine {
+	total := 0
+	for _, run := range runs {
+		total += len(run)
+	}
+	merged := make([]LogLine, 0, total)
+	heads := make([]int, len(runs))
+	for len(merged) < total {
+		next := -1
+		for i, run := range runs {
+			if heads[i] == len(run) {
+				continue
+			}
+			if next < 0 || LogLineBefore(run[heads[i]], runs[next][heads[next]]) {
+				next = i
 			}
 		}
+		merged = append(merged, runs[next][heads[next]])
+		heads[next]++
 	}
+	return merged
 }
 
+// MergeLogLines inserts lines into logs, both in time order, keeping the
+// result in time order; lines without a timestamp go last. It reuses logs'
+// backing array when lines all come after it.
+func MergeLogLines(logs, lines []LogLine) []LogLine {
+	if len(logs) == 0 || len(lines) == 0 || !LogLineBefore(lines[0], logs[len(logs)-1]) {
+		return append(logs, lines...)
+	}
+	merged := make([]LogLine, 0, len(logs)+len(lines))
+	i, j := 0, 0
+	for i < len(logs) && j < len(lines) {
+		if LogLineBefore(lines[j], logs[i]) {
+			merged = append(merged, lines[j])
+			j++
+		} else {
+			merged = append(merged, logs[i])
+			i++
+		}
+	}
+	merged = append(merged, logs[i:]...)
+	return append(merged, lines[j:]...)
+}
+
+// LogLineBefore orders lines by time, with the ones lacking a timestamp after
+// all others
+func LogLineBefore(a, b LogLine) bool {
+	if a.Timestamp.IsZero() || b.Timestamp.IsZero() {
+		return !a.Timestamp.IsZero() && b.Timestamp.IsZero()
+	}
+	return a.Timestamp.Before(b.Timestamp)
+}
+
+// ErrNoPreviousLogs means the container has not restarted, or the logs of
+// its previous instance are gone
+var ErrNoPreviousLogs = errors.New("no previous container logs")
+
+// GetPreviousLogs reads the logs of the container's previous instance, like
+// kubectl logs --previous, which is where a crash loop's error ends up
 func GetPreviousLogs(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName, container string, tailLines int64) ([]LogLine, error) {
 	opts := LogOptions{
 		Container:  container,
@@ -154,7 +311,28 @@ func GetPreviousLogs(ctx context.Context, clientset *kubernetes.Clientset, names
 		Previous:   true,
 		Timestamps: true,
 	}
-	return GetPodLogs(ctx, clientset, namespace, podName, opts)
+	logs, err := GetPodLogs(ctx, clientset, namespace, podName, opts)
+	if apierrors.IsBadRequest(err) {
+		return nil, fmt.Errorf("%w for %s", ErrNoPreviousLogs, container)
+	}
+	return logs, err
+}
+
+// PreviousLogsContainer picks whose previous logs to show: the selected
+// container, or else the one that restarted most, since that is the one
+// crashing
+func PreviousLogsContainer(pod *PodInfo, selected string) string {
+	if selected != "" {
+		return selected
+	}
+	name := ""
+	var restarts int32 = -1
+	for _, c := range pod.Containers {
+		if c.RestartCount > restarts {
+			name, restarts = c.Name, c.RestartCount
+		}
+	}
+	return name
 }
 
 func SearchLogs(logs []LogLine, query string) []LogLine {
@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPreviousLogsContainer(t *testing.T) {
+	pod := &PodInfo{Containers: []ContainerInfo{
+		{Name: "proxy", RestartCount: 0},
+		{Name: "app", RestartCount: 7},
+		{Name: "worker", RestartCount: 7},
+	}}
+	tests := []struct {
+		pod      *PodInfo
+		selected string
+		want     string
+	}{
+		{pod, "proxy", "proxy"},
+		{pod, "", "app"},
+		{&PodInfo{Containers: []ContainerInfo{{Name: "only"}}}, "", "only"},
+		{&PodInfo{}, "", ""},
+	}
+	for _, tt := range tests {
+		if got := PreviousLogsContainer(tt.pod, tt.selected); got != tt.want {
+			t.Errorf("PreviousLogsContainer(%q) = %q, want %q", tt.selected, got, tt.want)
+		}
+	}
+}
+
+func TestFollowLogStream(t *testing.T) {
+	after := time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC)
+	stream := strings.NewReader(strings.Join([]string{
+		"2024-05-01T12:00:00.000000100Z already shown",
+		"2024-05-01T12:00:00.000000500Z already shown too",
+		"2024-05-01T12:00:00.000000900Z new line",
+		"2024-05-01T12:00:01.000000000Z error: disk full",
+	}, "\n"))
+
+	ch := make(chan LogLine, 10)
+	followLogStream(context.Background(), stream, "app", after, ch)
+	close(ch)
+
+	var got []LogLine
+	for line := range ch {
+		got = append(got, line)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d lines, want the 2 newer than after: %+v", len(got), got)
+	}
+	if got[0].Content != "new line" || got[0].Container != "app" {
+		t.Errorf("first line = %+v", got[0])
+	}
+	if !got[1].IsError {
+		t.Error("error line should be flagged")
+	}
+}
+
+func TestMergeLogLines(t *testing.T) {
+	at := func(sec int, content string) LogLine {
+		return LogLine{Timestamp: time.Date(2024, 5, 1, 12, 0, sec, 0, time.UTC), Content: content}
+	}
+	join := func(lines []LogLine) string {
+		var parts []string
+		for _, l := range lines {
+			parts = append(parts, l.Content)
+		}
+		return strings.Join(parts, ",")
+	}
+
+	tests := []struct {
+		logs, lines []LogLine
+		want        string
+	}{
+		{nil, []LogLine{at(1, "a")}, "a"},
+		{[]LogLine{at(1, "a")}, []LogLine{at(2, "b"), at(3, "c")}, "a,b,c"},
+		// A pod's tail arriving after newer lines of another pod
+		{[]LogLine{at(1, "a"), at(4, "d")}, []LogLine{at(2, "b"), at(3, "c"), at(5, "e")}, "a,b,c,d,e"},
+		// Equal times keep the lines already shown first
+		{[]LogLine{at(2, "old")}, []LogLine{at(2, "new")}, "old,new"},
+		{[]LogLine{at(3, "c"), {Content: "undated"}}, []LogLine{at(1, "a")}, "a,c,undated"},
+	}
+	for _, tt := range tests {
+		if got := join(MergeLogLines(tt.logs, tt.lines)); got != tt.want {
+			t.Errorf("MergeLogLines(%s + %s) = %s, want %s", join(tt.logs), join(tt.lines), got, tt.want)
+		}
+	}
+}
+
+func TestMergeSortedLogs(t *testing.T) {
+	at := func(sec int, container string) LogLine {
+		return LogLine{Timestamp: time.Date(2024, 5, 1, 12, 0, sec, 0, time.UTC), Container: container}
+	}
+	runs := [][]LogLine{
+		{at(1, "app"), at(4, "app"), at(4, "app"), {Container: "app"}},
+		nil,
+		{at(2, "proxy"), at(3, "proxy"), at(9, "proxy")},
+		{at(4, "init")},
+	}
+	got := mergeSortedLogs(runs)
+	if len(got) != 8 {
+		t.Fatalf("merged %d lines, want 8", len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if LogLineBefore(got[i], got[i-1]) {
+			t.Errorf("line %d (%v) is before line %d (%v)", i, got[i].Timestamp, i-1, got[i-1].Timestamp)
+		}
+	}
+	// Equal times keep the earlier container first, and undated lines go last
+	if got[3].Container != "app" || got[5].Container != "init" || !got[7].Timestamp.IsZero() {
+		t.Errorf("order = %v", got)
+	}
+
+	logs := []LogLine{at(3, "c"), {Content: "undated"}, at(1, "a"), at(2, "b")}
+	sortLogsByTime(logs)
+	if logs[0].Container != "a" || logs[2].Container != "c" || !logs[3].Timestamp.IsZero() {
+		t.Errorf("sortLogsByTime() = %v", logs)
+	}
+}
@@ -0,0 +1,49 @@
+package k8s
+
+import "regexp"
+
+// LogMatcher matches log lines against a search: a plain substring or a
+// regular expression, ignoring case unless asked not to. A nil matcher
+// matches every line.
+type LogMatcher struct {
+	re *regexp.Regexp
+}
+
+// NewLogMatcher compiles a search; an empty query gives a nil matcher
+func NewLogMatcher(query string, regex, caseSensitive bool) (*LogMatcher, error) {
+	if query == "" {
+		return nil, nil
+	}
+	pattern := query
+	if !regex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &LogMatcher{re: re}, nil
+}
+
+// Match reports whether the line contains a match
+func (m *LogMatcher) Match(line string) bool {
+	return m == nil || m.re.MatchString(line)
+}
+
+// Spans returns the start and end of each non-empty match in the line, for
+// highlighting
+func (m *LogMatcher) Spans(line string) [][2]int {
+	if m == nil {
+		return nil
+	}
+	var spans [][2]int
+	for _, loc := range m.re.FindAllStringIndex(line, -1) {
+		if loc[1] > loc[0] {
+			spans = append(spans, [2]int{loc[0], loc[1]})
+		}
+	}
+	return spans
+}
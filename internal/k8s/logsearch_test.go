@@ -0,0 +1,43 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLogMatcher(t *testing.T) {
+	tests := []struct {
+		query        string
+		regex, exact bool
+		line         string
+		want         bool
+		wantSpans    string
+	}{
+		{"error", false, false, "ERROR: disk full, error again", true, "[[0 5] [18 23]]"},
+		{"error", false, true, "ERROR: disk full", false, "[]"},
+		{"a.c", false, false, "abc", false, "[]"},
+		{"a.c", true, false, "xABC", true, "[[1 4]]"},
+		{`status=5\d\d`, true, false, "GET / status=503 in 2ms", true, "[[6 16]]"},
+		{"x*", true, false, "abc", true, "[]"},
+	}
+	for _, tt := range tests {
+		m, err := NewLogMatcher(tt.query, tt.regex, tt.exact)
+		if err != nil {
+			t.Fatalf("NewLogMatcher(%q): %v", tt.query, err)
+		}
+		if got := m.Match(tt.line); got != tt.want {
+			t.Errorf("%q.Match(%q) = %v, want %v", tt.query, tt.line, got, tt.want)
+		}
+		if got := fmt.Sprint(m.Spans(tt.line)); got != tt.wantSpans {
+			t.Errorf("%q.Spans(%q) = %s, want %s", tt.query, tt.line, got, tt.wantSpans)
+		}
+	}
+
+	if _, err := NewLogMatcher("a(b", true, false); err == nil {
+		t.Error("an invalid regex should fail")
+	}
+	var none *LogMatcher
+	if m, _ := NewLogMatcher("", true, false); m != nil || !none.Match("anything") {
+		t.Error("an empty query should match everything")
+	}
+}
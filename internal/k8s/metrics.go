@@ -2,12 +2,19 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
+// ErrMetricsUnavailable means metrics-server is not installed or has no
+// sample for the pod yet; callers treat it as "no data" rather than a failure
+var ErrMetricsUnavailable = errors.New("metrics server not available")
+
 type PodMetrics struct {
 	Name       string
 	Namespace  string
@@ -20,14 +27,19 @@ type ContainerMetrics struct {
 	MemoryUsage string
 	CPUPercent  float64
 	MemPercent  float64
+	CPUMilli    int64 // usage as a number, for totals
+	MemoryBytes int64
 }
 
 func GetPodMetrics(ctx context.Context, metricsClient *metricsv.Clientset, namespace, podName string) (*PodMetrics, error) {
 	if metricsClient == nil {
-		return nil, fmt.Errorf("metrics server not available")
+		return nil, ErrMetricsUnavailable
 	}
 
 	metrics, err := metricsClient.MetricsV1beta1().PodMetricses(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+		return nil, ErrMetricsUnavailable
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +57,8 @@ func GetPodMetrics(ctx context.Context, metricsClient *metricsv.Clientset, names
 			Name:        c.Name,
 			CPUUsage:    formatCPU(cpu.MilliValue()),
 			MemoryUsage: formatMemory(mem.Value()),
+			CPUMilli:    cpu.MilliValue(),
+			MemoryBytes: mem.Value(),
 		})
 	}
 
@@ -53,7 +67,7 @@ func GetPodMetrics(ctx context.Context, metricsClient *metricsv.Clientset, names
 
 func GetNamespaceMetrics(ctx context.Context, metricsClient *metricsv.Clientset, namespace string) ([]PodMetrics, error) {
 	if metricsClient == nil {
-		return nil, fmt.Errorf("metrics server not available")
+		return nil, ErrMetricsUnavailable
 	}
 
 	metricsList, err := metricsClient.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
@@ -76,6 +90,8 @@ func GetNamespaceMetrics(ctx context.Context, metricsClient *metricsv.Clientset,
 				Name:        c.Name,
 				CPUUsage:    formatCPU(cpu.MilliValue()),
 				MemoryUsage: formatMemory(mem.Value()),
+				CPUMilli:    cpu.MilliValue(),
+				MemoryBytes: mem.Value(),
 			})
 		}
 		result = append(result, pm)
@@ -84,6 +100,78 @@ func GetNamespaceMetrics(ctx context.Context, metricsClient *metricsv.Clientset,
 	return result, nil
 }
 
+// ResourceTotals is a pod's requests, limits and live usage summed over its
+// containers, formatted like the per-container values
+type ResourceTotals struct {
+	CPURequest, CPULimit, CPUUsage          string
+	MemoryRequest, MemoryLimit, MemoryUsage string
+}
+
+// PodResourceTotals sums the requests, limits and usage of the pod's
+// containers. A container without a limit makes the pod's limit unbounded;
+// the usage is empty without metrics.
+func PodResourceTotals(pod *PodInfo, metrics *PodMetrics) ResourceTotals {
+	var cpuReq, cpuLim, memReq, memLim int64
+	cpuUnbounded, memUnbounded := false, false
+	for _, c := range pod.Containers {
+		cpuReq += quantityValue(c.Resources.CPURequest, true)
+		memReq += quantityValue(c.Resources.MemoryRequest, false)
+		if v := quantityValue(c.Resources.CPULimit, true); v > 0 {
+			cpuLim += v
+		} else {
+			cpuUnbounded = true
+		}
+		if v := quantityValue(c.Resources.MemoryLimit, false); v > 0 {
+			memLim += v
+		} else {
+			memUnbounded = true
+		}
+	}
+
+	t := ResourceTotals{
+		CPURequest:    formatTotal(cpuReq, formatCPU),
+		MemoryRequest: formatTotal(memReq, formatMemory),
+		CPULimit:      "unbounded",
+		MemoryLimit:   "unbounded",
+	}
+	if !cpuUnbounded {
+		t.CPULimit = formatTotal(cpuLim, formatCPU)
+	}
+	if !memUnbounded {
+		t.MemoryLimit = formatTotal(memLim, formatMemory)
+	}
+	if metrics != nil {
+		var cpu, mem int64
+		for _, c := range metrics.Containers {
+			cpu += c.CPUMilli
+			mem += c.MemoryBytes
+		}
+		t.CPUUsage, t.MemoryUsage = formatCPU(cpu), formatMemory(mem)
+	}
+	return t
+}
+
+// quantityValue parses a resource quantity as millicores for CPU or bytes
+// for memory; unset or unparseable counts as 0
+func quantityValue(s string, milli bool) int64 {
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return 0
+	}
+	if milli {
+		return q.MilliValue()
+	}
+	return q.Value()
+}
+
+// formatTotal leaves a zero total as "0", which the panel shows as not set
+func formatTotal(v int64, format func(int64) string) string {
+	if v == 0 {
+		return "0"
+	}
+	return format(v)
+}
+
 func formatCPU(milliCores int64) string {
 	if milliCores < 1000 {
 		return fmt.Sprintf("%dm", milliCores)
@@ -0,0 +1,28 @@
+package k8s
+
+import "testing"
+
+func TestPodResourceTotals(t *testing.T) {
+	pod := &PodInfo{Containers: []ContainerInfo{
+		{Name: "app", Resources: ResourceRequirements{CPURequest: "250m", CPULimit: "1", MemoryRequest: "256Mi", MemoryLimit: "512Mi"}},
+		{Name: "proxy", Resources: ResourceRequirements{CPURequest: "100m", CPULimit: "0", MemoryRequest: "64Mi", MemoryLimit: "128Mi"}},
+	}}
+	metrics := &PodMetrics{Containers: []ContainerMetrics{
+		{Name: "app", CPUMilli: 420, MemoryBytes: 300 << 20},
+		{Name: "proxy", CPUMilli: 30, MemoryBytes: 20 << 20},
+	}}
+
+	got := PodResourceTotals(pod, metrics)
+	want := ResourceTotals{
+		CPURequest: "350m", CPULimit: "unbounded", CPUUsage: "450m",
+		MemoryRequest: "320.0Mi", MemoryLimit: "640.0Mi", MemoryUsage: "320.0Mi",
+	}
+	if got != want {
+		t.Errorf("PodResourceTotals() = %+v, want %+v", got, want)
+	}
+
+	unset := &PodInfo{Containers: []ContainerInfo{{Name: "app", Resources: ResourceRequirements{CPURequest: "0", CPULimit: "0", MemoryRequest: "0", MemoryLimit: "0"}}}}
+	if got := PodResourceTotals(unset, nil); got.CPURequest != "0" || got.MemoryLimit != "unbounded" || got.CPUUsage != "" {
+		t.Errorf("unset totals = %+v", got)
+	}
+}
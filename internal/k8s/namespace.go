@@ -0,0 +1,187 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+// systemNamespaces are never offered for deletion
+var systemNamespaces = map[string]bool{
+	"default":         true,
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+}
+
+// ParseNamespaceSpec validates a namespace name and its optional labels,
+// given as "key=value" pairs separated by commas or spaces
+func ParseNamespaceSpec(name, labels string) (map[string]string, error) {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid name %q: %s", name, errs[0])
+	}
+	parsed := make(map[string]string)
+	for _, pair := range strings.FieldsFunc(labels, func(r rune) bool { return r == ',' || r == ' ' }) {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("label %q is not key=value", pair)
+		}
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", k, errs[0])
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label value %q: %s", v, errs[0])
+		}
+		parsed[k] = v
+	}
+	return parsed, nil
+}
+
+func CreateNamespace(ctx context.Context, clientset *kubernetes.Clientset, name string, labels map[string]string) error {
+	_, err := clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+	}, metav1.CreateOptions{})
+	return err
+}
+
+func DeleteNamespace(ctx context.Context, clientset *kubernetes.Clientset, name string) error {
+	return clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// NamespaceContents counts what still lives in a namespace, e.g.
+// ["3 pods", "1 services"], leaving out what every namespace gets on its
+// own: the default service account and the kube-root-ca.crt ConfigMap.
+// An empty result means the namespace is safe to delete.
+func NamespaceContents(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]string, error) {
+	if systemNamespaces[namespace] {
+		return []string{"system namespace"}, nil
+	}
+
+	opts := metav1.ListOptions{}
+	core, apps, batch := clientset.CoreV1(), clientset.AppsV1(), clientset.BatchV1()
+	lists := []struct {
+		kind string
+		list func() (runtime.Object, error)
+	}{
+		{"pods", func() (runtime.Object, error) { return core.Pods(namespace).List(ctx, opts) }},
+		{"deployments", func() (runtime.Object, error) { return apps.Deployments(namespace).List(ctx, opts) }},
+		{"statefulsets", func() (runtime.Object, error) { return apps.StatefulSets(namespace).List(ctx, opts) }},
+		{"daemonsets", func() (runtime.Object, error) { return apps.DaemonSets(namespace).List(ctx, opts) }},
+		{"jobs", func() (runtime.Object, error) { return batch.Jobs(namespace).List(ctx, opts) }},
+		{"cronjobs", func() (runtime.Object, error) { return batch.CronJobs(namespace).List(ctx, opts) }},
+		{"services", func() (runtime.Object, error) { return core.Services(namespace).List(ctx, opts) }},
+		{"persistentvolumeclaims", func() (runtime.Object, error) { return core.PersistentVolumeClaims(namespace).List(ctx, opts) }},
+		{"configmaps", func() (runtime.Object, error) { return core.ConfigMaps(namespace).List(ctx, opts) }},
+		{"secrets", func() (runtime.Object, error) { return core.Secrets(namespace).List(ctx, opts) }},
+	}
+
+	var contents []string
+	for _, l := range lists {
+		obj, err := l.list()
+		if err != nil {
+			return nil, err
+		}
+		if n := namespaceItemCount(obj); n > 0 {
+			contents = append(contents, fmt.Sprintf("%d %s", n, l.kind))
+		}
+	}
+	return contents, nil
+}
+
+// namespaceItemCount counts a list's items, minus those Kubernetes creates in
+// every namespace
+func namespaceItemCount(obj runtime.Object) int {
+	switch l := obj.(type) {
+	case *corev1.ConfigMapList:
+		n := 0
+		for _, cm := range l.Items {
+			if cm.Name != "kube-root-ca.crt" {
+				n++
+			}
+		}
+		return n
+	case *corev1.SecretList:
+		n := 0
+		for _, s := range l.Items {
+			// Legacy token secrets of the default service account
+			if s.Type != corev1.SecretTypeServiceAccountToken || s.Annotations[corev1.ServiceAccountNameKey] != "default" {
+				n++
+			}
+		}
+		return n
+	}
+	return meta.LenList(obj)
+}
+
+func ListResourceQuotas(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]corev1.ResourceQuota, error) {
+	quotas, err := clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return quotas.Items, nil
+}
+
+// quotaNearFull is the share of a hard limit past which it is flagged
+const quotaNearFull = 0.9
+
+// FormatResourceQuotas renders each quota's used and hard amounts per
+// resource, flagging those at 90% or more, since a full quota makes new
+// pods fail to create rather than stay Pending
+func FormatResourceQuotas(quotas []corev1.ResourceQuota) string {
+	if len(quotas) == 0 {
+		return "No ResourceQuotas in this namespace\n"
+	}
+
+	var b strings.Builder
+	for i, q := range quotas {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s\n", q.Name)
+		resources := make([]string, 0, len(q.Status.Hard))
+		for r := range q.Status.Hard {
+			resources = append(resources, string(r))
+		}
+		sort.Strings(resources)
+		fmt.Fprintf(&b, "  %-32s %-10s %-10s %s\n", "RESOURCE", "USED", "HARD", "")
+		for _, r := range resources {
+			hard := q.Status.Hard[corev1.ResourceName(r)]
+			used := q.Status.Used[corev1.ResourceName(r)]
+			note := ""
+			if hard.MilliValue() > 0 {
+				ratio := float64(used.MilliValue()) / float64(hard.MilliValue())
+				note = fmt.Sprintf("%.0f%%", ratio*100)
+				if ratio >= quotaNearFull {
+					note = "⚠ " + note
+				}
+			}
+			fmt.Fprintf(&b, "  %-32s %-10s %-10s %s\n", r, used.String(), hard.String(), note)
+		}
+	}
+	return b.String()
+}
+
+// FormatWarningEvents lists warning events newest first with the object
+// each is about
+func FormatWarningEvents(events []EventInfo) string {
+	if len(events) == 0 {
+		return "No warning events\n"
+	}
+	var b strings.Builder
+	for _, e := range events {
+		count := ""
+		if e.Count > 1 {
+			count = fmt.Sprintf(" (x%d)", e.Count)
+		}
+		fmt.Fprintf(&b, "%-6s %s %s%s\n       %s\n", e.Age, e.Object, e.Reason, count, e.Message)
+	}
+	return b.String()
+}
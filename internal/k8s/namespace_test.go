@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseNamespaceSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "scratch", want: map[string]string{}},
+		{name: "debug-1", labels: "owner=ana, purpose=debug", want: map[string]string{"owner": "ana", "purpose": "debug"}},
+		{name: "debug", labels: "team.example.com/owner=ops", want: map[string]string{"team.example.com/owner": "ops"}},
+		{name: "Scratch", wantErr: true},
+		{name: "", wantErr: true},
+		{name: "debug", labels: "owner", wantErr: true},
+		{name: "debug", labels: "owner=has space", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseNamespaceSpec(tt.name, tt.labels)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseNamespaceSpec(%q, %q) error = %v, wantErr %v", tt.name, tt.labels, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParseNamespaceSpec(%q, %q) = %v, want %v", tt.name, tt.labels, got, tt.want)
+		}
+		for k, v := range tt.want {
+			if got[k] != v {
+				t.Errorf("label %s = %q, want %q", k, got[k], v)
+			}
+		}
+	}
+}
+
+func TestNamespaceItemCount(t *testing.T) {
+	configMaps := &corev1.ConfigMapList{Items: []corev1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-root-ca.crt"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "app-config"}},
+	}}
+	if n := namespaceItemCount(configMaps); n != 1 {
+		t.Errorf("configmaps = %d, want 1", n)
+	}
+
+	secrets := &corev1.SecretList{Items: []corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{corev1.ServiceAccountNameKey: "default"}},
+		Type:       corev1.SecretTypeServiceAccountToken,
+	}}}
+	if n := namespaceItemCount(secrets); n != 0 {
+		t.Errorf("secrets = %d, want 0", n)
+	}
+
+	pods := &corev1.PodList{Items: make([]corev1.Pod, 3)}
+	if n := namespaceItemCount(pods); n != 3 {
+		t.Errorf("pods = %d, want 3", n)
+	}
+}
+
+func TestFormatResourceQuotas(t *testing.T) {
+	if got := FormatResourceQuotas(nil); !strings.Contains(got, "No ResourceQuotas") {
+		t.Errorf("FormatResourceQuotas(nil) = %q", got)
+	}
+
+	quota := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("10"),
+				corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("10"),
+				corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+	got := FormatResourceQuotas([]corev1.ResourceQuota{quota})
+	lines := strings.Split(got, "\n")
+	if lines[0] != "compute" {
+		t.Errorf("first line = %q, want the quota name", lines[0])
+	}
+	// Resources are sorted, and only the full one is flagged
+	if !strings.Contains(lines[2], "pods") || !strings.Contains(lines[2], "⚠ 100%") {
+		t.Errorf("pods line = %q", lines[2])
+	}
+	if !strings.Contains(lines[3], "requests.memory") || !strings.Contains(lines[3], "25%") || strings.Contains(lines[3], "⚠") {
+		t.Errorf("memory line = %q", lines[3])
+	}
+}
+
+func TestFormatWarningEvents(t *testing.T) {
+	got := FormatWarningEvents([]EventInfo{
+		{Age: "2m", Object: "Pod/web-1", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 4},
+		{Age: "5m", Object: "Pod/web-2", Reason: "FailedMount", Message: "secret not found", Count: 1},
+	})
+	want := "2m     Pod/web-1 BackOff (x4)\n       Back-off restarting failed container\n" +
+		"5m     Pod/web-2 FailedMount\n       secret not found\n"
+	if got != want {
+		t.Errorf("FormatWarningEvents() =\n%s\nwant\n%s", got, want)
+	}
+}
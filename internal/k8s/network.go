@@ -0,0 +1,100 @@
+package k8s
+
+import (
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodNetwork is how a pod is addressed and resolves names
+type PodNetwork struct {
+	IPs         []string // every family's address, IPv4 first as assigned
+	HostNetwork bool
+	DNSPolicy   string
+	Nameservers []string // from dnsConfig, added to or replacing the policy's
+	Searches    []string
+	Options     []string
+	Hints       []string // dnsPolicy and hostNetwork combinations that misbehave
+}
+
+// GetPodNetwork reads the pod's addresses and DNS settings, and explains
+// combinations that do not resolve names the way they look like they would.
+// Node agents get no hostNetwork or dnsPolicy Default hints: they use them
+// on purpose.
+func GetPodNetwork(pod *corev1.Pod) PodNetwork {
+	n := PodNetwork{
+		HostNetwork: pod.Spec.HostNetwork,
+		DNSPolicy:   string(pod.Spec.DNSPolicy),
+	}
+	if n.DNSPolicy == "" {
+		n.DNSPolicy = string(corev1.DNSClusterFirst)
+	}
+	for _, ip := range pod.Status.PodIPs {
+		n.IPs = append(n.IPs, ip.IP)
+	}
+	if len(n.IPs) == 0 && pod.Status.PodIP != "" {
+		n.IPs = []string{pod.Status.PodIP}
+	}
+	if cfg := pod.Spec.DNSConfig; cfg != nil {
+		n.Nameservers = cfg.Nameservers
+		n.Searches = cfg.Searches
+		for _, o := range cfg.Options {
+			if o.Value != nil {
+				n.Options = append(n.Options, o.Name+":"+*o.Value)
+			} else {
+				n.Options = append(n.Options, o.Name)
+			}
+		}
+	}
+
+	agent := isNodeAgent(pod)
+	switch corev1.DNSPolicy(n.DNSPolicy) {
+	case corev1.DNSClusterFirst:
+		if n.HostNetwork && !agent {
+			n.Hints = append(n.Hints, "hostNetwork with dnsPolicy ClusterFirst uses the node's resolv.conf, so Service names do not resolve; use ClusterFirstWithHostNet")
+		}
+	case corev1.DNSDefault:
+		if !agent {
+			n.Hints = append(n.Hints, "dnsPolicy Default uses the node's resolvers, not cluster DNS, so Service names do not resolve; ClusterFirst is the actual default")
+		}
+	case corev1.DNSClusterFirstWithHostNet:
+		if !n.HostNetwork {
+			n.Hints = append(n.Hints, "ClusterFirstWithHostNet only differs from ClusterFirst with hostNetwork, which this pod does not use")
+		}
+	case corev1.DNSNone:
+		if len(n.Nameservers) == 0 {
+			n.Hints = append(n.Hints, "dnsPolicy None without dnsConfig nameservers leaves the pod with no resolver")
+		}
+	}
+	if n.HostNetwork && len(n.IPs) > 0 && !agent {
+		n.Hints = append(n.Hints, "hostNetwork shares the node's IP and ports; container ports can clash with other pods on the node")
+	}
+	return n
+}
+
+// isNodeAgent reports a pod that serves its node rather than the cluster's
+// workloads, such as CNI plugins, kube-proxy, node exporters and CoreDNS:
+// one run by a DaemonSet, a static pod, or anything in kube-system
+func isNodeAgent(pod *corev1.Pod) bool {
+	if pod.Namespace == metav1.NamespaceSystem {
+		return true
+	}
+	owner := metav1.GetControllerOf(pod)
+	return owner != nil && (owner.Kind == "DaemonSet" || owner.Kind == "Node")
+}
+
+// IPFamilies labels each address with its family, e.g.
+// "10.1.0.4 (IPv4), fd00::4 (IPv6)" for a dual-stack pod
+func IPFamilies(ips []string) string {
+	parts := make([]string, len(ips))
+	for i, ip := range ips {
+		family := "IPv4"
+		if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+			family = "IPv6"
+		}
+		parts[i] = ip + " (" + family + ")"
+	}
+	return strings.Join(parts, ", ")
+}
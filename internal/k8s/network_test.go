@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPodNetwork(t *testing.T) {
+	ndots := "2"
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			DNSConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"1.1.1.1"},
+				Options:     []corev1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}, {Name: "edns0"}},
+			},
+		},
+		Status: corev1.PodStatus{
+			PodIP:  "10.1.0.4",
+			PodIPs: []corev1.PodIP{{IP: "10.1.0.4"}, {IP: "fd00::4"}},
+		},
+	}
+	n := GetPodNetwork(pod)
+	if n.DNSPolicy != "ClusterFirst" || len(n.Hints) != 0 {
+		t.Errorf("defaults: policy %q, hints %q", n.DNSPolicy, n.Hints)
+	}
+	if got := IPFamilies(n.IPs); got != "10.1.0.4 (IPv4), fd00::4 (IPv6)" {
+		t.Errorf("IPFamilies() = %q", got)
+	}
+	if strings.Join(n.Options, " ") != "ndots:2 edns0" {
+		t.Errorf("Options = %q", n.Options)
+	}
+
+	tests := []struct {
+		name        string
+		hostNetwork bool
+		policy      corev1.DNSPolicy
+		dnsConfig   *corev1.PodDNSConfig
+		want        []string
+	}{
+		{"host network, cluster first", true, "", nil, []string{"use ClusterFirstWithHostNet", "shares the node's IP"}},
+		{"host network done right", true, corev1.DNSClusterFirstWithHostNet, nil, []string{"shares the node's IP"}},
+		{"default policy", false, corev1.DNSDefault, nil, []string{"not cluster DNS"}},
+		{"with host net, no host network", false, corev1.DNSClusterFirstWithHostNet, nil, []string{"does not use"}},
+		{"none without nameservers", false, corev1.DNSNone, &corev1.PodDNSConfig{Searches: []string{"x"}}, []string{"no resolver"}},
+		{"none with nameservers", false, corev1.DNSNone, &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.10"}}, nil},
+	}
+	for _, tt := range tests {
+		pod := &corev1.Pod{
+			Spec:   corev1.PodSpec{HostNetwork: tt.hostNetwork, DNSPolicy: tt.policy, DNSConfig: tt.dnsConfig},
+			Status: corev1.PodStatus{PodIP: "192.168.1.5"},
+		}
+		hints := GetPodNetwork(pod).Hints
+		if len(hints) != len(tt.want) {
+			t.Errorf("%s: hints = %q", tt.name, hints)
+			continue
+		}
+		for i, want := range tt.want {
+			if !strings.Contains(hints[i], want) {
+				t.Errorf("%s: hint %d = %q, want it to mention %q", tt.name, i, hints[i], want)
+			}
+		}
+	}
+
+	// Node agents use the node's network and resolvers on purpose
+	isController := true
+	for _, meta := range []metav1.ObjectMeta{
+		{Namespace: "kube-system", Name: "coredns-5d78c9869d-x2x4q"},
+		{Namespace: "monitoring", Name: "node-exporter-7xk2p", OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "node-exporter", Controller: &isController}}},
+	} {
+		for _, policy := range []corev1.DNSPolicy{corev1.DNSClusterFirst, corev1.DNSDefault} {
+			pod := &corev1.Pod{
+				ObjectMeta: meta,
+				Spec:       corev1.PodSpec{HostNetwork: true, DNSPolicy: policy},
+				Status:     corev1.PodStatus{PodIP: "192.168.1.5"},
+			}
+			if hints := GetPodNetwork(pod).Hints; len(hints) != 0 {
+				t.Errorf("%s with %s: hints = %q", meta.Name, policy, hints)
+			}
+		}
+	}
+}
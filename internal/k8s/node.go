@@ -0,0 +1,370 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NodeSummary is what the pod dashboard shows about the node a pod runs on
+type NodeSummary struct {
+	Name             string
+	ProviderID       string // e.g. "aws:///us-east-1a/i-0abc", used for console links
+	ContainerRuntime string // e.g. "containerd://1.7.2"
+	KubeletVersion   string
+	OSImage          string
+	Architecture     string
+	// Placement explains why the pod was put on the node, see ExplainPlacement
+	Placement []PlacementReason
+	// Helpers flags the node's pressure and readiness conditions, see
+	// AnalyzeNodeConditions
+	Helpers []DebugHelper
+}
+
+// GetNodeSummary fetches the node a pod runs on and flags its conditions;
+// with the pod given, the summary also explains the placement
+func GetNodeSummary(ctx context.Context, clientset *kubernetes.Clientset, nodeName string, pod *corev1.Pod) (*NodeSummary, error) {
+	if nodeName == "" {
+		return nil, fmt.Errorf("pod is not scheduled to a node")
+	}
+	node, err := clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	info := node.Status.NodeInfo
+	summary := &NodeSummary{
+		Name:             node.Name,
+		ProviderID:       node.Spec.ProviderID,
+		ContainerRuntime: info.ContainerRuntimeVersion,
+		KubeletVersion:   info.KubeletVersion,
+		OSImage:          info.OSImage,
+		Architecture:     info.Architecture,
+		Helpers:          AnalyzeNodeConditions(node, time.Now()),
+	}
+	if pod != nil {
+		summary.Placement = ExplainPlacement(pod, node)
+	}
+	return summary, nil
+}
+
+// NodeDetail is the node view's answer to "is the node the problem": its
+// condition, how full it is and what runs on it
+type NodeDetail struct {
+	Name             string
+	State            string // e.g. "Ready, MemoryPressure"
+	Roles            []string
+	Age              string
+	KubeletVersion   string
+	ContainerRuntime string
+	OSImage          string
+	Architecture     string
+	Conditions       []corev1.NodeCondition
+	Taints           []corev1.Taint
+	Resources        []NodeResource
+	Pods             []NodePod
+	PodsHidden       bool // listing pods in every namespace is forbidden, so Pods and the requests are unknown
+}
+
+// NodeResource compares what the scheduler may place on the node with what
+// the pods on it request
+type NodeResource struct {
+	Name        corev1.ResourceName
+	Allocatable resource.Quantity
+	Requested   resource.Quantity
+	Limits      resource.Quantity
+}
+
+// RequestedPercent is the share of allocatable the pods request
+func (r NodeResource) RequestedPercent() int {
+	return quantityPercent(r.Requested, r.Allocatable)
+}
+
+// LimitsPercent is the share of allocatable the pods' limits add up to; it
+// can exceed 100 on an overcommitted node
+func (r NodeResource) LimitsPercent() int {
+	return quantityPercent(r.Limits, r.Allocatable)
+}
+
+// NodePod is a pod scheduled on the node with what it requests
+type NodePod struct {
+	Namespace  string
+	Name       string
+	Status     string
+	CPURequest resource.Quantity
+	MemRequest resource.Quantity
+}
+
+// GetNodeDetail reads a node and the pods scheduled on it in every namespace
+func GetNodeDetail(ctx context.Context, clientset *kubernetes.Clientset, name string) (*NodeDetail, error) {
+	node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if apierrors.IsForbidden(err) {
+		// Namespace-scoped access still shows the node itself
+		d := nodeDetail(node, nil)
+		d.PodsHidden = true
+		return d, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nodeDetail(node, pods.Items), nil
+}
+
+func nodeDetail(node *corev1.Node, pods []corev1.Pod) *NodeDetail {
+	info := node.Status.NodeInfo
+	d := &NodeDetail{
+		Name:             node.Name,
+		Roles:            nodeRoles(node),
+		Age:              formatAge(node.CreationTimestamp.Time),
+		KubeletVersion:   info.KubeletVersion,
+		ContainerRuntime: info.ContainerRuntimeVersion,
+		OSImage:          info.OSImage,
+		Architecture:     info.Architecture,
+		Conditions:       node.Status.Conditions,
+		Taints:           node.Spec.Taints,
+	}
+	d.State, _ = nodeState(node)
+
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	active := int64(0)
+	for i := range pods {
+		p := &pods[i]
+		req, lim := podResources(&p.Spec)
+		d.Pods = append(d.Pods, NodePod{
+			Namespace:  p.Namespace,
+			Name:       p.Name,
+			Status:     getPodStatus(p),
+			CPURequest: req[corev1.ResourceCPU],
+			MemRequest: req[corev1.ResourceMemory],
+		})
+		// Finished pods no longer hold their share of the node
+		if p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		active++
+		addResources(requests, req)
+		addResources(limits, lim)
+	}
+	requests[corev1.ResourcePods] = *resource.NewQuantity(active, resource.DecimalSI)
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage, corev1.ResourcePods} {
+		alloc, ok := node.Status.Allocatable[name]
+		if !ok {
+			continue
+		}
+		d.Resources = append(d.Resources, NodeResource{
+			Name:        name,
+			Allocatable: alloc,
+			Requested:   requests[name],
+			Limits:      limits[name],
+		})
+	}
+
+	sort.Slice(d.Pods, func(i, j int) bool {
+		if d.Pods[i].Namespace != d.Pods[j].Namespace {
+			return d.Pods[i].Namespace < d.Pods[j].Namespace
+		}
+		return d.Pods[i].Name < d.Pods[j].Name
+	})
+	return d
+}
+
+// podResources is what the scheduler reserves for a pod: the containers'
+// sum, or the largest init container when that is more, plus the overhead
+func podResources(spec *corev1.PodSpec) (corev1.ResourceList, corev1.ResourceList) {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, c := range spec.Containers {
+		addResources(requests, c.Resources.Requests)
+		addResources(limits, c.Resources.Limits)
+	}
+	for _, c := range spec.InitContainers {
+		maxResources(requests, c.Resources.Requests)
+		maxResources(limits, c.Resources.Limits)
+	}
+	addResources(requests, spec.Overhead)
+	addResources(limits, spec.Overhead)
+	return requests, limits
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, q := range add {
+		sum := total[name]
+		sum.Add(q)
+		total[name] = sum
+	}
+}
+
+func maxResources(total, other corev1.ResourceList) {
+	for name, q := range other {
+		if cur, ok := total[name]; !ok || q.Cmp(cur) > 0 {
+			total[name] = q.DeepCopy()
+		}
+	}
+}
+
+func quantityPercent(part, whole resource.Quantity) int {
+	if whole.IsZero() {
+		return 0
+	}
+	return int(part.MilliValue() * 100 / whole.MilliValue())
+}
+
+// nodeRoles reads the node-role.kubernetes.io/<role> labels
+func nodeRoles(node *corev1.Node) []string {
+	var roles []string
+	for _, k := range sortedKeys(node.Labels) {
+		if role, ok := strings.CutPrefix(k, "node-role.kubernetes.io/"); ok && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// FormatNodeDetail renders the node detail panel
+func FormatNodeDetail(d *NodeDetail) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "State:    %s\n", d.State)
+	if len(d.Roles) > 0 {
+		fmt.Fprintf(&b, "Roles:    %s\n", strings.Join(d.Roles, ", "))
+	}
+	fmt.Fprintf(&b, "Kubelet:  %s\n", d.KubeletVersion)
+	fmt.Fprintf(&b, "Runtime:  %s\n", d.ContainerRuntime)
+	fmt.Fprintf(&b, "OS:       %s (%s)\n", d.OSImage, d.Architecture)
+	fmt.Fprintf(&b, "Age:      %s\n", d.Age)
+
+	b.WriteString("\nRESOURCE            ALLOCATABLE  REQUESTED       LIMITS\n")
+	for _, r := range d.Resources {
+		if d.PodsHidden {
+			fmt.Fprintf(&b, "%-18s  %-11s  %-14s  %s\n", r.Name, formatQuantity(r.Name, r.Allocatable), "?", "?")
+			continue
+		}
+		fmt.Fprintf(&b, "%-18s  %-11s  %-14s  %s\n", r.Name,
+			formatQuantity(r.Name, r.Allocatable),
+			fmt.Sprintf("%s (%d%%)", formatQuantity(r.Name, r.Requested), r.RequestedPercent()),
+			fmt.Sprintf("%s (%d%%)", formatQuantity(r.Name, r.Limits), r.LimitsPercent()))
+	}
+
+	b.WriteString("\nCONDITIONS\n")
+	for _, c := range d.Conditions {
+		marker := "  "
+		if nodeConditionBad(c) {
+			marker = "✗ "
+		}
+		fmt.Fprintf(&b, "%s%-18s  %-7s  %s", marker, c.Type, c.Status, c.Reason)
+		if c.Message != "" {
+			b.WriteString(": " + c.Message)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\nTAINTS\n")
+	if len(d.Taints) == 0 {
+		b.WriteString("  none\n")
+	}
+	for i := range d.Taints {
+		b.WriteString("  " + d.Taints[i].ToString() + "\n")
+	}
+
+	if d.PodsHidden {
+		b.WriteString("\nPODS\n  not visible: listing pods in every namespace is forbidden\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\nPODS (%d)\n", len(d.Pods))
+	nsWidth, nameWidth := len("NAMESPACE"), len("NAME")
+	for _, p := range d.Pods {
+		nsWidth = max(nsWidth, len(p.Namespace))
+		nameWidth = max(nameWidth, len(p.Name))
+	}
+	format := "  %-" + strconv.Itoa(nsWidth) + "s  %-" + strconv.Itoa(nameWidth) + "s  %-18s  %-8s  %s\n"
+	fmt.Fprintf(&b, format, "NAMESPACE", "NAME", "STATUS", "CPU REQ", "MEM REQ")
+	for _, p := range d.Pods {
+		fmt.Fprintf(&b, format, p.Namespace, p.Name, p.Status,
+			formatQuantity(corev1.ResourceCPU, p.CPURequest), formatQuantity(corev1.ResourceMemory, p.MemRequest))
+	}
+	return b.String()
+}
+
+// nodeConditionBad reports a condition in its unhealthy state: Ready not
+// True, or any pressure True
+func nodeConditionBad(c corev1.NodeCondition) bool {
+	if c.Type == corev1.NodeReady {
+		return c.Status != corev1.ConditionTrue
+	}
+	return c.Status == corev1.ConditionTrue
+}
+
+func formatQuantity(name corev1.ResourceName, q resource.Quantity) string {
+	switch name {
+	case corev1.ResourceCPU:
+		return formatCPU(q.MilliValue())
+	case corev1.ResourceMemory, corev1.ResourceEphemeralStorage:
+		return formatMemory(q.Value())
+	}
+	return q.String()
+}
+
+// nodePressureEffects explains what each pressure condition does to the pods
+// on the node, which otherwise look like they fail at random
+var nodePressureEffects = map[corev1.NodeConditionType]string{
+	corev1.NodeMemoryPressure: "The kubelet evicts pods to reclaim memory, BestEffort and over-request pods first",
+	corev1.NodeDiskPressure:   "The kubelet removes unused images and evicts pods; new pods fail to pull images",
+	corev1.NodePIDPressure:    "Processes fail to fork and the kubelet evicts pods to free process IDs",
+}
+
+// AnalyzeNodeConditions turns a node that is NotReady or under memory, disk
+// or PID pressure into High severity hints naming the node
+func AnalyzeNodeConditions(node *corev1.Node, now time.Time) []DebugHelper {
+	var helpers []DebugHelper
+	for _, c := range node.Status.Conditions {
+		since := ""
+		if !c.LastTransitionTime.IsZero() {
+			since = " for " + FormatDuration(now.Sub(c.LastTransitionTime.Time))
+		}
+		var h DebugHelper
+		switch {
+		case c.Type == corev1.NodeReady && c.Status != corev1.ConditionTrue:
+			state := "NotReady"
+			if c.Status == corev1.ConditionUnknown {
+				state = "Unknown (the kubelet stopped reporting)"
+			}
+			h = DebugHelper{
+				Issue: fmt.Sprintf("Node %s is %s%s", node.Name, state, since),
+				Suggestions: []string{
+					"Pods on it stop being updated and are evicted once their not-ready toleration (5m by default) runs out",
+					"Check the kubelet and container runtime on the node",
+				},
+			}
+		case nodePressureEffects[c.Type] != "" && c.Status == corev1.ConditionTrue:
+			h = DebugHelper{
+				Issue:       fmt.Sprintf("Node %s has %s%s", node.Name, c.Type, since),
+				Suggestions: []string{nodePressureEffects[c.Type]},
+			}
+		default:
+			continue
+		}
+		h.Severity = "High"
+		if c.Message != "" {
+			h.Suggestions = append(h.Suggestions, c.Message)
+		}
+		h.Suggestions = append(h.Suggestions, "Open the node (t, nodes) to see what else runs on it")
+		helpers = append(helpers, h)
+	}
+	return helpers
+}
@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeDetail(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{"node-role.kubernetes.io/worker": ""},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+				corev1.ResourcePods:   resource.MustParse("10"),
+			},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasInsufficientMemory"},
+			},
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.29.1"},
+		},
+	}
+
+	requests := func(cpu, mem string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(mem),
+		}}
+	}
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec: corev1.PodSpec{
+				// The init container asks for more CPU than the app, so it sets the CPU request
+				InitContainers: []corev1.Container{{Name: "migrate", Resources: requests("1", "128Mi")}},
+				Containers:     []corev1.Container{{Name: "app", Resources: requests("500m", "1Gi")}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "kube-system"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "agent", Resources: requests("100m", "1Gi")}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: "shop"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "job", Resources: requests("1", "1Gi")}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	}
+
+	d := nodeDetail(node, pods)
+	if d.State != "Ready, MemoryPressure" || len(d.Roles) != 1 || d.Roles[0] != "worker" {
+		t.Errorf("state = %q, roles = %v", d.State, d.Roles)
+	}
+	if len(d.Pods) != 3 || d.Pods[0].Namespace != "kube-system" {
+		t.Errorf("pods = %+v", d.Pods)
+	}
+
+	want := map[corev1.ResourceName]int{
+		corev1.ResourceCPU:    55, // 1 + 100m of 2, the finished pod not counted
+		corev1.ResourceMemory: 50, // 2Gi of 4Gi
+		corev1.ResourcePods:   20, // 2 of 10
+	}
+	if len(d.Resources) != len(want) {
+		t.Fatalf("got %d resources, want %d", len(d.Resources), len(want))
+	}
+	for _, r := range d.Resources {
+		if got := r.RequestedPercent(); got != want[r.Name] {
+			t.Errorf("%s requested = %d%%, want %d%%", r.Name, got, want[r.Name])
+		}
+	}
+
+	out := FormatNodeDetail(d)
+	for _, s := range []string{"Kubelet:  v1.29.1", "✗ MemoryPressure", "dedicated=db:NoSchedule", "PODS (3)", "1.10 (55%)"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("detail missing %q:\n%s", s, out)
+		}
+	}
+
+	hidden := nodeDetail(node, nil)
+	hidden.PodsHidden = true
+	out = FormatNodeDetail(hidden)
+	if !strings.Contains(out, "not visible") || strings.Contains(out, "(0%)") {
+		t.Errorf("detail without pods claims requests:\n%s", out)
+	}
+}
+
+func TestAnalyzeNodeConditions(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tenMinutesAgo := metav1.NewTime(now.Add(-10 * time.Minute))
+	tests := []struct {
+		name       string
+		conditions []corev1.NodeCondition
+		want       []string
+	}{
+		{"healthy", []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+		}, nil},
+		{"memory pressure", []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, LastTransitionTime: tenMinutesAgo},
+		}, []string{"Node node-1 has MemoryPressure for 10m0s"}},
+		{"not ready with disk and pid pressure", []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+			{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+			{Type: corev1.NodePIDPressure, Status: corev1.ConditionTrue},
+		}, []string{"Node node-1 is NotReady", "Node node-1 has DiskPressure", "Node node-1 has PIDPressure"}},
+		{"stopped reporting", []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Message: "Kubelet stopped posting node status."},
+		}, []string{"Node node-1 is Unknown (the kubelet stopped reporting)"}},
+	}
+	for _, tt := range tests {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Status: corev1.NodeStatus{Conditions: tt.conditions}}
+		helpers := AnalyzeNodeConditions(node, now)
+		if len(helpers) != len(tt.want) {
+			t.Errorf("%s: got %d hints, want %d: %+v", tt.name, len(helpers), len(tt.want), helpers)
+			continue
+		}
+		for i, h := range helpers {
+			if h.Issue != tt.want[i] || h.Severity != "High" {
+				t.Errorf("%s: hint %d = %q (%s), want %q", tt.name, i, h.Issue, h.Severity, tt.want[i])
+			}
+		}
+	}
+}
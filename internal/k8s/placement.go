@@ -0,0 +1,105 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PlacementReason is one part of a pod's spec that steered it to its node,
+// and whether the node satisfies it
+type PlacementReason struct {
+	Kind    string // nodeSelector, required affinity, preferred affinity, toleration, taint or topology spread
+	Detail  string
+	Matched bool
+	Weight  int32 // for preferred affinity terms
+}
+
+// ExplainPlacement lists what in the pod's spec, read against the node's
+// labels and taints, explains why the pod runs there: the node selector,
+// node affinity terms, tolerated taints and topology spread domains. An
+// empty result means nothing constrained the choice and the scheduler went
+// by score alone.
+func ExplainPlacement(pod *corev1.Pod, node *corev1.Node) []PlacementReason {
+	var reasons []PlacementReason
+	spec := &pod.Spec
+
+	for _, k := range sortedKeys(spec.NodeSelector) {
+		v := spec.NodeSelector[k]
+		reasons = append(reasons, PlacementReason{
+			Kind: "nodeSelector", Detail: k + "=" + v, Matched: node.Labels[k] == v,
+		})
+	}
+
+	if a := spec.Affinity; a != nil && a.NodeAffinity != nil {
+		if req := a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; req != nil {
+			for _, term := range req.NodeSelectorTerms {
+				reasons = append(reasons, PlacementReason{
+					Kind: "required affinity", Detail: nodeSelectorTermText(term), Matched: nodeMatchesTerm(node, term),
+				})
+			}
+		}
+		for _, pref := range a.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			reasons = append(reasons, PlacementReason{
+				Kind: "preferred affinity", Detail: nodeSelectorTermText(pref.Preference),
+				Matched: nodeMatchesTerm(node, pref.Preference), Weight: pref.Weight,
+			})
+		}
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if toleratesTaint(spec.Tolerations, taint) {
+			reasons = append(reasons, PlacementReason{Kind: "toleration", Detail: taint.ToString(), Matched: true})
+		} else if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			// Scheduled despite a taint it only counts against the node's score
+			reasons = append(reasons, PlacementReason{Kind: "taint", Detail: taint.ToString()})
+		}
+	}
+
+	for _, c := range spec.TopologySpreadConstraints {
+		domain, ok := node.Labels[c.TopologyKey]
+		detail := fmt.Sprintf("%s=%s, maxSkew %d, %s", c.TopologyKey, domain, c.MaxSkew, c.WhenUnsatisfiable)
+		if !ok {
+			detail = fmt.Sprintf("node has no %s label, %s", c.TopologyKey, c.WhenUnsatisfiable)
+		}
+		reasons = append(reasons, PlacementReason{Kind: "topology spread", Detail: detail, Matched: ok})
+	}
+	return reasons
+}
+
+// PreferenceScore sums the weights of the preferred affinity terms the node
+// matches, out of the total weight of all of them
+func PreferenceScore(reasons []PlacementReason) (matched, total int32) {
+	for _, r := range reasons {
+		if r.Kind != "preferred affinity" {
+			continue
+		}
+		total += r.Weight
+		if r.Matched {
+			matched += r.Weight
+		}
+	}
+	return matched, total
+}
+
+// nodeSelectorTermText renders a term as kubectl describe does, e.g.
+// "topology.kubernetes.io/zone in [a b], gpu exists"
+func nodeSelectorTermText(term corev1.NodeSelectorTerm) string {
+	var parts []string
+	for _, reqs := range [][]corev1.NodeSelectorRequirement{term.MatchExpressions, term.MatchFields} {
+		for _, r := range reqs {
+			op := strings.ToLower(string(r.Operator))
+			if len(r.Values) == 0 {
+				parts = append(parts, r.Key+" "+op)
+				continue
+			}
+			parts = append(parts, fmt.Sprintf("%s %s %v", r.Key, op, r.Values))
+		}
+	}
+	if len(parts) == 0 {
+		return "(empty term)"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExplainPlacement(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{
+			"disk": "ssd", "topology.kubernetes.io/zone": "eu-west-1a",
+		}},
+		Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+		}},
+	}
+	zone := func(zones ...string) corev1.NodeSelectorTerm {
+		return corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: zones},
+		}}
+	}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		NodeSelector: map[string]string{"disk": "ssd"},
+		Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{zone("eu-west-1a", "eu-west-1b")},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+				{Weight: 80, Preference: zone("eu-west-1a")},
+				{Weight: 20, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "gpu", Operator: corev1.NodeSelectorOpExists},
+				}}},
+			},
+		}},
+		Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "db", Effect: corev1.TaintEffectNoSchedule}},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule},
+			{MaxSkew: 2, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway},
+		},
+	}}
+
+	var got []string
+	for _, r := range ExplainPlacement(pod, node) {
+		mark := "+"
+		if !r.Matched {
+			mark = "-"
+		}
+		got = append(got, mark+" "+r.Kind+": "+r.Detail)
+	}
+	want := []string{
+		"+ nodeSelector: disk=ssd",
+		"+ required affinity: topology.kubernetes.io/zone in [eu-west-1a eu-west-1b]",
+		"+ preferred affinity: topology.kubernetes.io/zone in [eu-west-1a]",
+		"- preferred affinity: gpu exists",
+		"+ toleration: dedicated=db:NoSchedule",
+		"- taint: spot:PreferNoSchedule",
+		"+ topology spread: topology.kubernetes.io/zone=eu-west-1a, maxSkew 1, DoNotSchedule",
+		"- topology spread: node has no kubernetes.io/hostname label, ScheduleAnyway",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ExplainPlacement() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if matched, total := PreferenceScore(ExplainPlacement(pod, node)); matched != 80 || total != 100 {
+		t.Errorf("PreferenceScore() = %d/%d, want 80/100", matched, total)
+	}
+	if reasons := ExplainPlacement(&corev1.Pod{}, &corev1.Node{}); len(reasons) != 0 {
+		t.Errorf("an unconstrained pod got %v", reasons)
+	}
+}
@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// podQuotaResources are the quota items a new pod counts against; once one
+// is used up the controllers' pod creations are rejected
+var podQuotaResources = map[corev1.ResourceName]bool{
+	corev1.ResourcePods:                     true,
+	"count/pods":                            true,
+	corev1.ResourceCPU:                      true,
+	corev1.ResourceMemory:                   true,
+	corev1.ResourceEphemeralStorage:         true,
+	corev1.ResourceRequestsCPU:              true,
+	corev1.ResourceRequestsMemory:           true,
+	corev1.ResourceRequestsEphemeralStorage: true,
+	corev1.ResourceLimitsCPU:                true,
+	corev1.ResourceLimitsMemory:             true,
+	corev1.ResourceLimitsEphemeralStorage:   true,
+}
+
+// PDBHelpers flags PodDisruptionBudgets covering the pod that allow no
+// disruption right now, so evicting it, or draining its node, waits
+func PDBHelpers(pdbs []PDBInfo) []DebugHelper {
+	var helpers []DebugHelper
+	for _, pdb := range pdbs {
+		if pdb.DisruptionsAllowed > 0 {
+			continue
+		}
+		helpers = append(helpers, DebugHelper{
+			Issue: fmt.Sprintf("Eviction blocked by PDB %s (%s): %d of %d required pods healthy",
+				pdb.Name, pdb.Budget, pdb.CurrentHealthy, pdb.DesiredHealthy),
+			Severity: "Medium",
+			Suggestions: []string{
+				"Evicting this pod, or draining its node, waits until the budget allows a disruption",
+				"A budget that allows none even when every pod is healthy blocks node drains for good",
+				"kubectl get pdb " + pdb.Name + " -o yaml",
+			},
+		})
+	}
+	return helpers
+}
+
+// QuotaHelpers flags ResourceQuota items a pod counts against that are used
+// up, naming the item, since new pods of any workload then fail to create
+func QuotaHelpers(quotas []corev1.ResourceQuota) []DebugHelper {
+	var helpers []DebugHelper
+	for _, q := range quotas {
+		var names []string
+		for name := range q.Status.Hard {
+			if podQuotaResources[name] {
+				names = append(names, string(name))
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			hard := q.Status.Hard[corev1.ResourceName(name)]
+			used, ok := q.Status.Used[corev1.ResourceName(name)]
+			if !ok || used.Cmp(hard) < 0 {
+				continue
+			}
+			helpers = append(helpers, DebugHelper{
+				Issue:    fmt.Sprintf("Quota exceeded: pods cannot be created (%s %s: %s of %s used)", q.Name, name, used.String(), hard.String()),
+				Severity: "High",
+				Suggestions: []string{
+					"New pods are rejected with FailedCreate on their ReplicaSet or Job, so rollouts and scale-ups stall",
+					"Free up " + name + " in the namespace or raise the quota",
+					"kubectl describe resourcequota " + q.Name,
+				},
+			})
+		}
+	}
+	return helpers
+}
+
+// GetPolicyHelpers flags the PodDisruptionBudgets blocking the pod's
+// eviction and the ResourceQuotas of its namespace that are used up. Either
+// is left out when it cannot be listed.
+func GetPolicyHelpers(ctx context.Context, clientset *kubernetes.Clientset, pod *PodInfo) []DebugHelper {
+	var helpers []DebugHelper
+	if pdbs, err := clientset.PolicyV1().PodDisruptionBudgets(pod.Namespace).List(ctx, metav1.ListOptions{}); err == nil {
+		helpers = append(helpers, PDBHelpers(matchPDBs(pdbs.Items, pod.Labels))...)
+	}
+	if quotas, err := ListResourceQuotas(ctx, clientset, pod.Namespace); err == nil {
+		helpers = append(helpers, QuotaHelpers(quotas)...)
+	}
+	return helpers
+}
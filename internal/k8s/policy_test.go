@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPDBHelpers(t *testing.T) {
+	got := PDBHelpers([]PDBInfo{
+		{Name: "web", Budget: "minAvailable 2", DisruptionsAllowed: 0, CurrentHealthy: 2, DesiredHealthy: 2},
+		{Name: "api", Budget: "maxUnavailable 1", DisruptionsAllowed: 1, CurrentHealthy: 3, DesiredHealthy: 2},
+	})
+	if len(got) != 1 {
+		t.Fatalf("got %d hints, want 1: %+v", len(got), got)
+	}
+	if want := "Eviction blocked by PDB web (minAvailable 2): 2 of 2 required pods healthy"; got[0].Issue != want {
+		t.Errorf("Issue = %q, want %q", got[0].Issue, want)
+	}
+}
+
+func TestQuotaHelpers(t *testing.T) {
+	quota := func(name string, hard, used corev1.ResourceList) corev1.ResourceQuota {
+		return corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.ResourceQuotaStatus{Hard: hard, Used: used},
+		}
+	}
+	quotas := []corev1.ResourceQuota{
+		quota("compute",
+			corev1.ResourceList{"requests.cpu": resource.MustParse("4"), "requests.memory": resource.MustParse("8Gi")},
+			corev1.ResourceList{"requests.cpu": resource.MustParse("4000m"), "requests.memory": resource.MustParse("2Gi")}),
+		// Only quota items pods count against matter here
+		quota("objects",
+			corev1.ResourceList{"services": resource.MustParse("2"), "pods": resource.MustParse("10")},
+			corev1.ResourceList{"services": resource.MustParse("2"), "pods": resource.MustParse("9")}),
+	}
+
+	got := QuotaHelpers(quotas)
+	if len(got) != 1 {
+		t.Fatalf("got %d hints, want 1: %+v", len(got), got)
+	}
+	if !strings.Contains(got[0].Issue, "compute requests.cpu: 4 of 4 used") || got[0].Severity != "High" {
+		t.Errorf("hint = %q (%s)", got[0].Issue, got[0].Severity)
+	}
+}
@@ -0,0 +1,417 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// serviceForwardRetry is how long a Service forward waits before looking for
+// another ready pod after losing its current one
+const serviceForwardRetry = 2 * time.Second
+
+// PortForward is one forward from a local port to a pod, or to a Service
+// through one of its ready pods, as listed in the port-forward panel
+type PortForward struct {
+	ID          int
+	Namespace   string
+	Service     string // empty when forwarding straight to Pod
+	ServicePort int
+	Pod         string
+	LocalPort   int
+	RemotePort  int // the pod's port
+	Started     time.Time
+	Reconnects  int  // times a Service forward moved to another pod
+	Waiting     bool // a Service forward lost its pod and looks for another
+	Ended       bool
+	Err         error // why the forward ended or is waiting, nil when stopped
+}
+
+// Target describes where the forward goes, e.g. "localhost:8080 → shop/web-0:80"
+// or "localhost:8080 → shop/svc/web:80 (web-6d4b9-x2x7k)"
+func (f PortForward) Target() string {
+	if f.Service == "" {
+		return fmt.Sprintf("localhost:%d → %s/%s:%d", f.LocalPort, f.Namespace, f.Pod, f.RemotePort)
+	}
+	pod := f.Pod
+	if f.Waiting {
+		pod = "no ready pod"
+	}
+	return fmt.Sprintf("localhost:%d → %s/svc/%s:%d (%s)", f.LocalPort, f.Namespace, f.Service, f.ServicePort, pod)
+}
+
+// ForwardManager runs port-forwards in-process through the API server, so
+// they need no kubectl and keep running while the UI is in use
+type ForwardManager struct {
+	client *Client
+
+	mu       sync.Mutex
+	nextID   int
+	forwards map[int]*activeForward
+	lastErr  string // latest connection error from any forward
+}
+
+type activeForward struct {
+	info PortForward
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewForwardManager(client *Client) *ForwardManager {
+	m := &ForwardManager{client: client, forwards: make(map[int]*activeForward)}
+	// client-go reports errors on forwarded connections (e.g. nothing listens
+	// on the pod's port) only through HandleError; its other handlers stay,
+	// main keeps their logging off the UI
+	utilruntime.ErrorHandlers = append(utilruntime.ErrorHandlers, m.recordError)
+	return m
+}
+
+func (m *ForwardManager) recordError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastErr = ShortError(err)
+}
+
+// LastError is the latest error on a forwarded connection, "" when none
+func (m *ForwardManager) LastError() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastErr
+}
+
+// Start forwards localPort to remotePort on the pod and returns once the
+// local port listens. A localPort of 0 picks a free port. The returned
+// channel closes when the forward ends.
+func (m *ForwardManager) Start(namespace, pod string, localPort, remotePort int) (PortForward, <-chan struct{}, error) {
+	config, clientset := m.client.restClients()
+	stop := make(chan struct{})
+	localPort, failed, err := forward(config, clientset, namespace, pod, localPort, remotePort, stop)
+	if err != nil {
+		return PortForward{}, nil, err
+	}
+
+	f := m.add(PortForward{Namespace: namespace, Pod: pod, LocalPort: localPort, RemotePort: remotePort}, stop)
+	// Copied before the goroutine can change it under m.mu
+	info := f.info
+	go func() {
+		err := <-failed
+		m.finish(f, err)
+	}()
+	return info, f.done, nil
+}
+
+// StartService forwards localPort to a Service port through one of the
+// Service's ready pods. When that pod goes away the forward moves to another
+// ready pod on the same local port, so it only ends when stopped. It stays
+// on the cluster it was started on, also across a context switch.
+func (m *ForwardManager) StartService(namespace, service string, localPort, servicePort int) (PortForward, <-chan struct{}, error) {
+	config, clientset := m.client.restClients()
+	pod, podPort, err := ResolveServicePod(context.Background(), clientset, namespace, service, servicePort)
+	if err != nil {
+		return PortForward{}, nil, err
+	}
+	stop := make(chan struct{})
+	localPort, failed, err := forward(config, clientset, namespace, pod, localPort, podPort, stop)
+	if err != nil {
+		return PortForward{}, nil, err
+	}
+
+	f := m.add(PortForward{
+		Namespace:   namespace,
+		Service:     service,
+		ServicePort: servicePort,
+		Pod:         pod,
+		LocalPort:   localPort,
+		RemotePort:  podPort,
+	}, stop)
+	// Copied before the goroutine can change it under m.mu
+	info := f.info
+	go func() {
+		for {
+			err := <-failed
+			select {
+			case <-stop:
+				m.finish(f, nil)
+				return
+			default:
+			}
+			m.update(f, func(info *PortForward) { info.Waiting, info.Err = true, err })
+
+			// Retry until a ready pod takes the forward or it is stopped
+			for failed = nil; failed == nil; {
+				select {
+				case <-stop:
+					m.finish(f, nil)
+					return
+				case <-time.After(serviceForwardRetry):
+				}
+				pod, podPort, err := ResolveServicePod(context.Background(), clientset, namespace, service, servicePort)
+				if err == nil {
+					_, failed, err = forward(config, clientset, namespace, pod, localPort, podPort, stop)
+				}
+				if err != nil {
+					m.update(f, func(info *PortForward) { info.Err = err })
+					continue
+				}
+				m.update(f, func(info *PortForward) {
+					info.Pod, info.RemotePort = pod, podPort
+					info.Waiting, info.Err = false, nil
+					info.Reconnects++
+				})
+			}
+		}
+	}()
+	return info, f.done, nil
+}
+
+// forward starts forwarding through the cluster config and clientset
+// address and waits until the local port listens, returning the port, which
+// differs from localPort when that is 0, and a channel that receives
+// ForwardPorts' result once the forward ends
+func forward(config *rest.Config, clientset *kubernetes.Clientset, namespace, pod string, localPort, remotePort int, stop chan struct{}) (int, <-chan error, error) {
+	dialer, err := portForwardDialer(config, clientset, namespace, pod)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	ready := make(chan struct{})
+	var errOut strings.Builder
+	fw, err := portforward.NewOnAddresses(dialer, []string{"localhost"},
+		[]string{fmt.Sprintf("%d:%d", localPort, remotePort)}, stop, ready, io.Discard, &syncWriter{w: &errOut})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	failed := make(chan error, 1)
+	go func() { failed <- fw.ForwardPorts() }()
+	select {
+	case <-ready:
+	case err := <-failed:
+		if msg := strings.TrimSpace(errOut.String()); msg != "" {
+			err = errors.New(msg)
+		}
+		if err == nil {
+			err = errors.New("port-forward ended before it was ready")
+		}
+		return 0, nil, err
+	}
+
+	if ports, err := fw.GetPorts(); err == nil && len(ports) > 0 {
+		localPort = int(ports[0].Local)
+	}
+	return localPort, failed, nil
+}
+
+func (m *ForwardManager) add(info PortForward, stop chan struct{}) *activeForward {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	info.ID = m.nextID
+	info.Started = time.Now()
+	f := &activeForward{info: info, stop: stop, done: make(chan struct{})}
+	m.forwards[f.info.ID] = f
+	return f
+}
+
+func (m *ForwardManager) update(f *activeForward, change func(*PortForward)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	change(&f.info)
+}
+
+func (m *ForwardManager) finish(f *activeForward, err error) {
+	m.update(f, func(info *PortForward) { info.Ended, info.Waiting, info.Err = true, false, err })
+	close(f.done)
+}
+
+// Stop ends a forward and removes it from the list
+func (m *ForwardManager) Stop(id int) {
+	m.mu.Lock()
+	f, ok := m.forwards[id]
+	running := ok && !f.info.Ended
+	delete(m.forwards, id)
+	m.mu.Unlock()
+	if running {
+		close(f.stop)
+	}
+}
+
+// StopAll ends every forward, e.g. when the program exits
+func (m *ForwardManager) StopAll() {
+	m.mu.Lock()
+	ids := make([]int, 0, len(m.forwards))
+	for id := range m.forwards {
+		ids = append(ids, id)
+	}
+	m.mu.Unlock()
+	for _, id := range ids {
+		m.Stop(id)
+	}
+}
+
+// List returns the forwards, oldest first, including ended ones until they
+// are stopped
+func (m *ForwardManager) List() []PortForward {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := make([]PortForward, 0, len(m.forwards))
+	for _, f := range m.forwards {
+		list = append(list, f.info)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// Get returns one forward by ID
+func (m *ForwardManager) Get(id int) (PortForward, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.forwards[id]
+	if !ok {
+		return PortForward{}, false
+	}
+	return f.info, true
+}
+
+// ResolveServicePod picks a ready pod behind the Service and the pod port
+// servicePort maps to, the way kubectl port-forward svc/... does
+func ResolveServicePod(ctx context.Context, clientset *kubernetes.Clientset, namespace, service string, servicePort int) (string, int, error) {
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, service, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("service %s has no selector", service)
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return servicePodTarget(svc, servicePort, pods.Items)
+}
+
+// GetServicePorts lists a Service's ports for the port-forward prompt
+func GetServicePorts(ctx context.Context, clientset *kubernetes.Clientset, namespace, service string) ([]int32, error) {
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, service, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ports := make([]int32, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		ports = append(ports, p.Port)
+	}
+	return ports, nil
+}
+
+func servicePodTarget(svc *corev1.Service, servicePort int, pods []corev1.Pod) (string, int, error) {
+	var port *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if int(svc.Spec.Ports[i].Port) == servicePort {
+			port = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if port == nil {
+		return "", 0, fmt.Errorf("service %s has no port %d", svc.Name, servicePort)
+	}
+
+	// Prefer the oldest ready pod, the one least likely to be mid-rollout
+	var ready []*corev1.Pod
+	for i := range pods {
+		p := &pods[i]
+		if p.DeletionTimestamp == nil && p.Status.Phase == corev1.PodRunning && isPodReady(p) {
+			ready = append(ready, p)
+		}
+	}
+	if len(ready) == 0 {
+		return "", 0, fmt.Errorf("service %s has no ready pods", svc.Name)
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		if !ready[i].CreationTimestamp.Equal(&ready[j].CreationTimestamp) {
+			return ready[i].CreationTimestamp.Before(&ready[j].CreationTimestamp)
+		}
+		return ready[i].Name < ready[j].Name
+	})
+	pod := ready[0]
+
+	switch {
+	case port.TargetPort.Type == intstr.String:
+		for _, c := range pod.Spec.Containers {
+			for _, cp := range c.Ports {
+				if cp.Name == port.TargetPort.StrVal {
+					return pod.Name, int(cp.ContainerPort), nil
+				}
+			}
+		}
+		return "", 0, fmt.Errorf("pod %s has no port named %s", pod.Name, port.TargetPort.StrVal)
+	case port.TargetPort.IntVal != 0:
+		return pod.Name, int(port.TargetPort.IntVal), nil
+	}
+	return pod.Name, int(port.Port), nil
+}
+
+func portForwardDialer(config *rest.Config, clientset *kubernetes.Clientset, namespace, pod string) (httpstream.Dialer, error) {
+	// The client's request timeout would cut the forward off
+	streamConfig := rest.CopyConfig(config)
+	streamConfig.Timeout = 0
+	transport, upgrader, err := spdy.RoundTripperFor(streamConfig)
+	if err != nil {
+		return nil, err
+	}
+	url := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("portforward").
+		URL()
+	return spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url), nil
+}
+
+// ParsePortPair reads "local:remote", or a single port used for both
+func ParsePortPair(s string) (int, int, error) {
+	localText, remoteText, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		remoteText = localText
+	}
+	local, err := strconv.Atoi(strings.TrimSpace(localText))
+	if err != nil || local < 0 || local > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port %q", localText)
+	}
+	remote, err := strconv.Atoi(strings.TrimSpace(remoteText))
+	if err != nil || remote < 1 || remote > 65535 {
+		return 0, 0, fmt.Errorf("invalid pod port %q", remoteText)
+	}
+	return local, remote, nil
+}
+
+// syncWriter guards a writer the forwarder may use from several goroutines
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
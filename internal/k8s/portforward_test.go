@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestParsePortPair(t *testing.T) {
+	tests := []struct {
+		in      string
+		local   int
+		remote  int
+		wantErr bool
+	}{
+		{in: "8080:80", local: 8080, remote: 80},
+		{in: " 9090 ", local: 9090, remote: 9090},
+		{in: "0:80", local: 0, remote: 80},
+		{in: "x:80", wantErr: true},
+		{in: "80:0", wantErr: true},
+		{in: "70000:80", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		local, remote, err := ParsePortPair(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePortPair(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (local != tt.local || remote != tt.remote) {
+			t.Errorf("ParsePortPair(%q) = %d, %d, want %d, %d", tt.in, local, remote, tt.local, tt.remote)
+		}
+	}
+}
+
+func TestServicePodTarget(t *testing.T) {
+	now := time.Now()
+	pod := func(name string, age time.Duration, ready bool) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Port: 80, TargetPort: intstr.FromString("http")},
+			{Port: 9090, TargetPort: intstr.FromInt(9091)},
+			{Port: 5000},
+		}},
+	}
+	pods := []corev1.Pod{pod("web-new", time.Minute, true), pod("web-old", time.Hour, true), pod("web-broken", 2*time.Hour, false)}
+
+	tests := []struct {
+		port     int
+		wantPod  string
+		wantPort int
+		wantErr  bool
+	}{
+		{port: 80, wantPod: "web-old", wantPort: 8080},
+		{port: 9090, wantPod: "web-old", wantPort: 9091},
+		{port: 5000, wantPod: "web-old", wantPort: 5000},
+		{port: 443, wantErr: true},
+	}
+	for _, tt := range tests {
+		gotPod, gotPort, err := servicePodTarget(svc, tt.port, pods)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("port %d: error = %v, wantErr %v", tt.port, err, tt.wantErr)
+			continue
+		}
+		if gotPod != tt.wantPod || gotPort != tt.wantPort {
+			t.Errorf("port %d: got %s:%d, want %s:%d", tt.port, gotPod, gotPort, tt.wantPod, tt.wantPort)
+		}
+	}
+
+	if _, _, err := servicePodTarget(svc, 80, pods[2:]); err == nil {
+		t.Error("a service without ready pods should fail")
+	}
+}
@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// preemptedMessage matches the scheduler's event on a preempted pod: "Preempted
+// by pod <uid> on node <node>", or "Preempted by <ns>/<name> on node <node>"
+// before Kubernetes 1.22
+var preemptedMessage = regexp.MustCompile(`Preempted by (?:pod )?(\S+) on node (\S+)`)
+
+// Preemption is the scheduler evicting a pod to make room for one of higher
+// priority
+type Preemption struct {
+	By       string // the preemptor as namespace/name, or its UID until resolved
+	Priority *int32 // the preemptor's priority, once resolved
+	Node     string
+	At       time.Time
+}
+
+// PodPriority describes the pod's priority class and value, e.g.
+// "high-priority (1000000)", or "" for a pod without either
+func PodPriority(pod *corev1.Pod) string {
+	if pod == nil || (pod.Spec.PriorityClassName == "" && pod.Spec.Priority == nil) {
+		return ""
+	}
+	var parts []string
+	if pod.Spec.PriorityClassName != "" {
+		parts = append(parts, pod.Spec.PriorityClassName)
+	}
+	if pod.Spec.Priority != nil {
+		parts = append(parts, fmt.Sprintf("(%d)", *pod.Spec.Priority))
+	}
+	if p := pod.Spec.PreemptionPolicy; p != nil && *p == corev1.PreemptNever {
+		parts = append(parts, "never preempts")
+	}
+	return strings.Join(parts, " ")
+}
+
+// FindPreemption tells whether the pod was preempted, from the scheduler's
+// Preempted event or, once that has expired, the DisruptionTarget condition
+// it sets, which does not name the preemptor
+func FindPreemption(pod *corev1.Pod, events []EventInfo) *Preemption {
+	var latest *Preemption
+	for _, e := range events {
+		if e.Reason != "Preempted" {
+			continue
+		}
+		m := preemptedMessage.FindStringSubmatch(e.Message)
+		if m == nil {
+			continue
+		}
+		if latest == nil || e.LastSeen.After(latest.At) {
+			latest = &Preemption{By: m[1], Node: m[2], At: e.LastSeen}
+		}
+	}
+	if latest != nil || pod == nil {
+		return latest
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.DisruptionTarget && c.Status == corev1.ConditionTrue && c.Reason == "PreemptionByScheduler" {
+			return &Preemption{Node: pod.Spec.NodeName, At: c.LastTransitionTime.Time}
+		}
+	}
+	return nil
+}
+
+// ResolvePreemptor names the preemptor recorded by UID, looking for it among
+// the pods on the node, and reads its priority. It leaves p as is when the
+// pod cannot be found, e.g. it has since gone.
+func ResolvePreemptor(ctx context.Context, clientset *kubernetes.Clientset, p *Preemption) {
+	if p.By == "" {
+		return
+	}
+	if ns, name, ok := strings.Cut(p.By, "/"); ok {
+		if pod, err := clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{}); err == nil {
+			p.Priority = pod.Spec.Priority
+		}
+		return
+	}
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", p.Node).String(),
+	})
+	if err != nil {
+		return
+	}
+	for _, pod := range pods.Items {
+		if string(pod.UID) == p.By {
+			p.By = pod.Namespace + "/" + pod.Name
+			p.Priority = pod.Spec.Priority
+			return
+		}
+	}
+}
+
+// PreemptionHelper explains a preemption as a High severity hint
+func PreemptionHelper(p Preemption, now time.Time) DebugHelper {
+	issue := "Preempted by a higher-priority pod"
+	if p.By != "" {
+		issue = "Preempted by " + p.By
+		if p.Priority != nil {
+			issue += fmt.Sprintf(" (priority %d)", *p.Priority)
+		}
+	}
+	if p.Node != "" {
+		issue += " on node " + p.Node
+	}
+	if !p.At.IsZero() {
+		issue += ", " + FormatDuration(now.Sub(p.At)) + " ago"
+	}
+	return DebugHelper{
+		Issue:    issue,
+		Severity: "High",
+		Suggestions: []string{
+			"The scheduler evicted this pod to make room for a pod of higher priority",
+			"Give this workload a PriorityClass at least as high, or add capacity so preemption is not needed",
+			"kubectl get priorityclasses",
+		},
+	}
+}
+
+// GetPreemptionHelpers flags a preempted pod, naming the pod that displaced it
+func GetPreemptionHelpers(ctx context.Context, clientset *kubernetes.Clientset, pod *corev1.Pod, events []EventInfo) []DebugHelper {
+	p := FindPreemption(pod, events)
+	if p == nil {
+		return nil
+	}
+	ResolvePreemptor(ctx, clientset, p)
+	return []DebugHelper{PreemptionHelper(*p, time.Now())}
+}
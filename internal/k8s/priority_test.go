@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodPriority(t *testing.T) {
+	high, never := int32(1000000), corev1.PreemptNever
+	tests := []struct {
+		name string
+		spec corev1.PodSpec
+		want string
+	}{
+		{"none", corev1.PodSpec{}, ""},
+		{"class and value", corev1.PodSpec{PriorityClassName: "critical", Priority: &high}, "critical (1000000)"},
+		{"never preempts", corev1.PodSpec{PriorityClassName: "batch", Priority: &high, PreemptionPolicy: &never}, "batch (1000000) never preempts"},
+	}
+	for _, tt := range tests {
+		if got := PodPriority(&corev1.Pod{Spec: tt.spec}); got != tt.want {
+			t.Errorf("%s: PodPriority() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := PodPriority(nil); got != "" {
+		t.Errorf("PodPriority(nil) = %q", got)
+	}
+}
+
+func TestFindPreemption(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	preempted := &corev1.Pod{
+		Spec: corev1.PodSpec{NodeName: "node-1"},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+			Type:               corev1.DisruptionTarget,
+			Status:             corev1.ConditionTrue,
+			Reason:             "PreemptionByScheduler",
+			LastTransitionTime: metav1.NewTime(now),
+		}}},
+	}
+
+	tests := []struct {
+		name     string
+		pod      *corev1.Pod
+		events   []EventInfo
+		wantBy   string
+		wantNode string
+		wantNone bool
+	}{
+		{"not preempted", &corev1.Pod{}, []EventInfo{{Reason: "Killing", Message: "Stopping container app"}}, "", "", true},
+		{"by uid", nil, []EventInfo{{Reason: "Preempted", Message: "Preempted by pod 5f2c1d9e-aaaa on node node-2", LastSeen: now}}, "5f2c1d9e-aaaa", "node-2", false},
+		{"by name, latest", nil, []EventInfo{
+			{Reason: "Preempted", Message: "Preempted by shop/db-0 on node node-3", LastSeen: now},
+			{Reason: "Preempted", Message: "Preempted by shop/old-0 on node node-4", LastSeen: now.Add(-time.Hour)},
+		}, "shop/db-0", "node-3", false},
+		{"condition only", preempted, nil, "", "node-1", false},
+	}
+	for _, tt := range tests {
+		p := FindPreemption(tt.pod, tt.events)
+		if tt.wantNone {
+			if p != nil {
+				t.Errorf("%s: got %+v, want none", tt.name, p)
+			}
+			continue
+		}
+		if p == nil || p.By != tt.wantBy || p.Node != tt.wantNode {
+			t.Errorf("%s: got %+v, want by %q on %q", tt.name, p, tt.wantBy, tt.wantNode)
+		}
+	}
+
+	prio := int32(1000)
+	h := PreemptionHelper(Preemption{By: "shop/db-0", Priority: &prio, Node: "node-3", At: now.Add(-5 * time.Minute)}, now)
+	if want := "Preempted by shop/db-0 (priority 1000) on node node-3, 5m0s ago"; h.Issue != want || h.Severity != "High" {
+		t.Errorf("PreemptionHelper() = %q (%s), want %q", h.Issue, h.Severity, want)
+	}
+}
@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// minLivenessStartup is the least time a liveness probe should give a
+// container without a startup probe before restarting it; less than this
+// and a slow start turns into a restart loop
+const minLivenessStartup = 10 * time.Second
+
+// livenessKilled matches the kubelet's Killing event for a container that
+// failed its liveness probe
+var livenessKilled = regexp.MustCompile(`Container (\S+) failed liveness probe`)
+
+// AnalyzeProbes flags probe misconfigurations: probes aimed at a port the
+// container does not expose or that does not resolve, liveness probes that
+// restart a container too eagerly, and restarts the events show were caused
+// by the liveness probe rather than a crash
+func AnalyzeProbes(containers []ContainerInfo, events []EventInfo) []DebugHelper {
+	var helpers []DebugHelper
+	for _, c := range containers {
+		hasStartup := slices.ContainsFunc(c.Probes, func(p ProbeInfo) bool { return p.Kind == "startup" })
+		for _, p := range c.Probes {
+			helpers = append(helpers, probePortHelpers(c, p)...)
+			if p.Kind == "liveness" {
+				helpers = append(helpers, livenessTimingHelpers(c.Name, p, hasStartup)...)
+			}
+		}
+	}
+	return append(helpers, livenessRestartHelpers(containers, events)...)
+}
+
+func probePortHelpers(c ContainerInfo, p ProbeInfo) []DebugHelper {
+	if p.Handler != "http" && p.Handler != "tcp" && p.Handler != "grpc" {
+		return nil
+	}
+	if p.Port == 0 && p.PortName != "" {
+		return []DebugHelper{{
+			Issue:    fmt.Sprintf("%s probe of %s uses port %q, which the container does not name", capitalize(p.Kind), c.Name, p.PortName),
+			Severity: "High",
+			Suggestions: []string{
+				"A named probe port must match the name of one of the container's ports, so this probe always fails",
+				"Name the port in the container's ports, or use the port number in the probe",
+			},
+		}}
+	}
+	// Without declared ports, or with a probe aimed at another host, there
+	// is nothing to compare with
+	if p.Host != "" || len(c.Ports) == 0 || slices.Contains(c.Ports, p.Port) {
+		return nil
+	}
+	ports := make([]string, len(c.Ports))
+	for i, port := range c.Ports {
+		ports[i] = fmt.Sprint(port)
+	}
+	return []DebugHelper{{
+		Issue:    fmt.Sprintf("%s probe of %s checks port %d, which the container does not expose (%s)", capitalize(p.Kind), c.Name, p.Port, strings.Join(ports, ", ")),
+		Severity: "Medium",
+		Suggestions: []string{
+			"Make sure the application listens on " + p.Target(),
+			"A probe port that nothing listens on fails with connection refused",
+		},
+	}}
+}
+
+func livenessTimingHelpers(container string, p ProbeInfo, hasStartup bool) []DebugHelper {
+	var helpers []DebugHelper
+	if p.FailureThreshold == 1 {
+		helpers = append(helpers, DebugHelper{
+			Issue:    fmt.Sprintf("Liveness probe of %s restarts it on a single failed check", container),
+			Severity: "Medium",
+			Suggestions: []string{
+				"One slow response, e.g. during a GC pause or a burst of load, restarts the container",
+				"Raise failureThreshold to 3 or more: " + p.Timing(),
+			},
+		})
+	}
+	if budget := p.InitialDelay + p.Period*time.Duration(p.FailureThreshold); !hasStartup && budget < minLivenessStartup {
+		helpers = append(helpers, DebugHelper{
+			Issue:    fmt.Sprintf("Liveness probe of %s restarts it unless it is up within %s", container, FormatDuration(budget)),
+			Severity: "Medium",
+			Suggestions: []string{
+				"A start slower than that, e.g. on a busy node, is restarted before it finishes, over and over",
+				"Add a startupProbe, or raise initialDelaySeconds or failureThreshold: " + p.Timing(),
+			},
+		})
+	}
+	return helpers
+}
+
+// livenessRestartHelpers ties restarts to the liveness probe through the
+// kubelet's Killing events, quoting the probe's latest failure
+func livenessRestartHelpers(containers []ContainerInfo, events []EventInfo) []DebugHelper {
+	killed := make(map[string]bool)
+	var lastFailure EventInfo
+	for _, e := range events {
+		if m := livenessKilled.FindStringSubmatch(e.Message); e.Reason == "Killing" && m != nil {
+			killed[m[1]] = true
+		}
+		if e.Reason == "Unhealthy" && strings.HasPrefix(e.Message, "Liveness probe") && !e.LastSeen.Before(lastFailure.LastSeen) {
+			lastFailure = e
+		}
+	}
+
+	var helpers []DebugHelper
+	for _, c := range containers {
+		if c.RestartCount == 0 || !killed[c.Name] {
+			continue
+		}
+		suggestions := []string{}
+		if lastFailure.Message != "" {
+			suggestions = append(suggestions, lastFailure.Message)
+		}
+		for _, p := range c.Probes {
+			if p.Kind == "liveness" {
+				suggestions = append(suggestions, fmt.Sprintf("The probe: %s, %s", p.Target(), p.Timing()))
+			}
+		}
+		suggestions = append(suggestions,
+			"The kubelet restarted it, not a crash: the logs of the previous run may look healthy",
+			"Check the endpoint answers within the timeout under load, and add a startupProbe if it fails while starting",
+		)
+		helpers = append(helpers, DebugHelper{
+			Issue:       fmt.Sprintf("Container %s restarted %d times after failing its liveness probe", c.Name, c.RestartCount),
+			Severity:    "High",
+			Suggestions: suggestions,
+		})
+	}
+	return helpers
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
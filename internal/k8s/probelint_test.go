@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeProbes(t *testing.T) {
+	liveness := ProbeInfo{Kind: "liveness", Handler: "http", Port: 8080, Path: "/healthz",
+		InitialDelay: 10 * time.Second, Period: 10 * time.Second, Timeout: time.Second, FailureThreshold: 3}
+
+	tests := []struct {
+		name       string
+		containers []ContainerInfo
+		events     []EventInfo
+		want       []string // issue substrings, in order
+	}{
+		{
+			name:       "healthy probe",
+			containers: []ContainerInfo{{Name: "app", Ports: []int32{8080}, Probes: []ProbeInfo{liveness}}},
+		},
+		{
+			name: "unresolved named port",
+			containers: []ContainerInfo{{Name: "app", Ports: []int32{8080},
+				Probes: []ProbeInfo{{Kind: "readiness", Handler: "http", PortName: "metrics"}}}},
+			want: []string{`Readiness probe of app uses port "metrics"`},
+		},
+		{
+			name: "port not exposed",
+			containers: []ContainerInfo{{Name: "app", Ports: []int32{8080},
+				Probes: []ProbeInfo{{Kind: "readiness", Handler: "tcp", Port: 9090}}}},
+			want: []string{"checks port 9090, which the container does not expose (8080)"},
+		},
+		{
+			name: "no declared ports or exec probe",
+			containers: []ContainerInfo{
+				{Name: "app", Probes: []ProbeInfo{{Kind: "readiness", Handler: "tcp", Port: 9090}}},
+				{Name: "sidecar", Ports: []int32{8080}, Probes: []ProbeInfo{{Kind: "readiness", Handler: "exec"}}},
+			},
+		},
+		{
+			name: "single failure restarts",
+			containers: []ContainerInfo{{Name: "app", Probes: []ProbeInfo{
+				{Kind: "liveness", Handler: "http", Port: 8080, InitialDelay: 30 * time.Second, Period: 10 * time.Second, FailureThreshold: 1},
+			}}},
+			want: []string{"restarts it on a single failed check"},
+		},
+		{
+			name: "too little time to start",
+			containers: []ContainerInfo{{Name: "app", Probes: []ProbeInfo{
+				{Kind: "liveness", Handler: "http", Port: 8080, Period: 2 * time.Second, FailureThreshold: 3},
+			}}},
+			want: []string{"unless it is up within 6s"},
+		},
+		{
+			name: "startup probe covers a short liveness budget",
+			containers: []ContainerInfo{{Name: "app", Probes: []ProbeInfo{
+				{Kind: "startup", Handler: "http", Port: 8080, Period: 10 * time.Second, FailureThreshold: 30},
+				{Kind: "liveness", Handler: "http", Port: 8080, Period: 2 * time.Second, FailureThreshold: 3},
+			}}},
+		},
+		{
+			name:       "restarts after liveness failures",
+			containers: []ContainerInfo{{Name: "app", RestartCount: 4, Probes: []ProbeInfo{liveness}}, {Name: "sidecar", RestartCount: 1}},
+			events: []EventInfo{
+				{Reason: "Unhealthy", Message: "Liveness probe failed: HTTP probe failed with statuscode: 500"},
+				{Reason: "Killing", Message: "Container app failed liveness probe, will be restarted"},
+			},
+			want: []string{"Container app restarted 4 times after failing its liveness probe"},
+		},
+		{
+			name:       "restarts without liveness kills",
+			containers: []ContainerInfo{{Name: "app", RestartCount: 4, Probes: []ProbeInfo{liveness}}},
+			events:     []EventInfo{{Reason: "BackOff", Message: "Back-off restarting failed container"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeProbes(tt.containers, tt.events)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d helpers, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i].Issue, want) {
+					t.Errorf("helper %d = %q, want it to contain %q", i, got[i].Issue, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyzeProbesQuotesLastFailure(t *testing.T) {
+	now := time.Now()
+	got := AnalyzeProbes(
+		[]ContainerInfo{{Name: "app", RestartCount: 2, Probes: []ProbeInfo{{Kind: "liveness", Handler: "tcp", Port: 8080, InitialDelay: 30 * time.Second, Period: 10 * time.Second, FailureThreshold: 3}}}},
+		[]EventInfo{
+			{Reason: "Unhealthy", Message: "Liveness probe failed: timeout", LastSeen: now},
+			{Reason: "Unhealthy", Message: "Liveness probe failed: older", LastSeen: now.Add(-time.Minute)},
+			{Reason: "Killing", Message: "Container app failed liveness probe, will be restarted", LastSeen: now},
+		},
+	)
+	if len(got) != 1 || got[0].Severity != "High" {
+		t.Fatalf("got %+v, want one High helper", got)
+	}
+	if got[0].Suggestions[0] != "Liveness probe failed: timeout" {
+		t.Errorf("first suggestion = %q, want the latest failure", got[0].Suggestions[0])
+	}
+}
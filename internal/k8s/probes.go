@@ -0,0 +1,295 @@
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ProbeCheckImage runs live probe checks; it ships curl, which handles both
+// HTTP and TCP probes
+const ProbeCheckImage = "curlimages/curl"
+
+// probeCheckRequestTimeout bounds each API request of a probe check, so an
+// unreachable cluster fails the check instead of hanging it
+const probeCheckRequestTimeout = "30s"
+
+// ProbeInfo is one liveness, readiness or startup probe of a container
+type ProbeInfo struct {
+	Container        string
+	Kind             string // liveness, readiness or startup
+	Handler          string // http, tcp, grpc or exec
+	Scheme           string // HTTP or HTTPS
+	Host             string // explicit host, empty for the pod IP
+	Port             int32  // zero when a named port does not resolve
+	PortName         string
+	Path             string
+	Headers          []string // "Name: value"
+	Command          []string
+	InitialDelay     time.Duration
+	Period           time.Duration
+	Timeout          time.Duration
+	SuccessThreshold int32
+	FailureThreshold int32
+}
+
+// Target describes what the probe hits, e.g. "HTTP GET http://:8080/healthz"
+func (p ProbeInfo) Target() string {
+	port := p.portText()
+	switch p.Handler {
+	case "http":
+		return fmt.Sprintf("HTTP GET %s://%s:%s%s", strings.ToLower(p.Scheme), p.Host, port, p.Path)
+	case "tcp":
+		return fmt.Sprintf("TCP %s:%s", p.Host, port)
+	case "grpc":
+		return "gRPC :" + port
+	case "exec":
+		return "exec " + strings.Join(p.Command, " ")
+	}
+	return "no handler"
+}
+
+// Timing summarizes when the probe runs and when it gives up
+func (p ProbeInfo) Timing() string {
+	parts := []string{}
+	if p.InitialDelay > 0 {
+		parts = append(parts, "delay "+FormatDuration(p.InitialDelay))
+	}
+	parts = append(parts,
+		"every "+FormatDuration(p.Period),
+		"timeout "+FormatDuration(p.Timeout),
+		fmt.Sprintf("fails after %d", p.FailureThreshold),
+	)
+	if p.SuccessThreshold > 1 {
+		parts = append(parts, fmt.Sprintf("passes after %d", p.SuccessThreshold))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Checkable reports whether the probe can be replayed from a debug container
+func (p ProbeInfo) Checkable() bool {
+	return (p.Handler == "http" || p.Handler == "tcp") && p.Port > 0
+}
+
+func (p ProbeInfo) portText() string {
+	switch {
+	case p.Port > 0 && p.PortName != "":
+		return fmt.Sprintf("%d (%s)", p.Port, p.PortName)
+	case p.Port > 0:
+		return strconv.Itoa(int(p.Port))
+	}
+	return p.PortName + " (unresolved)"
+}
+
+// DescribeProbes lists the probes of each container in spec order, with
+// named ports resolved against the container's ports
+func DescribeProbes(pod *corev1.Pod) []ProbeInfo {
+	if pod == nil {
+		return nil
+	}
+
+	var probes []ProbeInfo
+	for _, c := range pod.Spec.Containers {
+		probes = append(probes, containerProbes(c)...)
+	}
+	return probes
+}
+
+// containerProbes lists the probes of one container: startup, liveness and
+// readiness, those it has
+func containerProbes(c corev1.Container) []ProbeInfo {
+	var probes []ProbeInfo
+	for _, kp := range []struct {
+		kind  string
+		probe *corev1.Probe
+	}{
+		{"startup", c.StartupProbe},
+		{"liveness", c.LivenessProbe},
+		{"readiness", c.ReadinessProbe},
+	} {
+		if kp.probe != nil {
+			probes = append(probes, probeInfo(c, kp.kind, kp.probe))
+		}
+	}
+	return probes
+}
+
+func probeInfo(c corev1.Container, kind string, probe *corev1.Probe) ProbeInfo {
+	p := ProbeInfo{
+		Container:        c.Name,
+		Kind:             kind,
+		InitialDelay:     time.Duration(probe.InitialDelaySeconds) * time.Second,
+		Period:           time.Duration(probe.PeriodSeconds) * time.Second,
+		Timeout:          time.Duration(probe.TimeoutSeconds) * time.Second,
+		SuccessThreshold: probe.SuccessThreshold,
+		FailureThreshold: probe.FailureThreshold,
+	}
+	// The API server fills these in, but pods built by hand may leave them out
+	if p.Period == 0 {
+		p.Period = 10 * time.Second
+	}
+	if p.Timeout == 0 {
+		p.Timeout = time.Second
+	}
+	if p.FailureThreshold == 0 {
+		p.FailureThreshold = 3
+	}
+
+	switch h := probe.ProbeHandler; {
+	case h.HTTPGet != nil:
+		p.Handler = "http"
+		p.Scheme = string(h.HTTPGet.Scheme)
+		if p.Scheme == "" {
+			p.Scheme = "HTTP"
+		}
+		p.Host = h.HTTPGet.Host
+		p.Path = h.HTTPGet.Path
+		if p.Path == "" {
+			p.Path = "/"
+		}
+		for _, hdr := range h.HTTPGet.HTTPHeaders {
+			p.Headers = append(p.Headers, hdr.Name+": "+hdr.Value)
+		}
+		p.Port, p.PortName = resolveProbePort(c, h.HTTPGet.Port.IntValue(), h.HTTPGet.Port.StrVal)
+	case h.TCPSocket != nil:
+		p.Handler = "tcp"
+		p.Host = h.TCPSocket.Host
+		p.Port, p.PortName = resolveProbePort(c, h.TCPSocket.Port.IntValue(), h.TCPSocket.Port.StrVal)
+	case h.GRPC != nil:
+		p.Handler = "grpc"
+		p.Port = h.GRPC.Port
+	case h.Exec != nil:
+		p.Handler = "exec"
+		p.Command = h.Exec.Command
+	}
+	return p
+}
+
+// resolveProbePort maps a named probe port to the container port's number
+func resolveProbePort(c corev1.Container, number int, name string) (int32, string) {
+	if name == "" || number > 0 {
+		return int32(number), ""
+	}
+	for _, port := range c.Ports {
+		if port.Name == name {
+			return port.ContainerPort, name
+		}
+	}
+	return 0, name
+}
+
+// ProbeCheckCommand builds a kubectl command that replays an HTTP or TCP
+// probe from an ephemeral debug container in the pod, printing a result line
+// for ProbeCheckVerdict. The debug container shares the pod's network, so it
+// sees the same endpoint the kubelet does. kubectl is the invocation
+// addressing the pod's cluster, see KubectlFor. ok is false for probes that
+// cannot be replayed.
+func ProbeCheckCommand(kubectl, namespace, podName, podIP string, p ProbeInfo) (string, bool) {
+	if !p.Checkable() {
+		return "", false
+	}
+
+	host := p.Host
+	if host == "" {
+		host = podIP
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	hostPort := host + ":" + strconv.Itoa(int(p.Port))
+	if strings.Contains(host, ":") {
+		hostPort = "[" + host + "]:" + strconv.Itoa(int(p.Port))
+	}
+
+	// Allow well past the probe's timeout so a slow answer is measured rather than cut off
+	maxTime := int((p.Timeout + 10*time.Second).Seconds())
+	timeout := strconv.Itoa(int(p.Timeout.Seconds()))
+
+	args := []string{"curl", "-sS", "-o", "/dev/null", "--max-time", strconv.Itoa(maxTime)}
+	switch p.Handler {
+	case "http":
+		// Like the kubelet, do not verify certificates and do not follow redirects
+		args = append(args, "-k", "-w", "probe-result code=%{http_code} time=%{time_total} timeout="+timeout+`\n`)
+		for _, hdr := range p.Headers {
+			args = append(args, "-H", hdr)
+		}
+		args = append(args, strings.ToLower(p.Scheme)+"://"+hostPort+p.Path)
+	case "tcp":
+		// telnet:// just opens the connection; closed stdin ends it at once
+		args = append(args, "-w", "probe-result connect=%{time_connect} timeout="+timeout+`\n`, "telnet://"+hostPort)
+	}
+
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = shellArg(a)
+	}
+	cmd := fmt.Sprintf("%s --request-timeout=%s debug -n %s %s --image=%s --attach --quiet -- %s",
+		kubectl, probeCheckRequestTimeout, namespace, podName, ProbeCheckImage, strings.Join(quoted, " "))
+	if p.Handler == "tcp" {
+		cmd += " </dev/null"
+	}
+	return cmd, true
+}
+
+// ProbeCheckVerdict reads the result line printed by a ProbeCheckCommand and
+// explains whether the probe would pass, judged the way the kubelet does
+func ProbeCheckVerdict(output string) string {
+	fields := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "probe-result ") {
+			continue
+		}
+		for _, f := range strings.Fields(strings.TrimPrefix(line, "probe-result ")) {
+			if k, v, ok := strings.Cut(f, "="); ok {
+				fields[k] = v
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return "✗ No result: the debug container did not run the check (ephemeral containers need Kubernetes 1.25+ and permission to update pods/ephemeralcontainers)"
+	}
+
+	timeout, _ := strconv.ParseFloat(fields["timeout"], 64)
+	slow := func(seconds float64) string {
+		if timeout > 0 && seconds > timeout {
+			return fmt.Sprintf("\n✗ Took %.3fs, over the probe's %ss timeout; the kubelet counts this as a failure, so raise timeoutSeconds or speed up the endpoint", seconds, fields["timeout"])
+		}
+		return ""
+	}
+
+	if code, ok := fields["code"]; ok {
+		status, _ := strconv.Atoi(code)
+		seconds, _ := strconv.ParseFloat(fields["time"], 64)
+		switch {
+		case status == 0:
+			return "✗ No HTTP response: nothing accepted the connection or it timed out; check the probe's port and that the app listens on the pod IP"
+		case status >= 200 && status < 400:
+			if s := slow(seconds); s != "" {
+				return fmt.Sprintf("HTTP %d in %.3fs", status, seconds) + s
+			}
+			return fmt.Sprintf("✓ HTTP %d in %.3fs: the probe passes from inside the pod; if the kubelet still reports failures, look at the app's behavior under load or at node networking", status, seconds)
+		}
+		return fmt.Sprintf("✗ HTTP %d in %.3fs: the kubelet only accepts 200-399, so the endpoint itself is failing the probe", status, seconds) + slow(seconds)
+	}
+
+	seconds, _ := strconv.ParseFloat(fields["connect"], 64)
+	if seconds == 0 {
+		return "✗ Connection failed: nothing listens on the probe's port at that address"
+	}
+	if s := slow(seconds); s != "" {
+		return fmt.Sprintf("Connected in %.3fs", seconds) + s
+	}
+	return fmt.Sprintf("✓ Connected in %.3fs: the port accepts connections, so the TCP probe passes", seconds)
+}
+
+// shellArg quotes s for sh unless it is made only of safe characters
+func shellArg(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
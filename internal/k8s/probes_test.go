@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDescribeProbes(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{
+							Path:        "/healthz",
+							Port:        intstr.FromString("http"),
+							HTTPHeaders: []corev1.HTTPHeader{{Name: "X-Probe", Value: "1"}},
+						}},
+						InitialDelaySeconds: 5,
+						PeriodSeconds:       10,
+						TimeoutSeconds:      2,
+						FailureThreshold:    3,
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)}},
+					},
+				},
+				{
+					Name: "sidecar",
+					StartupProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"cat", "/tmp/ready"}}},
+					},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Port: intstr.FromString("metrics")}},
+					},
+				},
+			},
+		},
+	}
+
+	probes := DescribeProbes(pod)
+	if len(probes) != 4 {
+		t.Fatalf("got %d probes, want 4", len(probes))
+	}
+
+	live := probes[0]
+	if live.Kind != "liveness" || live.Port != 8080 || live.PortName != "http" {
+		t.Errorf("liveness probe = %+v", live)
+	}
+	if got := live.Target(); got != "HTTP GET http://:8080 (http)/healthz" {
+		t.Errorf("Target() = %q", got)
+	}
+	if got := live.Timing(); got != "delay 5s, every 10s, timeout 2s, fails after 3" {
+		t.Errorf("Timing() = %q", got)
+	}
+
+	ready := probes[1]
+	if ready.Handler != "tcp" || ready.Timeout != time.Second || ready.FailureThreshold != 3 {
+		t.Errorf("readiness probe defaults = %+v", ready)
+	}
+
+	if probes[2].Kind != "startup" || probes[2].Target() != "exec cat /tmp/ready" || probes[2].Checkable() {
+		t.Errorf("startup probe = %+v", probes[2])
+	}
+	if probes[3].Port != 0 || probes[3].Checkable() {
+		t.Errorf("unresolved named port should not be checkable: %+v", probes[3])
+	}
+}
+
+func TestProbeCheckCommand(t *testing.T) {
+	http := ProbeInfo{
+		Handler: "http", Scheme: "HTTP", Port: 8080, Path: "/healthz",
+		Headers: []string{"X-Probe: 1"}, Timeout: 2 * time.Second,
+	}
+	cmd, ok := ProbeCheckCommand("kubectl --context prod", "shop", "web-0", "10.0.0.7", http)
+	if !ok {
+		t.Fatal("HTTP probe should be checkable")
+	}
+	for _, want := range []string{
+		"kubectl --context prod --request-timeout=30s debug -n shop web-0 --image=curlimages/curl --attach",
+		"--max-time 12",
+		"timeout=2",
+		"-H 'X-Probe: 1'",
+		"http://10.0.0.7:8080/healthz",
+	} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("command %q missing %q", cmd, want)
+		}
+	}
+
+	tcp := ProbeInfo{Handler: "tcp", Port: 5432, Timeout: time.Second}
+	cmd, _ = ProbeCheckCommand("kubectl", "shop", "db-0", "", tcp)
+	if !strings.Contains(cmd, "telnet://127.0.0.1:5432") || !strings.HasSuffix(cmd, "</dev/null") {
+		t.Errorf("tcp command = %q", cmd)
+	}
+
+	if _, ok := ProbeCheckCommand("kubectl", "shop", "web-0", "", ProbeInfo{Handler: "exec"}); ok {
+		t.Error("exec probes should not be checkable")
+	}
+}
+
+func TestProbeCheckVerdict(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"probe-result code=200 time=0.012 timeout=1\n", "✓ HTTP 200"},
+		{"probe-result code=200 time=2.500 timeout=1\n", "over the probe's 1s timeout"},
+		{"probe-result code=503 time=0.010 timeout=1\n", "✗ HTTP 503"},
+		{"curl: (7) Failed to connect\nprobe-result code=000 time=0.001 timeout=1\n", "No HTTP response"},
+		{"probe-result connect=0.002 timeout=1\n", "✓ Connected"},
+		{"probe-result connect=0.000000 timeout=1\n", "Connection failed"},
+		{"error: ephemeral containers are disabled for this cluster\n", "No result"},
+	}
+	for _, tt := range tests {
+		if got := ProbeCheckVerdict(tt.output); !strings.Contains(got, tt.want) {
+			t.Errorf("ProbeCheckVerdict(%q) = %q, want it to contain %q", tt.output, got, tt.want)
+		}
+	}
+}
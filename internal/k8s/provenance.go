@@ -0,0 +1,215 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Provenance describes which tool produced an object and what changed in its last rollout
+type Provenance struct {
+	Source           string // Kind/name of the object the metadata was read from
+	ManagedBy        string
+	HelmChart        string
+	HelmRelease      string // namespace/name
+	KustomizeOrigin  string // file the object was generated from
+	GitOps           string // Flux or Argo CD owner
+	Checksums        map[string]string
+	ChangedChecksums []string // checksum annotations that differ from the previous revision
+	PreviousRevision string
+}
+
+// IsEmpty reports whether no provenance information was found
+func (p *Provenance) IsEmpty() bool {
+	return p == nil || (p.ManagedBy == "" && p.HelmChart == "" && p.HelmRelease == "" &&
+		p.KustomizeOrigin == "" && p.GitOps == "" && len(p.Checksums) == 0)
+}
+
+// ParseProvenance extracts Helm, Kustomize and GitOps metadata from an object's labels and annotations
+func ParseProvenance(labels, annotations map[string]string) Provenance {
+	p := Provenance{
+		ManagedBy: labels["app.kubernetes.io/managed-by"],
+		HelmChart: labels["helm.sh/chart"],
+	}
+
+	if name := annotations["meta.helm.sh/release-name"]; name != "" {
+		p.HelmRelease = name
+		if ns := annotations["meta.helm.sh/release-namespace"]; ns != "" {
+			p.HelmRelease = ns + "/" + name
+		}
+	}
+
+	// Kustomize records build metadata as a small YAML document, e.g. "path: overlays/prod/app.yaml\n"
+	if origin := annotations["config.kubernetes.io/origin"]; origin != "" {
+		for _, line := range strings.Split(origin, "\n") {
+			if path, ok := strings.CutPrefix(strings.TrimSpace(line), "path:"); ok {
+				p.KustomizeOrigin = strings.TrimSpace(path)
+				break
+			}
+		}
+	}
+
+	switch {
+	case labels["kustomize.toolkit.fluxcd.io/name"] != "":
+		p.GitOps = fmt.Sprintf("Flux Kustomization %s/%s",
+			labels["kustomize.toolkit.fluxcd.io/namespace"], labels["kustomize.toolkit.fluxcd.io/name"])
+	case labels["helm.toolkit.fluxcd.io/name"] != "":
+		p.GitOps = fmt.Sprintf("Flux HelmRelease %s/%s",
+			labels["helm.toolkit.fluxcd.io/namespace"], labels["helm.toolkit.fluxcd.io/name"])
+	case annotations["argocd.argoproj.io/tracking-id"] != "":
+		app, _, _ := strings.Cut(annotations["argocd.argoproj.io/tracking-id"], ":")
+		p.GitOps = "Argo CD app " + app
+	case labels["argocd.argoproj.io/instance"] != "":
+		p.GitOps = "Argo CD app " + labels["argocd.argoproj.io/instance"]
+	}
+
+	p.Checksums = checksumAnnotations(annotations)
+	return p
+}
+
+// checksumAnnotations returns annotations such as checksum/config that are
+// commonly put on pod templates to force a rollout when config changes
+func checksumAnnotations(annotations map[string]string) map[string]string {
+	var sums map[string]string
+	for k, v := range annotations {
+		if strings.Contains(strings.ToLower(k), "checksum") {
+			if sums == nil {
+				sums = make(map[string]string)
+			}
+			sums[k] = v
+		}
+	}
+	return sums
+}
+
+// ChangedChecksums returns the checksum keys whose values differ between two revisions
+func ChangedChecksums(current, previous map[string]string) []string {
+	var changed []string
+	for k, v := range current {
+		if previous[k] != v {
+			changed = append(changed, k)
+		}
+	}
+	for k := range previous {
+		if _, ok := current[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// GetProvenance reads provenance from the pod's top-level owner and, for
+// Deployments, compares checksum annotations with the previous ReplicaSet
+func GetProvenance(ctx context.Context, clientset *kubernetes.Clientset, pod *corev1.Pod) *Provenance {
+	labels := mergeMaps(nil, pod.Labels)
+	annotations := mergeMaps(nil, pod.Annotations)
+	source := "Pod/" + pod.Name
+
+	var currentRS, previousRS map[string]string
+	var previousRevision string
+
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		source = owner.Kind + "/" + owner.Name
+
+		switch owner.Kind {
+		case "ReplicaSet":
+			rs, err := clientset.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+			if err != nil {
+				break
+			}
+			currentRS = rs.Spec.Template.Annotations
+
+			if dep := metav1.GetControllerOf(rs); dep != nil && dep.Kind == "Deployment" {
+				source = "Deployment/" + dep.Name
+				if d, err := clientset.AppsV1().Deployments(pod.Namespace).Get(ctx, dep.Name, metav1.GetOptions{}); err == nil {
+					labels = mergeMaps(labels, d.Labels)
+					annotations = mergeMaps(annotations, d.Annotations)
+					previousRS, previousRevision = previousReplicaSetAnnotations(ctx, clientset, d, rs.Annotations)
+				}
+			}
+		case "StatefulSet":
+			if s, err := clientset.AppsV1().StatefulSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{}); err == nil {
+				labels = mergeMaps(labels, s.Labels)
+				annotations = mergeMaps(annotations, s.Annotations)
+			}
+		case "DaemonSet":
+			if ds, err := clientset.AppsV1().DaemonSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{}); err == nil {
+				labels = mergeMaps(labels, ds.Labels)
+				annotations = mergeMaps(annotations, ds.Annotations)
+			}
+		}
+	}
+
+	p := ParseProvenance(labels, annotations)
+	p.Source = source
+	// Checksums live on the pod template, so only the pod's own annotations count
+	p.Checksums = checksumAnnotations(pod.Annotations)
+	if previousRS != nil {
+		p.ChangedChecksums = ChangedChecksums(checksumAnnotations(currentRS), checksumAnnotations(previousRS))
+		p.PreviousRevision = previousRevision
+	}
+	return &p
+}
+
+// previousReplicaSetAnnotations finds the deployment's ReplicaSet with the
+// highest revision below the current one and returns its pod template
+// annotations. Only the ReplicaSets its selector matches are listed.
+func previousReplicaSetAnnotations(ctx context.Context, clientset *kubernetes.Clientset, deployment *appsv1.Deployment, currentAnnotations map[string]string) (map[string]string, string) {
+	current, err := strconv.Atoi(currentAnnotations["deployment.kubernetes.io/revision"])
+	if err != nil {
+		return nil, ""
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, ""
+	}
+	rsList, err := clientset.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, ""
+	}
+
+	best := 0
+	var annotations map[string]string
+	for _, rs := range rsList.Items {
+		owner := metav1.GetControllerOf(&rs)
+		if owner == nil || owner.UID != deployment.UID {
+			continue
+		}
+		rev, err := strconv.Atoi(rs.Annotations["deployment.kubernetes.io/revision"])
+		if err != nil || rev >= current || rev <= best {
+			continue
+		}
+		best = rev
+		annotations = rs.Spec.Template.Annotations
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+	}
+
+	if best == 0 {
+		return nil, ""
+	}
+	return annotations, strconv.Itoa(best)
+}
+
+// mergeMaps copies src into dst without overwriting existing keys
+func mergeMaps(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		if _, ok := dst[k]; !ok {
+			dst[k] = v
+		}
+	}
+	return dst
+}
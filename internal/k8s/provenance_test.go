@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProvenance(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		want        Provenance
+	}{
+		{
+			name: "helm release",
+			labels: map[string]string{
+				"app.kubernetes.io/managed-by": "Helm",
+				"helm.sh/chart":                "api-1.4.0",
+			},
+			annotations: map[string]string{
+				"meta.helm.sh/release-name":      "api",
+				"meta.helm.sh/release-namespace": "prod",
+				"checksum/config":                "abc",
+			},
+			want: Provenance{
+				ManagedBy:   "Helm",
+				HelmChart:   "api-1.4.0",
+				HelmRelease: "prod/api",
+				Checksums:   map[string]string{"checksum/config": "abc"},
+			},
+		},
+		{
+			name: "kustomize via flux",
+			labels: map[string]string{
+				"kustomize.toolkit.fluxcd.io/name":      "apps",
+				"kustomize.toolkit.fluxcd.io/namespace": "flux-system",
+			},
+			annotations: map[string]string{
+				"config.kubernetes.io/origin": "path: overlays/prod/deployment.yaml\n",
+			},
+			want: Provenance{
+				KustomizeOrigin: "overlays/prod/deployment.yaml",
+				GitOps:          "Flux Kustomization flux-system/apps",
+			},
+		},
+		{
+			name:        "argo cd tracking id",
+			annotations: map[string]string{"argocd.argoproj.io/tracking-id": "payments:apps/Deployment:prod/api"},
+			want:        Provenance{GitOps: "Argo CD app payments"},
+		},
+		{
+			name: "nothing",
+			want: Provenance{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseProvenance(tt.labels, tt.annotations)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseProvenance() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangedChecksums(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  map[string]string
+		previous map[string]string
+		want     []string
+	}{
+		{
+			name:     "unchanged",
+			current:  map[string]string{"checksum/config": "a"},
+			previous: map[string]string{"checksum/config": "a"},
+			want:     nil,
+		},
+		{
+			name:     "value changed",
+			current:  map[string]string{"checksum/config": "b", "checksum/secret": "s"},
+			previous: map[string]string{"checksum/config": "a", "checksum/secret": "s"},
+			want:     []string{"checksum/config"},
+		},
+		{
+			name:     "added and removed",
+			current:  map[string]string{"checksum/new": "x"},
+			previous: map[string]string{"checksum/old": "y"},
+			want:     []string{"checksum/new", "checksum/old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChangedChecksums(tt.current, tt.previous); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChangedChecksums() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
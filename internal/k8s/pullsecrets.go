@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PullSecret is one of a pod's imagePullSecrets and the registries it holds
+// credentials for
+type PullSecret struct {
+	Name       string
+	Missing    bool     // the pod names it but the namespace has no such secret
+	Registries []string // the keys of its docker config, as written
+	Err        error    // unreadable, or not a docker config secret
+}
+
+// GetPullSecrets reads the pod's imagePullSecrets, which include those of
+// its service account since admission copies them into the pod spec
+func GetPullSecrets(ctx context.Context, clientset *kubernetes.Clientset, pod *PodInfo) []PullSecret {
+	if pod.Object == nil {
+		return nil
+	}
+	var secrets []PullSecret
+	for _, ref := range pod.Object.Spec.ImagePullSecrets {
+		ps := PullSecret{Name: ref.Name}
+		secret, err := clientset.CoreV1().Secrets(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			ps.Missing = true
+		case err != nil:
+			ps.Err = err
+		default:
+			ps.Registries, ps.Err = pullSecretRegistries(secret)
+		}
+		secrets = append(secrets, ps)
+	}
+	return secrets
+}
+
+// pullSecretRegistries lists the registries a dockerconfigjson or legacy
+// dockercfg secret has credentials for
+func pullSecretRegistries(secret *corev1.Secret) ([]string, error) {
+	var auths map[string]json.RawMessage
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		var cfg struct {
+			Auths map[string]json.RawMessage `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &cfg); err != nil {
+			return nil, fmt.Errorf("%s does not parse: %w", corev1.DockerConfigJsonKey, err)
+		}
+		auths = cfg.Auths
+	case corev1.SecretTypeDockercfg:
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths); err != nil {
+			return nil, fmt.Errorf("%s does not parse: %w", corev1.DockerConfigKey, err)
+		}
+	default:
+		return nil, fmt.Errorf("type is %s, not %s", secret.Type, corev1.SecretTypeDockerConfigJson)
+	}
+
+	registries := make([]string, 0, len(auths))
+	for host := range auths {
+		registries = append(registries, host)
+	}
+	sort.Strings(registries)
+	return registries, nil
+}
@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPullSecretRegistries(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  *corev1.Secret
+		want    string
+		wantErr bool
+	}{
+		{
+			"dockerconfigjson",
+			&corev1.Secret{Type: corev1.SecretTypeDockerConfigJson, Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"ghcr.io":{"auth":"dTpw"},"https://index.docker.io/v1/":{"auth":"dTpw"}}}`),
+			}},
+			"ghcr.io,https://index.docker.io/v1/", false,
+		},
+		{
+			"dockercfg",
+			&corev1.Secret{Type: corev1.SecretTypeDockercfg, Data: map[string][]byte{
+				corev1.DockerConfigKey: []byte(`{"registry.example.com:5000":{"auth":"dTpw"}}`),
+			}},
+			"registry.example.com:5000", false,
+		},
+		{
+			"broken json",
+			&corev1.Secret{Type: corev1.SecretTypeDockerConfigJson, Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":`),
+			}},
+			"", true,
+		},
+		{"opaque", &corev1.Secret{Type: corev1.SecretTypeOpaque}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := pullSecretRegistries(tt.secret)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("%s: got %v, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	reportMaxErrors = 20
+	reportMaxEvents = 15
+)
+
+// FormatIssueReport renders the current debug state of a pod as a Markdown
+// issue body suitable for GitHub or Jira
+func FormatIssueReport(pod *PodInfo, helpers []DebugHelper, events []EventInfo, logs []LogLine) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Pod `%s/%s` is %s\n\n", pod.Namespace, pod.Name, pod.Status)
+
+	b.WriteString("| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Namespace | %s |\n", pod.Namespace)
+	fmt.Fprintf(&b, "| Pod | %s |\n", pod.Name)
+	if pod.OwnerKind != "" {
+		fmt.Fprintf(&b, "| Owner | %s/%s |\n", pod.OwnerKind, pod.OwnerRef)
+	}
+	fmt.Fprintf(&b, "| Status | %s |\n", pod.Status)
+	fmt.Fprintf(&b, "| Ready | %s |\n", pod.Ready)
+	fmt.Fprintf(&b, "| Restarts | %d |\n", pod.Restarts)
+	fmt.Fprintf(&b, "| Node | %s |\n", pod.Node)
+	fmt.Fprintf(&b, "| Age | %s |\n", pod.Age)
+
+	b.WriteString("\n### Containers\n\n")
+	b.WriteString("| Name | Image | State | Restarts |\n|---|---|---|---|\n")
+	for _, c := range pod.Containers {
+		state := c.State
+		if c.Reason != "" {
+			state += " (" + c.Reason + ")"
+		}
+		fmt.Fprintf(&b, "| %s | `%s` | %s | %d |\n", c.Name, c.Image, state, c.RestartCount)
+	}
+
+	if len(helpers) > 0 {
+		b.WriteString("\n### Diagnosis\n\n")
+		for _, h := range helpers {
+			fmt.Fprintf(&b, "- **%s** (%s)\n", h.Issue, h.Severity)
+			for _, s := range h.Suggestions {
+				fmt.Fprintf(&b, "  - %s\n", s)
+			}
+		}
+	}
+
+	errorLogs := FilterErrorLogs(logs)
+	if len(errorLogs) > reportMaxErrors {
+		errorLogs = errorLogs[len(errorLogs)-reportMaxErrors:]
+	}
+	if len(errorLogs) > 0 {
+		b.WriteString("\n### Recent errors\n\n```\n")
+		for _, l := range errorLogs {
+			if !l.Timestamp.IsZero() {
+				b.WriteString(l.Timestamp.UTC().Format(time.RFC3339) + " ")
+			}
+			if l.Container != "" {
+				b.WriteString("[" + l.Container + "] ")
+			}
+			b.WriteString(strings.ReplaceAll(l.Content, "```", "'''") + "\n")
+		}
+		b.WriteString("```\n")
+	}
+
+	if len(events) > 0 {
+		b.WriteString("\n### Events\n\n")
+		b.WriteString("| Type | Reason | Age | Count | Message |\n|---|---|---|---|---|\n")
+		for i, e := range events {
+			if i >= reportMaxEvents {
+				break
+			}
+			message := strings.ReplaceAll(e.Message, "|", "\\|")
+			message = strings.ReplaceAll(message, "\n", " ")
+			fmt.Fprintf(&b, "| %s | %s | %s | %d | %s |\n", e.Type, e.Reason, e.Age, e.Count, message)
+		}
+	}
+
+	return b.String()
+}
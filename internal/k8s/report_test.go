@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatIssueReport(t *testing.T) {
+	pod := &PodInfo{
+		Name:      "api-7d9f",
+		Namespace: "prod",
+		Status:    "CrashLoopBackOff",
+		Ready:     "0/1",
+		Restarts:  4,
+		Node:      "node-1",
+		OwnerKind: "ReplicaSet",
+		OwnerRef:  "api-7d",
+		Containers: []ContainerInfo{
+			{Name: "app", Image: "api:1.2", State: "Waiting", Reason: "CrashLoopBackOff", RestartCount: 4},
+		},
+	}
+	helpers := []DebugHelper{{Issue: "Container crashing", Severity: "High", Suggestions: []string{"Check logs"}}}
+	events := []EventInfo{{Type: "Warning", Reason: "BackOff", Age: "1m", Count: 3, Message: "Back-off | restarting"}}
+	logs := []LogLine{
+		{Container: "app", Content: "booting up"},
+		{Container: "app", Content: "panic: nil map", IsError: true},
+	}
+
+	report := FormatIssueReport(pod, helpers, events, logs)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"title", "## Pod `prod/api-7d9f` is CrashLoopBackOff"},
+		{"owner", "| Owner | ReplicaSet/api-7d |"},
+		{"container", "| app | `api:1.2` | Waiting (CrashLoopBackOff) | 4 |"},
+		{"diagnosis", "- **Container crashing** (High)\n  - Check logs"},
+		{"error log", "[app] panic: nil map"},
+		{"event escaped", "Back-off \\| restarting"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(report, tt.want) {
+				t.Errorf("report missing %q:\n%s", tt.want, report)
+			}
+		})
+	}
+
+	if strings.Contains(report, "booting up") {
+		t.Errorf("report should only include error lines:\n%s", report)
+	}
+}
+
+func TestFormatIssueReportOmitsEmptySections(t *testing.T) {
+	report := FormatIssueReport(&PodInfo{Name: "p", Namespace: "ns", Status: "Running"}, nil, nil, nil)
+
+	for _, section := range []string{"### Diagnosis", "### Recent errors", "### Events"} {
+		if strings.Contains(report, section) {
+			t.Errorf("report should omit %q when empty", section)
+		}
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
@@ -24,6 +27,8 @@ const (
 	ResourceDaemonSets   ResourceType = "daemonsets"
 	ResourceJobs         ResourceType = "jobs"
 	ResourceCronJobs     ResourceType = "cronjobs"
+	ResourceServices     ResourceType = "services"
+	ResourceNodes        ResourceType = "nodes"
 )
 
 var AllResourceTypes = []ResourceType{
@@ -33,6 +38,44 @@ var AllResourceTypes = []ResourceType{
 	ResourceJobs,
 	ResourceCronJobs,
 	ResourcePods,
+	ResourceServices,
+	ResourceNodes,
+}
+
+// ParseResourceTypes turns the configured list of type names into the types
+// to offer, in that order. An empty list enables every type. Unknown names
+// are skipped and reported in the error; when none is left, every type is
+// enabled as well.
+func ParseResourceTypes(names []string) ([]ResourceType, error) {
+	if len(names) == 0 {
+		return append([]ResourceType(nil), AllResourceTypes...), nil
+	}
+
+	known := make(map[ResourceType]bool, len(AllResourceTypes))
+	for _, rt := range AllResourceTypes {
+		known[rt] = true
+	}
+	var types []ResourceType
+	var unknown []string
+	seen := make(map[ResourceType]bool)
+	for _, name := range names {
+		rt := ResourceType(strings.ToLower(strings.TrimSpace(name)))
+		if !known[rt] {
+			unknown = append(unknown, strconv.Quote(name))
+			continue
+		}
+		if !seen[rt] {
+			seen[rt] = true
+			types = append(types, rt)
+		}
+	}
+	if len(types) == 0 {
+		types = append(types, AllResourceTypes...)
+	}
+	if len(unknown) > 0 {
+		return types, fmt.Errorf("unknown resource type %s", strings.Join(unknown, ", "))
+	}
+	return types, nil
 }
 
 type WorkloadInfo struct {
@@ -43,8 +86,15 @@ type WorkloadInfo struct {
 	Replicas     int32
 	Age          string
 	Status       string
-	Labels       map[string]string
+	Message      string            // why a Deployment has its Status, from its conditions
+	Labels       map[string]string // pod selector
+	ObjectLabels map[string]string // the object's own labels
+	Annotations  map[string]string
 	RestartCount int32
+	Image        string    // first container's image, "+N" when there are more
+	Revision     string    // Deployment rollout revision
+	LastSchedule time.Time // CronJob's last run
+	NextSchedule time.Time // CronJob's estimated next run, zero when suspended
 }
 
 type PodInfo struct {
@@ -57,22 +107,26 @@ type PodInfo struct {
 	Age          string
 	IP           string
 	Labels       map[string]string
+	Annotations  map[string]string
 	Containers   []ContainerInfo
 	Conditions   []corev1.PodCondition
 	Phase        corev1.PodPhase
 	OwnerRef     string
 	OwnerKind    string
+	Object       *corev1.Pod // the pod this was built from, for its spec
 }
 
 type ContainerInfo struct {
 	Name         string
 	Image        string
+	Digest       string // of the image the runtime actually pulled, from the container status
 	Ready        bool
 	RestartCount int32
 	State        string
 	Reason       string
 	Resources    ResourceRequirements
 	Ports        []int32
+	Probes       []ProbeInfo // startup, liveness and readiness, those it has
 }
 
 type ResourceRequirements struct {
@@ -110,6 +164,10 @@ func ListWorkloads(ctx context.Context, clientset *kubernetes.Clientset, namespa
 		return listCronJobs(ctx, clientset, namespace)
 	case ResourcePods:
 		return listPodsAsWorkloads(ctx, clientset, namespace)
+	case ResourceServices:
+		return listServices(ctx, clientset, namespace)
+	case ResourceNodes:
+		return listNodes(ctx, clientset)
 	default:
 		return nil, fmt.Errorf("unknown resource type: %s", resourceType)
 	}
@@ -122,27 +180,78 @@ func listDeployments(ctx context.Context, clientset *kubernetes.Clientset, names
 	}
 
 	var workloads []WorkloadInfo
-	for _, d := range deps.Items {
-		status := "Running"
-		if d.Status.ReadyReplicas < d.Status.Replicas {
-			status = "Progressing"
-		}
-		if d.Status.ReadyReplicas == 0 && d.Status.Replicas > 0 {
-			status = "NotReady"
+	for i := range deps.Items {
+		workloads = append(workloads, deploymentToWorkload(&deps.Items[i]))
+	}
+	return workloads, nil
+}
+
+// deploymentStatus derives a Deployment's status from its Available,
+// Progressing and ReplicaFailure conditions, with the message of the one it
+// comes from. A paused Deployment or one past its progress deadline stays
+// that way rather than Progressing.
+func deploymentStatus(d *appsv1.Deployment) (string, string) {
+	if d.Spec.Paused {
+		return "Paused", "Deployment is paused"
+	}
+	var available, progressing, replicaFailure *appsv1.DeploymentCondition
+	for i := range d.Status.Conditions {
+		c := &d.Status.Conditions[i]
+		switch c.Type {
+		case appsv1.DeploymentAvailable:
+			available = c
+		case appsv1.DeploymentProgressing:
+			progressing = c
+		case appsv1.DeploymentReplicaFailure:
+			replicaFailure = c
 		}
+	}
 
-		workloads = append(workloads, WorkloadInfo{
-			Name:      d.Name,
-			Namespace: d.Namespace,
-			Type:      ResourceDeployments,
-			Ready:     fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, d.Status.Replicas),
-			Replicas:  d.Status.Replicas,
-			Age:       formatAge(d.CreationTimestamp.Time),
-			Status:    status,
-			Labels:    d.Spec.Selector.MatchLabels,
-		})
+	switch {
+	case replicaFailure != nil && replicaFailure.Status == corev1.ConditionTrue:
+		return "ReplicaFailure", replicaFailure.Message
+	case progressing != nil && progressing.Reason == "ProgressDeadlineExceeded":
+		return "DeadlineExceeded", progressing.Message
+	case available != nil && available.Status == corev1.ConditionFalse:
+		return "NotReady", available.Message
+	case progressing != nil && progressing.Status == corev1.ConditionTrue && progressing.Reason != "NewReplicaSetAvailable":
+		return "Progressing", progressing.Message
+	}
+
+	// Without conditions (not yet observed, or an API server without them),
+	// and after a complete rollout that has since lost a pod, which Available
+	// tolerates within maxUnavailable, the ready count tells
+	desired := d.Status.Replicas
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	if d.Status.ReadyReplicas == 0 && desired > 0 {
+		return "NotReady", ""
+	}
+	if d.Status.ReadyReplicas < desired {
+		return "Progressing", ""
+	}
+	return "Running", ""
+}
+
+func deploymentToWorkload(d *appsv1.Deployment) WorkloadInfo {
+	status, message := deploymentStatus(d)
+
+	return WorkloadInfo{
+		Name:         d.Name,
+		Namespace:    d.Namespace,
+		Type:         ResourceDeployments,
+		Ready:        fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, d.Status.Replicas),
+		Replicas:     d.Status.Replicas,
+		Age:          formatAge(d.CreationTimestamp.Time),
+		Status:       status,
+		Message:      message,
+		Labels:       d.Spec.Selector.MatchLabels,
+		ObjectLabels: d.Labels,
+		Annotations:  d.Annotations,
+		Image:        primaryImage(&d.Spec.Template.Spec),
+		Revision:     d.Annotations["deployment.kubernetes.io/revision"],
 	}
-	return workloads, nil
 }
 
 func listStatefulSets(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]WorkloadInfo, error) {
@@ -152,26 +261,33 @@ func listStatefulSets(ctx context.Context, clientset *kubernetes.Clientset, name
 	}
 
 	var workloads []WorkloadInfo
-	for _, s := range sts.Items {
-		status := "Running"
-		if s.Status.ReadyReplicas < s.Status.Replicas {
-			status = "Progressing"
-		}
-
-		workloads = append(workloads, WorkloadInfo{
-			Name:      s.Name,
-			Namespace: s.Namespace,
-			Type:      ResourceStatefulSets,
-			Ready:     fmt.Sprintf("%d/%d", s.Status.ReadyReplicas, s.Status.Replicas),
-			Replicas:  s.Status.Replicas,
-			Age:       formatAge(s.CreationTimestamp.Time),
-			Status:    status,
-			Labels:    s.Spec.Selector.MatchLabels,
-		})
+	for i := range sts.Items {
+		workloads = append(workloads, statefulSetToWorkload(&sts.Items[i]))
 	}
 	return workloads, nil
 }
 
+func statefulSetToWorkload(s *appsv1.StatefulSet) WorkloadInfo {
+	status := "Running"
+	if s.Status.ReadyReplicas < s.Status.Replicas {
+		status = "Progressing"
+	}
+
+	return WorkloadInfo{
+		Name:         s.Name,
+		Namespace:    s.Namespace,
+		Type:         ResourceStatefulSets,
+		Ready:        fmt.Sprintf("%d/%d", s.Status.ReadyReplicas, s.Status.Replicas),
+		Replicas:     s.Status.Replicas,
+		Age:          formatAge(s.CreationTimestamp.Time),
+		Status:       status,
+		Labels:       s.Spec.Selector.MatchLabels,
+		ObjectLabels: s.Labels,
+		Annotations:  s.Annotations,
+		Image:        primaryImage(&s.Spec.Template.Spec),
+	}
+}
+
 func listDaemonSets(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]WorkloadInfo, error) {
 	ds, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -179,26 +295,33 @@ func listDaemonSets(ctx context.Context, clientset *kubernetes.Clientset, namesp
 	}
 
 	var workloads []WorkloadInfo
-	for _, d := range ds.Items {
-		status := "Running"
-		if d.Status.NumberReady < d.Status.DesiredNumberScheduled {
-			status = "Progressing"
-		}
-
-		workloads = append(workloads, WorkloadInfo{
-			Name:      d.Name,
-			Namespace: d.Namespace,
-			Type:      ResourceDaemonSets,
-			Ready:     fmt.Sprintf("%d/%d", d.Status.NumberReady, d.Status.DesiredNumberScheduled),
-			Replicas:  d.Status.DesiredNumberScheduled,
-			Age:       formatAge(d.CreationTimestamp.Time),
-			Status:    status,
-			Labels:    d.Spec.Selector.MatchLabels,
-		})
+	for i := range ds.Items {
+		workloads = append(workloads, daemonSetToWorkload(&ds.Items[i]))
 	}
 	return workloads, nil
 }
 
+func daemonSetToWorkload(d *appsv1.DaemonSet) WorkloadInfo {
+	status := "Running"
+	if d.Status.NumberReady < d.Status.DesiredNumberScheduled {
+		status = "Progressing"
+	}
+
+	return WorkloadInfo{
+		Name:         d.Name,
+		Namespace:    d.Namespace,
+		Type:         ResourceDaemonSets,
+		Ready:        fmt.Sprintf("%d/%d", d.Status.NumberReady, d.Status.DesiredNumberScheduled),
+		Replicas:     d.Status.DesiredNumberScheduled,
+		Age:          formatAge(d.CreationTimestamp.Time),
+		Status:       status,
+		Labels:       d.Spec.Selector.MatchLabels,
+		ObjectLabels: d.Labels,
+		Annotations:  d.Annotations,
+		Image:        primaryImage(&d.Spec.Template.Spec),
+	}
+}
+
 func listJobs(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]WorkloadInfo, error) {
 	jobs, err := clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -206,27 +329,34 @@ func listJobs(ctx context.Context, clientset *kubernetes.Clientset, namespace st
 	}
 
 	var workloads []WorkloadInfo
-	for _, j := range jobs.Items {
-		status := "Running"
-		if j.Status.Succeeded > 0 {
-			status = "Completed"
-		} else if j.Status.Failed > 0 {
-			status = "Failed"
-		}
-
-		workloads = append(workloads, WorkloadInfo{
-			Name:      j.Name,
-			Namespace: j.Namespace,
-			Type:      ResourceJobs,
-			Ready:     fmt.Sprintf("%d/%d", j.Status.Succeeded, *j.Spec.Completions),
-			Age:       formatAge(j.CreationTimestamp.Time),
-			Status:    status,
-			Labels:    j.Spec.Selector.MatchLabels,
-		})
+	for i := range jobs.Items {
+		workloads = append(workloads, jobToWorkload(&jobs.Items[i]))
 	}
 	return workloads, nil
 }
 
+func jobToWorkload(j *batchv1.Job) WorkloadInfo {
+	status := "Running"
+	if j.Status.Succeeded > 0 {
+		status = "Completed"
+	} else if j.Status.Failed > 0 {
+		status = "Failed"
+	}
+
+	return WorkloadInfo{
+		Name:         j.Name,
+		Namespace:    j.Namespace,
+		Type:         ResourceJobs,
+		Ready:        fmt.Sprintf("%d/%d", j.Status.Succeeded, *j.Spec.Completions),
+		Age:          formatAge(j.CreationTimestamp.Time),
+		Status:       status,
+		Labels:       j.Spec.Selector.MatchLabels,
+		ObjectLabels: j.Labels,
+		Annotations:  j.Annotations,
+		Image:        primaryImage(&j.Spec.Template.Spec),
+	}
+}
+
 func listCronJobs(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]WorkloadInfo, error) {
 	cjs, err := clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -234,24 +364,118 @@ func listCronJobs(ctx context.Context, clientset *kubernetes.Clientset, namespac
 	}
 
 	var workloads []WorkloadInfo
-	for _, cj := range cjs.Items {
-		status := "Active"
-		if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
-			status = "Suspended"
+	for i := range cjs.Items {
+		workloads = append(workloads, cronJobToWorkload(&cjs.Items[i]))
+	}
+	return workloads, nil
+}
+
+func cronJobToWorkload(cj *batchv1.CronJob) WorkloadInfo {
+	status := "Active"
+	suspended := cj.Spec.Suspend != nil && *cj.Spec.Suspend
+	if suspended {
+		status = "Suspended"
+	}
+
+	w := WorkloadInfo{
+		Name:         cj.Name,
+		Namespace:    cj.Namespace,
+		Type:         ResourceCronJobs,
+		Ready:        fmt.Sprintf("%d active", len(cj.Status.Active)),
+		Age:          formatAge(cj.CreationTimestamp.Time),
+		Status:       status,
+		ObjectLabels: cj.Labels,
+		Annotations:  cj.Annotations,
+		Image:        primaryImage(&cj.Spec.JobTemplate.Spec.Template.Spec),
+	}
+	if cj.Status.LastScheduleTime != nil {
+		w.LastSchedule = cj.Status.LastScheduleTime.Time
+	}
+	if !suspended {
+		var tz string
+		if cj.Spec.TimeZone != nil {
+			tz = *cj.Spec.TimeZone
 		}
+		// An unparseable schedule just leaves the estimate blank
+		w.NextSchedule, _ = NextCronRun(cj.Spec.Schedule, tz, time.Now())
+	}
+	return w
+}
 
-		workloads = append(workloads, WorkloadInfo{
-			Name:      cj.Name,
-			Namespace: cj.Namespace,
-			Type:      ResourceCronJobs,
-			Ready:     fmt.Sprintf("%d active", len(cj.Status.Active)),
-			Age:       formatAge(cj.CreationTimestamp.Time),
-			Status:    status,
-		})
+func listServices(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]WorkloadInfo, error) {
+	svcs, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var workloads []WorkloadInfo
+	for i := range svcs.Items {
+		workloads = append(workloads, serviceToWorkload(&svcs.Items[i]))
+	}
+	return workloads, nil
+}
+
+// serviceToWorkload lists a Service like a workload whose pods are the ones
+// its selector picks
+func serviceToWorkload(svc *corev1.Service) WorkloadInfo {
+	status := "Active"
+	if len(svc.Spec.Selector) == 0 {
+		status = "No selector"
+	}
+
+	return WorkloadInfo{
+		Name:         svc.Name,
+		Namespace:    svc.Namespace,
+		Type:         ResourceServices,
+		Ready:        string(svc.Spec.Type),
+		Age:          formatAge(svc.CreationTimestamp.Time),
+		Status:       status,
+		Labels:       svc.Spec.Selector,
+		ObjectLabels: svc.Labels,
+		Annotations:  svc.Annotations,
+	}
+}
+
+// listNodes lists the cluster's nodes; they have no namespace
+func listNodes(ctx context.Context, clientset *kubernetes.Clientset) ([]WorkloadInfo, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var workloads []WorkloadInfo
+	for i := range nodes.Items {
+		workloads = append(workloads, nodeToWorkload(&nodes.Items[i]))
 	}
 	return workloads, nil
 }
 
+// nodeToWorkload lists a node with its kubelet version as READY and, for a
+// ready node, its first problem as STATUS; the detail panel has the rest
+func nodeToWorkload(node *corev1.Node) WorkloadInfo {
+	state, ready := nodeState(node)
+	parts := strings.Split(state, ", ")
+	status := parts[0]
+	if ready && len(parts) > 1 {
+		switch status = parts[1]; status {
+		case "SchedulingDisabled":
+			status = "Cordoned"
+		case string(corev1.NodeNetworkUnavailable):
+			status = "NoNetwork"
+		}
+	}
+
+	return WorkloadInfo{
+		Name:         node.Name,
+		Type:         ResourceNodes,
+		Ready:        node.Status.NodeInfo.KubeletVersion,
+		Age:          formatAge(node.CreationTimestamp.Time),
+		Status:       status,
+		ObjectLabels: node.Labels,
+		Annotations:  node.Annotations,
+	}
+}
+
 func listPodsAsWorkloads(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]WorkloadInfo, error) {
 	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -259,31 +483,51 @@ func listPodsAsWorkloads(ctx context.Context, clientset *kubernetes.Clientset, n
 	}
 
 	var workloads []WorkloadInfo
-	for _, p := range pods.Items {
-		var restartCount int32
-		for _, cs := range p.Status.ContainerStatuses {
-			restartCount += cs.RestartCount
-		}
+	for i := range pods.Items {
+		workloads = append(workloads, podToWorkload(&pods.Items[i]))
+	}
+	return workloads, nil
+}
 
-		ready := 0
-		for _, cs := range p.Status.ContainerStatuses {
-			if cs.Ready {
-				ready++
-			}
+func podToWorkload(p *corev1.Pod) WorkloadInfo {
+	var restartCount int32
+	for _, cs := range p.Status.ContainerStatuses {
+		restartCount += cs.RestartCount
+	}
+
+	ready := 0
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Ready {
+			ready++
 		}
+	}
 
-		workloads = append(workloads, WorkloadInfo{
-			Name:         p.Name,
-			Namespace:    p.Namespace,
-			Type:         ResourcePods,
-			Ready:        fmt.Sprintf("%d/%d", ready, len(p.Spec.Containers)),
-			Age:          formatAge(p.CreationTimestamp.Time),
-			Status:       string(p.Status.Phase),
-			Labels:       p.Labels,
-			RestartCount: restartCount,
-		})
+	return WorkloadInfo{
+		Name:         p.Name,
+		Namespace:    p.Namespace,
+		Type:         ResourcePods,
+		Ready:        fmt.Sprintf("%d/%d", ready, len(p.Spec.Containers)),
+		Age:          formatAge(p.CreationTimestamp.Time),
+		Status:       string(p.Status.Phase),
+		Labels:       p.Labels,
+		ObjectLabels: p.Labels,
+		Annotations:  p.Annotations,
+		RestartCount: restartCount,
+		Image:        primaryImage(&p.Spec),
 	}
-	return workloads, nil
+}
+
+// primaryImage names the first container's image, the one a rollout usually
+// changes, noting how many other containers there are
+func primaryImage(spec *corev1.PodSpec) string {
+	if len(spec.Containers) == 0 {
+		return ""
+	}
+	image := spec.Containers[0].Image
+	if more := len(spec.Containers) - 1; more > 0 {
+		image += fmt.Sprintf(" +%d", more)
+	}
+	return image
 }
 
 func GetWorkloadPods(ctx context.Context, clientset *kubernetes.Clientset, workload WorkloadInfo) ([]PodInfo, error) {
@@ -294,6 +538,9 @@ func GetWorkloadPods(ctx context.Context, clientset *kubernetes.Clientset, workl
 		}
 		return []PodInfo{podToPodInfo(pod)}, nil
 	}
+	if len(workload.Labels) == 0 {
+		return nil, nil // e.g. a Service without a selector; an empty selector would match every pod
+	}
 
 	labelSelector := labels.SelectorFromSet(workload.Labels).String()
 	pods, err := clientset.CoreV1().Pods(workload.Namespace).List(ctx, metav1.ListOptions{
@@ -304,8 +551,8 @@ func GetWorkloadPods(ctx context.Context, clientset *kubernetes.Clientset, workl
 	}
 
 	var podInfos []PodInfo
-	for _, p := range pods.Items {
-		podInfos = append(podInfos, podToPodInfo(&p))
+	for i := range pods.Items {
+		podInfos = append(podInfos, podToPodInfo(&pods.Items[i]))
 	}
 	return podInfos, nil
 }
@@ -319,6 +566,19 @@ func GetPod(ctx context.Context, clientset *kubernetes.Clientset, namespace, nam
 	return &info, nil
 }
 
+func GetWorkload(ctx context.Context, clientset *kubernetes.Clientset, namespace string, resourceType ResourceType, name string) (*WorkloadInfo, error) {
+	workloads, err := ListWorkloads(ctx, clientset, namespace, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	for i := range workloads {
+		if workloads[i].Name == name {
+			return &workloads[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s not found in namespace %s", resourceType, name, namespace)
+}
+
 func podToPodInfo(p *corev1.Pod) PodInfo {
 	var restarts int32
 	var containers []ContainerInfo
@@ -338,11 +598,13 @@ func podToPodInfo(p *corev1.Pod) PodInfo {
 		for _, port := range c.Ports {
 			ci.Ports = append(ci.Ports, port.ContainerPort)
 		}
+		ci.Probes = containerProbes(c)
 
 		if i < len(p.Status.ContainerStatuses) {
 			cs := p.Status.ContainerStatuses[i]
 			ci.Ready = cs.Ready
 			ci.RestartCount = cs.RestartCount
+			ci.Digest = ImageDigest(cs.ImageID)
 			restarts += cs.RestartCount
 
 			if cs.State.Running != nil {
@@ -373,20 +635,22 @@ func podToPodInfo(p *corev1.Pod) PodInfo {
 	}
 
 	return PodInfo{
-		Name:       p.Name,
-		Namespace:  p.Namespace,
-		Node:       p.Spec.NodeName,
-		Status:     getPodStatus(p),
-		Ready:      fmt.Sprintf("%d/%d", ready, len(p.Spec.Containers)),
-		Restarts:   restarts,
-		Age:        formatAge(p.CreationTimestamp.Time),
-		IP:         p.Status.PodIP,
-		Labels:     p.Labels,
-		Containers: containers,
-		Conditions: p.Status.Conditions,
-		Phase:      p.Status.Phase,
-		OwnerRef:   ownerRef,
-		OwnerKind:  ownerKind,
+		Name:        p.Name,
+		Namespace:   p.Namespace,
+		Node:        p.Spec.NodeName,
+		Status:      getPodStatus(p),
+		Ready:       fmt.Sprintf("%d/%d", ready, len(p.Spec.Containers)),
+		Restarts:    restarts,
+		Age:         formatAge(p.CreationTimestamp.Time),
+		IP:          p.Status.PodIP,
+		Labels:      p.Labels,
+		Annotations: p.Annotations,
+		Containers:  containers,
+		Conditions:  p.Status.Conditions,
+		Phase:       p.Status.Phase,
+		OwnerRef:    ownerRef,
+		OwnerKind:   ownerKind,
+		Object:      p,
 	}
 }
 
@@ -414,15 +678,17 @@ type RelatedResources struct {
 	Ingresses  []IngressInfo
 	ConfigMaps []string
 	Secrets    []string
-	Owner      *OwnerInfo
+	Owner      *OwnerInfo // resolved as ResolveOwnerWorkload does, e.g. to the Deployment
+	Provenance *Provenance
 }
 
 type ServiceInfo struct {
-	Name      string
-	Type      string
-	ClusterIP string
-	Ports     string
-	Endpoints int
+	Name       string
+	Type       string
+	ClusterIP  string
+	ClusterIPs []string // one per family on a dual-stack Service
+	Ports      string
+	Endpoints  int
 }
 
 type IngressInfo struct {
@@ -436,89 +702,97 @@ type OwnerInfo struct {
 	Name string
 }
 
-func GetRelatedResources(ctx context.Context, clientset *kubernetes.Clientset, pod PodInfo) (*RelatedResources, error) {
+// GetRelatedResources finds the pod's owner, services, ingresses, and mounted
+// ConfigMaps/Secrets. Service and ingress lists come from lists when cached;
+// endpoints are fetched in one call and the pod spec is reused from pod.Object.
+func GetRelatedResources(ctx context.Context, clientset *kubernetes.Clientset, lists *ListCache, pod PodInfo) (*RelatedResources, error) {
 	related := &RelatedResources{}
 
 	if pod.OwnerRef != "" {
+		kind, name := ResolveOwnerWorkload(ctx, clientset, pod.Namespace, pod.OwnerKind, pod.OwnerRef)
 		related.Owner = &OwnerInfo{
-			Kind: pod.OwnerKind,
-			Name: pod.OwnerRef,
+			Kind: kind,
+			Name: name,
 		}
 	}
 
-	svcs, err := clientset.CoreV1().Services(pod.Namespace).List(ctx, metav1.ListOptions{})
-	if err == nil {
-		for _, svc := range svcs.Items {
-			if svc.Spec.Selector == nil {
-				continue
-			}
-			if labelsMatch(svc.Spec.Selector, pod.Labels) {
-				var ports []string
-				for _, p := range svc.Spec.Ports {
-					ports = append(ports, fmt.Sprintf("%d/%s", p.Port, p.Protocol))
-				}
-
-				eps, _ := clientset.CoreV1().Endpoints(pod.Namespace).Get(ctx, svc.Name, metav1.GetOptions{})
-				endpointCount := 0
-				if eps != nil {
-					for _, subset := range eps.Subsets {
-						endpointCount += len(subset.Addresses)
-					}
-				}
+	// A forbidden service list still leaves the owner and mounts worth showing
+	svcs, _ := lists.Services(ctx, clientset, pod.Namespace)
+	var matched []corev1.Service
+	for _, svc := range svcs {
+		if svc.Spec.Selector != nil && labelsMatch(svc.Spec.Selector, pod.Labels) {
+			matched = append(matched, svc)
+		}
+	}
 
-				related.Services = append(related.Services, ServiceInfo{
-					Name:      svc.Name,
-					Type:      string(svc.Spec.Type),
-					ClusterIP: svc.Spec.ClusterIP,
-					Ports:     strings.Join(ports, ", "),
-					Endpoints: endpointCount,
-				})
-			}
+	endpointCounts := getEndpointCounts(ctx, clientset, pod.Namespace, matched)
+	for _, svc := range matched {
+		var ports []string
+		for _, p := range svc.Spec.Ports {
+			ports = append(ports, fmt.Sprintf("%d/%s", p.Port, p.Protocol))
 		}
+		related.Services = append(related.Services, ServiceInfo{
+			Name:       svc.Name,
+			Type:       string(svc.Spec.Type),
+			ClusterIP:  svc.Spec.ClusterIP,
+			ClusterIPs: svc.Spec.ClusterIPs,
+			Ports:      strings.Join(ports, ", "),
+			Endpoints:  endpointCounts[svc.Name],
+		})
 	}
 
-	ings, err := clientset.NetworkingV1().Ingresses(pod.Namespace).List(ctx, metav1.ListOptions{})
-	if err == nil {
-		for _, svc := range related.Services {
-			for _, ing := range ings.Items {
-				if ingressReferencesService(ing, svc.Name) {
-					var hosts, paths []string
-					for _, rule := range ing.Spec.Rules {
-						hosts = append(hosts, rule.Host)
-						if rule.HTTP != nil {
-							for _, p := range rule.HTTP.Paths {
-								paths = append(paths, p.Path)
+	// Ingresses only matter when they route to one of the pod's services
+	if len(related.Services) > 0 {
+		if ings, err := lists.Ingresses(ctx, clientset, pod.Namespace); err == nil {
+			for _, svc := range related.Services {
+				for _, ing := range ings {
+					if ingressReferencesService(ing, svc.Name) {
+						var hosts, paths []string
+						for _, rule := range ing.Spec.Rules {
+							hosts = append(hosts, rule.Host)
+							if rule.HTTP != nil {
+								for _, p := range rule.HTTP.Paths {
+									paths = append(paths, p.Path)
+								}
 							}
 						}
+						related.Ingresses = append(related.Ingresses, IngressInfo{
+							Name:  ing.Name,
+							Hosts: strings.Join(hosts, ", "),
+							Paths: strings.Join(paths, ", "),
+						})
 					}
-					related.Ingresses = append(related.Ingresses, IngressInfo{
-						Name:  ing.Name,
-						Hosts: strings.Join(hosts, ", "),
-						Paths: strings.Join(paths, ", "),
-					})
 				}
 			}
 		}
 	}
 
-	podObj, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
-	if err == nil {
-		for _, vol := range podObj.Spec.Volumes {
-			if vol.ConfigMap != nil {
-				related.ConfigMaps = append(related.ConfigMaps, vol.ConfigMap.Name)
-			}
-			if vol.Secret != nil {
-				related.Secrets = append(related.Secrets, vol.Secret.SecretName)
-			}
+	podObj := pod.Object
+	if podObj == nil {
+		var err error
+		podObj, err = clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return related, nil
 		}
-		for _, c := range podObj.Spec.Containers {
-			for _, env := range c.EnvFrom {
-				if env.ConfigMapRef != nil {
-					related.ConfigMaps = append(related.ConfigMaps, env.ConfigMapRef.Name)
-				}
-				if env.SecretRef != nil {
-					related.Secrets = append(related.Secrets, env.SecretRef.Name)
-				}
+	}
+
+	related.Provenance = GetProvenance(ctx, clientset, podObj)
+
+	for _, vol := range podObj.Spec.Volumes {
+		if vol.ConfigMap != nil {
+			related.ConfigMaps = append(related.ConfigMaps, vol.ConfigMap.Name)
+		}
+		if vol.Secret != nil {
+			related.Secrets = append(related.Secrets, vol.Secret.SecretName)
+		}
+	}
+	for _, c := range podObj.Spec.Containers {
+		for _, env := range c.EnvFrom {
+			if env.ConfigMapRef != nil {
+				related.ConfigMaps = append(related.ConfigMaps, env.ConfigMapRef.Name)
+			}
+			if env.SecretRef != nil {
+				related.Secrets = append(related.Secrets, env.SecretRef.Name)
 			}
 		}
 	}
@@ -526,6 +800,37 @@ func GetRelatedResources(ctx context.Context, clientset *kubernetes.Clientset, p
 	return related, nil
 }
 
+// getEndpointCounts returns ready addresses per service, using a single Get
+// for one service and a single List for several
+func getEndpointCounts(ctx context.Context, clientset *kubernetes.Clientset, namespace string, svcs []corev1.Service) map[string]int {
+	counts := make(map[string]int, len(svcs))
+	var endpoints []corev1.Endpoints
+
+	switch len(svcs) {
+	case 0:
+		return counts
+	case 1:
+		eps, err := clientset.CoreV1().Endpoints(namespace).Get(ctx, svcs[0].Name, metav1.GetOptions{})
+		if err != nil {
+			return counts
+		}
+		endpoints = []corev1.Endpoints{*eps}
+	default:
+		list, err := clientset.CoreV1().Endpoints(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return counts
+		}
+		endpoints = list.Items
+	}
+
+	for _, eps := range endpoints {
+		for _, subset := range eps.Subsets {
+			counts[eps.Name] += len(subset.Addresses)
+		}
+	}
+	return counts
+}
+
 func labelsMatch(selector, labels map[string]string) bool {
 	for k, v := range selector {
 		if labels[k] != v {
@@ -569,24 +874,12 @@ func DeletePod(ctx context.Context, clientset *kubernetes.Clientset, namespace,
 	return clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
-func ScaleDeployment(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string, replicas int32) error {
-	scale, err := clientset.AppsV1().Deployments(namespace).GetScale(ctx, name, metav1.GetOptions{})
-	if err != nil {
-		return err
-	}
-	scale.Spec.Replicas = replicas
-	_, err = clientset.AppsV1().Deployments(namespace).UpdateScale(ctx, name, scale, metav1.UpdateOptions{})
-	return err
-}
-
-func ScaleStatefulSet(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string, replicas int32) error {
-	scale, err := clientset.AppsV1().StatefulSets(namespace).GetScale(ctx, name, metav1.GetOptions{})
-	if err != nil {
-		return err
-	}
-	scale.Spec.Replicas = replicas
-	_, err = clientset.AppsV1().StatefulSets(namespace).UpdateScale(ctx, name, scale, metav1.UpdateOptions{})
-	return err
+// EvictPod deletes a pod through the Eviction API, which refuses when a
+// PodDisruptionBudget would be violated
+func EvictPod(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) error {
+	return clientset.CoreV1().Pods(namespace).EvictV1(ctx, &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	})
 }
 
 func RestartDeployment(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) error {
@@ -2,6 +2,9 @@ package k8s
 
 import (
 	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestLabelsMatch(t *testing.T) {
@@ -86,6 +89,8 @@ func TestAllResourceTypes(t *testing.T) {
 		ResourceJobs:         true,
 		ResourceCronJobs:     true,
 		ResourcePods:         true,
+		ResourceServices:     true,
+		ResourceNodes:        true,
 	}
 
 	if len(AllResourceTypes) != len(expectedTypes) {
@@ -98,3 +103,82 @@ func TestAllResourceTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestParseResourceTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []ResourceType
+		wantErr bool
+	}{
+		{name: "empty enables all", names: nil, want: AllResourceTypes},
+		{
+			name:  "custom order, case and duplicates",
+			names: []string{"Pods", "deployments", "pods", " services "},
+			want:  []ResourceType{ResourcePods, ResourceDeployments, ResourceServices},
+		},
+		{name: "unknown type skipped", names: []string{"deployments", "widgets"}, want: []ResourceType{ResourceDeployments}, wantErr: true},
+		{name: "nothing known enables all", names: []string{"widgets"}, want: AllResourceTypes, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResourceTypes(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestDeploymentStatus(t *testing.T) {
+	condition := func(t appsv1.DeploymentConditionType, status corev1.ConditionStatus, reason, message string) appsv1.DeploymentCondition {
+		return appsv1.DeploymentCondition{Type: t, Status: status, Reason: reason, Message: message}
+	}
+	complete := condition(appsv1.DeploymentProgressing, corev1.ConditionTrue, "NewReplicaSetAvailable", `ReplicaSet "web-1" has successfully progressed.`)
+	available := condition(appsv1.DeploymentAvailable, corev1.ConditionTrue, "MinimumReplicasAvailable", "Deployment has minimum availability.")
+
+	tests := []struct {
+		name        string
+		paused      bool
+		status      appsv1.DeploymentStatus
+		want        string
+		wantMessage string
+	}{
+		{"rolled out", false, appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 3, Conditions: []appsv1.DeploymentCondition{available, complete}}, "Running", ""},
+		{"lost a pod after rolling out", false, appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 2, Conditions: []appsv1.DeploymentCondition{available, complete}}, "Progressing", ""},
+		{"paused", true, appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 1}, "Paused", "Deployment is paused"},
+		{"deadline exceeded", false, appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 2, Conditions: []appsv1.DeploymentCondition{
+			available,
+			condition(appsv1.DeploymentProgressing, corev1.ConditionFalse, "ProgressDeadlineExceeded", `ReplicaSet "web-2" has timed out progressing.`),
+		}}, "DeadlineExceeded", `ReplicaSet "web-2" has timed out progressing.`},
+		{"quota", false, appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+			condition(appsv1.DeploymentReplicaFailure, corev1.ConditionTrue, "FailedCreate", "pods is forbidden: exceeded quota"),
+			condition(appsv1.DeploymentAvailable, corev1.ConditionFalse, "MinimumReplicasUnavailable", "Deployment does not have minimum availability."),
+		}}, "ReplicaFailure", "pods is forbidden: exceeded quota"},
+		{"unavailable", false, appsv1.DeploymentStatus{Replicas: 2, Conditions: []appsv1.DeploymentCondition{
+			condition(appsv1.DeploymentAvailable, corev1.ConditionFalse, "MinimumReplicasUnavailable", "Deployment does not have minimum availability."),
+		}}, "NotReady", "Deployment does not have minimum availability."},
+		{"rolling", false, appsv1.DeploymentStatus{Replicas: 4, ReadyReplicas: 3, Conditions: []appsv1.DeploymentCondition{
+			available,
+			condition(appsv1.DeploymentProgressing, corev1.ConditionTrue, "ReplicaSetUpdated", `ReplicaSet "web-2" is progressing.`),
+		}}, "Progressing", `ReplicaSet "web-2" is progressing.`},
+		{"no conditions", false, appsv1.DeploymentStatus{Replicas: 2, ReadyReplicas: 1}, "Progressing", ""},
+	}
+	for _, tt := range tests {
+		d := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Paused: tt.paused}, Status: tt.status}
+		status, message := deploymentStatus(d)
+		if status != tt.want || message != tt.wantMessage {
+			t.Errorf("%s: deploymentStatus() = %q, %q, want %q, %q", tt.name, status, message, tt.want, tt.wantMessage)
+		}
+	}
+}
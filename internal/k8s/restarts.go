@@ -0,0 +1,145 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The kubelet's crash-loop back-off doubles up to this cap
+const maxRestartBackoff = 5 * time.Minute
+
+// RestartEntry is one point on a container's restart timeline
+type RestartEntry struct {
+	At     time.Time
+	What   string        // e.g. "Exited 137 (OOMKilled)", "Probe kill ×2", "Back-off ×6"
+	Detail string        // event message, when there is one
+	Ran    time.Duration // how long the run lasted, for terminations
+}
+
+// RestartTimeline describes how a container has been restarting
+type RestartTimeline struct {
+	Container string
+	Restarts  int32
+	Entries   []RestartEntry // oldest first
+	Interval  time.Duration  // average time between restarts, zero if unknown
+	Current   string         // what the container is doing now
+	Cadence   string         // one-line characterization of the crash loop
+}
+
+// BuildRestartTimelines combines each restarted container's last termination
+// with the pod's events into a timeline. Containers that never restarted are
+// skipped.
+func BuildRestartTimelines(pod *corev1.Pod, events []EventInfo, now time.Time) []RestartTimeline {
+	if pod == nil {
+		return nil
+	}
+
+	var timelines []RestartTimeline
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.RestartCount == 0 && cs.LastTerminationState.Terminated == nil {
+			continue
+		}
+
+		tl := RestartTimeline{Container: cs.Name, Restarts: cs.RestartCount}
+		var lastRan time.Duration
+
+		if t := cs.LastTerminationState.Terminated; t != nil {
+			what := fmt.Sprintf("Exited %d", t.ExitCode)
+			if t.Reason != "" {
+				what += " (" + t.Reason + ")"
+			}
+			entry := RestartEntry{At: t.FinishedAt.Time, What: what, Detail: t.Message}
+			if !t.StartedAt.IsZero() && !t.FinishedAt.IsZero() {
+				entry.Ran = t.FinishedAt.Sub(t.StartedAt.Time)
+				lastRan = entry.Ran
+			}
+			tl.Entries = append(tl.Entries, entry)
+		}
+
+		for _, e := range events {
+			if !mentionsContainer(e.Message, cs.Name) {
+				continue
+			}
+			switch e.Reason {
+			case "Started":
+				// Aggregated start events give the restart cadence directly
+				if e.Count > 1 && e.LastSeen.After(e.FirstSeen) {
+					tl.Interval = e.LastSeen.Sub(e.FirstSeen) / time.Duration(e.Count-1)
+				}
+			case "Killing":
+				// Only probe failures; "Stopping container" is a normal shutdown
+				if strings.Contains(e.Message, "will be restarted") {
+					tl.Entries = append(tl.Entries, RestartEntry{At: e.LastSeen, What: countLabel("Probe kill", e.Count), Detail: e.Message})
+				}
+			case "BackOff":
+				tl.Entries = append(tl.Entries, RestartEntry{At: e.LastSeen, What: countLabel("Back-off", e.Count)})
+			}
+		}
+
+		switch {
+		case cs.State.Running != nil:
+			tl.Current = "Running for " + FormatDuration(now.Sub(cs.State.Running.StartedAt.Time))
+		case cs.State.Waiting != nil:
+			tl.Current = "Waiting: " + cs.State.Waiting.Reason
+		case cs.State.Terminated != nil:
+			tl.Current = "Terminated: " + cs.State.Terminated.Reason
+		}
+
+		// Without start events, spread the restarts over the pod's lifetime
+		if tl.Interval == 0 && cs.RestartCount > 1 && !pod.CreationTimestamp.IsZero() {
+			tl.Interval = now.Sub(pod.CreationTimestamp.Time) / time.Duration(cs.RestartCount)
+		}
+
+		sort.SliceStable(tl.Entries, func(i, j int) bool {
+			return tl.Entries[i].At.Before(tl.Entries[j].At)
+		})
+		tl.Cadence = describeCadence(tl.Interval, lastRan)
+		timelines = append(timelines, tl)
+	}
+	return timelines
+}
+
+// describeCadence explains a crash loop from how often it restarts and how
+// long the last run lasted
+func describeCadence(interval, lastRan time.Duration) string {
+	switch {
+	case lastRan > 0 && lastRan < 10*time.Second:
+		return "crashes within seconds of starting; check the command, config and dependencies"
+	case interval >= maxRestartBackoff*9/10 && interval <= maxRestartBackoff*6/5:
+		return "restarting at the 5m back-off cap"
+	case interval > 0 && lastRan > 0 && lastRan > interval/2:
+		return fmt.Sprintf("runs ~%s before failing; look for leaks, probes or timeouts", FormatDuration(lastRan))
+	case interval > 0:
+		return "restarts every ~" + FormatDuration(interval)
+	}
+	return ""
+}
+
+// mentionsContainer matches kubelet messages such as "Started container app"
+// or "Container app failed liveness probe", not containers sharing a prefix
+func mentionsContainer(message, container string) bool {
+	msg := strings.ToLower(message)
+	needle := "container " + strings.ToLower(container)
+	for i := 0; ; {
+		j := strings.Index(msg[i:], needle)
+		if j < 0 {
+			return false
+		}
+		end := i + j + len(needle)
+		if end == len(msg) || strings.ContainsRune(" ,.:;\"'", rune(msg[end])) {
+			return true
+		}
+		i = end
+	}
+}
+
+func countLabel(label string, count int32) string {
+	if count > 1 {
+		return fmt.Sprintf("%s ×%d", label, count)
+	}
+	return label
+}
@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildRestartTimelines(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) metav1.Time { return metav1.NewTime(now.Add(-ago)) }
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: at(time.Hour)},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:         "app",
+					RestartCount: 6,
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:   137,
+							Reason:     "OOMKilled",
+							StartedAt:  at(2*time.Minute + 3*time.Second),
+							FinishedAt: at(2 * time.Minute),
+						},
+					},
+				},
+				{Name: "sidecar", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	}
+	events := []EventInfo{
+		{Reason: "Started", Message: "Started container app", Count: 6, FirstSeen: now.Add(-30 * time.Minute), LastSeen: now.Add(-5 * time.Minute)},
+		{Reason: "BackOff", Message: "Back-off restarting failed container app in pod web", Count: 4, LastSeen: now.Add(-time.Minute)},
+		{Reason: "BackOff", Message: "Back-off restarting failed container app-init in pod web", Count: 2, LastSeen: now},
+		{Reason: "Killing", Message: "Stopping container app", LastSeen: now.Add(-10 * time.Minute)},
+	}
+
+	timelines := BuildRestartTimelines(pod, events, now)
+	if len(timelines) != 1 {
+		t.Fatalf("got %d timelines, want 1 (sidecar never restarted)", len(timelines))
+	}
+	tl := timelines[0]
+
+	if tl.Interval != 5*time.Minute {
+		t.Errorf("Interval = %v, want 5m from the aggregated start events", tl.Interval)
+	}
+	if tl.Current != "Waiting: CrashLoopBackOff" {
+		t.Errorf("Current = %q", tl.Current)
+	}
+	if len(tl.Entries) != 2 {
+		t.Fatalf("got %d entries, want termination and back-off: %+v", len(tl.Entries), tl.Entries)
+	}
+	if tl.Entries[0].What != "Exited 137 (OOMKilled)" || tl.Entries[0].Ran != 3*time.Second {
+		t.Errorf("first entry = %+v", tl.Entries[0])
+	}
+	if tl.Entries[1].What != "Back-off ×4" {
+		t.Errorf("second entry = %+v", tl.Entries[1])
+	}
+	if tl.Cadence == "" {
+		t.Error("expected a cadence description")
+	}
+}
+
+func TestMentionsContainer(t *testing.T) {
+	tests := []struct {
+		message   string
+		container string
+		expected  bool
+	}{
+		{"Started container app", "app", true},
+		{"Container app failed liveness probe, will be restarted", "app", true},
+		{"Back-off restarting failed container app in pod web", "app", true},
+		{"Started container app-init", "app", false},
+		{"Started container app-init; container app pending", "app", true},
+		{"Pulling image", "app", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			if got := mentionsContainer(tt.message, tt.container); got != tt.expected {
+				t.Errorf("mentionsContainer(%q, %q) = %v, want %v", tt.message, tt.container, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{42 * time.Second, "42s"},
+		{4*time.Minute + 12*time.Second, "4m12s"},
+		{3*time.Hour + 5*time.Minute, "3h5m"},
+		{50 * time.Hour, "2d2h"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.expected {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Retry policy for transient API failures on idempotent reads
+const (
+	retryAttempts  = 3
+	retryBaseDelay = 250 * time.Millisecond
+	retryMaxDelay  = 4 * time.Second
+)
+
+// retrying counts requests currently waiting to be retried
+var retrying atomic.Int32
+
+// Retrying reports whether any API request is backing off after a transient
+// error, so the UI can say "retrying…" instead of flipping to an error
+func Retrying() bool {
+	return retrying.Load() > 0
+}
+
+// WrapRetry retries GET requests that fail with timeouts, connection resets,
+// 429 or 5xx-unavailable responses, backing off exponentially. Watches and
+// followed log streams are left alone.
+func WrapRetry(rt http.RoundTripper) http.RoundTripper {
+	return &retryTransport{next: rt, baseDelay: retryBaseDelay}
+}
+
+type retryTransport struct {
+	next      http.RoundTripper
+	baseDelay time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || isStreaming(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		retry, after := retryable(resp, err)
+		if !retry || attempt == retryAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		delay := t.baseDelay << attempt
+		if after > delay {
+			delay = after
+		}
+		delay = min(delay, retryMaxDelay)
+
+		if err := wait(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	retrying.Add(1)
+	defer retrying.Add(-1)
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isStreaming(req *http.Request) bool {
+	q := req.URL.Query()
+	return q.Get("watch") == "true" || q.Get("follow") == "true"
+}
+
+// retryable classifies a response as transient, returning the server's
+// Retry-After hint when it sent one
+func retryable(resp *http.Response, err error) (bool, time.Duration) {
+	if err != nil {
+		var netErr net.Error
+		switch {
+		case errors.Is(err, context.Canceled):
+			return false, 0
+		case errors.As(err, &netErr) && netErr.Timeout(),
+			errors.Is(err, syscall.ECONNRESET),
+			errors.Is(err, syscall.ECONNREFUSED),
+			errors.Is(err, io.ErrUnexpectedEOF),
+			errors.Is(err, io.EOF):
+			return true, 0
+		}
+		return false, 0
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		var after time.Duration
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			after = time.Duration(secs) * time.Second
+		}
+		return true, after
+	}
+	return false, 0
+}
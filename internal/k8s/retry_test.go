@@ -0,0 +1,79 @@
+package k8s
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		header    string
+		err       error
+		want      bool
+		wantAfter time.Duration
+	}{
+		{"ok", http.StatusOK, "", nil, false, 0},
+		{"forbidden", http.StatusForbidden, "", nil, false, 0},
+		{"throttled", http.StatusTooManyRequests, "2", nil, true, 2 * time.Second},
+		{"unavailable", http.StatusServiceUnavailable, "", nil, true, 0},
+		{"connection reset", 0, "", syscall.ECONNRESET, true, 0},
+		{"other error", 0, "", errors.New("x509: certificate signed by unknown authority"), false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status, Header: http.Header{}}
+				if tt.header != "" {
+					resp.Header.Set("Retry-After", tt.header)
+				}
+			}
+			got, after := retryable(resp, tt.err)
+			if got != tt.want || after != tt.wantAfter {
+				t.Errorf("retryable() = %v, %v; want %v, %v", got, after, tt.want, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{next: http.DefaultTransport, baseDelay: time.Millisecond}}
+
+	resp, err := client.Get(server.URL + "/api/v1/pods")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("got status %d after %d calls, want 200 after 3", resp.StatusCode, calls.Load())
+	}
+
+	// Writes are never retried
+	calls.Store(0)
+	resp, err = client.Post(server.URL+"/api/v1/pods", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Errorf("POST made %d calls, want 1", calls.Load())
+	}
+}
@@ -0,0 +1,301 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// rollbackSkippedAnnotations are managed by the deployment controller and
+// are not copied from a ReplicaSet back onto its Deployment on undo, as in
+// kubectl rollout undo
+var rollbackSkippedAnnotations = map[string]bool{
+	corev1.LastAppliedConfigAnnotation:          true,
+	revisionAnnotation:                          true,
+	"deployment.kubernetes.io/revision-history": true,
+	"deployment.kubernetes.io/desired-replicas": true,
+	"deployment.kubernetes.io/max-replicas":     true,
+	"deprecated.deployment.rollback.to":         true,
+}
+
+// RolloutRevision is one Deployment revision, backed by its ReplicaSet
+type RolloutRevision struct {
+	Revision    int64
+	ReplicaSet  string
+	ChangeCause string
+	Images      []string
+	Created     time.Time
+	Replicas    int32
+	Current     bool // the revision the Deployment runs now
+}
+
+// GetRolloutHistory lists a Deployment's revisions from the ReplicaSets it
+// owns, newest first
+func GetRolloutHistory(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) ([]RolloutRevision, error) {
+	dep, rsList, err := deploymentReplicaSets(ctx, clientset, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return rolloutHistory(dep, rsList), nil
+}
+
+// OwnerDeployment names the Deployment whose ReplicaSet owns the pod, or ""
+// for pods of other workloads
+func OwnerDeployment(ctx context.Context, clientset *kubernetes.Clientset, pod *PodInfo) string {
+	if pod.OwnerKind != "ReplicaSet" {
+		return ""
+	}
+	if kind, name := ResolveOwnerWorkload(ctx, clientset, pod.Namespace, pod.OwnerKind, pod.OwnerRef); kind == "Deployment" {
+		return name
+	}
+	return ""
+}
+
+func deploymentReplicaSets(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) (*appsv1.Deployment, []appsv1.ReplicaSet, error) {
+	dep, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(dep.Spec.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	rsList, err := clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, nil, err
+	}
+	return dep, rsList.Items, nil
+}
+
+func rolloutHistory(dep *appsv1.Deployment, replicaSets []appsv1.ReplicaSet) []RolloutRevision {
+	current := dep.Annotations[revisionAnnotation]
+
+	var revisions []RolloutRevision
+	for _, rs := range replicaSets {
+		if owner := metav1.GetControllerOf(&rs); owner == nil || owner.UID != dep.UID {
+			continue
+		}
+		revision, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		var images []string
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		revisions = append(revisions, RolloutRevision{
+			Revision:    revision,
+			ReplicaSet:  rs.Name,
+			ChangeCause: rs.Annotations[changeCauseAnnotation],
+			Images:      images,
+			Created:     rs.CreationTimestamp.Time,
+			Replicas:    rs.Status.Replicas,
+			Current:     rs.Annotations[revisionAnnotation] == current,
+		})
+	}
+
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision > revisions[j].Revision })
+	return revisions
+}
+
+// UndoDeployment rolls a Deployment back to an earlier revision the way
+// kubectl rollout undo does: the revision's pod template and annotations are
+// copied onto the Deployment, which then rolls out as a new revision
+func UndoDeployment(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string, revision int64) error {
+	dep, rsList, err := deploymentReplicaSets(ctx, clientset, namespace, name)
+	if err != nil {
+		return err
+	}
+	if dep.Spec.Paused {
+		return fmt.Errorf("deployment %s is paused; resume it before undoing", name)
+	}
+
+	var target *appsv1.ReplicaSet
+	for _, rev := range rolloutHistory(dep, rsList) {
+		if rev.Revision != revision {
+			continue
+		}
+		if rev.Current {
+			return fmt.Errorf("revision %d is already the current one", revision)
+		}
+		for i := range rsList {
+			if rsList[i].Name == rev.ReplicaSet {
+				target = &rsList[i]
+			}
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("revision %d not found", revision)
+	}
+
+	applyRollback(dep, target)
+	_, err = clientset.AppsV1().Deployments(namespace).Update(ctx, dep, metav1.UpdateOptions{})
+	return err
+}
+
+// applyRollback sets the Deployment's template and annotations to the
+// ReplicaSet's, minus the controller's pod-template-hash label
+func applyRollback(dep *appsv1.Deployment, rs *appsv1.ReplicaSet) {
+	template := rs.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	dep.Spec.Template = *template
+
+	for k := range dep.Annotations {
+		if !rollbackSkippedAnnotations[k] {
+			delete(dep.Annotations, k)
+		}
+	}
+	for k, v := range rs.Annotations {
+		if rollbackSkippedAnnotations[k] {
+			continue
+		}
+		if dep.Annotations == nil {
+			dep.Annotations = make(map[string]string)
+		}
+		dep.Annotations[k] = v
+	}
+}
+
+// FormatRolloutHistory renders revisions as a table, newest first
+func FormatRolloutHistory(revisions []RolloutRevision) string {
+	if len(revisions) == 0 {
+		return "No revisions found"
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  REVISION\tAGE\tPODS\tIMAGES\tCHANGE-CAUSE")
+	for _, r := range revisions {
+		marker := "  "
+		if r.Current {
+			marker = "→ "
+		}
+		cause := r.ChangeCause
+		if cause == "" {
+			cause = "<none>"
+		}
+		images := make([]string, len(r.Images))
+		for i, image := range r.Images {
+			images[i] = ShortImage(image)
+		}
+		fmt.Fprintf(tw, "%s%d\t%s\t%d\t%s\t%s\n", marker, r.Revision, formatAge(r.Created), r.Replicas, strings.Join(images, ", "), cause)
+	}
+	tw.Flush()
+	return b.String()
+}
+
+// RolloutState is how far a workload's rollout has got, worded like
+// kubectl rollout status
+type RolloutState struct {
+	Done    bool
+	Failed  bool // the Deployment exceeded its progress deadline
+	Message string
+}
+
+// GetRolloutState checks the rollout of a Deployment, StatefulSet or
+// DaemonSet
+func GetRolloutState(ctx context.Context, clientset *kubernetes.Clientset, namespace string, resourceType ResourceType, name string) (RolloutState, error) {
+	switch resourceType {
+	case ResourceDeployments:
+		d, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutState{}, err
+		}
+		return deploymentRolloutState(d), nil
+	case ResourceStatefulSets:
+		s, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutState{}, err
+		}
+		return statefulSetRolloutState(s), nil
+	case ResourceDaemonSets:
+		ds, err := clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutState{}, err
+		}
+		return daemonSetRolloutState(ds), nil
+	default:
+		return RolloutState{}, fmt.Errorf("%s have no rollout", resourceType)
+	}
+}
+
+// HasRollout reports whether GetRolloutState can follow the resource type
+func HasRollout(rt ResourceType) bool {
+	return rt == ResourceDeployments || rt == ResourceStatefulSets || rt == ResourceDaemonSets
+}
+
+func deploymentRolloutState(d *appsv1.Deployment) RolloutState {
+	if d.Generation > d.Status.ObservedGeneration {
+		return RolloutState{Message: "waiting for the spec update to be observed"}
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return RolloutState{Failed: true, Message: "exceeded its progress deadline"}
+		}
+	}
+	s := d.Status
+	switch {
+	case d.Spec.Replicas != nil && s.UpdatedReplicas < *d.Spec.Replicas:
+		return RolloutState{Message: fmt.Sprintf("%d of %d new replicas updated", s.UpdatedReplicas, *d.Spec.Replicas)}
+	case s.Replicas > s.UpdatedReplicas:
+		return RolloutState{Message: fmt.Sprintf("%d old replicas pending termination", s.Replicas-s.UpdatedReplicas)}
+	case s.AvailableReplicas < s.UpdatedReplicas:
+		return RolloutState{Message: fmt.Sprintf("%d of %d updated replicas available", s.AvailableReplicas, s.UpdatedReplicas)}
+	}
+	return RolloutState{Done: true, Message: "successfully rolled out"}
+}
+
+func statefulSetRolloutState(sts *appsv1.StatefulSet) RolloutState {
+	if sts.Generation > sts.Status.ObservedGeneration {
+		return RolloutState{Message: "waiting for the spec update to be observed"}
+	}
+	s := sts.Status
+	if sts.Spec.Replicas != nil && s.ReadyReplicas < *sts.Spec.Replicas {
+		return RolloutState{Message: fmt.Sprintf("%d of %d pods ready", s.ReadyReplicas, *sts.Spec.Replicas)}
+	}
+	// With OnDelete the controller leaves old pods be
+	if sts.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return RolloutState{Done: true, Message: "successfully rolled out"}
+	}
+	// With a partition only the pods with an ordinal at or above it are
+	// updated, so the revisions never converge
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil && *ru.Partition > 0 && sts.Spec.Replicas != nil {
+		want := max(0, *sts.Spec.Replicas-*ru.Partition)
+		if s.UpdatedReplicas < want {
+			return RolloutState{Message: fmt.Sprintf("%d of %d pods at or above partition %d updated", s.UpdatedReplicas, want, *ru.Partition)}
+		}
+		return RolloutState{Done: true, Message: fmt.Sprintf("partitioned roll out complete, %d pods updated", s.UpdatedReplicas)}
+	}
+	if s.UpdateRevision != s.CurrentRevision {
+		return RolloutState{Message: fmt.Sprintf("%d of %d pods updated", s.UpdatedReplicas, s.Replicas)}
+	}
+	return RolloutState{Done: true, Message: "successfully rolled out"}
+}
+
+func daemonSetRolloutState(ds *appsv1.DaemonSet) RolloutState {
+	if ds.Generation > ds.Status.ObservedGeneration {
+		return RolloutState{Message: "waiting for the spec update to be observed"}
+	}
+	s := ds.Status
+	switch {
+	case s.UpdatedNumberScheduled < s.DesiredNumberScheduled:
+		return RolloutState{Message: fmt.Sprintf("%d of %d pods updated", s.UpdatedNumberScheduled, s.DesiredNumberScheduled)}
+	case s.NumberAvailable < s.DesiredNumberScheduled:
+		return RolloutState{Message: fmt.Sprintf("%d of %d updated pods available", s.NumberAvailable, s.DesiredNumberScheduled)}
+	}
+	return RolloutState{Done: true, Message: "successfully rolled out"}
+}
@@ -0,0 +1,151 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRolloutHistory(t *testing.T) {
+	isController := true
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			UID:         "dep-uid",
+			Annotations: map[string]string{revisionAnnotation: "3", "team": "shop"},
+		},
+	}
+	rs := func(name, revision, cause, image string, owner string) appsv1.ReplicaSet {
+		annotations := map[string]string{revisionAnnotation: revision}
+		if cause != "" {
+			annotations[changeCauseAnnotation] = cause
+		}
+		return appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Annotations:     annotations,
+				OwnerReferences: []metav1.OwnerReference{{UID: "dep-uid", Name: owner, Controller: &isController}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: name}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+			}},
+		}
+	}
+	replicaSets := []appsv1.ReplicaSet{
+		rs("web-a", "1", "", "ghcr.io/acme/web:v1", "web"),
+		rs("web-c", "3", "bump to v3", "ghcr.io/acme/web:v3", "web"),
+		rs("web-b", "2", "bump to v2", "ghcr.io/acme/web:v2", "web"),
+	}
+	// A ReplicaSet the Deployment does not own
+	stray := rs("other", "7", "", "acme/other:v1", "other")
+	stray.OwnerReferences[0].UID = "other-uid"
+	replicaSets = append(replicaSets, stray)
+
+	revisions := rolloutHistory(dep, replicaSets)
+	if len(revisions) != 3 {
+		t.Fatalf("got %d revisions, want 3: %+v", len(revisions), revisions)
+	}
+	for i, want := range []int64{3, 2, 1} {
+		if revisions[i].Revision != want {
+			t.Errorf("revisions[%d] = %d, want %d", i, revisions[i].Revision, want)
+		}
+	}
+	if !revisions[0].Current || revisions[1].Current {
+		t.Errorf("only revision 3 should be current: %+v", revisions)
+	}
+
+	out := FormatRolloutHistory(revisions)
+	if !strings.Contains(out, "→ 3") || !strings.Contains(out, "acme/web:v2") || !strings.Contains(out, "<none>") {
+		t.Errorf("unexpected history table:\n%s", out)
+	}
+
+	applyRollback(dep, &replicaSets[2])
+	if got := dep.Spec.Template.Spec.Containers[0].Image; got != "ghcr.io/acme/web:v2" {
+		t.Errorf("template image = %q after rollback", got)
+	}
+	if _, ok := dep.Spec.Template.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+		t.Error("pod-template-hash label should be dropped")
+	}
+	if dep.Annotations[revisionAnnotation] != "3" || dep.Annotations[changeCauseAnnotation] != "bump to v2" {
+		t.Errorf("annotations after rollback = %v", dep.Annotations)
+	}
+	if _, ok := dep.Annotations["team"]; ok {
+		t.Error("annotations not on the revision should be removed, as kubectl does")
+	}
+	if replicaSets[2].Spec.Template.Labels[appsv1.DefaultDeploymentUniqueLabelKey] == "" {
+		t.Error("rollback modified the ReplicaSet's template")
+	}
+}
+
+func TestDeploymentRolloutState(t *testing.T) {
+	three := int32(3)
+	dep := func(generation, observed int64, updated, replicas, available int32, conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: &three},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: observed,
+				UpdatedReplicas:    updated,
+				Replicas:           replicas,
+				AvailableReplicas:  available,
+				Conditions:         conditions,
+			},
+		}
+	}
+	deadline := appsv1.DeploymentCondition{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"}
+
+	tests := []struct {
+		name    string
+		dep     *appsv1.Deployment
+		done    bool
+		failed  bool
+		message string
+	}{
+		{"not observed", dep(2, 1, 3, 3, 3), false, false, "waiting for the spec update to be observed"},
+		{"updating", dep(2, 2, 1, 4, 3), false, false, "1 of 3 new replicas updated"},
+		{"old pods left", dep(2, 2, 3, 4, 3), false, false, "1 old replicas pending termination"},
+		{"unavailable", dep(2, 2, 3, 3, 2), false, false, "2 of 3 updated replicas available"},
+		{"done", dep(2, 2, 3, 3, 3), true, false, "successfully rolled out"},
+		{"deadline", dep(2, 2, 1, 4, 3, deadline), false, true, "exceeded its progress deadline"},
+	}
+	for _, tt := range tests {
+		got := deploymentRolloutState(tt.dep)
+		if got.Done != tt.done || got.Failed != tt.failed || got.Message != tt.message {
+			t.Errorf("%s: got %+v", tt.name, got)
+		}
+	}
+}
+
+func TestStatefulSetRolloutState(t *testing.T) {
+	two := int32(2)
+	sts := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:       &two,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+		},
+		Status: appsv1.StatefulSetStatus{ReadyReplicas: 2, Replicas: 2, UpdatedReplicas: 1, CurrentRevision: "a", UpdateRevision: "b"},
+	}
+	if got := statefulSetRolloutState(sts); got.Done || got.Message != "1 of 2 pods updated" {
+		t.Errorf("mid-rollout: got %+v", got)
+	}
+	sts.Status.CurrentRevision = "b"
+	if got := statefulSetRolloutState(sts); !got.Done {
+		t.Errorf("rolled out: got %+v", got)
+	}
+
+	// A partition of 1 only updates web-1; web-0 keeps the old revision
+	one := int32(1)
+	sts.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: &one}
+	sts.Status = appsv1.StatefulSetStatus{ReadyReplicas: 2, Replicas: 2, UpdatedReplicas: 0, CurrentRevision: "a", UpdateRevision: "b"}
+	if got := statefulSetRolloutState(sts); got.Done || got.Message != "0 of 1 pods at or above partition 1 updated" {
+		t.Errorf("partitioned, pending: got %+v", got)
+	}
+	sts.Status.UpdatedReplicas = 1
+	if got := statefulSetRolloutState(sts); !got.Done {
+		t.Errorf("partitioned, updated: got %+v", got)
+	}
+}
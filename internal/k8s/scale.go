@@ -0,0 +1,60 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
+)
+
+// scaleResources maps the resource types k9sight scales to the resource
+// serving their /scale subresource
+var scaleResources = map[ResourceType]schema.GroupResource{
+	ResourceDeployments:  {Group: "apps", Resource: "deployments"},
+	ResourceStatefulSets: {Group: "apps", Resource: "statefulsets"},
+}
+
+// IsScalable reports whether workloads of this type have a replica count
+func IsScalable(resourceType ResourceType) bool {
+	_, ok := scaleResources[resourceType]
+	return ok
+}
+
+// newScaleClient builds a client for the /scale subresource of any resource,
+// including custom resources, resolving their group and version through
+// discovery
+func newScaleClient(config *rest.Config, disc discovery.DiscoveryInterface) (scale.ScalesGetter, error) {
+	cached := memory.NewMemCacheClient(disc)
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(cached)
+	return scale.NewForConfig(config, mapper, dynamic.LegacyAPIPathResolverFunc, scale.NewDiscoveryScaleKindResolver(cached))
+}
+
+// Scale sets the replica count of any resource that serves /scale:
+// Deployments, StatefulSets, ReplicaSets, and custom resources that
+// implement it, e.g. {Group: "argoproj.io", Resource: "rollouts"}
+func Scale(ctx context.Context, scales scale.ScalesGetter, resource schema.GroupResource, namespace, name string, replicas int32) error {
+	current, err := scales.Scales(namespace).Get(ctx, resource, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	current.Spec.Replicas = replicas
+	_, err = scales.Scales(namespace).Update(ctx, resource, current, metav1.UpdateOptions{})
+	return err
+}
+
+// scaleResource is the resource to scale workloads of the type through
+func scaleResource(resourceType ResourceType) (schema.GroupResource, error) {
+	resource, ok := scaleResources[resourceType]
+	if !ok {
+		// DaemonSets run one pod per node and Jobs size by parallelism
+		return schema.GroupResource{}, fmt.Errorf("%s cannot be scaled", resourceType)
+	}
+	return resource, nil
+}
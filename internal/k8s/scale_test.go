@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestScaleResource(t *testing.T) {
+	if gr, err := scaleResource(ResourceStatefulSets); err != nil || gr.Group != "apps" || gr.Resource != "statefulsets" {
+		t.Errorf("scaleResource(statefulsets) = %v, %v", gr, err)
+	}
+	if _, err := scaleResource(ResourceDaemonSets); err == nil {
+		t.Error("daemonsets should not be scalable")
+	}
+	if !IsScalable(ResourceDeployments) || IsScalable(ResourceJobs) {
+		t.Error("IsScalable disagrees with scaleResources")
+	}
+}
+
+func TestScale(t *testing.T) {
+	rollouts := schema.GroupResource{Group: "argoproj.io", Resource: "rollouts"}
+	scales := &fakescale.FakeScaleClient{}
+	scales.AddReactor("get", "rollouts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		name := action.(clienttesting.GetAction).GetName()
+		return true, &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: action.GetNamespace(), ResourceVersion: "7"},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: 2},
+		}, nil
+	})
+	var updated *autoscalingv1.Scale
+	scales.AddReactor("update", "rollouts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		updated = action.(clienttesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		return true, updated, nil
+	})
+
+	if err := Scale(context.Background(), scales, rollouts, "shop", "web", 5); err != nil {
+		t.Fatalf("Scale() error = %v", err)
+	}
+	if updated == nil {
+		t.Fatal("Scale() did not update the /scale subresource")
+	}
+	// The read resourceVersion is sent back, so a concurrent change conflicts
+	if updated.Name != "web" || updated.Spec.Replicas != 5 || updated.ResourceVersion != "7" {
+		t.Errorf("updated scale = %+v", updated)
+	}
+	for _, a := range scales.Actions() {
+		if a.GetSubresource() != "scale" || a.GetNamespace() != "shop" {
+			t.Errorf("action %s %s/%s, want the shop scale subresource", a.GetVerb(), a.GetNamespace(), a.GetSubresource())
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NodeFit is whether a pod could be scheduled onto one node, and why not
+type NodeFit struct {
+	Node    string
+	Reasons []string // empty when the pod fits
+}
+
+// Fits reports whether no predicate failed on the node
+func (f NodeFit) Fits() bool {
+	return len(f.Reasons) == 0
+}
+
+// SimulateScheduling checks a pod against every node the way the scheduler's
+// filters do: cordoning, nodeSelector, required node affinity, taints and
+// free resources. Inter-pod affinity, topology spread, host ports and volume
+// zones are not checked, so a pod can still be refused on a node that fits.
+func SimulateScheduling(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) ([]NodeFit, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return scheduleFits(pod, nodes.Items, pods.Items), nil
+}
+
+func scheduleFits(pod *corev1.Pod, nodes []corev1.Node, pods []corev1.Pod) []NodeFit {
+	// What each node already has requested by the pods bound to it
+	requested := make(map[string]corev1.ResourceList)
+	for i := range pods {
+		p := &pods[i]
+		if p.Spec.NodeName == "" || p.UID == pod.UID ||
+			p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		total, ok := requested[p.Spec.NodeName]
+		if !ok {
+			total = corev1.ResourceList{}
+			requested[p.Spec.NodeName] = total
+		}
+		req, _ := podResources(&p.Spec)
+		req[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+		addResources(total, req)
+	}
+
+	need, _ := podResources(&pod.Spec)
+	need[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+
+	fits := make([]NodeFit, 0, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		fit := NodeFit{Node: node.Name}
+		if pod.Spec.NodeName != "" && pod.Spec.NodeName != node.Name {
+			fit.Reasons = append(fit.Reasons, "pod is bound to "+pod.Spec.NodeName)
+		}
+		if node.Spec.Unschedulable && !toleratesTaint(pod.Spec.Tolerations, &corev1.Taint{
+			Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule,
+		}) {
+			fit.Reasons = append(fit.Reasons, "node is cordoned")
+		}
+		if reason := nodeMismatch(&pod.Spec, pod.Spec.Tolerations, node); reason != "" {
+			fit.Reasons = append(fit.Reasons, reason)
+		}
+		fit.Reasons = append(fit.Reasons, insufficientResources(need, requested[node.Name], node.Status.Allocatable)...)
+		fits = append(fits, fit)
+	}
+
+	sort.SliceStable(fits, func(i, j int) bool {
+		if fits[i].Fits() != fits[j].Fits() {
+			return fits[i].Fits()
+		}
+		return fits[i].Node < fits[j].Node
+	})
+	return fits
+}
+
+// insufficientResources names each resource the pod requests more of than
+// the node has left, e.g. "insufficient cpu: needs 500m, 200m free"
+func insufficientResources(need, requested, allocatable corev1.ResourceList) []string {
+	var reasons []string
+	for _, name := range sortedResourceNames(need) {
+		want := need[name]
+		if want.IsZero() {
+			continue
+		}
+		alloc, ok := allocatable[name]
+		if !ok {
+			// Extended resources (e.g. GPUs) the node does not offer at all
+			reasons = append(reasons, fmt.Sprintf("no %s on node", name))
+			continue
+		}
+		free := alloc.DeepCopy()
+		free.Sub(requested[name])
+		if want.Cmp(free) > 0 {
+			if free.Sign() < 0 {
+				free = resource.Quantity{}
+			}
+			reasons = append(reasons, fmt.Sprintf("insufficient %s: needs %s, %s free",
+				name, formatQuantity(name, want), formatQuantity(name, free)))
+		}
+	}
+	return reasons
+}
+
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// FormatScheduling renders the per-node result as a table with a summary line
+func FormatScheduling(fits []NodeFit) string {
+	fitting := 0
+	nodeWidth := len("NODE")
+	for _, f := range fits {
+		if f.Fits() {
+			fitting++
+		}
+		nodeWidth = max(nodeWidth, len(f.Node))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d/%d nodes fit this pod", fitting, len(fits))
+	if fitting > 0 {
+		b.WriteString(" (inter-pod affinity, topology spread and volumes were not checked)")
+	}
+	b.WriteString("\n\n")
+
+	format := "%s%-" + strconv.Itoa(nodeWidth) + "s  %s\n"
+	fmt.Fprintf(&b, format, "  ", "NODE", "RESULT")
+	for _, f := range fits {
+		if f.Fits() {
+			fmt.Fprintf(&b, format, "✓ ", f.Node, "fits")
+			continue
+		}
+		fmt.Fprintf(&b, format, "✗ ", f.Node, strings.Join(f.Reasons, "; "))
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScheduleFits(t *testing.T) {
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+		corev1.ResourcePods:   resource.MustParse("10"),
+	}
+	node := func(name string, labels map[string]string, spec corev1.NodeSpec) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       spec,
+			Status:     corev1.NodeStatus{Allocatable: allocatable},
+		}
+	}
+	requests := func(cpu, mem string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(mem),
+		}}
+	}
+
+	nodes := []corev1.Node{
+		node("busy", map[string]string{"disk": "ssd"}, corev1.NodeSpec{}),
+		node("free", map[string]string{"disk": "ssd"}, corev1.NodeSpec{}),
+		node("hdd", map[string]string{"disk": "hdd"}, corev1.NodeSpec{}),
+		node("tainted", map[string]string{"disk": "ssd"}, corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule}},
+		}),
+		node("cordoned", map[string]string{"disk": "ssd"}, corev1.NodeSpec{Unschedulable: true}),
+	}
+	pods := []corev1.Pod{
+		{
+			Spec:   corev1.PodSpec{NodeName: "busy", Containers: []corev1.Container{{Resources: requests("1800m", "1Gi")}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			// Finished pods no longer hold resources
+			Spec:   corev1.PodSpec{NodeName: "free", Containers: []corev1.Container{{Resources: requests("2", "1Gi")}}},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	}
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", UID: "pending"},
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{"disk": "ssd"},
+			Containers:   []corev1.Container{{Resources: requests("500m", "1Gi")}},
+		},
+	}
+
+	fits := scheduleFits(pending, nodes, pods)
+	got := make(map[string]string)
+	for _, f := range fits {
+		got[f.Node] = strings.Join(f.Reasons, "; ")
+	}
+	want := map[string]string{
+		"free":     "",
+		"busy":     "insufficient cpu: needs 500m, 200m free",
+		"hdd":      "nodeSelector disk=ssd",
+		"tainted":  "taint dedicated=db:NoSchedule",
+		"cordoned": "node is cordoned",
+	}
+	for name, reasons := range want {
+		if got[name] != reasons {
+			t.Errorf("%s: reasons %q, want %q", name, got[name], reasons)
+		}
+	}
+	if fits[0].Node != "free" || !fits[0].Fits() {
+		t.Errorf("fitting nodes should come first, got %+v", fits[0])
+	}
+
+	out := FormatScheduling(fits)
+	if !strings.HasPrefix(out, "1/5 nodes fit this pod") {
+		t.Errorf("summary line = %q", strings.SplitN(out, "\n", 2)[0])
+	}
+}
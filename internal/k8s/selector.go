@@ -0,0 +1,47 @@
+package k8s
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// LabelFilter narrows a workload list to the objects whose own labels match
+// a label selector such as "app=web,tier!=cache"
+type LabelFilter struct {
+	text     string
+	selector labels.Selector
+}
+
+// ParseLabelFilter parses a selector in kubectl's -l syntax. An empty
+// selector gives a nil filter, which matches everything.
+func ParseLabelFilter(text string) (*LabelFilter, error) {
+	if text == "" {
+		return nil, nil
+	}
+	selector, err := labels.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &LabelFilter{text: selector.String(), selector: selector}, nil
+}
+
+// String returns the selector in canonical form
+func (f *LabelFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.text
+}
+
+// Filter returns the workloads the selector matches, in order
+func (f *LabelFilter) Filter(workloads []WorkloadInfo) []WorkloadInfo {
+	if f == nil {
+		return workloads
+	}
+	var matched []WorkloadInfo
+	for _, w := range workloads {
+		if f.selector.Matches(labels.Set(w.ObjectLabels)) {
+			matched = append(matched, w)
+		}
+	}
+	return matched
+}
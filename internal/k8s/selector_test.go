@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"testing"
+)
+
+func TestLabelFilter(t *testing.T) {
+	workloads := []WorkloadInfo{
+		{Name: "web", ObjectLabels: map[string]string{"app": "web", "tier": "frontend"}},
+		{Name: "cache", ObjectLabels: map[string]string{"app": "web", "tier": "cache"}},
+		{Name: "db", ObjectLabels: map[string]string{"app": "db"}},
+		{Name: "bare"},
+	}
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"", []string{"web", "cache", "db", "bare"}},
+		{"app=web", []string{"web", "cache"}},
+		{"app=web,tier!=cache", []string{"web"}},
+		{"app in (db, web)", []string{"web", "cache", "db"}},
+		{"!tier", []string{"db", "bare"}},
+	}
+	for _, tt := range tests {
+		f, err := ParseLabelFilter(tt.selector)
+		if err != nil {
+			t.Errorf("ParseLabelFilter(%q): %v", tt.selector, err)
+			continue
+		}
+		var got []string
+		for _, w := range f.Filter(workloads) {
+			got = append(got, w.Name)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q matched %v, want %v", tt.selector, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q matched %v, want %v", tt.selector, got, tt.want)
+				break
+			}
+		}
+	}
+
+	if _, err := ParseLabelFilter("app in (web"); err == nil {
+		t.Error("ParseLabelFilter(\"app in (web\") should fail")
+	}
+}
@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PodSnapshot is the pod's state as one dashboard refresh saw it, to look
+// back at states that have since passed
+type PodSnapshot struct {
+	At          time.Time
+	Status      string
+	Ready       string
+	Restarts    int32
+	Conditions  []string // "Ready=False (ContainersNotReady)"
+	Containers  []string // "app: Running, ready, 2 restarts"
+	Events      int
+	Warnings    int
+	HasMetrics  bool
+	CPUMilli    int64
+	MemoryBytes int64
+}
+
+// TakePodSnapshot records the pod with the events and metrics last loaded
+// for it; metrics may be nil
+func TakePodSnapshot(pod *PodInfo, events []EventInfo, metrics *PodMetrics, at time.Time) PodSnapshot {
+	s := PodSnapshot{
+		At:       at,
+		Status:   pod.Status,
+		Ready:    pod.Ready,
+		Restarts: pod.Restarts,
+		Events:   len(events),
+	}
+	for _, c := range pod.Conditions {
+		cond := fmt.Sprintf("%s=%s", c.Type, c.Status)
+		if c.Reason != "" {
+			cond += " (" + c.Reason + ")"
+		}
+		s.Conditions = append(s.Conditions, cond)
+	}
+	for _, c := range pod.Containers {
+		state := c.State
+		if c.Reason != "" {
+			state += " (" + c.Reason + ")"
+		}
+		if c.Ready {
+			state += ", ready"
+		}
+		s.Containers = append(s.Containers, fmt.Sprintf("%s: %s, %d restarts", c.Name, state, c.RestartCount))
+	}
+	for _, e := range events {
+		if e.Type == "Warning" {
+			s.Warnings++
+		}
+	}
+	if metrics != nil {
+		s.HasMetrics = true
+		for _, c := range metrics.Containers {
+			s.CPUMilli += c.CPUMilli
+			s.MemoryBytes += c.MemoryBytes
+		}
+	}
+	return s
+}
+
+// FormatPodSnapshot renders a snapshot, marking each value that differs in
+// the latest one with what it is now
+func FormatPodSnapshot(s, latest PodSnapshot, now time.Time) string {
+	var b strings.Builder
+	field := func(name, then, current string) {
+		b.WriteString(fmt.Sprintf("%-12s %s", name+":", then))
+		if current != then {
+			b.WriteString(fmt.Sprintf("   (now %s)", current))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(fmt.Sprintf("%s, %s ago\n\n", s.At.Format("15:04:05"), FormatDuration(now.Sub(s.At))))
+	field("Status", s.Status, latest.Status)
+	field("Ready", s.Ready, latest.Ready)
+	field("Restarts", fmt.Sprint(s.Restarts), fmt.Sprint(latest.Restarts))
+	field("Events", snapshotEvents(s), snapshotEvents(latest))
+	if s.HasMetrics {
+		field("Usage", snapshotUsage(s), snapshotUsage(latest))
+	}
+
+	list := func(name string, then, current []string) {
+		b.WriteString("\n" + name + ":\n")
+		for _, item := range then {
+			mark := " "
+			if !containsString(current, item) {
+				mark = "*" // changed since
+			}
+			b.WriteString(fmt.Sprintf("  %s %s\n", mark, item))
+		}
+	}
+	list("Conditions", s.Conditions, latest.Conditions)
+	list("Containers", s.Containers, latest.Containers)
+	b.WriteString("\n* no longer so\n")
+	return b.String()
+}
+
+func snapshotEvents(s PodSnapshot) string {
+	return fmt.Sprintf("%d (%d warnings)", s.Events, s.Warnings)
+}
+
+func snapshotUsage(s PodSnapshot) string {
+	if !s.HasMetrics {
+		return "unknown"
+	}
+	return fmt.Sprintf("CPU %dm, memory %s", s.CPUMilli, formatMemory(s.MemoryBytes))
+}
@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPodSnapshot(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ready := &PodInfo{
+		Status: "Running", Ready: "1/1", Restarts: 2,
+		Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		Containers: []ContainerInfo{{Name: "app", State: "Running", Ready: true, RestartCount: 2}},
+	}
+	events := []EventInfo{{Type: "Normal"}, {Type: "Warning"}}
+	metrics := &PodMetrics{Containers: []ContainerMetrics{{CPUMilli: 100, MemoryBytes: 64 << 20}, {CPUMilli: 20}}}
+
+	then := TakePodSnapshot(ready, events, metrics, at)
+	if then.Warnings != 1 || then.CPUMilli != 120 || !then.HasMetrics {
+		t.Errorf("snapshot = %+v", then)
+	}
+	if then.Containers[0] != "app: Running, ready, 2 restarts" || then.Conditions[0] != "Ready=True" {
+		t.Errorf("containers %q, conditions %q", then.Containers, then.Conditions)
+	}
+
+	crashing := &PodInfo{
+		Status: "CrashLoopBackOff", Ready: "0/1", Restarts: 3,
+		Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady"}},
+		Containers: []ContainerInfo{{Name: "app", State: "Waiting", Reason: "CrashLoopBackOff", RestartCount: 3}},
+	}
+	now := TakePodSnapshot(crashing, events, nil, at.Add(2*time.Minute))
+	out := FormatPodSnapshot(then, now, at.Add(2*time.Minute))
+	for _, want := range []string{
+		"12:00:00, 2m0s ago",
+		"Status:      Running   (now CrashLoopBackOff)",
+		"Ready:       1/1   (now 0/1)",
+		"Events:      2 (1 warnings)\n",
+		"Usage:       CPU 120m, memory 64.0Mi   (now unknown)",
+		"* Ready=True",
+		"* app: Running, ready, 2 restarts",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("FormatPodSnapshot() missing %q in:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// StatefulSetStatus is what the pod list needs to show a StatefulSet by
+// ordinal: its rollout state and the claims behind each ordinal
+type StatefulSetStatus struct {
+	Name            string
+	Replicas        int32
+	Partition       int32 // ordinals below it stay on the old revision
+	OrderedReady    bool  // pods start, stop and update one at a time
+	UpdateRevision  string
+	CurrentRevision string
+	ClaimTemplates  []string
+	Claims          map[string]ClaimBinding // by claim name
+}
+
+// ClaimBinding is the state of one ordinal's PersistentVolumeClaim
+type ClaimBinding struct {
+	Name     string
+	Phase    string // Bound, Pending, Lost, or Missing when not created
+	Capacity string
+}
+
+// GetStatefulSetStatus reads the StatefulSet's rollout state and the claims
+// its pods mount, which its volumeClaimTemplates created. pods are the set's
+// pods as the caller already listed them; only their claims are read.
+func GetStatefulSetStatus(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string, pods []PodInfo) (*StatefulSetStatus, error) {
+	sts, err := GetStatefulSet(ctx, clientset, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pvcs []corev1.PersistentVolumeClaim
+	if len(sts.Spec.VolumeClaimTemplates) > 0 {
+		for _, claim := range podClaimNames(pods) {
+			// Claims are missing from the status rather than failing it
+			if pvc, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claim, metav1.GetOptions{}); err == nil {
+				pvcs = append(pvcs, *pvc)
+			}
+		}
+	}
+	return statefulSetStatus(sts, pvcs), nil
+}
+
+// podClaimNames lists the PersistentVolumeClaims the pods mount, once each
+func podClaimNames(pods []PodInfo) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, p := range pods {
+		if p.Object == nil {
+			continue
+		}
+		for _, v := range p.Object.Spec.Volumes {
+			if v.PersistentVolumeClaim == nil || seen[v.PersistentVolumeClaim.ClaimName] {
+				continue
+			}
+			seen[v.PersistentVolumeClaim.ClaimName] = true
+			names = append(names, v.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	return names
+}
+
+func statefulSetStatus(sts *appsv1.StatefulSet, pvcs []corev1.PersistentVolumeClaim) *StatefulSetStatus {
+	s := &StatefulSetStatus{
+		Name:            sts.Name,
+		OrderedReady:    sts.Spec.PodManagementPolicy != appsv1.ParallelPodManagement,
+		UpdateRevision:  sts.Status.UpdateRevision,
+		CurrentRevision: sts.Status.CurrentRevision,
+		Claims:          make(map[string]ClaimBinding),
+	}
+	s.Replicas = 1
+	if sts.Spec.Replicas != nil {
+		s.Replicas = *sts.Spec.Replicas
+	}
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		s.Partition = *ru.Partition
+	}
+	for _, t := range sts.Spec.VolumeClaimTemplates {
+		s.ClaimTemplates = append(s.ClaimTemplates, t.Name)
+	}
+	for _, pvc := range pvcs {
+		binding := ClaimBinding{Name: pvc.Name, Phase: string(pvc.Status.Phase)}
+		if size, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			binding.Capacity = size.String()
+		}
+		s.Claims[pvc.Name] = binding
+	}
+	return s
+}
+
+// PodOrdinal returns the ordinal of a StatefulSet pod, the number after the
+// set's name
+func PodOrdinal(setName, podName string) (int, bool) {
+	suffix, ok := strings.CutPrefix(podName, setName+"-")
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	return ordinal, err == nil && ordinal >= 0
+}
+
+// SortPodsByOrdinal orders pods by ordinal instead of by name, so web-10
+// follows web-9; other pods go last
+func SortPodsByOrdinal(setName string, pods []PodInfo) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		oi, iok := PodOrdinal(setName, pods[i].Name)
+		oj, jok := PodOrdinal(setName, pods[j].Name)
+		if iok != jok {
+			return iok
+		}
+		return oi < oj
+	})
+}
+
+// PodClaims returns the claims the volumeClaimTemplates give a pod
+func (s *StatefulSetStatus) PodClaims(podName string) []ClaimBinding {
+	claims := make([]ClaimBinding, 0, len(s.ClaimTemplates))
+	for _, t := range s.ClaimTemplates {
+		name := t + "-" + podName
+		binding, ok := s.Claims[name]
+		if !ok {
+			binding = ClaimBinding{Name: name, Phase: "Missing"}
+		}
+		claims = append(claims, binding)
+	}
+	return claims
+}
+
+// Rolling reports whether an update is still being rolled out
+func (s *StatefulSetStatus) Rolling() bool {
+	return s.UpdateRevision != "" && s.UpdateRevision != s.CurrentRevision
+}
+
+// Updated reports whether a pod runs the update revision
+func (s *StatefulSetStatus) Updated(pod PodInfo) bool {
+	return pod.Labels[appsv1.ControllerRevisionHashLabelKey] == s.UpdateRevision
+}
+
+// Blocker finds the ordinal the controller is waiting on, returning its pod
+// name and why, or "" when nothing is held up. With OrderedReady it is the
+// lowest ordinal that is missing or not ready: nothing above it is created,
+// deleted or updated until it is. With Parallel management only a rolling
+// update waits, on the highest unready ordinal it still has to cover.
+func (s *StatefulSetStatus) Blocker(pods []PodInfo) (string, string) {
+	byOrdinal := make(map[int]PodInfo, len(pods))
+	for _, p := range pods {
+		if ordinal, ok := PodOrdinal(s.Name, p.Name); ok {
+			byOrdinal[ordinal] = p
+		}
+	}
+
+	describe := func(ordinal int) (string, string) {
+		name := fmt.Sprintf("%s-%d", s.Name, ordinal)
+		p, ok := byOrdinal[ordinal]
+		if !ok {
+			return name, "not created"
+		}
+		reason := p.Status
+		if s.Rolling() {
+			if s.Updated(p) {
+				reason += ", new revision"
+			} else {
+				reason += ", old revision"
+			}
+		}
+		return name, reason
+	}
+
+	if s.OrderedReady {
+		for ordinal := 0; ordinal < int(s.Replicas); ordinal++ {
+			if p, ok := byOrdinal[ordinal]; !ok || !podInfoReady(p) {
+				if !s.Rolling() && ok && s.allPresent(byOrdinal) {
+					return "", "" // nothing left to do, the pod is just unhealthy
+				}
+				return describe(ordinal)
+			}
+		}
+		return "", ""
+	}
+
+	if !s.Rolling() {
+		return "", ""
+	}
+	for ordinal := int(s.Replicas) - 1; ordinal >= int(s.Partition); ordinal-- {
+		if p, ok := byOrdinal[ordinal]; !ok || !podInfoReady(p) {
+			return describe(ordinal)
+		}
+	}
+	return "", ""
+}
+
+func (s *StatefulSetStatus) allPresent(byOrdinal map[int]PodInfo) bool {
+	for ordinal := 0; ordinal < int(s.Replicas); ordinal++ {
+		if _, ok := byOrdinal[ordinal]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func podInfoReady(p PodInfo) bool {
+	for _, c := range p.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortPodsByOrdinal(t *testing.T) {
+	pods := []PodInfo{{Name: "web-10"}, {Name: "web-2"}, {Name: "other"}, {Name: "web-0"}}
+	SortPodsByOrdinal("web", pods)
+
+	want := []string{"web-0", "web-2", "web-10", "other"}
+	for i, p := range pods {
+		if p.Name != want[i] {
+			t.Fatalf("order = %v, want %v", pods, want)
+		}
+	}
+}
+
+func TestStatefulSetBlocker(t *testing.T) {
+	pod := func(name, revision string, ready bool, status string) PodInfo {
+		cond := corev1.ConditionFalse
+		if ready {
+			cond = corev1.ConditionTrue
+		}
+		return PodInfo{
+			Name:       name,
+			Status:     status,
+			Labels:     map[string]string{appsv1.ControllerRevisionHashLabelKey: revision},
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: cond}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		status     StatefulSetStatus
+		pods       []PodInfo
+		wantPod    string
+		wantReason string
+	}{
+		{
+			name:   "rolling update stuck on new revision",
+			status: StatefulSetStatus{Name: "web", Replicas: 3, OrderedReady: true, UpdateRevision: "v2", CurrentRevision: "v1"},
+			pods: []PodInfo{
+				pod("web-0", "v1", true, "Running"),
+				pod("web-1", "v1", true, "Running"),
+				pod("web-2", "v2", false, "CrashLoopBackOff"),
+			},
+			// OrderedReady waits on the lowest unready ordinal
+			wantPod:    "web-2",
+			wantReason: "CrashLoopBackOff, new revision",
+		},
+		{
+			name:       "ordered start waiting on a pending ordinal",
+			status:     StatefulSetStatus{Name: "db", Replicas: 3, OrderedReady: true},
+			pods:       []PodInfo{pod("db-0", "", true, "Running"), pod("db-1", "", false, "Pending")},
+			wantPod:    "db-1",
+			wantReason: "Pending",
+		},
+		{
+			name:   "all created and no rollout",
+			status: StatefulSetStatus{Name: "db", Replicas: 2, OrderedReady: true},
+			pods:   []PodInfo{pod("db-0", "", true, "Running"), pod("db-1", "", false, "Running")},
+		},
+		{
+			name:   "parallel rollout above the partition",
+			status: StatefulSetStatus{Name: "kv", Replicas: 4, Partition: 2, UpdateRevision: "v2", CurrentRevision: "v1"},
+			pods: []PodInfo{
+				pod("kv-0", "v1", false, "Running"),
+				pod("kv-1", "v1", true, "Running"),
+				pod("kv-3", "v2", true, "Running"),
+			},
+			wantPod:    "kv-2",
+			wantReason: "not created",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPod, gotReason := tt.status.Blocker(tt.pods)
+			if gotPod != tt.wantPod || gotReason != tt.wantReason {
+				t.Errorf("Blocker() = %q, %q, want %q, %q", gotPod, gotReason, tt.wantPod, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestStatefulSetPodClaims(t *testing.T) {
+	replicas := int32(2)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+			}},
+		},
+	}
+	pvcs := []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-db-0"},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase:    corev1.ClaimBound,
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+		},
+	}}
+
+	s := statefulSetStatus(sts, pvcs)
+	if !s.OrderedReady {
+		t.Error("default pod management should be OrderedReady")
+	}
+	if got := s.PodClaims("db-0"); len(got) != 1 || got[0].Phase != "Bound" || got[0].Capacity != "10Gi" {
+		t.Errorf("db-0 claims = %+v", got)
+	}
+	if got := s.PodClaims("db-1"); got[0].Name != "data-db-1" || got[0].Phase != "Missing" {
+		t.Errorf("db-1 claims = %+v", got)
+	}
+}
+
+func TestPodClaimNames(t *testing.T) {
+	claim := func(name string) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+		}}
+	}
+	pods := []PodInfo{
+		{Name: "db-0", Object: &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+			claim("data-db-0"), claim("shared"), {Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		}}}},
+		{Name: "db-1", Object: &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{claim("data-db-1"), claim("shared")}}}},
+		{Name: "db-2"}, // listed without its spec
+	}
+	got := podClaimNames(pods)
+	want := []string{"data-db-0", "shared", "data-db-1"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("podClaimNames() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,172 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Termination messages are at most 4 KiB; keep the panel to the start of one
+const maxTerminationLines = 12
+
+// TerminationMessage is what a container wrote to its termination message
+// path, or the log tail the kubelet put there instead, when it last exited
+type TerminationMessage struct {
+	Container  string
+	Init       bool
+	ExitCode   int32
+	Reason     string
+	FinishedAt time.Time
+	Policy     corev1.TerminationMessagePolicy
+	Lines      []string
+}
+
+// ExitMeaning explains an exit code: a signal for codes above 128, the
+// shell's meaning for 126 and 127
+func (t TerminationMessage) ExitMeaning() string {
+	switch code := t.ExitCode; {
+	case code == 126:
+		return "command not executable"
+	case code == 127:
+		return "command not found"
+	case code > 128 && code < 160:
+		return signalName(code - 128)
+	}
+	return ""
+}
+
+// MayBeLogTail reports whether the kubelet may have filled the message from
+// the container's last log lines because it wrote no termination message
+func (t TerminationMessage) MayBeLogTail() bool {
+	return t.Policy == corev1.TerminationMessageFallbackToLogsOnError
+}
+
+func signalName(sig int32) string {
+	names := map[int32]string{1: "SIGHUP", 2: "SIGINT", 3: "SIGQUIT", 6: "SIGABRT", 9: "SIGKILL", 11: "SIGSEGV", 13: "SIGPIPE", 15: "SIGTERM"}
+	if name, ok := names[sig]; ok {
+		return name
+	}
+	return fmt.Sprintf("signal %d", sig)
+}
+
+// TerminationMessages collects the non-empty termination messages of the
+// pod's init and app containers, from the current state of a terminated
+// container or else its last termination
+func TerminationMessages(pod *corev1.Pod) []TerminationMessage {
+	if pod == nil {
+		return nil
+	}
+	policies := make(map[string]corev1.TerminationMessagePolicy)
+	for _, c := range pod.Spec.InitContainers {
+		policies[c.Name] = c.TerminationMessagePolicy
+	}
+	for _, c := range pod.Spec.Containers {
+		policies[c.Name] = c.TerminationMessagePolicy
+	}
+
+	var messages []TerminationMessage
+	collect := func(statuses []corev1.ContainerStatus, init bool) {
+		for _, cs := range statuses {
+			t := cs.State.Terminated
+			if t == nil || strings.TrimSpace(t.Message) == "" {
+				t = cs.LastTerminationState.Terminated
+			}
+			if t == nil || strings.TrimSpace(t.Message) == "" {
+				continue
+			}
+			messages = append(messages, TerminationMessage{
+				Container:  cs.Name,
+				Init:       init,
+				ExitCode:   t.ExitCode,
+				Reason:     t.Reason,
+				FinishedAt: t.FinishedAt.Time,
+				Policy:     policies[cs.Name],
+				Lines:      decodeTerminationMessage(t.Message),
+			})
+		}
+	}
+	collect(pod.Status.InitContainerStatuses, true)
+	collect(pod.Status.ContainerStatuses, false)
+	return messages
+}
+
+// decodeTerminationMessage turns a message into display lines. A JSON object,
+// as structured loggers write, becomes one "key: value" line per field with
+// the message and error fields first; anything else is split into lines with
+// escape codes and blank lines dropped.
+func decodeTerminationMessage(msg string) []string {
+	msg = strings.TrimSpace(msg)
+
+	var fields map[string]interface{}
+	if strings.HasPrefix(msg, "{") && json.Unmarshal([]byte(msg), &fields) == nil {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		rank := func(k string) int {
+			switch strings.ToLower(k) {
+			case "msg", "message":
+				return 0
+			case "error", "err":
+				return 1
+			}
+			return 2
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if ri, rj := rank(keys[i]), rank(keys[j]); ri != rj {
+				return ri < rj
+			}
+			return keys[i] < keys[j]
+		})
+		lines := make([]string, 0, len(keys))
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("%s: %v", k, fields[k]))
+		}
+		return truncateLines(lines)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimRight(stripControl(line), " \t\r")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return truncateLines(lines)
+}
+
+func truncateLines(lines []string) []string {
+	if len(lines) <= maxTerminationLines {
+		return lines
+	}
+	more := len(lines) - maxTerminationLines + 1
+	return append(lines[:maxTerminationLines-1], fmt.Sprintf("... %d more lines", more))
+}
+
+// stripControl drops ANSI escape sequences and other control characters that
+// would garble the panel
+func stripControl(s string) string {
+	var b strings.Builder
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			// A CSI sequence ends with a letter
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+		case r == 0x1b:
+			inEscape = true
+		case r == '\t':
+			b.WriteString("    ")
+		case r < 0x20 || r == 0x7f:
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTerminationMessages(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate"}},
+			Containers: []corev1.Container{
+				{Name: "app", TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError},
+				{Name: "sidecar"},
+			},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "migrate",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}},
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "app",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 137, Reason: "Error", Message: "\x1b[31mpanic: config missing\x1b[0m\n\n\tat main.go:12\n",
+					}},
+				},
+				{Name: "sidecar", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	}
+
+	messages := TerminationMessages(pod)
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1: %+v", len(messages), messages)
+	}
+	m := messages[0]
+	if m.Container != "app" || m.ExitCode != 137 || m.Policy != corev1.TerminationMessageFallbackToLogsOnError {
+		t.Errorf("message = %+v", m)
+	}
+	if got := strings.Join(m.Lines, "|"); got != "panic: config missing|    at main.go:12" {
+		t.Errorf("lines = %q", got)
+	}
+	if m.ExitMeaning() != "SIGKILL" {
+		t.Errorf("ExitMeaning() = %q", m.ExitMeaning())
+	}
+}
+
+func TestDecodeTerminationMessage(t *testing.T) {
+	got := decodeTerminationMessage(`{"level":"fatal","ts":1714557600,"error":"dial tcp: refused","msg":"cannot connect"}`)
+	want := "msg: cannot connect|error: dial tcp: refused|level: fatal|ts: 1.7145576e+09"
+	if strings.Join(got, "|") != want {
+		t.Errorf("JSON = %q, want %q", strings.Join(got, "|"), want)
+	}
+
+	long := strings.Repeat("line\n", 20)
+	if got := decodeTerminationMessage(long); len(got) != maxTerminationLines || got[len(got)-1] != "... 9 more lines" {
+		t.Errorf("long message = %q", got)
+	}
+}
@@ -0,0 +1,79 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// timelineEntry is a log line or an event at its place in time
+type timelineEntry struct {
+	at    time.Time
+	event bool
+	text  string
+}
+
+// FormatTimeline interleaves log lines and events in time order, so an event
+// such as "Killing" sits right after the lines logged before it. Events are
+// placed when last seen, with their count; lines without a timestamp are
+// left out and counted at the end.
+func FormatTimeline(logs []LogLine, events []EventInfo) string {
+	containers := make(map[string]bool)
+	for _, l := range logs {
+		containers[l.Container] = true
+	}
+
+	entries := make([]timelineEntry, 0, len(logs)+len(events))
+	untimed := 0
+	for _, l := range logs {
+		if l.Timestamp.IsZero() {
+			untimed++
+			continue
+		}
+		text := "  " + StripANSI(l.Content)
+		if len(containers) > 1 && l.Container != "" {
+			text = "  [" + l.Container + "] " + StripANSI(l.Content)
+		}
+		entries = append(entries, timelineEntry{at: l.Timestamp, text: text})
+	}
+	for _, e := range events {
+		at := e.LastSeen
+		if at.IsZero() {
+			at = e.FirstSeen
+		}
+		text := fmt.Sprintf("▶ %s %s: %s", e.Type, e.Reason, e.Message)
+		if e.Count > 1 {
+			text += fmt.Sprintf(" (x%d since %s)", e.Count, e.FirstSeen.Local().Format("15:04:05"))
+		}
+		entries = append(entries, timelineEntry{at: at, event: true, text: text})
+	}
+	if len(entries) == 0 {
+		return "No timestamped logs or events loaded\n"
+	}
+	// Events only have second precision, so at the same time the lines come
+	// first, as they led up to the event
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].at.Equal(entries[j].at) {
+			return entries[i].at.Before(entries[j].at)
+		}
+		return !entries[i].event && entries[j].event
+	})
+
+	layout := "15:04:05.000"
+	first, last := entries[0].at.Local(), entries[len(entries)-1].at.Local()
+	if first.YearDay() != last.YearDay() || first.Year() != last.Year() {
+		layout = "01-02 15:04:05.000"
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(e.at.Local().Format(layout))
+		b.WriteString(" ")
+		b.WriteString(e.text)
+		b.WriteString("\n")
+	}
+	if untimed > 0 {
+		fmt.Fprintf(&b, "\n%d log lines without a timestamp left out\n", untimed)
+	}
+	return b.String()
+}
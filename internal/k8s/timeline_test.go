@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatTimeline(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	logs := []LogLine{
+		{Timestamp: base.Add(500 * time.Millisecond), Container: "app", Content: "starting"},
+		{Timestamp: base.Add(2 * time.Second), Container: "app", Content: "\x1b[31mpanic: nil map\x1b[0m"},
+		{Container: "app", Content: "no timestamp"},
+	}
+	events := []EventInfo{
+		{Type: "Normal", Reason: "Killing", Message: "Stopping container app", LastSeen: base.Add(2 * time.Second)},
+		{Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 3, FirstSeen: base, LastSeen: base.Add(time.Minute)},
+	}
+
+	got := FormatTimeline(logs, events)
+	order := []string{"  starting", "  panic: nil map\n", "▶ Normal Killing: Stopping container app", "▶ Warning BackOff: Back-off restarting failed container (x3 since", "1 log lines without a timestamp"}
+	last := -1
+	for _, want := range order {
+		i := strings.Index(got, want)
+		if i < 0 {
+			t.Fatalf("timeline lacks %q:\n%s", want, got)
+		}
+		if i < last {
+			t.Errorf("%q is out of order:\n%s", want, got)
+		}
+		last = i
+	}
+	if strings.Contains(got, "[app]") {
+		t.Errorf("a single container should not be named:\n%s", got)
+	}
+
+	if got := FormatTimeline(nil, nil); !strings.HasPrefix(got, "No timestamped") {
+		t.Errorf("empty timeline = %q", got)
+	}
+}
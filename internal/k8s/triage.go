@@ -0,0 +1,173 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// triageWindow is how far back warning events count towards a pod's score
+const triageWindow = 15 * time.Minute
+
+// statusSeverity weighs the pod statuses that mean something is broken;
+// anything not listed (Running, Completed, ...) scores nothing
+var statusSeverity = map[string]int{
+	"CrashLoopBackOff":           100,
+	"OOMKilled":                  90,
+	"CreateContainerConfigError": 85,
+	"CreateContainerError":       85,
+	"InvalidImageName":           85,
+	"ImagePullBackOff":           80,
+	"ErrImagePull":               80,
+	"Error":                      80,
+	"RunContainerError":          80,
+	"Evicted":                    60,
+	"Failed":                     50,
+	"Unknown":                    50,
+	"Pending":                    40,
+	"ContainerCreating":          30,
+	"Terminating":                20,
+}
+
+// PodProblem is a pod that looks unhealthy and why
+type PodProblem struct {
+	Pod     PodInfo
+	Score   int
+	Reasons []string
+}
+
+// FindPodProblems ranks the pods of a namespace, or of one workload when
+// workload is not nil, worst first
+func FindPodProblems(ctx context.Context, clientset *kubernetes.Clientset, namespace string, workload *WorkloadInfo) ([]PodProblem, error) {
+	var pods []PodInfo
+	if workload != nil {
+		var err error
+		if pods, err = GetWorkloadPods(ctx, clientset, *workload); err != nil {
+			return nil, err
+		}
+		namespace = workload.Namespace
+	} else {
+		list, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			pods = append(pods, podToPodInfo(&list.Items[i]))
+		}
+	}
+	// Without events the statuses and restarts still rank the pods
+	warnings, _ := GetRecentWarnings(ctx, clientset, namespace, triageWindow)
+	return rankPodProblems(pods, warnings, time.Now()), nil
+}
+
+func rankPodProblems(pods []PodInfo, warnings []EventInfo, now time.Time) []PodProblem {
+	warningsByPod := make(map[string][]EventInfo)
+	for _, e := range warnings {
+		if name, ok := strings.CutPrefix(e.Object, "Pod/"); ok {
+			warningsByPod[name] = append(warningsByPod[name], e)
+		}
+	}
+
+	var problems []PodProblem
+	for _, pod := range pods {
+		p := PodProblem{Pod: pod}
+
+		if severity := statusSeverity[pod.Status]; severity > 0 {
+			p.Score += severity
+			p.Reasons = append(p.Reasons, pod.Status)
+		} else if pod.Phase == corev1.PodRunning && !allReady(pod.Ready) {
+			p.Score += 30
+			p.Reasons = append(p.Reasons, "not ready ("+pod.Ready+")")
+		}
+
+		if pod.Restarts > 0 {
+			p.Score += min(int(pod.Restarts)*2, 40)
+			p.Reasons = append(p.Reasons, fmt.Sprintf("%d restarts", pod.Restarts))
+		}
+
+		if events := warningsByPod[pod.Name]; len(events) > 0 {
+			count := 0
+			latest := events[0]
+			for _, e := range events {
+				count += max(int(e.Count), 1)
+				if e.LastSeen.After(latest.LastSeen) {
+					latest = e
+				}
+			}
+			p.Score += min(count*5, 30)
+			if now.Sub(latest.LastSeen) < 5*time.Minute {
+				p.Score += 10
+			}
+			p.Reasons = append(p.Reasons, fmt.Sprintf("%s %s ago", latest.Reason, FormatDuration(now.Sub(latest.LastSeen))))
+		}
+
+		if p.Score > 0 {
+			problems = append(problems, p)
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Score != problems[j].Score {
+			return problems[i].Score > problems[j].Score
+		}
+		return problems[i].Pod.Name < problems[j].Pod.Name
+	})
+	return problems
+}
+
+// allReady reports whether a "ready/total" count has every container ready
+func allReady(ready string) bool {
+	n, total, ok := strings.Cut(ready, "/")
+	return !ok || n == total
+}
+
+// ownerResourceTypes maps the owner kinds recorded on pods to the workload
+// types k9sight lists
+var ownerResourceTypes = map[string]ResourceType{
+	"Deployment":  ResourceDeployments,
+	"StatefulSet": ResourceStatefulSets,
+	"DaemonSet":   ResourceDaemonSets,
+	"Job":         ResourceJobs,
+}
+
+// WorkloadKey names the workload a pod belongs to as namespace/Kind/name
+// without asking the API: a ReplicaSet is taken for its Deployment by
+// dropping the pod-template-hash suffix, and a pod without owner stands for
+// itself
+func WorkloadKey(pod *PodInfo) string {
+	kind, name := pod.OwnerKind, pod.OwnerRef
+	if kind == "ReplicaSet" {
+		if hash := pod.Labels["pod-template-hash"]; hash != "" {
+			if deployment, ok := strings.CutSuffix(name, "-"+hash); ok {
+				kind, name = "Deployment", deployment
+			}
+		}
+	}
+	if name == "" {
+		kind, name = "Pod", pod.Name
+	}
+	return pod.Namespace + "/" + kind + "/" + name
+}
+
+// WorkloadOwner finds the listed workload a pod belongs to, following a
+// ReplicaSet to its Deployment. A pod without one stands in for itself.
+func WorkloadOwner(ctx context.Context, clientset *kubernetes.Clientset, pod *PodInfo) *WorkloadInfo {
+	kind, name := pod.OwnerKind, pod.OwnerRef
+	if kind == "ReplicaSet" {
+		if deployment := OwnerDeployment(ctx, clientset, pod); deployment != "" {
+			kind, name = "Deployment", deployment
+		}
+	}
+	if rt, ok := ownerResourceTypes[kind]; ok {
+		if workload, err := GetWorkload(ctx, clientset, pod.Namespace, rt, name); err == nil {
+			return workload
+		}
+	}
+	return &WorkloadInfo{Name: pod.Name, Namespace: pod.Namespace, Type: ResourcePods}
+}
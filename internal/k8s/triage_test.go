@@ -0,0 +1,59 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRankPodProblems(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pods := []PodInfo{
+		{Name: "healthy", Status: "Running", Phase: corev1.PodRunning, Ready: "1/1"},
+		{Name: "done", Status: "Completed", Phase: corev1.PodSucceeded, Ready: "0/1"},
+		{Name: "unready", Status: "Running", Phase: corev1.PodRunning, Ready: "1/2"},
+		{Name: "crashing", Status: "CrashLoopBackOff", Phase: corev1.PodRunning, Ready: "0/1", Restarts: 12},
+		{Name: "flapping", Status: "Running", Phase: corev1.PodRunning, Ready: "1/1", Restarts: 3},
+		{Name: "pulling", Status: "ImagePullBackOff", Phase: corev1.PodPending, Ready: "0/1"},
+	}
+	warnings := []EventInfo{
+		{Object: "Pod/flapping", Reason: "Unhealthy", Count: 4, LastSeen: now.Add(-time.Minute)},
+		{Object: "Deployment/web", Reason: "ScalingFailed", Count: 9, LastSeen: now},
+	}
+
+	problems := rankPodProblems(pods, warnings, now)
+	var order []string
+	for _, p := range problems {
+		order = append(order, p.Pod.Name)
+	}
+	if got, want := strings.Join(order, ","), "crashing,pulling,flapping,unready"; got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+
+	if got := strings.Join(problems[0].Reasons, "; "); got != "CrashLoopBackOff; 12 restarts" {
+		t.Errorf("crashing reasons = %q", got)
+	}
+	if got := strings.Join(problems[2].Reasons, "; "); got != "3 restarts; Unhealthy 1m0s ago" {
+		t.Errorf("flapping reasons = %q", got)
+	}
+}
+
+func TestWorkloadKey(t *testing.T) {
+	tests := []struct {
+		pod  PodInfo
+		want string
+	}{
+		{PodInfo{Name: "web-7d9f-abcde", Namespace: "prod", OwnerKind: "ReplicaSet", OwnerRef: "web-7d9f",
+			Labels: map[string]string{"pod-template-hash": "7d9f"}}, "prod/Deployment/web"},
+		{PodInfo{Name: "rs-x", Namespace: "prod", OwnerKind: "ReplicaSet", OwnerRef: "bare-rs"}, "prod/ReplicaSet/bare-rs"},
+		{PodInfo{Name: "db-0", Namespace: "prod", OwnerKind: "StatefulSet", OwnerRef: "db"}, "prod/StatefulSet/db"},
+		{PodInfo{Name: "scratch", Namespace: "dev"}, "dev/Pod/scratch"},
+	}
+	for _, tt := range tests {
+		if got := WorkloadKey(&tt.pod); got != tt.want {
+			t.Errorf("WorkloadKey(%s) = %q, want %q", tt.pod.Name, got, tt.want)
+		}
+	}
+}
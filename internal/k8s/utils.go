@@ -156,5 +156,24 @@ func AnalyzePodIssues(pod *PodInfo, events []EventInfo) []DebugHelper {
 		}
 	}
 
+	helpers = append(helpers, AnalyzeProbes(pod.Containers, events)...)
+
 	return helpers
 }
+
+// FormatDuration renders d with its two most significant units, e.g. 4m12s or 3h5m
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
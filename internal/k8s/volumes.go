@@ -0,0 +1,227 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// VolumeInfo is one pod volume, where its data comes from and who mounts it
+type VolumeInfo struct {
+	Name    string
+	Type    string // configMap, secret, persistentVolumeClaim, emptyDir, ...
+	Source  string // backing object or path, empty when there is none
+	Sources []VolumeSourceRef
+	Mounts  []MountInfo
+	Status  string // e.g. "Bound 10Gi", filled in by CheckVolumeSources
+	Issue   string // why the backing object is unusable, empty when fine
+}
+
+// VolumeSourceRef is an object a volume reads from; projected volumes have several
+type VolumeSourceRef struct {
+	Kind     string // ConfigMap, Secret or PersistentVolumeClaim
+	Name     string
+	Keys     []string // keys the volume selects, all keys when empty
+	Optional bool
+}
+
+// MountInfo is one container's mount of a volume
+type MountInfo struct {
+	Container string
+	Path      string
+	SubPath   string
+	ReadOnly  bool
+}
+
+// DescribeVolumes lists the pod's volumes with their mounts, in spec order
+func DescribeVolumes(pod *corev1.Pod) []VolumeInfo {
+	if pod == nil {
+		return nil
+	}
+
+	volumes := make([]VolumeInfo, 0, len(pod.Spec.Volumes))
+	index := make(map[string]int, len(pod.Spec.Volumes))
+	for _, v := range pod.Spec.Volumes {
+		info := VolumeInfo{Name: v.Name}
+		info.Type, info.Source, info.Sources = volumeKind(v)
+		index[v.Name] = len(volumes)
+		volumes = append(volumes, info)
+	}
+
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, c := range containers {
+		for _, m := range c.VolumeMounts {
+			i, ok := index[m.Name]
+			if !ok {
+				continue
+			}
+			volumes[i].Mounts = append(volumes[i].Mounts, MountInfo{
+				Container: c.Name,
+				Path:      m.MountPath,
+				SubPath:   m.SubPath,
+				ReadOnly:  m.ReadOnly,
+			})
+		}
+	}
+	return volumes
+}
+
+// volumeKind returns a volume's type, a short description of its source and
+// the objects it reads from
+func volumeKind(v corev1.Volume) (string, string, []VolumeSourceRef) {
+	switch {
+	case v.ConfigMap != nil:
+		ref := VolumeSourceRef{Kind: "ConfigMap", Name: v.ConfigMap.Name, Keys: keysOf(v.ConfigMap.Items), Optional: isTrue(v.ConfigMap.Optional)}
+		return "configMap", v.ConfigMap.Name, []VolumeSourceRef{ref}
+	case v.Secret != nil:
+		ref := VolumeSourceRef{Kind: "Secret", Name: v.Secret.SecretName, Keys: keysOf(v.Secret.Items), Optional: isTrue(v.Secret.Optional)}
+		return "secret", v.Secret.SecretName, []VolumeSourceRef{ref}
+	case v.PersistentVolumeClaim != nil:
+		ref := VolumeSourceRef{Kind: "PersistentVolumeClaim", Name: v.PersistentVolumeClaim.ClaimName}
+		return "persistentVolumeClaim", v.PersistentVolumeClaim.ClaimName, []VolumeSourceRef{ref}
+	case v.Projected != nil:
+		var refs []VolumeSourceRef
+		var parts []string
+		for _, p := range v.Projected.Sources {
+			switch {
+			case p.ConfigMap != nil:
+				refs = append(refs, VolumeSourceRef{Kind: "ConfigMap", Name: p.ConfigMap.Name, Keys: keysOf(p.ConfigMap.Items), Optional: isTrue(p.ConfigMap.Optional)})
+				parts = append(parts, "configmap "+p.ConfigMap.Name)
+			case p.Secret != nil:
+				refs = append(refs, VolumeSourceRef{Kind: "Secret", Name: p.Secret.Name, Keys: keysOf(p.Secret.Items), Optional: isTrue(p.Secret.Optional)})
+				parts = append(parts, "secret "+p.Secret.Name)
+			case p.ServiceAccountToken != nil:
+				parts = append(parts, "serviceAccountToken")
+			case p.DownwardAPI != nil:
+				parts = append(parts, "downwardAPI")
+			}
+		}
+		return "projected", strings.Join(parts, ", "), refs
+	case v.EmptyDir != nil:
+		if v.EmptyDir.Medium == corev1.StorageMediumMemory {
+			return "emptyDir", "memory", nil
+		}
+		return "emptyDir", "", nil
+	case v.HostPath != nil:
+		return "hostPath", v.HostPath.Path, nil
+	case v.DownwardAPI != nil:
+		return "downwardAPI", "", nil
+	case v.CSI != nil:
+		return "csi", v.CSI.Driver, nil
+	case v.NFS != nil:
+		return "nfs", v.NFS.Server + ":" + v.NFS.Path, nil
+	case v.Ephemeral != nil:
+		return "ephemeral", "", nil
+	}
+	return "other", "", nil
+}
+
+// CheckVolumeSources looks up the ConfigMaps, Secrets and PVCs behind each
+// volume and records missing objects, missing keys and unbound claims
+func CheckVolumeSources(ctx context.Context, clientset *kubernetes.Clientset, namespace string, volumes []VolumeInfo) {
+	for i := range volumes {
+		var statuses, issues []string
+		for _, ref := range volumes[i].Sources {
+			status, issue := checkVolumeSource(ctx, clientset, namespace, ref)
+			if status != "" {
+				statuses = append(statuses, status)
+			}
+			if issue != "" {
+				issues = append(issues, issue)
+			}
+		}
+		volumes[i].Status = strings.Join(statuses, ", ")
+		volumes[i].Issue = strings.Join(issues, "; ")
+	}
+}
+
+func checkVolumeSource(ctx context.Context, clientset *kubernetes.Clientset, namespace string, ref VolumeSourceRef) (string, string) {
+	var keys map[string]bool
+	var err error
+
+	switch ref.Kind {
+	case "ConfigMap":
+		var cm *corev1.ConfigMap
+		if cm, err = clientset.CoreV1().ConfigMaps(namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err == nil {
+			keys = make(map[string]bool, len(cm.Data)+len(cm.BinaryData))
+			for k := range cm.Data {
+				keys[k] = true
+			}
+			for k := range cm.BinaryData {
+				keys[k] = true
+			}
+		}
+	case "Secret":
+		var secret *corev1.Secret
+		if secret, err = clientset.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err == nil {
+			keys = make(map[string]bool, len(secret.Data))
+			for k := range secret.Data {
+				keys[k] = true
+			}
+		}
+	case "PersistentVolumeClaim":
+		pvc, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return lookupFailure(ref, err)
+		}
+		return pvcStatus(pvc)
+	}
+
+	if err != nil {
+		return lookupFailure(ref, err)
+	}
+	var missing []string
+	for _, k := range ref.Keys {
+		if !keys[k] {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 && !ref.Optional {
+		return "", fmt.Sprintf("%s %s has no key %s", ref.Kind, ref.Name, strings.Join(missing, ", "))
+	}
+	return "", ""
+}
+
+func lookupFailure(ref VolumeSourceRef, err error) (string, string) {
+	switch {
+	case apierrors.IsNotFound(err) && ref.Optional:
+		return "optional, not present", ""
+	case apierrors.IsNotFound(err):
+		return "", fmt.Sprintf("%s %s not found", ref.Kind, ref.Name)
+	case apierrors.IsForbidden(err):
+		return "not checked (forbidden)", ""
+	}
+	return "", fmt.Sprintf("%s %s: %s", ref.Kind, ref.Name, ShortError(err))
+}
+
+func pvcStatus(pvc *corev1.PersistentVolumeClaim) (string, string) {
+	status := string(pvc.Status.Phase)
+	if size, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		status += " " + size.String()
+	}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimPending:
+		return status, fmt.Sprintf("PVC %s is not bound; check its storage class and provisioner", pvc.Name)
+	case corev1.ClaimLost:
+		return status, fmt.Sprintf("PVC %s lost its volume", pvc.Name)
+	}
+	return status, ""
+}
+
+func keysOf(items []corev1.KeyToPath) []string {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = item.Key
+	}
+	return keys
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestDescribeVolumes(t *testing.T) {
+	optional := true
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"},
+					Items:                []corev1.KeyToPath{{Key: "app.yaml", Path: "app.yaml"}},
+				}}},
+				{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+					SecretName: "db", Optional: &optional,
+				}}},
+				{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "data-web-0",
+				}}},
+				{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+			InitContainers: []corev1.Container{{
+				Name:         "migrate",
+				VolumeMounts: []corev1.VolumeMount{{Name: "creds", MountPath: "/creds", ReadOnly: true}},
+			}},
+			Containers: []corev1.Container{{
+				Name: "app",
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "config", MountPath: "/etc/app", ReadOnly: true},
+					{Name: "data", MountPath: "/data"},
+					{Name: "creds", MountPath: "/run/creds", SubPath: "password"},
+				},
+			}},
+		},
+	}
+
+	volumes := DescribeVolumes(pod)
+	if len(volumes) != 4 {
+		t.Fatalf("got %d volumes, want 4", len(volumes))
+	}
+
+	config := volumes[0]
+	if config.Type != "configMap" || config.Source != "app-config" {
+		t.Errorf("config volume = %s %s", config.Type, config.Source)
+	}
+	if len(config.Sources) != 1 || config.Sources[0].Keys[0] != "app.yaml" {
+		t.Errorf("config sources = %+v", config.Sources)
+	}
+
+	creds := volumes[1]
+	if !creds.Sources[0].Optional {
+		t.Error("secret volume should be optional")
+	}
+	if len(creds.Mounts) != 2 || creds.Mounts[0].Container != "migrate" || creds.Mounts[1].SubPath != "password" {
+		t.Errorf("creds mounts = %+v", creds.Mounts)
+	}
+
+	if volumes[3].Type != "emptyDir" || len(volumes[3].Mounts) != 0 {
+		t.Errorf("scratch volume = %+v", volumes[3])
+	}
+}
+
+func TestPVCStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		phase      corev1.PersistentVolumeClaimPhase
+		wantStatus string
+		wantIssue  bool
+	}{
+		{"bound", corev1.ClaimBound, "Bound 10Gi", false},
+		{"pending", corev1.ClaimPending, "Pending 10Gi", true},
+		{"lost", corev1.ClaimLost, "Lost 10Gi", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvc := &corev1.PersistentVolumeClaim{Status: corev1.PersistentVolumeClaimStatus{
+				Phase:    tt.phase,
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			}}
+			status, issue := pvcStatus(pvc)
+			if status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", status, tt.wantStatus)
+			}
+			if (issue != "") != tt.wantIssue {
+				t.Errorf("issue = %q, want issue: %v", issue, tt.wantIssue)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// VulnerabilityReport is the part of a trivy-operator VulnerabilityReport
+// (aquasecurity.github.io/v1alpha1) that k9sight shows: one container
+// image of one workload, and its CVE counts by severity
+type VulnerabilityReport struct {
+	ResourceKind string // workload the scan belongs to, e.g. "ReplicaSet"
+	ResourceName string
+	Container    string
+	Image        string // repository:tag as scanned, without the registry
+	Digest       string
+	Critical     int
+	High         int
+	Medium       int
+	Low          int
+	Updated      time.Time
+}
+
+// ImageVulnerabilities pairs a pod container with the report for its image
+type ImageVulnerabilities struct {
+	Container string
+	Report    VulnerabilityReport
+}
+
+type vulnerabilityReportList struct {
+	Items []struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Report struct {
+			UpdateTimestamp time.Time `json:"updateTimestamp"`
+			Artifact        struct {
+				Repository string `json:"repository"`
+				Tag        string `json:"tag"`
+				Digest     string `json:"digest"`
+			} `json:"artifact"`
+			Summary struct {
+				CriticalCount int `json:"criticalCount"`
+				HighCount     int `json:"highCount"`
+				MediumCount   int `json:"mediumCount"`
+				LowCount      int `json:"lowCount"`
+			} `json:"summary"`
+		} `json:"report"`
+	} `json:"items"`
+}
+
+// GetVulnerabilityReports lists the trivy-operator reports in a namespace.
+// Without trivy-operator installed there is nothing to list, which is not an
+// error.
+func GetVulnerabilityReports(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]VulnerabilityReport, error) {
+	path := fmt.Sprintf("/apis/aquasecurity.github.io/v1alpha1/namespaces/%s/vulnerabilityreports", namespace)
+	data, err := clientset.Discovery().RESTClient().Get().AbsPath(path).DoRaw(ctx)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseVulnerabilityReports(data)
+}
+
+func parseVulnerabilityReports(data []byte) ([]VulnerabilityReport, error) {
+	var list vulnerabilityReportList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decoding vulnerability reports: %w", err)
+	}
+
+	reports := make([]VulnerabilityReport, 0, len(list.Items))
+	for _, item := range list.Items {
+		labels := item.Metadata.Labels
+		artifact := item.Report.Artifact
+		image := artifact.Repository
+		if artifact.Tag != "" {
+			image += ":" + artifact.Tag
+		}
+		summary := item.Report.Summary
+		reports = append(reports, VulnerabilityReport{
+			ResourceKind: labels["trivy-operator.resource.kind"],
+			ResourceName: labels["trivy-operator.resource.name"],
+			Container:    labels["trivy-operator.container.name"],
+			Image:        image,
+			Digest:       artifact.Digest,
+			Critical:     summary.CriticalCount,
+			High:         summary.HighCount,
+			Medium:       summary.MediumCount,
+			Low:          summary.LowCount,
+			Updated:      item.Report.UpdateTimestamp,
+		})
+	}
+	return reports, nil
+}
+
+// MatchVulnerabilities finds the report for each of the pod's containers:
+// the one trivy-operator wrote for the pod's owner (or the pod itself) and
+// that container, or else the newest report on the same image from any other
+// workload in the namespace. Containers without a report are left out.
+func MatchVulnerabilities(pod *PodInfo, reports []VulnerabilityReport) []ImageVulnerabilities {
+	ownerKind, ownerName := pod.OwnerKind, pod.OwnerRef
+	if ownerKind == "" {
+		ownerKind, ownerName = "Pod", pod.Name
+	}
+
+	var matched []ImageVulnerabilities
+	for _, c := range pod.Containers {
+		var best *VulnerabilityReport
+		for i := range reports {
+			r := &reports[i]
+			if r.ResourceKind == ownerKind && r.ResourceName == ownerName && r.Container == c.Name {
+				best = r
+				break
+			}
+			if sameImage(r.Image, c.Image) && (best == nil || r.Updated.After(best.Updated)) {
+				best = r
+			}
+		}
+		if best != nil {
+			matched = append(matched, ImageVulnerabilities{Container: c.Name, Report: *best})
+		}
+	}
+	return matched
+}
+
+// sameImage compares a scanned repository:tag with a pod's image reference,
+// which may carry a registry host and omit the tag
+func sameImage(scanned, image string) bool {
+	image, _, _ = strings.Cut(image, "@")
+	image = ShortImage(image)
+	if !strings.Contains(image[strings.LastIndex(image, "/")+1:], ":") {
+		image += ":latest"
+	}
+	// Docker Hub official images are scanned as library/<name>
+	return scanned == image || scanned == "library/"+image
+}
@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseVulnerabilityReports(t *testing.T) {
+	data := []byte(`{"items": [{
+		"metadata": {"labels": {
+			"trivy-operator.resource.kind": "ReplicaSet",
+			"trivy-operator.resource.name": "web-7d4b9",
+			"trivy-operator.container.name": "app"
+		}},
+		"report": {
+			"updateTimestamp": "2024-05-01T10:00:00Z",
+			"artifact": {"repository": "acme/web", "tag": "v2"},
+			"summary": {"criticalCount": 2, "highCount": 7, "mediumCount": 11, "lowCount": 3}
+		}
+	}]}`)
+
+	reports, err := parseVulnerabilityReports(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := VulnerabilityReport{
+		ResourceKind: "ReplicaSet", ResourceName: "web-7d4b9", Container: "app",
+		Image: "acme/web:v2", Critical: 2, High: 7, Medium: 11, Low: 3,
+		Updated: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+	if len(reports) != 1 || reports[0] != want {
+		t.Errorf("got %+v, want %+v", reports, want)
+	}
+}
+
+func TestMatchVulnerabilities(t *testing.T) {
+	now := time.Now()
+	reports := []VulnerabilityReport{
+		{ResourceKind: "ReplicaSet", ResourceName: "web-old", Container: "app", Image: "acme/web:v2", Critical: 9, Updated: now.Add(-time.Hour)},
+		{ResourceKind: "ReplicaSet", ResourceName: "web-other", Container: "app", Image: "acme/web:v2", Critical: 1, Updated: now},
+		{ResourceKind: "ReplicaSet", ResourceName: "web-7d4b9", Container: "app", Image: "acme/web:v2", Critical: 2},
+		{ResourceKind: "ReplicaSet", ResourceName: "api-1", Container: "proxy", Image: "library/envoy:latest", Critical: 4},
+	}
+
+	tests := []struct {
+		name string
+		pod  PodInfo
+		want map[string]int // container -> critical count
+	}{
+		{
+			name: "report for the pod's own owner wins",
+			pod: PodInfo{Name: "web-7d4b9-x", OwnerKind: "ReplicaSet", OwnerRef: "web-7d4b9",
+				Containers: []ContainerInfo{{Name: "app", Image: "ghcr.io/acme/web:v2"}}},
+			want: map[string]int{"app": 2},
+		},
+		{
+			name: "newest report on the same image",
+			pod: PodInfo{Name: "web-9", OwnerKind: "ReplicaSet", OwnerRef: "web-new",
+				Containers: []ContainerInfo{{Name: "app", Image: "acme/web:v2"}}},
+			want: map[string]int{"app": 1},
+		},
+		{
+			name: "untagged docker hub image",
+			pod: PodInfo{Name: "bare",
+				Containers: []ContainerInfo{{Name: "sidecar", Image: "envoy"}, {Name: "app", Image: "acme/web:v3"}}},
+			want: map[string]int{"sidecar": 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchVulnerabilities(&tt.pod, reports)
+			if len(got) != len(tt.want) {
+				t.Fatalf("matched %d containers, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for _, m := range got {
+				if m.Report.Critical != tt.want[m.Container] {
+					t.Errorf("%s: critical = %d, want %d", m.Container, m.Report.Critical, tt.want[m.Container])
+				}
+			}
+		})
+	}
+}
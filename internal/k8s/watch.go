@@ -0,0 +1,148 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// WatchState is the last observed state of a watched pod or workload.
+type WatchState struct {
+	Status    string
+	Failing   bool
+	LastEvent time.Time
+	Checked   bool
+}
+
+var failingStatuses = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+	"ContainerCannotRun":         true,
+	"OOMKilled":                  true,
+	"Error":                      true,
+	"Failed":                     true,
+	"Evicted":                    true,
+	"NotReady":                   true,
+	"DeadlineExceeded":           true,
+	"ReplicaFailure":             true,
+}
+
+var criticalEventReasons = map[string]bool{
+	"BackOff":          true,
+	"Failed":           true,
+	"FailedScheduling": true,
+	"FailedMount":      true,
+	"FailedCreate":     true,
+	"OOMKilling":       true,
+	"Evicted":          true,
+	"Unhealthy":        true,
+}
+
+// IsFailingStatus reports whether a pod or workload status means it is broken.
+func IsFailingStatus(status string) bool {
+	return failingStatuses[status]
+}
+
+// IsCriticalEvent reports whether an event is worth interrupting the user for.
+func IsCriticalEvent(e EventInfo) bool {
+	return e.Type == "Warning" && criticalEventReasons[e.Reason]
+}
+
+// Changed reports whether the item started failing or recovered since prev.
+// The first check is a baseline and never counts as a change.
+func (s WatchState) Changed(prev WatchState) bool {
+	return prev.Checked && s.Checked && s.Failing != prev.Failing
+}
+
+// WatchKey builds the identifier stored in config for a watched item.
+func WatchKey(namespace string, resourceType ResourceType, name string) string {
+	return namespace + "/" + string(resourceType) + "/" + name
+}
+
+// ParseWatchKey splits a key built by WatchKey.
+func ParseWatchKey(key string) (namespace string, resourceType ResourceType, name string, ok bool) {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", false
+	}
+	return parts[0], ResourceType(parts[1]), parts[2], true
+}
+
+// CheckWatched fetches the current state of a watched item and returns
+// alert messages for any failing transition or new critical event since prev.
+// The transition, if any, is the first alert.
+func CheckWatched(ctx context.Context, clientset *kubernetes.Clientset, key string, prev WatchState) (WatchState, []string, error) {
+	namespace, resourceType, name, ok := ParseWatchKey(key)
+	if !ok {
+		return prev, nil, fmt.Errorf("invalid watch key: %s", key)
+	}
+
+	var state WatchState
+	var events []EventInfo
+
+	if resourceType == ResourcePods {
+		pod, err := GetPod(ctx, clientset, namespace, name)
+		if err != nil {
+			return prev, nil, err
+		}
+		state.Status = pod.Status
+		state.Failing = IsFailingStatus(pod.Status)
+		events, _ = GetPodEvents(ctx, clientset, namespace, name)
+	} else {
+		workload, err := GetWorkload(ctx, clientset, namespace, resourceType, name)
+		if err != nil {
+			return prev, nil, err
+		}
+		state.Status = workload.Status
+		state.Failing = IsFailingStatus(workload.Status)
+
+		pods, _ := GetWorkloadPods(ctx, clientset, *workload)
+		for _, p := range pods {
+			if IsFailingStatus(p.Status) {
+				state.Status = p.Status
+				state.Failing = true
+				break
+			}
+		}
+		events, _ = GetWorkloadEvents(ctx, clientset, *workload, pods)
+	}
+
+	state.Checked = true
+	state.LastEvent = prev.LastEvent
+	for _, e := range events {
+		if e.LastSeen.After(state.LastEvent) {
+			state.LastEvent = e.LastSeen
+		}
+	}
+
+	// The first check only records a baseline so restarting k9sight
+	// doesn't re-send alerts for problems that were already known
+	if !prev.Checked {
+		return state, nil, nil
+	}
+
+	var alerts []string
+	label := string(resourceType) + "/" + name
+
+	if state.Changed(prev) && state.Failing {
+		alerts = append(alerts, fmt.Sprintf("%s in %s is failing: %s", label, namespace, state.Status))
+	} else if state.Changed(prev) {
+		alerts = append(alerts, fmt.Sprintf("%s in %s recovered: %s", label, namespace, state.Status))
+	}
+
+	for _, e := range events {
+		if e.LastSeen.After(prev.LastEvent) && IsCriticalEvent(e) {
+			alerts = append(alerts, fmt.Sprintf("%s in %s: %s - %s", label, namespace, e.Reason, e.Message))
+		}
+	}
+
+	return state, alerts, nil
+}
@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"testing"
+)
+
+func TestParseWatchKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		key          string
+		expectOK     bool
+		expectNS     string
+		expectType   ResourceType
+		expectTarget string
+	}{
+		{
+			name:         "round trip",
+			key:          WatchKey("payments", ResourceDeployments, "api"),
+			expectOK:     true,
+			expectNS:     "payments",
+			expectType:   ResourceDeployments,
+			expectTarget: "api",
+		},
+		{
+			name:     "missing name",
+			key:      "payments/pods/",
+			expectOK: false,
+		},
+		{
+			name:     "too few parts",
+			key:      "deploy/nginx",
+			expectOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns, rt, name, ok := ParseWatchKey(tt.key)
+			if ok != tt.expectOK {
+				t.Fatalf("ParseWatchKey(%q) ok = %v, want %v", tt.key, ok, tt.expectOK)
+			}
+			if !ok {
+				return
+			}
+			if ns != tt.expectNS || rt != tt.expectType || name != tt.expectTarget {
+				t.Errorf("ParseWatchKey(%q) = %q, %q, %q", tt.key, ns, rt, name)
+			}
+		})
+	}
+}
+
+func TestIsCriticalEvent(t *testing.T) {
+	tests := []struct {
+		event    EventInfo
+		expected bool
+	}{
+		{EventInfo{Type: "Warning", Reason: "BackOff"}, true},
+		{EventInfo{Type: "Warning", Reason: "FailedScheduling"}, true},
+		{EventInfo{Type: "Normal", Reason: "Pulled"}, false},
+		{EventInfo{Type: "Warning", Reason: "SomethingElse"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.event.Reason, func(t *testing.T) {
+			if result := IsCriticalEvent(tt.event); result != tt.expected {
+				t.Errorf("IsCriticalEvent(%v) = %v, want %v", tt.event, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWatchStateChanged(t *testing.T) {
+	ready := WatchState{Status: "Running", Checked: true}
+	failing := WatchState{Status: "CrashLoopBackOff", Failing: true, Checked: true}
+	tests := []struct {
+		name       string
+		prev, next WatchState
+		want       bool
+	}{
+		{"baseline", WatchState{}, failing, false},
+		{"still ready", ready, ready, false},
+		{"started failing", ready, failing, true},
+		{"recovered", failing, ready, true},
+		{"still failing", failing, WatchState{Status: "Error", Failing: true, Checked: true}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.next.Changed(tt.prev); got != tt.want {
+			t.Errorf("%s: Changed() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
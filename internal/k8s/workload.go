@@ -0,0 +1,234 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// WorkloadDetail is what the workload dashboard shows of a Deployment,
+// StatefulSet, DaemonSet or Job
+type WorkloadDetail struct {
+	Workload      WorkloadInfo
+	Replicas      []ReplicaCount
+	Strategy      string
+	Rollout       string // worded like kubectl rollout status, empty for Jobs
+	RolloutFailed bool
+	Conditions    []WorkloadCondition
+	HPAs          []HPAInfo
+	Hints         []DebugHelper // autoscalers that will undo a manual scale
+	Events        []EventInfo
+	Pods          []PodInfo
+}
+
+// ReplicaCount is one of a workload's replica counts, e.g. "Ready" 2
+type ReplicaCount struct {
+	Label string
+	Count int32
+}
+
+// WorkloadCondition is a status condition of a workload
+type WorkloadCondition struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+	Since   time.Time
+}
+
+// HasWorkloadDetail reports whether GetWorkloadDetail can describe workloads
+// of the resource type
+func HasWorkloadDetail(rt ResourceType) bool {
+	switch rt {
+	case ResourceDeployments, ResourceStatefulSets, ResourceDaemonSets, ResourceJobs:
+		return true
+	}
+	return false
+}
+
+// GetWorkloadDetail fetches a workload's replica status, rollout, strategy,
+// conditions, autoscalers, pods and recent events. Only the workload itself
+// is required; the rest is left empty when it cannot be read.
+func GetWorkloadDetail(ctx context.Context, clientset *kubernetes.Clientset, workload WorkloadInfo) (*WorkloadDetail, error) {
+	var detail *WorkloadDetail
+	var replicas *int32 // the spec's, for Deployments and StatefulSets
+	switch workload.Type {
+	case ResourceDeployments:
+		d, err := GetDeployment(ctx, clientset, workload.Namespace, workload.Name)
+		if err != nil {
+			return nil, err
+		}
+		detail = deploymentDetail(d)
+		replicas = d.Spec.Replicas
+		workload = deploymentToWorkload(d)
+	case ResourceStatefulSets:
+		s, err := GetStatefulSet(ctx, clientset, workload.Namespace, workload.Name)
+		if err != nil {
+			return nil, err
+		}
+		detail = statefulSetDetail(s)
+		replicas = s.Spec.Replicas
+		workload = statefulSetToWorkload(s)
+	case ResourceDaemonSets:
+		ds, err := GetDaemonSet(ctx, clientset, workload.Namespace, workload.Name)
+		if err != nil {
+			return nil, err
+		}
+		detail = daemonSetDetail(ds)
+		workload = daemonSetToWorkload(ds)
+	case ResourceJobs:
+		j, err := GetJob(ctx, clientset, workload.Namespace, workload.Name)
+		if err != nil {
+			return nil, err
+		}
+		detail = jobDetail(j)
+		workload = jobToWorkload(j)
+	default:
+		return nil, fmt.Errorf("%s have no workload dashboard", workload.Type)
+	}
+	detail.Workload = workload
+
+	if IsScalable(workload.Type) {
+		detail.HPAs, _ = FindHPAs(ctx, clientset, workload.Namespace, workload.Type, workload.Name)
+		detail.Hints = HPAHelpers(scaleKind(workload.Type), workload.Name, desiredReplicas(replicas), detail.HPAs)
+	}
+	detail.Pods, _ = GetWorkloadPods(ctx, clientset, workload)
+	detail.Events, _ = GetWorkloadEvents(ctx, clientset, workload, detail.Pods)
+	return detail, nil
+}
+
+func deploymentDetail(d *appsv1.Deployment) *WorkloadDetail {
+	s := d.Status
+	detail := &WorkloadDetail{
+		Replicas: []ReplicaCount{
+			{"Desired", desiredReplicas(d.Spec.Replicas)},
+			{"Current", s.Replicas},
+			{"Updated", s.UpdatedReplicas},
+			{"Ready", s.ReadyReplicas},
+			{"Available", s.AvailableReplicas},
+			{"Unavailable", s.UnavailableReplicas},
+		},
+		Strategy: string(d.Spec.Strategy.Type),
+	}
+	if ru := d.Spec.Strategy.RollingUpdate; ru != nil {
+		detail.Strategy += fmt.Sprintf(" (max surge %s, max unavailable %s)", intOrPercent(ru.MaxSurge), intOrPercent(ru.MaxUnavailable))
+	}
+	if d.Spec.ProgressDeadlineSeconds != nil {
+		detail.Strategy += fmt.Sprintf(", progress deadline %ds", *d.Spec.ProgressDeadlineSeconds)
+	}
+
+	state := deploymentRolloutState(d)
+	detail.Rollout, detail.RolloutFailed = state.Message, state.Failed
+	if d.Spec.Paused {
+		detail.Rollout = "paused"
+	}
+	for _, c := range d.Status.Conditions {
+		detail.Conditions = append(detail.Conditions, workloadCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime))
+	}
+	return detail
+}
+
+func statefulSetDetail(sts *appsv1.StatefulSet) *WorkloadDetail {
+	s := sts.Status
+	detail := &WorkloadDetail{
+		Replicas: []ReplicaCount{
+			{"Desired", desiredReplicas(sts.Spec.Replicas)},
+			{"Current", s.Replicas},
+			{"Updated", s.UpdatedReplicas},
+			{"Ready", s.ReadyReplicas},
+			{"Available", s.AvailableReplicas},
+		},
+		Strategy: string(sts.Spec.UpdateStrategy.Type),
+	}
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil && *ru.Partition > 0 {
+		detail.Strategy += fmt.Sprintf(" (partition %d)", *ru.Partition)
+	}
+	if sts.Spec.PodManagementPolicy == appsv1.ParallelPodManagement {
+		detail.Strategy += ", parallel pod management"
+	}
+
+	state := statefulSetRolloutState(sts)
+	detail.Rollout = state.Message
+	for _, c := range sts.Status.Conditions {
+		detail.Conditions = append(detail.Conditions, workloadCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime))
+	}
+	return detail
+}
+
+func daemonSetDetail(ds *appsv1.DaemonSet) *WorkloadDetail {
+	s := ds.Status
+	detail := &WorkloadDetail{
+		Replicas: []ReplicaCount{
+			{"Desired", s.DesiredNumberScheduled},
+			{"Current", s.CurrentNumberScheduled},
+			{"Updated", s.UpdatedNumberScheduled},
+			{"Ready", s.NumberReady},
+			{"Available", s.NumberAvailable},
+			{"Misscheduled", s.NumberMisscheduled},
+		},
+		Strategy: string(ds.Spec.UpdateStrategy.Type),
+	}
+	if ru := ds.Spec.UpdateStrategy.RollingUpdate; ru != nil {
+		detail.Strategy += fmt.Sprintf(" (max unavailable %s, max surge %s)", intOrPercent(ru.MaxUnavailable), intOrPercent(ru.MaxSurge))
+	}
+
+	state := daemonSetRolloutState(ds)
+	detail.Rollout = state.Message
+	for _, c := range ds.Status.Conditions {
+		detail.Conditions = append(detail.Conditions, workloadCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime))
+	}
+	return detail
+}
+
+func jobDetail(j *batchv1.Job) *WorkloadDetail {
+	s := j.Status
+	detail := &WorkloadDetail{
+		Replicas: []ReplicaCount{
+			{"Completions", desiredReplicas(j.Spec.Completions)},
+			{"Active", s.Active},
+			{"Succeeded", s.Succeeded},
+			{"Failed", s.Failed},
+		},
+	}
+	var parts []string
+	if j.Spec.Parallelism != nil {
+		parts = append(parts, fmt.Sprintf("parallelism %d", *j.Spec.Parallelism))
+	}
+	if j.Spec.BackoffLimit != nil {
+		parts = append(parts, fmt.Sprintf("backoff limit %d", *j.Spec.BackoffLimit))
+	}
+	if j.Spec.ActiveDeadlineSeconds != nil {
+		parts = append(parts, fmt.Sprintf("active deadline %ds", *j.Spec.ActiveDeadlineSeconds))
+	}
+	detail.Strategy = strings.Join(parts, ", ")
+	for _, c := range j.Status.Conditions {
+		detail.Conditions = append(detail.Conditions, workloadCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime))
+	}
+	return detail
+}
+
+func workloadCondition(condType, status, reason, message string, since metav1.Time) WorkloadCondition {
+	return WorkloadCondition{Type: condType, Status: status, Reason: reason, Message: message, Since: since.Time}
+}
+
+// desiredReplicas reads an optional count, which defaults to 1
+func desiredReplicas(n *int32) int32 {
+	if n == nil {
+		return 1
+	}
+	return *n
+}
+
+func intOrPercent(v *intstr.IntOrString) string {
+	if v == nil {
+		return "default"
+	}
+	return v.String()
+}
@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDeploymentDetail(t *testing.T) {
+	replicas, deadline := int32(3), int32(600)
+	surge, unavailable := intstr.FromString("25%"), intstr.FromInt32(0)
+	d := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &surge, MaxUnavailable: &unavailable},
+			},
+			ProgressDeadlineSeconds: &deadline,
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          4,
+			UpdatedReplicas:   2,
+			ReadyReplicas:     3,
+			AvailableReplicas: 3,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "ReplicaSetUpdated"},
+			},
+		},
+	}
+
+	detail := deploymentDetail(d)
+	if want := "RollingUpdate (max surge 25%, max unavailable 0), progress deadline 600s"; detail.Strategy != want {
+		t.Errorf("Strategy = %q, want %q", detail.Strategy, want)
+	}
+	if want := "2 of 3 new replicas updated"; detail.Rollout != want {
+		t.Errorf("Rollout = %q, want %q", detail.Rollout, want)
+	}
+	if len(detail.Replicas) == 0 || detail.Replicas[0] != (ReplicaCount{"Desired", 3}) {
+		t.Errorf("Replicas = %v, want Desired 3 first", detail.Replicas)
+	}
+	if len(detail.Conditions) != 1 || detail.Conditions[0].Reason != "ReplicaSetUpdated" {
+		t.Errorf("Conditions = %v", detail.Conditions)
+	}
+
+	d.Spec.Paused = true
+	if got := deploymentDetail(d).Rollout; got != "paused" {
+		t.Errorf("paused Rollout = %q", got)
+	}
+}
+
+func TestStatefulSetDetailStrategy(t *testing.T) {
+	partition := int32(2)
+	tests := []struct {
+		name string
+		spec appsv1.StatefulSetSpec
+		want string
+	}{
+		{"on delete", appsv1.StatefulSetSpec{UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}}, "OnDelete"},
+		{"partition", appsv1.StatefulSetSpec{UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+		}}, "RollingUpdate (partition 2)"},
+		{"parallel", appsv1.StatefulSetSpec{
+			UpdateStrategy:      appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+		}, "RollingUpdate, parallel pod management"},
+	}
+	for _, tt := range tests {
+		if got := statefulSetDetail(&appsv1.StatefulSet{Spec: tt.spec}).Strategy; got != tt.want {
+			t.Errorf("%s: Strategy = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
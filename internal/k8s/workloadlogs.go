@@ -0,0 +1,60 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PodLogLabel is the Container of a line in a workload's merged logs: the
+// pod and container it came from
+func PodLogLabel(pod, container string) string {
+	return pod + "/" + container
+}
+
+// StreamWorkloadPodLogs follows every container of one of a workload's pods
+// for the workload's merged logs, each line's Container set by PodLogLabel.
+// It starts with the last tail lines of each container, or, when after is
+// set, with the lines since then, as when a pod's stream is picked up again.
+func StreamWorkloadPodLogs(ctx context.Context, clientset *kubernetes.Clientset, pod *PodInfo, tail int64, after time.Time) (<-chan LogLine, error) {
+	streams := make([]io.ReadCloser, 0, len(pod.Containers))
+	labels := make([]string, 0, len(pod.Containers))
+	for _, c := range pod.Containers {
+		opts := &corev1.PodLogOptions{Container: c.Name, Follow: true, Timestamps: true}
+		if after.IsZero() {
+			opts.TailLines = &tail
+		} else {
+			since := metav1.NewTime(after)
+			opts.SinceTime = &since
+		}
+		stream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+		if err != nil {
+			for _, s := range streams {
+				s.Close()
+			}
+			return nil, fmt.Errorf("failed to follow logs of %s: %w", pod.Name, err)
+		}
+		streams = append(streams, stream)
+		labels = append(labels, PodLogLabel(pod.Name, c.Name))
+	}
+	return mergeLogStreams(ctx, streams, labels, after), nil
+}
+
+// LogsFollowable reports whether a pod has a container running or exited
+// whose logs can be followed, rather than one still waiting to start
+func LogsFollowable(pod *PodInfo) bool {
+	if pod.Object == nil {
+		return pod.Phase == corev1.PodRunning
+	}
+	for _, s := range pod.Object.Status.ContainerStatuses {
+		if s.State.Running != nil || s.State.Terminated != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// GetManifestYAML fetches a pod or workload by kind (e.g. "Pod",
+// "Deployment") and renders it as YAML without managedFields
+func GetManifestYAML(ctx context.Context, clientset *kubernetes.Clientset, namespace, kind, name string) (string, error) {
+	obj, gvk, err := getObject(ctx, clientset, namespace, kind, name)
+	if err != nil {
+		return "", err
+	}
+	return ManifestYAML(obj, gvk)
+}
+
+// getObject fetches a namespaced object by kind, with the version it is
+// served as, since typed objects come back without apiVersion and kind
+func getObject(ctx context.Context, clientset *kubernetes.Clientset, namespace, kind, name string) (runtime.Object, schema.GroupVersionKind, error) {
+	var (
+		obj runtime.Object
+		gv  schema.GroupVersion
+		err error
+	)
+	opts := metav1.GetOptions{}
+	switch kind {
+	case "Pod":
+		obj, err = clientset.CoreV1().Pods(namespace).Get(ctx, name, opts)
+		gv = corev1.SchemeGroupVersion
+	case "Service":
+		obj, err = clientset.CoreV1().Services(namespace).Get(ctx, name, opts)
+		gv = corev1.SchemeGroupVersion
+	case "ConfigMap":
+		obj, err = clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, opts)
+		gv = corev1.SchemeGroupVersion
+	case "PersistentVolumeClaim":
+		obj, err = clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, opts)
+		gv = corev1.SchemeGroupVersion
+	case "Deployment":
+		obj, err = clientset.AppsV1().Deployments(namespace).Get(ctx, name, opts)
+		gv = appsv1.SchemeGroupVersion
+	case "ReplicaSet":
+		obj, err = clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, opts)
+		gv = appsv1.SchemeGroupVersion
+	case "StatefulSet":
+		obj, err = clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, opts)
+		gv = appsv1.SchemeGroupVersion
+	case "DaemonSet":
+		obj, err = clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, opts)
+		gv = appsv1.SchemeGroupVersion
+	case "Job":
+		obj, err = clientset.BatchV1().Jobs(namespace).Get(ctx, name, opts)
+		gv = batchv1.SchemeGroupVersion
+	case "CronJob":
+		obj, err = clientset.BatchV1().CronJobs(namespace).Get(ctx, name, opts)
+		gv = batchv1.SchemeGroupVersion
+	case "Ingress":
+		obj, err = clientset.NetworkingV1().Ingresses(namespace).Get(ctx, name, opts)
+		gv = networkingv1.SchemeGroupVersion
+	default:
+		return nil, schema.GroupVersionKind{}, fmt.Errorf("cannot show YAML for %s", kind)
+	}
+	if err != nil {
+		return nil, schema.GroupVersionKind{}, err
+	}
+	return obj, gv.WithKind(kind), nil
+}
+
+// ResolveOwnerWorkload follows a pod's owner up to the workload a user edits:
+// a ReplicaSet owned by a Deployment resolves to the Deployment, and a Job
+// owned by a CronJob to the CronJob. Anything else is returned unchanged.
+func ResolveOwnerWorkload(ctx context.Context, clientset *kubernetes.Clientset, namespace, kind, name string) (string, string) {
+	var owners []metav1.OwnerReference
+	switch kind {
+	case "ReplicaSet":
+		rs, err := clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return kind, name
+		}
+		owners = rs.OwnerReferences
+	case "Job":
+		job, err := clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return kind, name
+		}
+		owners = job.OwnerReferences
+	}
+	for _, owner := range owners {
+		if owner.Controller != nil && *owner.Controller {
+			return owner.Kind, owner.Name
+		}
+	}
+	return kind, name
+}
+
+// ManifestYAML serializes obj the way kubectl get -o yaml does, minus
+// managedFields. Typed objects from the clientset come back without
+// apiVersion and kind, so gvk fills them in; obj itself is left untouched.
+func ManifestYAML(obj runtime.Object, gvk schema.GroupVersionKind) (string, error) {
+	obj = obj.DeepCopyObject()
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
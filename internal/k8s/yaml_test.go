@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestManifestYAML(t *testing.T) {
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "shop",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply},
+			},
+		},
+	}
+
+	out, err := ManifestYAML(dep, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "apiVersion: apps/v1\nkind: Deployment\n") {
+		t.Errorf("missing apiVersion/kind header:\n%s", out)
+	}
+	if !strings.Contains(out, "  name: web\n") {
+		t.Errorf("missing metadata.name:\n%s", out)
+	}
+	if strings.Contains(out, "managedFields") {
+		t.Errorf("managedFields not stripped:\n%s", out)
+	}
+	if len(dep.ManagedFields) != 1 || dep.Kind != "" {
+		t.Error("ManifestYAML modified its input")
+	}
+}
@@ -0,0 +1,105 @@
+package logbackend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/doganarif/k9sight/internal/config"
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// Query selects the logs of one pod (optionally one container) over a time range.
+type Query struct {
+	Namespace string
+	Pod       string
+	Container string
+	Since     time.Duration
+	Limit     int
+}
+
+// Backend is an external log store that can stand in for the kubelet log API.
+type Backend interface {
+	Name() string
+	Logs(ctx context.Context, q Query) ([]k8s.LogLine, error)
+}
+
+// New builds the backend described by cfg.
+func New(cfg config.LogBackendConfig) (Backend, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("log backend url is required")
+	}
+
+	base := httpBackend{
+		url:    strings.TrimRight(cfg.URL, "/"),
+		cfg:    cfg,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	switch strings.ToLower(cfg.Type) {
+	case "loki":
+		return &lokiBackend{httpBackend: base}, nil
+	case "elasticsearch", "elastic", "opensearch":
+		return &elasticBackend{httpBackend: base}, nil
+	default:
+		return nil, fmt.Errorf("unknown log backend type: %q", cfg.Type)
+	}
+}
+
+// DefaultLookback is used when neither the query nor the config sets a range.
+func DefaultLookback(cfg config.LogBackendConfig) time.Duration {
+	if cfg.LookbackHours > 0 {
+		return time.Duration(cfg.LookbackHours) * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+type httpBackend struct {
+	url    string
+	cfg    config.LogBackendConfig
+	client *http.Client
+}
+
+func (b httpBackend) authorize(req *http.Request) {
+	switch {
+	case b.cfg.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+b.cfg.BearerToken)
+	case b.cfg.Username != "":
+		req.SetBasicAuth(b.cfg.Username, b.cfg.Password)
+	}
+}
+
+func (b httpBackend) do(req *http.Request) (*http.Response, error) {
+	b.authorize(req)
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s returned %s", b.url, resp.Status)
+	}
+	return resp, nil
+}
+
+// expand substitutes {namespace}, {pod} and {container} in a query template.
+func expand(template string, q Query) string {
+	return strings.NewReplacer(
+		"{namespace}", q.Namespace,
+		"{pod}", q.Pod,
+		"{container}", q.Container,
+	).Replace(template)
+}
+
+func sortAndTrim(lines []k8s.LogLine, limit int) []k8s.LogLine {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Timestamp.Before(lines[j].Timestamp)
+	})
+	if limit > 0 && len(lines) > limit {
+		lines = lines[len(lines)-limit:]
+	}
+	return lines
+}
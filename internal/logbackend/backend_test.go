@@ -0,0 +1,120 @@
+package logbackend
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/doganarif/k9sight/internal/config"
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.LogBackendConfig
+		wantName string
+		wantErr  bool
+	}{
+		{"loki", config.LogBackendConfig{Type: "loki", URL: "http://loki:3100"}, "loki", false},
+		{"elasticsearch", config.LogBackendConfig{Type: "Elasticsearch", URL: "http://es:9200"}, "elasticsearch", false},
+		{"missing url", config.LogBackendConfig{Type: "loki"}, "", true},
+		{"unknown type", config.LogBackendConfig{Type: "splunk", URL: "http://x"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && b.Name() != tt.wantName {
+				t.Errorf("Name() = %q, want %q", b.Name(), tt.wantName)
+			}
+		})
+	}
+}
+
+func TestLokiParams(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name      string
+		query     string
+		q         Query
+		wantQuery string
+	}{
+		{
+			"pod selector",
+			"",
+			Query{Namespace: "prod", Pod: "api-1"},
+			`{namespace="prod", pod="api-1"}`,
+		},
+		{
+			"container selector",
+			"",
+			Query{Namespace: "prod", Pod: "api-1", Container: "app"},
+			`{namespace="prod", pod="api-1", container="app"}`,
+		},
+		{
+			"custom template",
+			`{k8s_pod_name="{pod}"} |= "level"`,
+			Query{Namespace: "prod", Pod: "api-1"},
+			`{k8s_pod_name="api-1"} |= "level"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &lokiBackend{httpBackend{cfg: config.LogBackendConfig{Query: tt.query}}}
+			params := b.params(tt.q, now)
+			if got := params.Get("query"); got != tt.wantQuery {
+				t.Errorf("query = %q, want %q", got, tt.wantQuery)
+			}
+			wantStart := now.Add(-24 * time.Hour).UnixNano()
+			if got := params.Get("start"); got != strconv.FormatInt(wantStart, 10) {
+				t.Errorf("start = %q, want %d", got, wantStart)
+			}
+		})
+	}
+}
+
+func TestLookupString(t *testing.T) {
+	source := map[string]interface{}{
+		"log": "hello",
+		"kubernetes": map[string]interface{}{
+			"container_name": "app",
+		},
+		"kubernetes.pod_name": "api-1",
+	}
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"log", "hello"},
+		{"kubernetes.container_name", "app"},
+		{"kubernetes.pod_name", "api-1"},
+		{"kubernetes.namespace_name", ""},
+		{"log.nested", ""},
+	}
+
+	for _, tt := range tests {
+		if got := lookupString(source, tt.field); got != tt.want {
+			t.Errorf("lookupString(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestSortAndTrim(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	lines := []k8s.LogLine{
+		k8s.NewLogLine("app", "c", base.Add(2*time.Second)),
+		k8s.NewLogLine("app", "a", base),
+		k8s.NewLogLine("app", "b", base.Add(time.Second)),
+	}
+
+	got := sortAndTrim(lines, 2)
+	if len(got) != 2 || got[0].Content != "b" || got[1].Content != "c" {
+		t.Errorf("sortAndTrim() = %v, want [b c]", got)
+	}
+}
@@ -0,0 +1,137 @@
+package logbackend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// Field names produced by the fluent-bit / fluentd kubernetes metadata filters
+const (
+	esNamespaceField = "kubernetes.namespace_name"
+	esPodField       = "kubernetes.pod_name"
+	esContainerField = "kubernetes.container_name"
+	esMessageField   = "log"
+	esTimestampField = "@timestamp"
+	defaultESIndex   = "logstash-*"
+)
+
+type elasticBackend struct {
+	httpBackend
+}
+
+type esResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source map[string]interface{} `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (b *elasticBackend) Name() string {
+	return "elasticsearch"
+}
+
+func (b *elasticBackend) Logs(ctx context.Context, q Query) ([]k8s.LogLine, error) {
+	index := b.cfg.Index
+	if index == "" {
+		index = defaultESIndex
+	}
+
+	body, err := json.Marshal(b.searchBody(q))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url+"/"+index+"/_search", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result esResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	var lines []k8s.LogLine
+	for _, hit := range result.Hits.Hits {
+		message := lookupString(hit.Source, esMessageField)
+		if message == "" {
+			message = lookupString(hit.Source, "message")
+		}
+		ts, _ := time.Parse(time.RFC3339Nano, lookupString(hit.Source, esTimestampField))
+		container := lookupString(hit.Source, esContainerField)
+		lines = append(lines, k8s.NewLogLine(container, strings.TrimRight(message, "\n"), ts))
+	}
+
+	return sortAndTrim(lines, q.Limit), nil
+}
+
+func (b *elasticBackend) searchBody(q Query) map[string]interface{} {
+	since := q.Since
+	if since <= 0 {
+		since = DefaultLookback(b.cfg)
+	}
+
+	filters := []interface{}{
+		map[string]interface{}{"match_phrase": map[string]interface{}{esNamespaceField: q.Namespace}},
+		map[string]interface{}{"match_phrase": map[string]interface{}{esPodField: q.Pod}},
+		map[string]interface{}{"range": map[string]interface{}{
+			esTimestampField: map[string]interface{}{"gte": fmt.Sprintf("now-%ds", int(since.Seconds()))},
+		}},
+	}
+	if q.Container != "" {
+		filters = append(filters, map[string]interface{}{
+			"match_phrase": map[string]interface{}{esContainerField: q.Container},
+		})
+	}
+	if b.cfg.Query != "" {
+		filters = append(filters, map[string]interface{}{
+			"query_string": map[string]interface{}{"query": expand(b.cfg.Query, q)},
+		})
+	}
+
+	size := q.Limit
+	if size <= 0 {
+		size = 500
+	}
+
+	return map[string]interface{}{
+		"size":  size,
+		"sort":  []interface{}{map[string]interface{}{esTimestampField: "desc"}},
+		"query": map[string]interface{}{"bool": map[string]interface{}{"filter": filters}},
+	}
+}
+
+// lookupString reads a field that may be stored flat ("a.b") or nested ({"a": {"b": ...}})
+func lookupString(source map[string]interface{}, field string) string {
+	if v, ok := source[field]; ok {
+		s, _ := v.(string)
+		return s
+	}
+
+	parts := strings.Split(field, ".")
+	var current interface{} = source
+	for _, part := range parts {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = m[part]
+	}
+	s, _ := current.(string)
+	return s
+}
@@ -0,0 +1,97 @@
+package logbackend
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+const (
+	defaultLokiQuery          = `{namespace="{namespace}", pod="{pod}"}`
+	defaultLokiContainerQuery = `{namespace="{namespace}", pod="{pod}", container="{container}"}`
+)
+
+type lokiBackend struct {
+	httpBackend
+}
+
+type lokiResponse struct {
+	Data struct {
+		Result []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func (b *lokiBackend) Name() string {
+	return "loki"
+}
+
+func (b *lokiBackend) Logs(ctx context.Context, q Query) ([]k8s.LogLine, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.url+"/loki/api/v1/query_range", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = b.params(q, time.Now()).Encode()
+	if b.cfg.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", b.cfg.TenantID)
+	}
+
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body lokiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	var lines []k8s.LogLine
+	for _, stream := range body.Data.Result {
+		container := stream.Stream["container"]
+		for _, v := range stream.Values {
+			ns, err := strconv.ParseInt(v[0], 10, 64)
+			if err != nil {
+				continue
+			}
+			lines = append(lines, k8s.NewLogLine(container, v[1], time.Unix(0, ns)))
+		}
+	}
+
+	return sortAndTrim(lines, q.Limit), nil
+}
+
+func (b *lokiBackend) params(q Query, now time.Time) url.Values {
+	selector := b.cfg.Query
+	switch {
+	case selector != "":
+	case q.Container != "":
+		selector = defaultLokiContainerQuery
+	default:
+		selector = defaultLokiQuery
+	}
+	query := expand(selector, q)
+
+	since := q.Since
+	if since <= 0 {
+		since = DefaultLookback(b.cfg)
+	}
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(now.Add(-since).UnixNano(), 10))
+	params.Set("end", strconv.FormatInt(now.UnixNano(), 10))
+	params.Set("direction", "backward")
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return params
+}
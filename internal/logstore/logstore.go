@@ -0,0 +1,182 @@
+// Package logstore keeps the log lines of the logs panel in a ring buffer
+// capped by a line and byte budget, so new lines are appended in place and
+// the oldest ones dropped instead of the whole buffer being rebuilt.
+package logstore
+
+import (
+	"time"
+
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// lineOverhead approximates the per-line memory beyond the content bytes
+// (struct, timestamp, container name header)
+const lineOverhead = 64
+
+// Store holds up to maxLines lines and maxBytes bytes of logs in time order,
+// dropping the oldest beyond either; 0 disables a limit
+type Store struct {
+	buf      []k8s.LogLine // grows up to maxLines, then wraps
+	start    int           // index in buf of the oldest line
+	count    int
+	bytes    int
+	maxLines int
+	maxBytes int
+	dropped  int // lines dropped since the last Reset
+}
+
+func New(maxLines, maxBytes int) *Store {
+	return &Store{maxLines: maxLines, maxBytes: maxBytes}
+}
+
+// SetBudget changes the limits, dropping the oldest lines beyond them
+func (s *Store) SetBudget(maxLines, maxBytes int) {
+	s.maxLines, s.maxBytes = maxLines, maxBytes
+	s.enforce()
+	if s.maxLines > 0 && len(s.buf) > s.maxLines {
+		s.buf, s.start = s.Lines(), 0
+	}
+}
+
+// Reset replaces the stored lines, counting only those of lines that do not
+// fit as dropped
+func (s *Store) Reset(lines []k8s.LogLine) {
+	s.buf, s.start, s.count, s.bytes, s.dropped = nil, 0, 0, 0, 0
+	s.Append(lines...)
+}
+
+// Append adds lines after the stored ones
+func (s *Store) Append(lines ...k8s.LogLine) {
+	for _, line := range lines {
+		s.push(line)
+	}
+}
+
+// Insert adds lines in time order that may belong before stored ones, as
+// when another source's lines arrive late
+func (s *Store) Insert(lines []k8s.LogLine) {
+	if len(lines) == 0 {
+		return
+	}
+	if s.count == 0 || !k8s.LogLineBefore(lines[0], s.At(s.count-1)) {
+		s.Append(lines...)
+		return
+	}
+	dropped := s.dropped
+	s.Reset(k8s.MergeLogLines(s.Lines(), lines))
+	s.dropped += dropped
+}
+
+// AppendNewer adds the lines of a fresh fetch that are newer than the
+// stored ones, so polling does not replace what is already loaded. Lines
+// stamped with the newest stored time are added unless stored already, since
+// several lines can share a timestamp. It returns how many were added.
+func (s *Store) AppendNewer(lines []k8s.LogLine) int {
+	if s.count == 0 {
+		s.Reset(lines)
+		return len(lines)
+	}
+	newest := s.Newest()
+	// The stored lines at the newest time, counted, to skip them once each
+	type lineKey struct{ container, content string }
+	seen := make(map[lineKey]int)
+	for i := 0; i < s.count; i++ {
+		if line := s.At(i); line.Timestamp.Equal(newest) {
+			seen[lineKey{line.Container, line.Content}]++
+		}
+	}
+	added := 0
+	for _, line := range lines {
+		if line.Timestamp.Equal(newest) {
+			key := lineKey{line.Container, line.Content}
+			if seen[key] > 0 {
+				seen[key]--
+				continue
+			}
+		} else if !line.Timestamp.After(newest) {
+			continue
+		}
+		s.push(line)
+		added++
+	}
+	return added
+}
+
+func (s *Store) push(line k8s.LogLine) {
+	if s.maxLines > 0 && s.count == s.maxLines {
+		s.evict()
+	}
+	switch {
+	case s.count < len(s.buf):
+		s.buf[(s.start+s.count)%len(s.buf)] = line
+	case s.start == 0:
+		s.buf = append(s.buf, line)
+	default:
+		// Lines were dropped for bytes before the buffer filled up; lay
+		// it out from the oldest line again to grow it
+		s.buf, s.start = append(s.Lines(), line), 0
+	}
+	s.count++
+	s.bytes += lineSize(line)
+	s.enforce()
+}
+
+// enforce drops the oldest lines beyond the budget, always keeping the
+// newest one
+func (s *Store) enforce() {
+	for s.count > 1 && ((s.maxLines > 0 && s.count > s.maxLines) || (s.maxBytes > 0 && s.bytes > s.maxBytes)) {
+		s.evict()
+	}
+}
+
+func (s *Store) evict() {
+	s.bytes -= lineSize(s.buf[s.start])
+	s.buf[s.start] = k8s.LogLine{} // let the content be collected
+	s.start = (s.start + 1) % len(s.buf)
+	s.count--
+	s.dropped++
+}
+
+// Len returns the number of stored lines
+func (s *Store) Len() int {
+	return s.count
+}
+
+// Dropped returns how many older lines were dropped to stay within budget
+func (s *Store) Dropped() int {
+	return s.dropped
+}
+
+// At returns the i-th stored line, oldest first
+func (s *Store) At(i int) k8s.LogLine {
+	return s.buf[(s.start+i)%len(s.buf)]
+}
+
+// Lines returns a copy of the stored lines, oldest first
+func (s *Store) Lines() []k8s.LogLine {
+	lines := make([]k8s.LogLine, 0, s.count)
+	if s.count == 0 {
+		return lines
+	}
+	end := s.start + s.count
+	if end <= len(s.buf) {
+		return append(lines, s.buf[s.start:end]...)
+	}
+	lines = append(lines, s.buf[s.start:]...)
+	return append(lines, s.buf[:end-len(s.buf)]...)
+}
+
+// Newest returns the latest timestamp among the stored lines
+func (s *Store) Newest() time.Time {
+	var newest time.Time
+	for i := 0; i < s.count; i++ {
+		if ts := s.At(i).Timestamp; ts.After(newest) {
+			newest = ts
+		}
+	}
+	return newest
+}
+
+func lineSize(line k8s.LogLine) int {
+	return len(line.Content) + len(line.Container) + lineOverhead
+}
@@ -0,0 +1,123 @@
+package logstore
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+func at(sec int, content string) k8s.LogLine {
+	return k8s.LogLine{Timestamp: time.Date(2024, 5, 1, 12, 0, sec, 0, time.UTC), Content: content}
+}
+
+func contents(s *Store) string {
+	var parts []string
+	for _, l := range s.Lines() {
+		parts = append(parts, l.Content)
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestBudget(t *testing.T) {
+	makeLogs := func(n, size int) []k8s.LogLine {
+		logs := make([]k8s.LogLine, n)
+		for i := range logs {
+			logs[i] = k8s.LogLine{Content: strings.Repeat("x", size)}
+		}
+		logs[n-1].Content = "newest"
+		return logs
+	}
+
+	tests := []struct {
+		name        string
+		logs        []k8s.LogLine
+		maxLines    int
+		maxBytes    int
+		wantKept    int
+		wantDropped int
+	}{
+		{"within budget", makeLogs(10, 10), 100, 1 << 20, 10, 0},
+		{"no limits", makeLogs(10, 10), 0, 0, 10, 0},
+		{"line limit", makeLogs(10, 10), 4, 0, 4, 6},
+		{"byte limit", makeLogs(10, 36), 0, 300, 3, 7},
+		{"both, lines stricter", makeLogs(10, 36), 2, 300, 2, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.maxLines, tt.maxBytes)
+			s.Reset(tt.logs)
+			if s.Len() != tt.wantKept || s.Dropped() != tt.wantDropped {
+				t.Fatalf("kept %d, dropped %d; want %d, %d", s.Len(), s.Dropped(), tt.wantKept, tt.wantDropped)
+			}
+			if s.At(s.Len()-1).Content != "newest" {
+				t.Error("the newest line should be kept")
+			}
+		})
+	}
+}
+
+func TestRingWraps(t *testing.T) {
+	s := New(3, 0)
+	for i := 1; i <= 7; i++ {
+		s.Append(at(i, fmt.Sprint(i)))
+	}
+	if got := contents(s); got != "5,6,7" || s.Dropped() != 4 {
+		t.Errorf("after 7 appends = %s, dropped %d", got, s.Dropped())
+	}
+	if len(s.buf) != 3 {
+		t.Errorf("buffer grew to %d, want 3", len(s.buf))
+	}
+
+	s.SetBudget(5, 0)
+	s.Append(at(8, "8"), at(9, "9"), at(10, "10"))
+	if got := contents(s); got != "6,7,8,9,10" {
+		t.Errorf("after growing = %s", got)
+	}
+	s.SetBudget(2, 0)
+	if got := contents(s); got != "9,10" || s.Dropped() != 8 {
+		t.Errorf("after shrinking = %s, dropped %d", got, s.Dropped())
+	}
+
+	s.Reset(nil)
+	if s.Len() != 0 || s.Dropped() != 0 || !s.Newest().IsZero() {
+		t.Errorf("Reset(nil) left %d lines, %d dropped", s.Len(), s.Dropped())
+	}
+}
+
+func TestAppendNewer(t *testing.T) {
+	s := New(4, 0)
+	if n := s.AppendNewer([]k8s.LogLine{at(1, "a"), at(2, "b")}); n != 2 {
+		t.Errorf("first poll added %d, want 2", n)
+	}
+	// The next poll's tail overlaps the lines already loaded
+	if n := s.AppendNewer([]k8s.LogLine{at(2, "b"), at(3, "c"), at(4, "d"), at(5, "e")}); n != 3 {
+		t.Errorf("second poll added %d, want 3", n)
+	}
+	if got := contents(s); got != "b,c,d,e" || s.Dropped() != 1 {
+		t.Errorf("lines = %s, dropped %d", got, s.Dropped())
+	}
+	if !s.Newest().Equal(at(5, "").Timestamp) {
+		t.Errorf("Newest() = %v", s.Newest())
+	}
+
+	// A line logged in the same instant as the newest one is not a repeat
+	if n := s.AppendNewer([]k8s.LogLine{at(5, "e"), at(5, "f")}); n != 1 {
+		t.Errorf("same-time poll added %d, want 1", n)
+	}
+	if got := contents(s); got != "c,d,e,f" {
+		t.Errorf("lines = %s", got)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	s := New(4, 0)
+	s.Append(at(1, "a"), at(4, "d"))
+	s.Insert([]k8s.LogLine{at(5, "e")})
+	s.Insert([]k8s.LogLine{at(2, "b"), at(3, "c")})
+	if got := contents(s); got != "b,c,d,e" || s.Dropped() != 1 {
+		t.Errorf("lines = %s, dropped %d", got, s.Dropped())
+	}
+}
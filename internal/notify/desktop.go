@@ -0,0 +1,28 @@
+package notify
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+var appleScriptQuote = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// Desktop shows a desktop notification, through notify-send on Linux and
+// osascript on macOS. It fails where neither is available.
+func Desktop(title, text string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := `display notification "` + appleScriptQuote.Replace(text) + `" with title "` + appleScriptQuote.Replace(title) + `"`
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		return errors.New("desktop notifications are not supported on windows")
+	default:
+		cmd = exec.Command("notify-send", "--app-name=k9sight", title, text)
+	}
+
+	return cmd.Run()
+}
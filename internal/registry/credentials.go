@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// credentials are basic auth credentials for one registry
+type credentials struct {
+	Username string
+	Password string
+}
+
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth string `json:"auth"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// loadDockerConfig reads $DOCKER_CONFIG/config.json or ~/.docker/config.json
+func loadDockerConfig() *dockerConfig {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return nil
+	}
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil
+	}
+	return &cfg
+}
+
+// lookup returns credentials for a registry host from the docker config,
+// asking the configured credential helper when there is one
+func (c *dockerConfig) lookup(host string) *credentials {
+	if c == nil {
+		return nil
+	}
+
+	key := host
+	if host == dockerHubHost {
+		key = dockerHubAuthKey
+	}
+
+	if helper := c.CredHelpers[host]; helper != "" {
+		return credentialHelper(helper, key)
+	}
+
+	for k, entry := range c.Auths {
+		if authKeyMatches(k, key) {
+			if creds := decodeAuth(entry.Auth); creds != nil {
+				return creds
+			}
+		}
+	}
+
+	if c.CredsStore != "" {
+		return credentialHelper(c.CredsStore, key)
+	}
+	return nil
+}
+
+// authKeyMatches compares docker config keys, which may include a scheme or path
+func authKeyMatches(configKey, host string) bool {
+	if configKey == host {
+		return true
+	}
+	trim := func(s string) string {
+		s = strings.TrimPrefix(strings.TrimPrefix(s, "https://"), "http://")
+		s, _, _ = strings.Cut(s, "/")
+		return s
+	}
+	return trim(configKey) == trim(host)
+}
+
+func decodeAuth(auth string) *credentials {
+	if auth == "" {
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(auth)
+	if err != nil {
+		return nil
+	}
+	user, pass, ok := strings.Cut(string(data), ":")
+	if !ok {
+		return nil
+	}
+	return &credentials{Username: user, Password: pass}
+}
+
+// credentialHelper runs docker-credential-<helper> get, see
+// https://github.com/docker/docker-credential-helpers
+func credentialHelper(helper, serverURL string) *credentials {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(serverURL)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil
+	}
+
+	var resp struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil || resp.Secret == "" {
+		return nil
+	}
+	return &credentials{Username: resp.Username, Password: resp.Secret}
+}
@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+// SecretCovers reports whether a pull secret's docker config key gives the
+// kubelet credentials for the image, matching as the kubelet does: on the
+// host, with a leading "*." matching one subdomain level, and on a
+// repository path prefix when the key has one
+func SecretCovers(key string, ref Reference) bool {
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	host, repo, _ := strings.Cut(key, "/")
+	if host == "index.docker.io" || host == "docker.io" {
+		// Docker Hub keys carry an API path, https://index.docker.io/v1/
+		host, repo = dockerHubHost, ""
+	}
+	if !hostMatches(host, ref.Registry) {
+		return false
+	}
+	repo = strings.Trim(repo, "/")
+	return repo == "" || ref.Repository == repo || strings.HasPrefix(ref.Repository, repo+"/")
+}
+
+func hostMatches(pattern, host string) bool {
+	if !strings.HasPrefix(pattern, "*") {
+		return pattern == host
+	}
+	// path.Match treats * as any run of characters without a slash, and
+	// hosts contain no slashes, so require the same number of labels
+	ok, _ := path.Match(pattern, host)
+	return ok && strings.Count(pattern, ".") == strings.Count(host, ".")
+}
+
+// PullSecretHelper explains whether the pod's imagePullSecrets can pull the
+// image a container failed to pull: secrets that are missing or unreadable,
+// and whether any of them covers the image's registry
+func PullSecretHelper(container, image string, secrets []k8s.PullSecret) k8s.DebugHelper {
+	ref := ParseReference(image)
+	if len(secrets) == 0 {
+		return k8s.DebugHelper{
+			Issue:    fmt.Sprintf("No imagePullSecrets for %s (container %s)", ref.Registry, container),
+			Severity: "Warning",
+			Suggestions: []string{
+				"A private registry needs a pull secret on the pod or its service account",
+				"Unless the nodes themselves are authorized to pull from " + ref.Registry,
+			},
+		}
+	}
+
+	var problems, covering, hosts []string
+	for _, s := range secrets {
+		switch {
+		case s.Missing:
+			problems = append(problems, fmt.Sprintf("Secret %s does not exist in the namespace", s.Name))
+		case s.Err != nil:
+			problems = append(problems, fmt.Sprintf("Secret %s: %s", s.Name, k8s.ShortError(s.Err)))
+		}
+		for _, key := range s.Registries {
+			hosts = append(hosts, key)
+			if SecretCovers(key, ref) {
+				covering = append(covering, s.Name)
+				break
+			}
+		}
+	}
+
+	if len(covering) > 0 {
+		return k8s.DebugHelper{
+			Issue:    fmt.Sprintf("Pull secret %s covers %s (container %s)", strings.Join(covering, ", "), ref.Registry, container),
+			Severity: "Warning",
+			Suggestions: append(problems,
+				"The credentials in it may be wrong or expired, or lack access to "+ref.Repository,
+			),
+		}
+	}
+	suggestions := problems
+	if len(hosts) > 0 {
+		suggestions = append(suggestions, "Secrets cover: "+strings.Join(hosts, ", "))
+	}
+	suggestions = append(suggestions, "Add a docker-registry secret for "+ref.Registry+" to imagePullSecrets")
+	return k8s.DebugHelper{
+		Issue:       fmt.Sprintf("No pull secret covers %s (container %s)", ref.Registry, container),
+		Severity:    "High",
+		Suggestions: suggestions,
+	}
+}
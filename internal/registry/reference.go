@@ -0,0 +1,124 @@
+package registry
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	dockerHubHost    = "registry-1.docker.io"
+	dockerHubAuthKey = "https://index.docker.io/v1/"
+	defaultTag       = "latest"
+	maxSuggestedTags = 5
+)
+
+// Reference is a parsed container image reference
+type Reference struct {
+	Registry   string // API host, e.g. registry-1.docker.io or ghcr.io
+	Repository string // e.g. library/nginx
+	Tag        string
+	Digest     string
+}
+
+// String returns the reference the way it is usually written in a pod spec
+func (r Reference) String() string {
+	s := r.Repository
+	if r.Registry != dockerHubHost {
+		s = r.Registry + "/" + s
+	} else {
+		s = strings.TrimPrefix(s, "library/")
+	}
+	if r.Digest != "" {
+		return s + "@" + r.Digest
+	}
+	return s + ":" + r.Tag
+}
+
+// ParseReference parses an image name such as "nginx", "ghcr.io/org/app:1.2"
+// or "repo/app@sha256:...", applying Docker Hub defaults
+func ParseReference(image string) Reference {
+	var ref Reference
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+	}
+	// A colon after the last slash separates the tag; earlier ones are a registry port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = defaultTag
+	}
+
+	// The first component is a registry host only if it looks like one
+	first, rest, found := strings.Cut(name, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry = first
+		ref.Repository = rest
+	} else {
+		ref.Registry = dockerHubHost
+		ref.Repository = name
+	}
+
+	if ref.Registry == "docker.io" || ref.Registry == "index.docker.io" {
+		ref.Registry = dockerHubHost
+	}
+	if ref.Registry == dockerHubHost && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+
+	return ref
+}
+
+// ClosestTags returns up to limit tags ordered by edit distance to tag
+func ClosestTags(tag string, tags []string, limit int) []string {
+	type scored struct {
+		tag  string
+		dist int
+	}
+
+	candidates := make([]scored, 0, len(tags))
+	for _, t := range tags {
+		if t == tag {
+			continue
+		}
+		candidates = append(candidates, scored{t, levenshtein(tag, t)})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].tag > candidates[j].tag // newer-looking tags first on ties
+	})
+
+	var out []string
+	for i := 0; i < len(candidates) && i < limit; i++ {
+		out = append(out, candidates[i].tag)
+	}
+	return out
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
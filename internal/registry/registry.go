@@ -0,0 +1,303 @@
+// Package registry queries container registries (Docker Registry HTTP API v2)
+// to explain image pull failures.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+const cacheTTL = 5 * time.Minute
+
+var manifestAccept = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+// Result is what the registry knows about an image reference
+type Result struct {
+	Reference   Reference
+	Exists      bool
+	Digest      string
+	SimilarTags []string
+	Err         error // registry unreachable or unauthorized
+}
+
+type cacheEntry struct {
+	result  *Result
+	fetched time.Time
+}
+
+// Client looks up image metadata, caching results so refresh ticks do not
+// hammer the registry
+type Client struct {
+	http   *http.Client
+	docker *dockerConfig
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+func NewClient() *Client {
+	return &Client{
+		http:   &http.Client{Timeout: 15 * time.Second},
+		docker: loadDockerConfig(),
+		cache:  make(map[string]cacheEntry),
+	}
+}
+
+// Lookup reports whether image exists, its digest, and similar tags when it does not
+func (c *Client) Lookup(ctx context.Context, image string) *Result {
+	c.mu.Lock()
+	if entry, ok := c.cache[image]; ok && time.Since(entry.fetched) < cacheTTL {
+		c.mu.Unlock()
+		return entry.result
+	}
+	c.mu.Unlock()
+
+	ref := ParseReference(image)
+	result := &Result{Reference: ref}
+
+	session := &session{client: c, ref: ref, creds: c.docker.lookup(ref.Registry)}
+	reference := ref.Tag
+	if ref.Digest != "" {
+		reference = ref.Digest
+	}
+
+	exists, digest, err := session.manifest(ctx, reference)
+	switch {
+	case err != nil:
+		result.Err = err
+	case exists:
+		result.Exists = true
+		result.Digest = digest
+	case ref.Digest == "":
+		tags, err := session.tags(ctx)
+		if err != nil {
+			result.Err = err
+		} else {
+			result.SimilarTags = ClosestTags(ref.Tag, tags, maxSuggestedTags)
+		}
+	}
+
+	c.mu.Lock()
+	c.cache[image] = cacheEntry{result: result, fetched: time.Now()}
+	c.mu.Unlock()
+	return result
+}
+
+// Helper turns a lookup result into a debug hint for the manifest panel
+func Helper(container string, r *Result) k8s.DebugHelper {
+	image := r.Reference.String()
+
+	switch {
+	case r.Err != nil:
+		return k8s.DebugHelper{
+			Issue:    fmt.Sprintf("Could not query registry for %s", image),
+			Severity: "Info",
+			Suggestions: []string{
+				r.Err.Error(),
+				"Log in locally (docker login) to let k9sight check the registry",
+			},
+		}
+	case r.Exists:
+		return k8s.DebugHelper{
+			Issue:    fmt.Sprintf("Image %s exists in registry (container %s)", image, container),
+			Severity: "Warning",
+			Suggestions: []string{
+				"Digest: " + r.Digest,
+				"The tag is valid; check imagePullSecrets and node access to " + r.Reference.Registry,
+				"Verify the image has a manifest for the node's architecture",
+			},
+		}
+	default:
+		suggestions := []string{"The tag or digest does not exist in " + r.Reference.Registry}
+		if len(r.SimilarTags) > 0 {
+			suggestions = append(suggestions, "Closest tags: "+strings.Join(r.SimilarTags, ", "))
+		} else {
+			suggestions = append(suggestions, "No tags found; check the repository name")
+		}
+		return k8s.DebugHelper{
+			Issue:       fmt.Sprintf("Image %s not found (container %s)", image, container),
+			Severity:    "High",
+			Suggestions: suggestions,
+		}
+	}
+}
+
+// session performs requests for one repository, handling token auth
+type session struct {
+	client *Client
+	ref    Reference
+	creds  *credentials
+	token  string
+}
+
+func (s *session) baseURL() string {
+	scheme := "https"
+	if strings.HasPrefix(s.ref.Registry, "localhost") || strings.HasPrefix(s.ref.Registry, "127.0.0.1") {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s", scheme, s.ref.Registry, s.ref.Repository)
+}
+
+func (s *session) manifest(ctx context.Context, reference string) (bool, string, error) {
+	resp, err := s.do(ctx, http.MethodHead, s.baseURL()+"/manifests/"+reference, manifestAccept)
+	if err != nil {
+		return false, "", err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, resp.Header.Get("Docker-Content-Digest"), nil
+	case http.StatusNotFound:
+		return false, "", nil
+	default:
+		return false, "", fmt.Errorf("registry returned %s", resp.Status)
+	}
+}
+
+func (s *session) tags(ctx context.Context) ([]string, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.baseURL()+"/tags/list?n=1000", "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("listing tags: registry returned %s", resp.Status)
+	}
+
+	var body struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body.Tags, nil
+}
+
+// do sends a request, answering a 401 challenge once with basic or bearer auth
+func (s *session) do(ctx context.Context, method, rawURL, accept string) (*http.Response, error) {
+	send := func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", accept)
+		switch {
+		case s.token != "":
+			req.Header.Set("Authorization", "Bearer "+s.token)
+		case s.creds != nil:
+			req.SetBasicAuth(s.creds.Username, s.creds.Password)
+		}
+		return s.client.http.Do(req)
+	}
+
+	resp, err := send()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || s.token != "" {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	scheme, params := parseChallenge(challenge)
+	if !strings.EqualFold(scheme, "bearer") {
+		return nil, fmt.Errorf("registry requires authentication (%s)", challenge)
+	}
+	if err := s.fetchToken(ctx, params); err != nil {
+		return nil, err
+	}
+	return send()
+}
+
+func (s *session) fetchToken(ctx context.Context, params map[string]string) error {
+	realm := params["realm"]
+	if realm == "" {
+		return fmt.Errorf("registry auth challenge has no realm")
+	}
+
+	query := url.Values{}
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + s.ref.Repository + ":pull"
+	}
+	query.Set("scope", scope)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if s.creds != nil {
+		req.SetBasicAuth(s.creds.Username, s.creds.Password)
+	}
+
+	resp, err := s.client.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry token request returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+	s.token = body.Token
+	if s.token == "" {
+		s.token = body.AccessToken
+	}
+	if s.token == "" {
+		return fmt.Errorf("registry token response has no token")
+	}
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header such as
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(rest, "=")
+		key = strings.TrimSpace(strings.TrimLeft(key, ", "))
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+	return scheme, params
+}
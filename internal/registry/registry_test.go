@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/doganarif/k9sight/internal/k8s"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		image string
+		want  Reference
+	}{
+		{"nginx", Reference{Registry: dockerHubHost, Repository: "library/nginx", Tag: "latest"}},
+		{"nginx:1.25", Reference{Registry: dockerHubHost, Repository: "library/nginx", Tag: "1.25"}},
+		{"bitnami/redis:7.2", Reference{Registry: dockerHubHost, Repository: "bitnami/redis", Tag: "7.2"}},
+		{"docker.io/library/alpine:3", Reference{Registry: dockerHubHost, Repository: "library/alpine", Tag: "3"}},
+		{"ghcr.io/org/app:v1.2.0", Reference{Registry: "ghcr.io", Repository: "org/app", Tag: "v1.2.0"}},
+		{"localhost:5000/app", Reference{Registry: "localhost:5000", Repository: "app", Tag: "latest"}},
+		{"registry.example.com:443/team/app@sha256:abc", Reference{Registry: "registry.example.com:443", Repository: "team/app", Digest: "sha256:abc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := ParseReference(tt.image); got != tt.want {
+				t.Errorf("ParseReference(%q) = %+v, want %+v", tt.image, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferenceString(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"nginx", "nginx:latest"},
+		{"bitnami/redis:7.2", "bitnami/redis:7.2"},
+		{"ghcr.io/org/app@sha256:abc", "ghcr.io/org/app@sha256:abc"},
+	}
+
+	for _, tt := range tests {
+		if got := ParseReference(tt.image).String(); got != tt.want {
+			t.Errorf("ParseReference(%q).String() = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+}
+
+func TestClosestTags(t *testing.T) {
+	tags := []string{"1.2.0", "1.2.1", "1.3.0", "2.0.0", "latest", "1.2.10"}
+
+	got := ClosestTags("1.2.2", tags, 3)
+	want := []string{"1.2.1", "1.2.0", "1.3.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClosestTags() = %v, want %v", got, want)
+	}
+
+	if got := ClosestTags("x", nil, 3); got != nil {
+		t.Errorf("ClosestTags() with no tags = %v, want nil", got)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull"`)
+	if scheme != "Bearer" {
+		t.Errorf("scheme = %q, want Bearer", scheme)
+	}
+	want := map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/nginx:pull",
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %v, want %v", params, want)
+	}
+}
+
+func TestDockerConfigLookup(t *testing.T) {
+	cfg := &dockerConfig{}
+	cfg.Auths = map[string]struct {
+		Auth string `json:"auth"`
+	}{
+		"https://index.docker.io/v1/": {Auth: "dXNlcjpwYXNz"}, // user:pass
+		"ghcr.io":                     {Auth: "Ym90OnRva2Vu"}, // bot:token
+	}
+
+	tests := []struct {
+		host string
+		want *credentials
+	}{
+		{dockerHubHost, &credentials{Username: "user", Password: "pass"}},
+		{"ghcr.io", &credentials{Username: "bot", Password: "token"}},
+		{"quay.io", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := cfg.lookup(tt.host); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lookup(%q) = %+v, want %+v", tt.host, got, tt.want)
+			}
+		})
+	}
+
+	var missing *dockerConfig
+	if got := missing.lookup("ghcr.io"); got != nil {
+		t.Errorf("lookup on nil config = %+v, want nil", got)
+	}
+}
+
+func TestSecretCovers(t *testing.T) {
+	tests := []struct {
+		key, image string
+		want       bool
+	}{
+		{"ghcr.io", "ghcr.io/org/app:1.0", true},
+		{"https://ghcr.io", "ghcr.io/org/app:1.0", true},
+		{"ghcr.io", "quay.io/org/app:1.0", false},
+		{"https://index.docker.io/v1/", "nginx", true},
+		{"docker.io", "bitnami/redis:7.2", true},
+		{"*.example.com", "registry.example.com/app", true},
+		{"*.example.com", "a.registry.example.com/app", false},
+		{"registry.example.com:5000", "registry.example.com:5000/app", true},
+		{"registry.example.com", "registry.example.com:5000/app", false},
+		{"gcr.io/team", "gcr.io/team/app:1", true},
+		{"gcr.io/team", "gcr.io/teamb/app:1", false},
+	}
+	for _, tt := range tests {
+		if got := SecretCovers(tt.key, ParseReference(tt.image)); got != tt.want {
+			t.Errorf("SecretCovers(%q, %q) = %v, want %v", tt.key, tt.image, got, tt.want)
+		}
+	}
+}
+
+func TestPullSecretHelper(t *testing.T) {
+	secrets := []k8s.PullSecret{
+		{Name: "gone", Missing: true},
+		{Name: "hub", Registries: []string{"https://index.docker.io/v1/"}},
+	}
+	h := PullSecretHelper("app", "ghcr.io/org/app:1.0", secrets)
+	if h.Severity != "High" || !strings.HasPrefix(h.Issue, "No pull secret covers ghcr.io") {
+		t.Errorf("uncovered registry: %+v", h)
+	}
+	if got := strings.Join(h.Suggestions, "|"); !strings.Contains(got, "gone does not exist") || !strings.Contains(got, "Secrets cover: https://index.docker.io/v1/") {
+		t.Errorf("suggestions = %q", got)
+	}
+
+	if h := PullSecretHelper("app", "nginx:1.25", secrets); !strings.HasPrefix(h.Issue, "Pull secret hub covers") {
+		t.Errorf("covered registry: %+v", h)
+	}
+	if h := PullSecretHelper("app", "nginx", nil); !strings.HasPrefix(h.Issue, "No imagePullSecrets") {
+		t.Errorf("no secrets: %+v", h)
+	}
+}
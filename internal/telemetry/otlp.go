@@ -0,0 +1,187 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// OTLP/HTTP JSON encoding, see https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpAttr struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttr `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []otlpAttr `json:"attributes,omitempty"`
+	Status            otlpStatus `json:"status"`
+}
+
+type otlpDataPoint struct {
+	Attributes        []otlpAttr `json:"attributes,omitempty"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	TimeUnixNano      string     `json:"timeUnixNano"`
+	AsInt             *string    `json:"asInt,omitempty"`
+	AsDouble          *float64   `json:"asDouble,omitempty"`
+}
+
+type otlpSum struct {
+	AggregationTemporality int             `json:"aggregationTemporality"`
+	IsMonotonic            bool            `json:"isMonotonic"`
+	DataPoints             []otlpDataPoint `json:"dataPoints"`
+}
+
+type otlpMetric struct {
+	Name string  `json:"name"`
+	Unit string  `json:"unit"`
+	Sum  otlpSum `json:"sum"`
+}
+
+const (
+	statusError           = 2
+	temporalityCumulative = 2
+	spanKindInternal      = 1
+	spanKindClient        = 3
+)
+
+func attrs(in []Attr) []otlpAttr {
+	out := make([]otlpAttr, 0, len(in))
+	for _, a := range in {
+		out = append(out, otlpAttr{Key: a.Key, Value: otlpValue{StringValue: a.Value}})
+	}
+	return out
+}
+
+func nanos(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func (e *exporter) resource() otlpResource {
+	return otlpResource{Attributes: attrs([]Attr{
+		{"service.name", e.service},
+		{"service.version", e.version},
+	})}
+}
+
+func (e *exporter) scope() otlpScope {
+	return otlpScope{Name: "github.com/doganarif/k9sight", Version: e.version}
+}
+
+func (e *exporter) tracesPayload(spans []*Span) interface{} {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.traceID[:]),
+			SpanID:            hex.EncodeToString(s.spanID[:]),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: nanos(s.start),
+			EndTimeUnixNano:   nanos(s.end),
+			Attributes:        attrs(s.attrs),
+		}
+		if s.parentID != ([8]byte{}) {
+			span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+		}
+		if s.err != nil {
+			span.Status = otlpStatus{Code: statusError, Message: s.err.Error()}
+		}
+		out = append(out, span)
+	}
+
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": e.resource(),
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": e.scope(),
+				"spans": out,
+			}},
+		}},
+	}
+}
+
+// metricsPayload reports each counter as a cumulative call count and total duration
+func (e *exporter) metricsPayload(counters []counter, now time.Time) interface{} {
+	byName := make(map[string][]otlpMetric)
+	var order []string
+
+	for _, c := range counters {
+		count := strconv.FormatInt(c.count, 10)
+		total := c.totalMs
+		countPoint := otlpDataPoint{Attributes: attrs(c.attrs), StartTimeUnixNano: nanos(e.started), TimeUnixNano: nanos(now), AsInt: &count}
+		durationPoint := otlpDataPoint{Attributes: attrs(c.attrs), StartTimeUnixNano: nanos(e.started), TimeUnixNano: nanos(now), AsDouble: &total}
+
+		if _, ok := byName[c.name]; !ok {
+			order = append(order, c.name)
+			byName[c.name] = []otlpMetric{
+				{Name: c.name + ".count", Unit: "1", Sum: otlpSum{AggregationTemporality: temporalityCumulative, IsMonotonic: true}},
+				{Name: c.name + ".duration", Unit: "ms", Sum: otlpSum{AggregationTemporality: temporalityCumulative, IsMonotonic: true}},
+			}
+		}
+		byName[c.name][0].Sum.DataPoints = append(byName[c.name][0].Sum.DataPoints, countPoint)
+		byName[c.name][1].Sum.DataPoints = append(byName[c.name][1].Sum.DataPoints, durationPoint)
+	}
+
+	var metrics []otlpMetric
+	for _, name := range order {
+		metrics = append(metrics, byName[name]...)
+	}
+
+	return map[string]interface{}{
+		"resourceMetrics": []interface{}{map[string]interface{}{
+			"resource": e.resource(),
+			"scopeMetrics": []interface{}{map[string]interface{}{
+				"scope":   e.scope(),
+				"metrics": metrics,
+			}},
+		}},
+	}
+}
+
+func (e *exporter) post(url string, payload interface{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,273 @@
+// Package telemetry exports OTLP traces and metrics for k9sight's own API
+// calls and refresh cycles. It is disabled unless an OTLP endpoint is set in
+// the standard OTEL_EXPORTER_OTLP_* environment variables.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	flushInterval = 5 * time.Second
+	maxSpans      = 2048
+)
+
+// Attr is a span or metric attribute
+type Attr struct {
+	Key   string
+	Value string
+}
+
+// Span is an in-flight operation; all methods are safe on a nil Span
+type Span struct {
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	name     string
+	kind     int
+	start    time.Time
+	end      time.Time
+	attrs    []Attr
+	err      error
+
+	// metric, when set, records the span's count and duration under that name
+	metric      string
+	metricAttrs []Attr
+}
+
+type spanKey struct{}
+
+type counter struct {
+	name    string
+	attrs   []Attr
+	count   int64
+	totalMs float64
+}
+
+type exporter struct {
+	tracesURL  string
+	metricsURL string
+	headers    map[string]string
+	service    string
+	version    string
+	client     *http.Client
+	started    time.Time
+
+	mu       sync.Mutex
+	spans    []*Span
+	counters map[string]*counter
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// global is the running exporter, or nil when telemetry is disabled. Spans
+// end on any goroutine, so it is swapped atomically.
+var global atomic.Pointer[exporter]
+
+// Init enables telemetry when OTEL_EXPORTER_OTLP_ENDPOINT (or the signal
+// specific _TRACES_/_METRICS_ variants) is set. It is a no-op otherwise.
+func Init(version string) {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return
+	}
+
+	base := strings.TrimRight(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"), "/")
+	tracesURL := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	metricsURL := os.Getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT")
+	if tracesURL == "" && base != "" {
+		tracesURL = base + "/v1/traces"
+	}
+	if metricsURL == "" && base != "" {
+		metricsURL = base + "/v1/metrics"
+	}
+	if tracesURL == "" && metricsURL == "" {
+		return
+	}
+
+	service := os.Getenv("OTEL_SERVICE_NAME")
+	if service == "" {
+		service = "k9sight"
+	}
+
+	e := &exporter{
+		tracesURL:  tracesURL,
+		metricsURL: metricsURL,
+		headers:    parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")),
+		service:    service,
+		version:    version,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		started:    time.Now(),
+		counters:   make(map[string]*counter),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	global.Store(e)
+	go e.loop()
+}
+
+// Enabled reports whether telemetry is being exported
+func Enabled() bool {
+	return global.Load() != nil
+}
+
+// Shutdown flushes pending telemetry and stops the export loop
+func Shutdown() {
+	e := global.Swap(nil)
+	if e == nil {
+		return
+	}
+	close(e.stop)
+	<-e.done
+}
+
+// StartSpan starts an internal span as a child of any span in ctx
+func StartSpan(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return startSpan(ctx, name, spanKindInternal, attrs)
+}
+
+// StartRefresh starts a span for a refresh cycle and records it in the
+// k9sight.refresh metrics when it ends
+func StartRefresh(ctx context.Context, name string) (context.Context, *Span) {
+	ctx, span := startSpan(ctx, "refresh "+name, spanKindInternal, nil)
+	if span != nil {
+		span.metric = "k9sight.refresh"
+		span.metricAttrs = []Attr{{"refresh", name}}
+	}
+	return ctx, span
+}
+
+func startSpan(ctx context.Context, name string, kind int, attrs []Attr) (context.Context, *Span) {
+	if global.Load() == nil {
+		return ctx, nil
+	}
+
+	span := &Span{name: name, kind: kind, start: time.Now(), attrs: attrs}
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok && parent != nil {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else {
+		rand.Read(span.traceID[:])
+	}
+	rand.Read(span.spanID[:])
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SetAttr adds an attribute to the span
+func (s *Span) SetAttr(key, value string) {
+	if s == nil {
+		return
+	}
+	s.attrs = append(s.attrs, Attr{key, value})
+}
+
+// SetError marks the span as failed
+func (s *Span) SetError(err error) {
+	if s == nil {
+		return
+	}
+	s.err = err
+}
+
+// End finishes the span and queues it for export
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	e := global.Load()
+	if e == nil {
+		return
+	}
+	s.end = time.Now()
+	e.record(s)
+}
+
+func (e *exporter) record(s *Span) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.spans) < maxSpans {
+		e.spans = append(e.spans, s)
+	}
+
+	if s.metric == "" {
+		return
+	}
+	key := counterKey(s.metric, s.metricAttrs)
+	c, ok := e.counters[key]
+	if !ok {
+		c = &counter{name: s.metric, attrs: s.metricAttrs}
+		e.counters[key] = c
+	}
+	c.count++
+	c.totalMs += float64(s.end.Sub(s.start)) / float64(time.Millisecond)
+}
+
+func (e *exporter) loop() {
+	defer close(e.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.flush()
+		case <-e.stop:
+			e.flush()
+			return
+		}
+	}
+}
+
+func (e *exporter) flush() {
+	e.mu.Lock()
+	spans := e.spans
+	e.spans = nil
+	counters := make([]counter, 0, len(e.counters))
+	for _, c := range e.counters {
+		counters = append(counters, *c)
+	}
+	e.mu.Unlock()
+
+	// Export failures are dropped; there is nowhere to report them inside the TUI
+	if e.tracesURL != "" && len(spans) > 0 {
+		e.post(e.tracesURL, e.tracesPayload(spans))
+	}
+	if e.metricsURL != "" && len(counters) > 0 {
+		e.post(e.metricsURL, e.metricsPayload(counters, time.Now()))
+	}
+}
+
+func counterKey(name string, attrs []Attr) string {
+	sorted := append([]Attr(nil), attrs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, a := range sorted {
+		b.WriteString("|" + a.Key + "=" + a.Value)
+	}
+	return b.String()
+}
+
+// parseHeaders parses OTEL_EXPORTER_OTLP_HEADERS, e.g. "api-key=secret,x-team=infra"
+func parseHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			continue
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return headers
+}
@@ -0,0 +1,93 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestAPIResource(t *testing.T) {
+	tests := []struct {
+		path          string
+		wantResource  string
+		wantNamespace string
+	}{
+		{"/api/v1/namespaces", "namespaces", ""},
+		{"/api/v1/namespaces/prod", "namespaces", ""},
+		{"/api/v1/namespaces/prod/pods", "pods", "prod"},
+		{"/api/v1/namespaces/prod/pods/web-1", "pods", "prod"},
+		{"/api/v1/namespaces/prod/pods/web-1/log", "pods/log", "prod"},
+		{"/apis/apps/v1/namespaces/prod/deployments/api/scale", "deployments/scale", "prod"},
+		{"/api/v1/nodes/node-1", "nodes", ""},
+		{"/apis/metrics.k8s.io/v1beta1/namespaces/prod/pods/web-1", "pods", "prod"},
+		{"/api", "/api", ""},
+		{"/version", "/version", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resource, namespace := apiResource(tt.path)
+			if resource != tt.wantResource || namespace != tt.wantNamespace {
+				t.Errorf("apiResource(%q) = (%q, %q), want (%q, %q)",
+					tt.path, resource, namespace, tt.wantResource, tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func TestAPIVerb(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{http.MethodGet, "/api/v1/namespaces/prod/pods", "list"},
+		{http.MethodGet, "/api/v1/namespaces/prod/pods?watch=true", "watch"},
+		{http.MethodGet, "/api/v1/namespaces/prod/pods/web-1", "get"},
+		{http.MethodGet, "/api/v1/namespaces/prod/pods/web-1/log", "get"},
+		{http.MethodDelete, "/api/v1/namespaces/prod/pods/web-1", "delete"},
+		{http.MethodPatch, "/apis/apps/v1/namespaces/prod/deployments/api", "patch"},
+		{http.MethodPut, "/apis/apps/v1/namespaces/prod/deployments/api/scale", "update"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			u, _ := url.Parse(tt.url)
+			req := &http.Request{Method: tt.method, URL: u}
+			if got := apiVerb(req); got != tt.want {
+				t.Errorf("apiVerb() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	got := parseHeaders("api-key=secret, x-team = infra,broken,=novalue")
+	want := map[string]string{"api-key": "secret", "x-team": "infra"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseHeaders() = %v, want %v", got, want)
+	}
+}
+
+func TestCounterKeyIgnoresAttrOrder(t *testing.T) {
+	a := counterKey("k9sight.api", []Attr{{"k8s.verb", "list"}, {"k8s.resource", "pods"}})
+	b := counterKey("k9sight.api", []Attr{{"k8s.resource", "pods"}, {"k8s.verb", "list"}})
+	if a != b {
+		t.Errorf("counterKey() differs by attribute order: %q vs %q", a, b)
+	}
+}
+
+func TestDisabledSpansAreNoops(t *testing.T) {
+	ctx, span := StartSpan(context.Background(), "noop")
+	if span != nil {
+		t.Fatalf("StartSpan() returned a span while disabled")
+	}
+	span.SetAttr("k", "v")
+	span.SetError(nil)
+	span.End()
+	if ctx == nil {
+		t.Errorf("StartSpan() returned a nil context")
+	}
+}
@@ -0,0 +1,114 @@
+package telemetry
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type transport struct {
+	next http.RoundTripper
+}
+
+// WrapTransport traces every Kubernetes API request made through rt and
+// records it in the k9sight.api metrics. It returns rt unchanged when
+// telemetry is disabled, so it can be passed to rest.Config.Wrap directly.
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	if global.Load() == nil {
+		return rt
+	}
+	return &transport{next: rt}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource, namespace := apiResource(req.URL.Path)
+	verb := apiVerb(req)
+
+	_, span := startSpan(req.Context(), fmt.Sprintf("%s %s", verb, resource), spanKindClient, []Attr{
+		{"http.request.method", req.Method},
+		{"url.path", req.URL.Path},
+		{"server.address", req.URL.Host},
+		{"k8s.resource", resource},
+		{"k8s.verb", verb},
+	})
+	if namespace != "" {
+		span.SetAttr("k8s.namespace.name", namespace)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	code := "error"
+	if err != nil {
+		span.SetError(err)
+	} else {
+		code = strconv.Itoa(resp.StatusCode)
+		span.SetAttr("http.response.status_code", code)
+		if resp.StatusCode >= 400 {
+			span.SetError(fmt.Errorf("%s", resp.Status))
+		}
+	}
+
+	if span != nil {
+		span.metric = "k9sight.api"
+		span.metricAttrs = []Attr{{"k8s.verb", verb}, {"k8s.resource", resource}, {"http.response.status_code", code}}
+	}
+	span.End()
+
+	return resp, err
+}
+
+// apiResource extracts the resource (with subresource) and namespace from an
+// API path such as /api/v1/namespaces/prod/pods/web-1/log
+func apiResource(path string) (resource, namespace string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		parts = parts[3:]
+	default:
+		return path, ""
+	}
+
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		namespace = parts[1]
+		parts = parts[2:]
+	}
+
+	switch len(parts) {
+	case 0:
+		return "discovery", namespace
+	case 1, 2:
+		return parts[0], namespace
+	default:
+		return parts[0] + "/" + parts[2], namespace
+	}
+}
+
+// apiVerb maps an HTTP request to the Kubernetes verb (list vs get, watch)
+func apiVerb(req *http.Request) string {
+	switch req.Method {
+	case http.MethodGet:
+		if req.URL.Query().Get("watch") == "true" {
+			return "watch"
+		}
+		resource, _ := apiResource(req.URL.Path)
+		parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+		// Collection paths end in the resource name itself
+		if len(parts) > 0 && parts[len(parts)-1] == resource {
+			return "list"
+		}
+		return "get"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		return "delete"
+	}
+	return strings.ToLower(req.Method)
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultPattern matches the usual trace id spellings (trace_id, traceId,
+// trace-id, "traceID": "...") followed by a 16 or 32 hex digit id.
+const DefaultPattern = `(?i)trace[_-]?id["']?\s*[:=]\s*["']?([0-9a-f]{32}|[0-9a-f]{16})\b`
+
+// Link is a named URL template for a tracing backend. The template may use
+// {trace_id} as a placeholder, e.g. http://jaeger:16686/trace/{trace_id}.
+type Link struct {
+	Name string
+	URL  string
+}
+
+// Extractor finds trace ids in log lines.
+type Extractor struct {
+	re *regexp.Regexp
+}
+
+// NewExtractor compiles pattern, falling back to DefaultPattern when empty.
+// If the pattern has a capture group, the first group is the trace id,
+// otherwise the whole match is used.
+func NewExtractor(pattern string) (*Extractor, error) {
+	if pattern == "" {
+		pattern = DefaultPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Extractor{re: re}, nil
+}
+
+// Find returns the trace id in line, or "" if there is none.
+func (e *Extractor) Find(line string) string {
+	if e == nil {
+		return ""
+	}
+	m := e.re.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	if len(m) > 1 && m[1] != "" {
+		return m[1]
+	}
+	return m[0]
+}
+
+// RecentIDs returns up to limit distinct trace ids, newest line first.
+func (e *Extractor) RecentIDs(lines []string, limit int) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for i := len(lines) - 1; i >= 0; i-- {
+		id := e.Find(lines[i])
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+		if limit > 0 && len(ids) >= limit {
+			break
+		}
+	}
+	return ids
+}
+
+// BuildURL substitutes traceID into a link template.
+func BuildURL(template, traceID string) string {
+	return strings.ReplaceAll(template, "{trace_id}", url.PathEscape(traceID))
+}
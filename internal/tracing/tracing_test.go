@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"testing"
+)
+
+func TestExtractorFind(t *testing.T) {
+	e, err := NewExtractor("")
+	if err != nil {
+		t.Fatalf("NewExtractor with default pattern failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{
+			name:     "logfmt",
+			line:     "level=info msg=done trace_id=4bf92f3577b34da6a3ce929d0e0e4736",
+			expected: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:     "json camel case",
+			line:     `{"level":"error","traceId":"00f067aa0ba902b7","msg":"boom"}`,
+			expected: "00f067aa0ba902b7",
+		},
+		{
+			name:     "no trace id",
+			line:     "GET /healthz 200",
+			expected: "",
+		},
+		{
+			name:     "too short to be an id",
+			line:     "trace_id=abc123",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := e.Find(tt.line); result != tt.expected {
+				t.Errorf("Find(%q) = %q, want %q", tt.line, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCustomPatternWithoutGroup(t *testing.T) {
+	e, err := NewExtractor(`req-[0-9]+`)
+	if err != nil {
+		t.Fatalf("NewExtractor failed: %v", err)
+	}
+	if result := e.Find("handling req-42 now"); result != "req-42" {
+		t.Errorf("Find = %q, want %q", result, "req-42")
+	}
+}
+
+func TestRecentIDs(t *testing.T) {
+	e, _ := NewExtractor(`id=(\w+)`)
+	lines := []string{"id=a", "id=b", "nothing", "id=a", "id=c"}
+
+	ids := e.RecentIDs(lines, 2)
+	if len(ids) != 2 || ids[0] != "c" || ids[1] != "a" {
+		t.Errorf("RecentIDs = %v, want [c a]", ids)
+	}
+}
+
+func TestBuildURL(t *testing.T) {
+	result := BuildURL("http://jaeger:16686/trace/{trace_id}", "abc")
+	if result != "http://jaeger:16686/trace/abc" {
+		t.Errorf("BuildURL = %q", result)
+	}
+}
@@ -3,9 +3,12 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/tracing"
 	"github.com/doganarif/k9sight/internal/ui/styles"
 )
 
@@ -229,8 +232,15 @@ func KubectlCommands(namespace, podName, containerName string, containers []stri
 type PodActionItem struct {
 	Label       string
 	Description string
-	Action      string // "delete", "exec", "port-forward", "copy"
-	Command     string // kubectl command if applicable
+	Action      string // "delete", "exec", "debug", "port-forward", "probe-check", "copy", "open-url"
+	Command     string // kubectl command or URL if applicable
+	Container   string   // exec: the container to run Exec in
+	Exec        []string // exec: the command, run in-process without kubectl
+	Drift       int      // config-diff: index of the drifted object
+	AllLogs     bool     // logs-save: every loaded line, not just the shown ones
+	LocalPort   int      // port-forward-again: the ports to forward
+	RemotePort  int
+	Objects     []k8s.ObjectRef // yaml-save: the objects to export
 }
 
 // PodActionMenuResult is returned when a pod action is selected
@@ -391,8 +401,9 @@ func (m PodActionMenu) IsVisible() bool {
 type WorkloadActionItem struct {
 	Label       string
 	Description string
-	Action      string // "scale", "restart", "copy"
+	Action      string // "scale", "scale-custom", "restart", "undo", "trigger", "suspend", "resume", "runs", "ns-warnings", "ns-quotas", "ns-delete-failed", "copy"
 	Replicas    int32  // For scale actions
+	Revision    int64  // For undo actions
 	Command     string // kubectl command
 }
 
@@ -517,46 +528,191 @@ func (m *WorkloadActionMenu) Show(title string, items []WorkloadActionItem) {
 func (m *WorkloadActionMenu) Hide() { m.visible = false }
 func (m WorkloadActionMenu) IsVisible() bool { return m.visible }
 
-// ScaleActions returns scale options for a workload
-func ScaleActions(namespace, name, resourceType string, currentReplicas int32) []WorkloadActionItem {
+// ScaleActions returns scale options for a workload. With an HPA, presets
+// outside its bounds are left out.
+func ScaleActions(kubectl, namespace, name, resourceType string, currentReplicas int32, hpa *k8s.HPAInfo) []WorkloadActionItem {
+	scaleTo := func(label string, replicas int32) WorkloadActionItem {
+		return WorkloadActionItem{
+			Label:    label,
+			Action:   "scale",
+			Replicas: replicas,
+			Command:  fmt.Sprintf("%s scale %s/%s -n %s --replicas=%d", kubectl, resourceType, name, namespace, replicas),
+		}
+	}
 	items := []WorkloadActionItem{
-		{Label: "Scale to 0", Action: "scale", Replicas: 0},
-		{Label: "Scale to 1", Action: "scale", Replicas: 1},
-		{Label: "Scale to 2", Action: "scale", Replicas: 2},
-		{Label: "Scale to 3", Action: "scale", Replicas: 3},
-		{Label: "Scale to 5", Action: "scale", Replicas: 5},
+		scaleTo("Scale to 0", 0),
+		scaleTo("Scale to 1", 1),
+		scaleTo("Scale to 2", 2),
+		scaleTo("Scale to 3", 3),
+		scaleTo("Scale to 5", 5),
 	}
 
 	// Add current+1 and current-1 if not in list
 	if currentReplicas > 0 {
 		items = append([]WorkloadActionItem{
-			{Label: fmt.Sprintf("Scale to %d (current-1)", currentReplicas-1), Action: "scale", Replicas: currentReplicas - 1},
+			scaleTo(fmt.Sprintf("Scale to %d (current-1)", currentReplicas-1), currentReplicas-1),
 		}, items...)
 	}
 	if currentReplicas < 10 {
-		items = append(items, WorkloadActionItem{
-			Label: fmt.Sprintf("Scale to %d (current+1)", currentReplicas+1), Action: "scale", Replicas: currentReplicas + 1,
-		})
+		items = append(items, scaleTo(fmt.Sprintf("Scale to %d (current+1)", currentReplicas+1), currentReplicas+1))
+	}
+
+	if hpa != nil {
+		inRange := items[:0]
+		for _, item := range items {
+			if item.Replicas >= hpa.MinReplicas && item.Replicas <= hpa.MaxReplicas {
+				inRange = append(inRange, item)
+			}
+		}
+		items = inRange
 	}
+	items = append([]WorkloadActionItem{{
+		Label:       "Scale to...",
+		Description: "type a replica count",
+		Action:      "scale-custom",
+	}}, items...)
 
 	// Add copy command option
 	items = append(items, WorkloadActionItem{
 		Label:   "Copy scale command",
 		Action:  "copy",
-		Command: fmt.Sprintf("kubectl scale %s/%s -n %s --replicas=", resourceType, name, namespace),
+		Command: fmt.Sprintf("%s scale %s/%s -n %s --replicas=", kubectl, resourceType, name, namespace),
 	})
 
 	return items
 }
 
-// PodActions returns the available actions for a pod
-func PodActions(namespace, podName string, containers []string) []PodActionItem {
+// UndoActions offers to roll a Deployment back to each earlier revision,
+// newest first, plus copying the history command
+func UndoActions(kubectl, namespace, name string, revisions []k8s.RolloutRevision) []WorkloadActionItem {
+	var items []WorkloadActionItem
+	for _, r := range revisions {
+		if r.Current {
+			continue
+		}
+		desc := make([]string, 0, len(r.Images)+2)
+		for _, image := range r.Images {
+			desc = append(desc, k8s.ShortImage(image))
+		}
+		if r.ChangeCause != "" {
+			desc = append(desc, r.ChangeCause)
+		}
+		desc = append(desc, k8s.FormatDuration(time.Since(r.Created))+" ago")
+		items = append(items, WorkloadActionItem{
+			Label:       fmt.Sprintf("Undo to revision %d", r.Revision),
+			Description: strings.Join(desc, " • "),
+			Action:      "undo",
+			Revision:    r.Revision,
+			Command:     fmt.Sprintf("%s rollout undo deployment/%s -n %s --to-revision=%d", kubectl, name, namespace, r.Revision),
+		})
+	}
+	return append(items, WorkloadActionItem{
+		Label:   "Copy rollout history command",
+		Action:  "copy",
+		Command: fmt.Sprintf("%s rollout history deployment/%s -n %s", kubectl, name, namespace),
+	})
+}
+
+// CronJobActions offers to run a CronJob now, to suspend or resume its
+// schedule and to review its recent runs, plus copying the kubectl command
+// for a manual run
+func CronJobActions(kubectl string, w *k8s.WorkloadInfo) []WorkloadActionItem {
+	patch := func(suspend bool) string {
+		return fmt.Sprintf(`%s patch cronjob/%s -n %s -p '{"spec":{"suspend":%t}}'`, kubectl, w.Name, w.Namespace, suspend)
+	}
+	run := fmt.Sprintf("%s create job --from=cronjob/%s %s-manual -n %s", kubectl, w.Name, w.Name, w.Namespace)
+
+	items := []WorkloadActionItem{{
+		Label:       "Run now",
+		Description: "create a Job from the template",
+		Action:      "trigger",
+		Command:     run,
+	}}
+	if w.Status == "Suspended" {
+		items = append(items, WorkloadActionItem{Label: "Resume", Description: "start scheduling again", Action: "resume", Command: patch(false)})
+	} else {
+		items = append(items, WorkloadActionItem{Label: "Suspend", Description: "stop scheduling new runs", Action: "suspend", Command: patch(true)})
+	}
+	items = append(items, WorkloadActionItem{
+		Label:       "Run history",
+		Description: "durations, failure rate, slow runs",
+		Action:      "runs",
+	})
+	return append(items, WorkloadActionItem{
+		Label:   "Copy run command",
+		Action:  "copy",
+		Command: run,
+	})
+}
+
+// WorkloadActions offers the workload list's actions for the selected
+// workload: a CronJob's own, then deleting the finished pods of any workload
+func WorkloadActions(kubectl string, w *k8s.WorkloadInfo) []WorkloadActionItem {
+	var items []WorkloadActionItem
+	if w.Type == k8s.ResourceCronJobs {
+		items = CronJobActions(kubectl, w)
+	}
+	return append(items, FinishedPodsAction())
+}
+
+// FinishedPodsAction deletes the Failed and Succeeded pods in scope, after
+// previewing them; the kubectl command depends on which pods are found
+func FinishedPodsAction() WorkloadActionItem {
+	return WorkloadActionItem{
+		Label:       "Delete finished pods",
+		Description: "Evicted, Completed... (preview first)",
+		Action:      "delete-finished",
+	}
+}
+
+// NamespaceActions offers the namespace list's quick actions: its recent
+// warnings, its quotas, deleting its finished pods and copying a kubectl
+// prefix for it
+func NamespaceActions(namespace string) []WorkloadActionItem {
+	return []WorkloadActionItem{
+		{
+			Label:       "Recent warnings",
+			Description: "warning events of the last hour",
+			Action:      "ns-warnings",
+			Command:     fmt.Sprintf("kubectl get events -n %s --field-selector type=Warning", namespace),
+		},
+		{
+			Label:       "Quotas",
+			Description: "ResourceQuota usage",
+			Action:      "ns-quotas",
+			Command:     fmt.Sprintf("kubectl describe resourcequota -n %s", namespace),
+		},
+		FinishedPodsAction(),
+		{
+			Label:   "Copy kubectl -n " + namespace,
+			Action:  "copy",
+			Command: fmt.Sprintf("kubectl -n %s ", namespace),
+		},
+	}
+}
+
+// RestartCommand is the kubectl equivalent of restarting a workload
+func RestartCommand(kubectl, namespace, name, resourceType string) string {
+	return fmt.Sprintf("%s rollout restart %s/%s -n %s", kubectl, resourceType, name, namespace)
+}
+
+// PodActions returns the available actions for a pod. kubectl is the
+// invocation that addresses the pod's cluster, see k8s.KubectlFor.
+func PodActions(kubectl, namespace, podName string, containers []string) []PodActionItem {
 	items := []PodActionItem{
 		{
 			Label:       "Delete Pod",
 			Description: "(requires confirmation)",
 			Action:      "delete",
-			Command:     fmt.Sprintf("kubectl delete pod -n %s %s", namespace, podName),
+			Command:     fmt.Sprintf("%s delete pod -n %s %s", kubectl, namespace, podName),
+		},
+		{
+			Label:       "Evict Pod",
+			Description: "(respects PodDisruptionBudgets)",
+			Action:      "evict",
+			// kubectl has no evict command; post the Eviction the way drain does
+			Command: fmt.Sprintf(`echo '{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"%s","namespace":"%s"}}' | %s create --raw /api/v1/namespaces/%s/pods/%s/eviction -f -`,
+				podName, namespace, kubectl, namespace, podName),
 		},
 	}
 
@@ -566,13 +722,17 @@ func PodActions(namespace, podName string, containers []string) []PodActionItem
 			Label:       "Exec (sh)",
 			Description: "opens shell in terminal",
 			Action:      "exec",
-			Command:     fmt.Sprintf("kubectl exec -it -n %s %s -- sh", namespace, podName),
+			Command:     fmt.Sprintf("%s exec -it -n %s %s -- sh", kubectl, namespace, podName),
+			Container:   containers[0],
+			Exec:        []string{"sh"},
 		})
 		items = append(items, PodActionItem{
 			Label:       "Exec (bash)",
 			Description: "opens shell in terminal",
 			Action:      "exec",
-			Command:     fmt.Sprintf("kubectl exec -it -n %s %s -- bash", namespace, podName),
+			Command:     fmt.Sprintf("%s exec -it -n %s %s -- bash", kubectl, namespace, podName),
+			Container:   containers[0],
+			Exec:        []string{"bash"},
 		})
 	} else if len(containers) > 1 {
 		// Multi-container pod - exec into first container by default
@@ -581,23 +741,24 @@ func PodActions(namespace, podName string, containers []string) []PodActionItem
 				Label:       fmt.Sprintf("Exec into '%s' (sh)", container),
 				Description: "opens shell in terminal",
 				Action:      "exec",
-				Command:     fmt.Sprintf("kubectl exec -it -n %s %s -c %s -- sh", namespace, podName, container),
+				Command:     fmt.Sprintf("%s exec -it -n %s %s -c %s -- sh", kubectl, namespace, podName, container),
+				Container:   container,
+				Exec:        []string{"sh"},
 			})
 		}
 	}
 
-	// Add port-forward option - runs in foreground (Ctrl+C to return)
+	// Port-forwards run in the background; F lists and stops them
 	items = append(items, PodActionItem{
-		Label:       "Port Forward :8080",
-		Description: "runs in terminal, Ctrl+C to stop",
+		Label:       "Port Forward...",
+		Description: "choose ports, runs in background (F to manage)",
 		Action:      "port-forward",
-		Command:     fmt.Sprintf("kubectl port-forward -n %s %s 8080:8080", namespace, podName),
 	})
 
 	// Add describe - runs and shows output
 	items = append(items, PodActionItem{
 		Label:       "Describe Pod",
-		Description: "shows pod details",
+		Description: "kubectl describe layout, built in",
 		Action:      "describe",
 		Command:     fmt.Sprintf("kubectl describe pod -n %s %s", namespace, podName),
 	})
@@ -610,5 +771,229 @@ func PodActions(namespace, podName string, containers []string) []PodActionItem
 		Command:     fmt.Sprintf("kubectl logs -n %s %s -f", namespace, podName),
 	})
 
+	// Issue report - Markdown summary of the current debug state
+	items = append(items, PodActionItem{
+		Label:       "Copy issue report",
+		Description: "markdown to clipboard",
+		Action:      "issue-copy",
+	})
+	items = append(items, PodActionItem{
+		Label:       "Save issue report",
+		Description: podName + "-issue.md",
+		Action:      "issue-save",
+		Command:     podName + "-issue.md",
+	})
+
+	return items
+}
+
+// DebugActions offers to inject an ephemeral container running image into
+// the pod, targeting its first container, for images without a shell
+func DebugActions(kubectl string, pod *k8s.PodInfo, image string) []PodActionItem {
+	var target, targetFlag string
+	if len(pod.Containers) > 0 {
+		target = pod.Containers[0].Name
+		targetFlag = " --target=" + target
+	}
+	return []PodActionItem{{
+		Label:       "Debug container",
+		Description: "ephemeral " + image + " shell, for distroless images",
+		Action:      "debug",
+		Command:     fmt.Sprintf("%s debug -it -n %s %s --image=%s%s -- sh", kubectl, pod.Namespace, pod.Name, image, targetFlag),
+		Container:   target,
+	}}
+}
+
+// RecentExecAction re-runs an exec from the recent commands submenu
+func RecentExecAction(kubectl, namespace, podName, container string, command []string) PodActionItem {
+	return PodActionItem{
+		Label:       fmt.Sprintf("Exec %s", strings.Join(command, " ")),
+		Description: "in " + container,
+		Action:      "exec",
+		Command:     fmt.Sprintf("%s exec -it -n %s %s -c %s -- %s", kubectl, namespace, podName, container, strings.Join(command, " ")),
+		Container:   container,
+		Exec:        command,
+	}
+}
+
+// RecentPortForwardAction restarts a port-forward from the recent commands
+// submenu
+func RecentPortForwardAction(namespace, podName string, localPort, remotePort int) PodActionItem {
+	return PodActionItem{
+		Label:       fmt.Sprintf("Port forward %d:%d", localPort, remotePort),
+		Description: "runs in background (F to manage)",
+		Action:      "port-forward-again",
+		Command:     fmt.Sprintf("kubectl port-forward -n %s pod/%s %d:%d", namespace, podName, localPort, remotePort),
+		LocalPort:   localPort,
+		RemotePort:  remotePort,
+	}
+}
+
+// LogExportActions offers to save the shown log lines, or every loaded line
+// of every container, formatted or raw, to the files at shownPath and allPath.
+// An empty allPath leaves out the latter, for when only one container's logs
+// are loaded.
+func LogExportActions(shownPath, allPath string) []PodActionItem {
+	items := []PodActionItem{
+		{Label: "Save shown logs", Description: "with timestamps, as filtered", Action: "logs-save", Command: shownPath},
+		{Label: "Save shown logs, raw", Description: "as the container wrote them", Action: "logs-save-raw", Command: shownPath},
+	}
+	if allPath == "" {
+		return items
+	}
+	return append(items,
+		PodActionItem{Label: "Save all containers", Description: "every loaded line, with timestamps", Action: "logs-save", Command: allPath, AllLogs: true},
+		PodActionItem{Label: "Save all containers, raw", Description: "every loaded line", Action: "logs-save-raw", Command: allPath, AllLogs: true},
+	)
+}
+
+// ManifestExportActions offers to save the pod, its owner and the owner with
+// the Services, Ingresses and ConfigMaps related to the pod as YAML. Secrets
+// are left out so credentials do not end up in files. The owner is the
+// workload related resolved it to, e.g. the Deployment rather than its
+// ReplicaSet, and a static pod's Node is no workload to save.
+func ManifestExportActions(pod *k8s.PodInfo, related *k8s.RelatedResources) []PodActionItem {
+	podRef := k8s.ObjectRef{Kind: "Pod", Name: pod.Name}
+	items := []PodActionItem{{
+		Label:       "Save pod YAML",
+		Description: "clean, to re-apply elsewhere",
+		Action:      "yaml-save",
+		Command:     fmt.Sprintf("kubectl get pod %s -n %s -o yaml", pod.Name, pod.Namespace),
+		Objects:     []k8s.ObjectRef{podRef},
+	}}
+	main := podRef
+	owner := k8s.ObjectRef{Kind: pod.OwnerKind, Name: pod.OwnerRef}
+	if related != nil && related.Owner != nil {
+		owner = k8s.ObjectRef{Kind: related.Owner.Kind, Name: related.Owner.Name}
+	}
+	if owner.Kind != "" && owner.Kind != "Node" {
+		main = owner
+		items = append(items, PodActionItem{
+			Label:       "Save owner YAML",
+			Description: "the workload that manages the pod",
+			Action:      "yaml-save",
+			Command:     fmt.Sprintf("kubectl get %s %s -n %s -o yaml", strings.ToLower(owner.Kind), owner.Name, pod.Namespace),
+			Objects:     []k8s.ObjectRef{main},
+		})
+	}
+	if related == nil {
+		return items
+	}
+
+	objects := []k8s.ObjectRef{main}
+	for _, svc := range related.Services {
+		objects = append(objects, k8s.ObjectRef{Kind: "Service", Name: svc.Name})
+	}
+	for _, ing := range related.Ingresses {
+		objects = append(objects, k8s.ObjectRef{Kind: "Ingress", Name: ing.Name})
+	}
+	for _, cm := range related.ConfigMaps {
+		objects = append(objects, k8s.ObjectRef{Kind: "ConfigMap", Name: cm})
+	}
+	if len(objects) > 1 {
+		items = append(items, PodActionItem{
+			Label:       "Save YAML with related objects",
+			Description: fmt.Sprintf("%d objects, Secrets left out", len(objects)),
+			Action:      "yaml-save",
+			Objects:     objects,
+		})
+	}
+	return items
+}
+
+// ConfigDriftActions offers a diff of each drifted ConfigMap or Secret
+// between what the container runs with and the object now
+func ConfigDriftActions(namespace string, drift []k8s.ConfigDrift) []PodActionItem {
+	items := make([]PodActionItem, 0, len(drift))
+	for i, d := range drift {
+		items = append(items, PodActionItem{
+			Label:       fmt.Sprintf("Diff %s %s", d.Kind, d.Name),
+			Description: fmt.Sprintf("%s via %s, running vs current", d.Container, d.Via),
+			Action:      "config-diff",
+			Command:     fmt.Sprintf("kubectl get %s %s -n %s -o yaml", strings.ToLower(d.Kind), d.Name, namespace),
+			Drift:       i,
+		})
+	}
+	return items
+}
+
+// ProbeActions returns actions that replay each HTTP and TCP probe from a
+// debug container in the pod
+func ProbeActions(kubectl string, pod *k8s.PodInfo) []PodActionItem {
+	var items []PodActionItem
+	for _, p := range k8s.DescribeProbes(pod.Object) {
+		cmd, ok := k8s.ProbeCheckCommand(kubectl, pod.Namespace, pod.Name, pod.IP, p)
+		if !ok {
+			continue
+		}
+		items = append(items, PodActionItem{
+			Label:       fmt.Sprintf("Check %s probe (%s)", p.Kind, p.Container),
+			Description: p.Target(),
+			Action:      "probe-check",
+			Command:     cmd,
+		})
+	}
+	return items
+}
+
+// SchedulingActions offers a pending, unscheduled pod a check of which
+// nodes could take it
+func SchedulingActions(pod *k8s.PodInfo) []PodActionItem {
+	if pod.Phase != "Pending" || pod.Node != "" {
+		return nil
+	}
+	return []PodActionItem{{
+		Label:       "Simulate scheduling",
+		Description: "which nodes fit and why not",
+		Action:      "schedule-check",
+	}}
+}
+
+// ConsoleActions returns actions to open the pod's node in its cloud console
+func ConsoleActions(providerID string) []PodActionItem {
+	link, ok := k8s.CloudConsoleLink(providerID)
+	if !ok {
+		return nil
+	}
+	return []PodActionItem{
+		{
+			Label:       "Open node in " + link.Provider + " console",
+			Description: "opens browser",
+			Action:      "open-url",
+			Command:     link.URL,
+		},
+		{
+			Label:       "Copy node console link",
+			Description: "to clipboard",
+			Action:      "copy",
+			Command:     link.URL,
+		},
+	}
+}
+
+// TraceActions returns "open trace" actions for trace ids found in the logs.
+// Without configured links the ids can only be copied.
+func TraceActions(traceIDs []string, links []tracing.Link) []PodActionItem {
+	var items []PodActionItem
+	for _, id := range traceIDs {
+		short := styles.Truncate(id, 12)
+		if len(links) == 0 {
+			items = append(items, PodActionItem{
+				Label:       "Copy trace " + short,
+				Description: "to clipboard",
+				Action:      "copy",
+				Command:     id,
+			})
+			continue
+		}
+		for _, link := range links {
+			items = append(items, PodActionItem{
+				Label:       fmt.Sprintf("Open trace %s in %s", short, link.Name),
+				Description: "opens browser",
+				Action:      "open-url",
+				Command:     tracing.BuildURL(link.URL, id),
+			})
+		}
+	}
 	return items
 }
@@ -16,6 +16,7 @@ type ConfirmDialog struct {
 	selected bool // true = confirm (yes), false = cancel (no)
 	action   string
 	data     interface{}
+	command  string // equivalent kubectl command, offered to copy instead
 }
 
 // ConfirmResult is returned when a confirmation is made
@@ -23,6 +24,9 @@ type ConfirmResult struct {
 	Confirmed bool
 	Action    string
 	Data      interface{}
+	Copied    bool   // the command was copied instead of running the action
+	Command   string // the copied command
+	Err       error  // copy failure
 }
 
 func NewConfirmDialog() ConfirmDialog {
@@ -61,6 +65,16 @@ func (c ConfirmDialog) Update(msg tea.Msg) (ConfirmDialog, tea.Cmd) {
 				return ConfirmResult{Confirmed: true, Action: c.action, Data: c.data}
 			}
 
+		case "c":
+			if c.command == "" {
+				break
+			}
+			c.visible = false
+			return c, func() tea.Msg {
+				err := CopyToClipboard(c.command)
+				return ConfirmResult{Action: c.action, Data: c.data, Copied: err == nil, Command: c.command, Err: err}
+			}
+
 		case "left", "h":
 			c.selected = true // Yes is on left
 
@@ -95,6 +109,12 @@ func (c ConfirmDialog) View() string {
 	b.WriteString(msgStyle.Render(c.message))
 	b.WriteString("\n\n")
 
+	if c.command != "" {
+		cmdStyle := lipgloss.NewStyle().Foreground(styles.Secondary)
+		b.WriteString(cmdStyle.Render("$ " + c.command))
+		b.WriteString("\n\n")
+	}
+
 	// Buttons
 	yesStyle := lipgloss.NewStyle().
 		Padding(0, 2).
@@ -134,7 +154,11 @@ func (c ConfirmDialog) View() string {
 		Foreground(styles.Muted).
 		MarginTop(1)
 	b.WriteString("\n\n")
-	b.WriteString(hintStyle.Render("y/n • ←/→ to select • Enter to confirm"))
+	hint := "y/n • ←/→ to select • Enter to confirm"
+	if c.command != "" {
+		hint += " • c to copy command instead"
+	}
+	b.WriteString(hintStyle.Render(hint))
 
 	// Wrap in a box
 	content := b.String()
@@ -148,6 +172,13 @@ func (c ConfirmDialog) View() string {
 }
 
 func (c *ConfirmDialog) Show(title, message, action string, data interface{}) {
+	c.ShowCommand(title, message, "", action, data)
+}
+
+// ShowCommand shows the dialog with the kubectl command equivalent to the
+// action, which can be copied instead of confirming
+func (c *ConfirmDialog) ShowCommand(title, message, command, action string, data interface{}) {
+	c.command = command
 	c.title = title
 	c.message = message
 	c.action = action
@@ -0,0 +1,54 @@
+package components
+
+import (
+	"hash/fnv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// setViewportContent pushes content into vp only when it differs from what
+// was set last, so refresh ticks that change nothing don't re-render panels.
+// last holds the previous content hash.
+func setViewportContent(vp *viewport.Model, last *uint64, content string) {
+	h := fnv.New64a()
+	h.Write([]byte(content))
+	sum := h.Sum64()
+	if sum == *last {
+		return
+	}
+	*last = sum
+	vp.SetContent(content)
+}
+
+// minWrapWidth is the narrowest text column worth wrapping into; with less
+// room beside the prefix the line is left for the viewport to cut
+const minWrapWidth = 20
+
+// wrapHanging soft-wraps body to the width left after prefix, indenting the
+// continuation lines to line up under the body's first line. Styles in
+// either are kept.
+func wrapHanging(prefix, body string, width int) string {
+	indent := ansi.StringWidth(prefix)
+	if width-indent < minWrapWidth || indent+ansi.StringWidth(body) <= width {
+		return prefix + body
+	}
+	lines := strings.Split(ansi.Wrap(body, width-indent, ""), "\n")
+	pad := strings.Repeat(" ", indent)
+	for i := 1; i < len(lines); i++ {
+		lines[i] = pad + lines[i]
+	}
+	return prefix + strings.Join(lines, "\n")
+}
+
+// wrapText wraps each line of text to width, continuation lines indented
+// like the line they belong to
+func wrapText(text string, width int) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		body := strings.TrimLeft(line, " \t")
+		lines[i] = wrapHanging(line[:len(line)-len(body)], body, width)
+	}
+	return strings.Join(lines, "\n")
+}
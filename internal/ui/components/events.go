@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,6 +19,12 @@ type EventsPanel struct {
 	height    int
 	cursor    int
 	showAll   bool
+	errMsg    string // last load error, shown in the header
+	lastHash  uint64 // hash of the content last set on the viewport
+
+	// A warning that just arrived, flagged in the header until flashUntil
+	flash      string
+	flashUntil time.Time
 }
 
 func NewEventsPanel() EventsPanel {
@@ -68,6 +75,12 @@ func (e EventsPanel) View() string {
 	if !e.showAll {
 		header.WriteString(styles.SubtitleStyle.Render(" (warnings only, press 'w' for all)"))
 	}
+	if e.errMsg != "" {
+		header.WriteString(styles.StatusError.Render(" [" + e.errMsg + "]"))
+	}
+	if time.Now().Before(e.flashUntil) {
+		header.WriteString(" " + styles.EventWarning.Reverse(true).Render(" new: "+e.flash+" "))
+	}
 	header.WriteString("\n")
 
 	return header.String() + e.viewport.View()
@@ -79,7 +92,21 @@ func (e *EventsPanel) SetEvents(events []k8s.EventInfo) {
 	e.updateContent()
 }
 
+// Flash flags a newly arrived warning in the header until the given time
+func (e *EventsPanel) Flash(reason string, until time.Time) {
+	e.flash = reason
+	e.flashUntil = until
+}
+
+// SetError shows msg in the header; pass "" to clear it
+func (e *EventsPanel) SetError(msg string) {
+	e.errMsg = msg
+}
+
 func (e *EventsPanel) SetSize(width, height int) {
+	if e.ready && width == e.width && height-2 == e.height {
+		return // called on every render; nothing to redo
+	}
 	e.width = width
 	e.height = height - 2
 
@@ -112,7 +139,7 @@ func (e *EventsPanel) updateContent() {
 		}
 	}
 
-	e.viewport.SetContent(content.String())
+	setViewportContent(&e.viewport, &e.lastHash, content.String())
 }
 
 func (e EventsPanel) getDisplayedEvents() []k8s.EventInfo {
@@ -156,7 +183,11 @@ func (e EventsPanel) formatEvent(event k8s.EventInfo, selected bool) string {
 	if maxMsgLen < 20 {
 		maxMsgLen = 20
 	}
-	msg := styles.Truncate(event.Message, maxMsgLen)
+	msg := event.Message
+	if !strings.HasPrefix(event.Object, "Pod/") && event.Object != "" {
+		msg = event.Object + ": " + msg // an event of the pod's ReplicaSet or workload
+	}
+	msg = styles.Truncate(msg, maxMsgLen)
 	b.WriteString(styles.LogNormal.Render(msg))
 
 	return b.String()
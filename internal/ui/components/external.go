@@ -0,0 +1,97 @@
+package components
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ExternalCommand is a configured pager or diff tool prepared to run via
+// tea.ExecProcess. Cleanup removes the temporary files it reads from.
+type ExternalCommand struct {
+	Cmd   *exec.Cmd
+	files []string
+}
+
+func (e ExternalCommand) Cleanup() {
+	for _, f := range e.files {
+		os.Remove(f)
+	}
+}
+
+// PagerCommand writes content to a temp file and opens it with pager,
+// e.g. "less -R" or "bat --paging=always -l yaml"
+func PagerCommand(pager, name, content string) (ExternalCommand, error) {
+	file, err := writeTemp(name, content)
+	if err != nil {
+		return ExternalCommand{}, err
+	}
+	cmd := shellCommand(pager, file)
+	if _, set := os.LookupEnv("LESS"); !set {
+		// Let less show colors rather than escape codes, as git does
+		cmd.Env = append(os.Environ(), "LESS=R")
+	}
+	return ExternalCommand{
+		Cmd:   cmd,
+		files: []string{file},
+	}, nil
+}
+
+// LogPager is the pager for the logs: the configured one, else $PAGER, else
+// less -R
+func LogPager(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if pager := os.Getenv("PAGER"); pager != "" {
+		return pager
+	}
+	return "less -R"
+}
+
+// DiffCommand writes both sides to temp files and runs tool on them,
+// e.g. "delta" or "diff -u --color=always | less -R"
+func DiffCommand(tool, leftName, left, rightName, right string) (ExternalCommand, error) {
+	leftFile, err := writeTemp(leftName, left)
+	if err != nil {
+		return ExternalCommand{}, err
+	}
+	rightFile, err := writeTemp(rightName, right)
+	if err != nil {
+		os.Remove(leftFile)
+		return ExternalCommand{}, err
+	}
+	return ExternalCommand{
+		Cmd:   shellCommand(tool, leftFile, rightFile),
+		files: []string{leftFile, rightFile},
+	}, nil
+}
+
+// shellCommand runs tool through sh so pipes and flags in the configured
+// command work; file names are passed as positional args, never interpolated
+func shellCommand(tool string, files ...string) *exec.Cmd {
+	script := tool
+	if left, right, ok := strings.Cut(tool, "|"); ok {
+		// Files belong to the first command of a pipeline
+		script = left + ` "$@" |` + right
+	} else {
+		script += ` "$@"`
+	}
+	args := append([]string{"-c", script, "k9sight"}, files...)
+	return exec.Command("sh", args...)
+}
+
+func writeTemp(name, content string) (string, error) {
+	name = strings.NewReplacer("/", "_", " ", "_", ":", "_").Replace(name)
+	f, err := os.CreateTemp("", "k9sight-*-"+name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(content); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -36,17 +36,38 @@ func defaultHelpEntries() [][]HelpEntry {
 		{
 			{Key: "PgUp", Desc: "page up"},
 			{Key: "PgDn", Desc: "page down"},
-			{Key: "enter", Desc: "select"},
+			{Key: "enter", Desc: "select, workload dashboard"},
 			{Key: "esc", Desc: "back"},
 		},
 		{
 			{Key: "/", Desc: "search/filter"},
 			{Key: "c", Desc: "clear filter"},
 			{Key: "r", Desc: "refresh"},
+			{Key: "E", Desc: "error log"},
+			{Key: "F", Desc: "port-forwards"},
 		},
 		{
 			{Key: "n", Desc: "change namespace"},
 			{Key: "t", Desc: "change resource type"},
+			{Key: "C", Desc: "switch context"},
+			{Key: "W", Desc: "watch/unwatch"},
+			{Key: "S", Desc: "save view"},
+			{Key: "V", Desc: "saved views"},
+			{Key: "b", Desc: "bookmarks"},
+		},
+		{
+			{Key: "m", Desc: "mark pod"},
+			{Key: "x", Desc: "compare 2 marked pods"},
+			{Key: "N", Desc: "daemonset nodes"},
+			{Key: "D", Desc: "describe"},
+			{Key: "L", Desc: "logs of all pods"},
+			{Key: "H", Desc: "rollout history/undo"},
+			{Key: "!", Desc: "jump to unhealthiest pod"},
+			{Key: "a", Desc: "workload actions, finished pod cleanup"},
+			{Key: "P", Desc: "port-forward service/pod"},
+			{Key: "+", Desc: "new namespace (in n)"},
+			{Key: "d", Desc: "delete empty namespace (in n)"},
+			{Key: "a", Desc: "namespace actions (in n)"},
 		},
 		{
 			{Key: "tab", Desc: "next panel"},
@@ -56,8 +77,21 @@ func defaultHelpEntries() [][]HelpEntry {
 		{
 			{Key: "f", Desc: "follow logs"},
 			{Key: "e", Desc: "next error"},
+			{Key: "n/N", Desc: "next/prev match"},
+			{Key: "=", Desc: "filter JSON fields"},
+			{Key: "J", Desc: "JSON columns"},
+			{Key: "L", Desc: "minimum log level"},
+			{Key: "t", Desc: "timestamps: clock/full/relative/off"},
+			{Key: "p", Desc: "previous container logs"},
 			{Key: "w", Desc: "wrap lines"},
+			{Key: "|", Desc: "logs to pager"},
+			{Key: "i", Desc: "log+event timeline"},
+			{Key: "s", Desc: "save logs to file"},
+			{Key: "a", Desc: "pod actions, save YAML"},
+			{Key: "R", Desc: "restart pod's workload"},
 			{Key: "v", Desc: "fullscreen"},
+			{Key: "< >", Desc: "earlier snapshots"},
+			{Key: "b", Desc: "best practice hints (manifest)"},
 		},
 		{
 			{Key: "?", Desc: "toggle help"},
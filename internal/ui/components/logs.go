@@ -2,13 +2,16 @@ package components
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/logstore"
 	"github.com/doganarif/k9sight/internal/ui/styles"
 )
 
@@ -30,35 +33,119 @@ var timeFilterLabels = map[TimeFilter]string{
 	TimeFilter6Hours: "6h",
 }
 
+// TimestampMode is how log line timestamps are shown
+type TimestampMode int
+
+const (
+	TimestampClock    TimestampMode = iota // 15:04:05
+	TimestampFull                          // RFC 3339 with the date and zone
+	TimestampRelative                      // 2m ago
+	TimestampOff
+)
+
+var timestampModeNames = []string{"clock", "full", "relative", "off"}
+
+func (t TimestampMode) String() string {
+	return timestampModeNames[t]
+}
+
+// ParseTimestampMode maps a configured mode name to a TimestampMode, the
+// clock for unknown names
+func ParseTimestampMode(name string) TimestampMode {
+	for i, n := range timestampModeNames {
+		if n == name {
+			return TimestampMode(i)
+		}
+	}
+	return TimestampClock
+}
+
+// LogTimestampsMsg reports the timestamp mode picked with t, to remember it
+type LogTimestampsMsg struct {
+	Mode TimestampMode
+}
+
 type LogsPanel struct {
-	logs         []k8s.LogLine
+	store        *logstore.Store // capped ring of the loaded lines
 	viewport     viewport.Model
 	ready        bool
 	width        int
 	height       int
 	following    bool
+	live         bool // lines arrive from a log stream rather than polling
 	filter       string
-	containers   []string // list of container names
-	containerIdx int      // -1 = all, 0+ = specific container
-	showPrevious bool     // show previous container logs
-	searching    bool     // true when search input is active
+	regex        bool            // filter is a regular expression
+	matchCase    bool            // filter is case-sensitive
+	matcher      *k8s.LogMatcher // compiled search, nil for none
+	filterErr    string          // why the search does not compile
+	matches      int             // matches of the search in the shown lines
+	structured   bool            // render JSON lines as level, message and fields
+	fieldFilter  *k8s.FieldFilter
+	fieldInput   textinput.Model
+	editFields   bool         // true when the field filter input is active
+	fieldErr     string       // why the field filter does not parse
+	minLevel     k8s.LogLevel // hide lines below this level, unknown for all
+	containers   []string     // list of container names
+	containerIdx int          // -1 = all, 0+ = specific container
+	showPrevious bool         // show previous container logs
+	searching    bool         // true when search input is active
 	searchInput  textinput.Model
 	timeFilter   TimeFilter
+	logSource    string        // name of the configured external log backend, if any
+	useExternal  bool          // true when logs come from the external backend
+	errMsg       string        // last load error, shown in the header
+	lastHash     uint64        // hash of the content last set on the viewport
+	gapThreshold time.Duration // silences longer than this get a marker, 0 for none
+	timestamps   TimestampMode
+	wrap         bool // soft-wrap long lines instead of cutting them
+	ansiColors   bool // show the colors applications write instead of restyling
+
+	// Only a window of the filtered lines is rendered into the viewport;
+	// windowStart is the index of its first line in filtered. rowOffsets
+	// holds the viewport line each rendered row starts on, which differs
+	// from its index once rows wrap, and the total line count last.
+	filtered    []logRow
+	windowStart int
+	windowEnd   int
+	rowOffsets  []int
 }
 
+// logRow is one row of the logs panel: a log line, or a marker for a silence
+// of gap before the next line
+type logRow struct {
+	line k8s.LogLine
+	gap  time.Duration
+}
+
+// logRenderMargin is how many lines are rendered above and below the visible
+// part of the logs panel, so scrolling rarely needs a re-render
+const logRenderMargin = 200
+
 func NewLogsPanel() LogsPanel {
 	ti := textinput.New()
-	ti.Placeholder = "Search logs..."
+	ti.Placeholder = "Search (^r regex, ^t case)"
 	ti.CharLimit = 100
 	ti.Width = 30
 
+	fi := textinput.New()
+	fi.Placeholder = "level=error trace_id=abc"
+	fi.CharLimit = 200
+	fi.Width = 30
+
 	return LogsPanel{
 		following:    true,
 		containerIdx: -1, // -1 means all containers
 		searchInput:  ti,
+		fieldInput:   fi,
+		structured:   true,
+		store:        logstore.New(0, 0),
 	}
 }
 
+// jsonMessageWidth is the column JSON log messages are padded to, so the
+// fields after them line up
+const jsonMessageWidth = 40
+
 func (l LogsPanel) Init() tea.Cmd {
 	return nil
 }
@@ -68,6 +155,24 @@ func (l LogsPanel) Update(msg tea.Msg) (LogsPanel, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Handle field filter input
+		if l.editFields {
+			switch msg.String() {
+			case "esc":
+				l.editFields = false
+				l.fieldInput.Blur()
+				return l, nil
+			case "enter":
+				l.editFields = false
+				l.fieldInput.Blur()
+				l.setFieldFilter(l.fieldInput.Value())
+				return l, nil
+			default:
+				l.fieldInput, cmd = l.fieldInput.Update(msg)
+				return l, cmd
+			}
+		}
+
 		// Handle search mode
 		if l.searching {
 			switch msg.String() {
@@ -78,14 +183,20 @@ func (l LogsPanel) Update(msg tea.Msg) (LogsPanel, tea.Cmd) {
 			case "enter":
 				l.searching = false
 				l.searchInput.Blur()
-				l.filter = l.searchInput.Value()
-				l.updateContent()
+				l.setFilter(l.searchInput.Value())
+				return l, nil
+			case "ctrl+r":
+				l.regex = !l.regex
+				l.setFilter(l.searchInput.Value())
+				return l, nil
+			case "ctrl+t":
+				l.matchCase = !l.matchCase
+				l.setFilter(l.searchInput.Value())
 				return l, nil
 			default:
 				l.searchInput, cmd = l.searchInput.Update(msg)
 				// Live search as you type
-				l.filter = l.searchInput.Value()
-				l.updateContent()
+				l.setFilter(l.searchInput.Value())
 				return l, cmd
 			}
 		}
@@ -98,36 +209,74 @@ func (l LogsPanel) Update(msg tea.Msg) (LogsPanel, tea.Cmd) {
 			return l, textinput.Blink
 		case "c":
 			// Clear filter
-			l.filter = ""
 			l.searchInput.SetValue("")
+			l.fieldInput.SetValue("")
+			l.fieldFilter, l.fieldErr = nil, ""
+			l.setFilter("")
+			return l, nil
+		case "=":
+			l.editFields = true
+			l.fieldInput.SetValue(l.fieldFilter.String())
+			l.fieldInput.CursorEnd()
+			l.fieldInput.Focus()
+			return l, textinput.Blink
+		case "J":
+			l.structured = !l.structured
 			l.updateContent()
 			return l, nil
+		case "L":
+			l.cycleMinLevel()
+			l.updateContent()
+			return l, nil
+		case "n":
+			l.jumpToMatch(1)
+		case "N":
+			l.jumpToMatch(-1)
 		case "f":
-			l.following = !l.following
-			if l.following {
-				l.viewport.GotoBottom()
-			}
+			l.ToggleFollow()
 		case "e":
 			l.jumpToNextError()
 		case "g":
-			l.viewport.GotoTop()
+			l.scrollTo(0)
 		case "G":
-			l.viewport.GotoBottom()
+			l.scrollTo(len(l.filtered))
 		case "[":
 			l.prevContainer()
 		case "]":
 			l.nextContainer()
-		case "P":
+		case "p":
+			// The previous instance's logs are fetched by the app
 			l.showPrevious = !l.showPrevious
-			// Note: actual previous logs fetch handled by dashboard
 		case "T":
 			l.cycleTimeFilter()
 			l.updateContent()
 			return l, nil
+		case "t":
+			l.timestamps = (l.timestamps + 1) % TimestampMode(len(timestampModeNames))
+			l.updateContent()
+			mode := l.timestamps
+			return l, func() tea.Msg { return LogTimestampsMsg{Mode: mode} }
+		case "w":
+			l.wrap = !l.wrap
+			l.updateContent()
+			return l, nil
+		case "B":
+			// Switch between kubelet and external backend; fetch handled by dashboard
+			if l.logSource != "" {
+				l.useExternal = !l.useExternal
+			}
+			return l, nil
 		}
 	}
 
 	l.viewport, cmd = l.viewport.Update(msg)
+
+	// Re-render around the new position once scrolling nears the window edge
+	top := l.top()
+	if (top-l.windowStart < logRenderMargin/2 && l.windowStart > 0) ||
+		(l.windowEnd-(top+l.viewport.Height) < logRenderMargin/2 && l.windowEnd < len(l.filtered)) {
+		l.renderWindow(top)
+	}
 	return l, cmd
 }
 
@@ -153,22 +302,56 @@ func (l LogsPanel) View() string {
 		}
 	}
 
+	if l.useExternal {
+		header.WriteString(styles.HelpKeyStyle.Render(fmt.Sprintf(" [%s]", l.logSource)))
+	}
 	if l.showPrevious {
 		header.WriteString(styles.EventWarning.Render(" [Previous]"))
 	}
 	if l.following && !l.showPrevious {
-		header.WriteString(styles.StatusRunning.Render(" [Following]"))
+		if l.live {
+			header.WriteString(styles.StatusRunning.Render(" [Following ● live]"))
+		} else {
+			header.WriteString(styles.StatusRunning.Render(" [Following]"))
+		}
 	}
 
 	// Show time filter indicator
 	if l.timeFilter != TimeFilterAll {
 		header.WriteString(styles.HelpKeyStyle.Render(fmt.Sprintf(" [%s]", timeFilterLabels[l.timeFilter])))
 	}
+	if l.wrap {
+		header.WriteString(styles.HelpDescStyle.Render(" [wrap]"))
+	}
+
+	if l.minLevel != k8s.LevelUnknown {
+		header.WriteString(levelStyle(l.minLevel).Render(fmt.Sprintf(" [%s+]", l.minLevel)))
+	}
 
 	// Show filter indicator
 	if l.filter != "" && !l.searching {
 		header.WriteString(styles.HelpKeyStyle.Render(fmt.Sprintf(" /%s", l.filter)))
-		header.WriteString(styles.HelpDescStyle.Render(" (c:clear)"))
+		header.WriteString(styles.HelpDescStyle.Render(l.searchModes()))
+		if l.filterErr == "" {
+			header.WriteString(styles.HelpDescStyle.Render(fmt.Sprintf(" %d matches (n/N, c:clear)", l.matches)))
+		}
+	}
+	if l.filterErr != "" {
+		header.WriteString(styles.StatusError.Render(" [" + l.filterErr + "]"))
+	}
+	if l.fieldFilter != nil && !l.editFields {
+		header.WriteString(styles.HelpKeyStyle.Render(" {" + l.fieldFilter.String() + "}"))
+	}
+	if l.fieldErr != "" {
+		header.WriteString(styles.StatusError.Render(" [" + l.fieldErr + "]"))
+	}
+
+	if dropped := l.store.Dropped(); dropped > 0 {
+		header.WriteString(styles.HelpDescStyle.Render(fmt.Sprintf(" [loaded %d lines, %d dropped]", l.store.Len(), dropped)))
+	}
+
+	if l.errMsg != "" {
+		header.WriteString(styles.StatusError.Render(" [" + l.errMsg + "]"))
 	}
 
 	header.WriteString("\n")
@@ -177,30 +360,91 @@ func (l LogsPanel) View() string {
 	if l.searching {
 		header.WriteString(styles.HelpKeyStyle.Render("/"))
 		header.WriteString(l.searchInput.View())
+		header.WriteString(styles.HelpDescStyle.Render(l.searchModes()))
+		header.WriteString("\n")
+	}
+	if l.editFields {
+		header.WriteString(styles.HelpKeyStyle.Render("="))
+		header.WriteString(l.fieldInput.View())
 		header.WriteString("\n")
 	}
 
 	return header.String() + l.viewport.View()
 }
 
+// SetLogs replaces the stored lines, as when another container or the
+// previous instance is selected
 func (l *LogsPanel) SetLogs(logs []k8s.LogLine) {
-	l.logs = logs
+	l.store.Reset(logs)
 	l.updateContent()
 }
 
+// RefreshLogs adds the lines of a poll that are newer than the stored ones,
+// keeping older lines loaded until the budget drops them
+func (l *LogsPanel) RefreshLogs(logs []k8s.LogLine) {
+	if l.store.AppendNewer(logs) > 0 || l.store.Len() == 0 {
+		l.updateContent()
+	}
+}
+
+// AppendLogs adds streamed lines after the current ones, dropping the oldest
+// beyond the budget
+func (l *LogsPanel) AppendLogs(lines []k8s.LogLine) {
+	if len(lines) == 0 {
+		return
+	}
+	l.store.Append(lines...)
+	l.updateContent()
+}
+
+// Newest returns the latest timestamp among the stored lines
+func (l LogsPanel) Newest() time.Time {
+	return l.store.Newest()
+}
+
+func (l *LogsPanel) SetLive(live bool) {
+	l.live = live
+}
+
+// SetBudget caps the lines and bytes of logs kept in memory; older lines
+// beyond it are dropped
+func (l *LogsPanel) SetBudget(maxLines, maxBytes int) {
+	l.store.SetBudget(maxLines, maxBytes)
+}
+
+// SetGapThreshold marks silences between consecutive lines longer than d;
+// 0 turns the markers off
+func (l *LogsPanel) SetGapThreshold(d time.Duration) {
+	l.gapThreshold = d
+	l.updateContent()
+}
+
+// SetError shows msg in the header; pass "" to clear it
+func (l *LogsPanel) SetError(msg string) {
+	l.errMsg = msg
+}
+
 func (l *LogsPanel) SetSize(width, height int) {
+	if l.ready && width == l.width && height-2 == l.height {
+		return // called on every render; nothing to redo
+	}
 	l.width = width
 	l.height = height - 2
 
 	if !l.ready {
 		l.viewport = viewport.New(width, l.height)
 		l.ready = true
-	} else {
-		l.viewport.Width = width
-		l.viewport.Height = l.height
+		l.updateContent()
+		return
 	}
 
-	l.updateContent()
+	top := l.top()
+	l.viewport.Width = width
+	l.viewport.Height = l.height
+	if l.following {
+		top = len(l.filtered)
+	}
+	l.renderWindow(top)
 }
 
 func (l *LogsPanel) SetContainers(containers []string) {
@@ -243,6 +487,23 @@ func (l LogsPanel) ShowPrevious() bool {
 	return l.showPrevious
 }
 
+// SetLogSource enables the B toggle for the named external log backend
+func (l *LogsPanel) SetLogSource(name string) {
+	l.logSource = name
+	if name == "" {
+		l.useExternal = false
+	}
+}
+
+func (l LogsPanel) UseExternalSource() bool {
+	return l.useExternal
+}
+
+// TimeRange returns the selected time filter, 0 meaning all
+func (l LogsPanel) TimeRange() time.Duration {
+	return l.getTimeFilterDuration()
+}
+
 func (l *LogsPanel) cycleTimeFilter() {
 	l.timeFilter = (l.timeFilter + 1) % 5
 }
@@ -263,35 +524,154 @@ func (l LogsPanel) getTimeFilterDuration() time.Duration {
 }
 
 func (l *LogsPanel) SetFilter(filter string) {
+	l.setFilter(filter)
+}
+
+// setFilter compiles the search in the current regex and case modes. The
+// search hides no lines, it highlights its matches. A regex that does not
+// compile yet, while being typed, highlights nothing.
+func (l *LogsPanel) setFilter(filter string) {
 	l.filter = filter
+	matcher, err := k8s.NewLogMatcher(filter, l.regex, l.matchCase)
+	l.matcher, l.filterErr = matcher, ""
+	if err != nil {
+		l.filterErr = "bad regex"
+	}
+	l.updateContent()
+}
+
+// setFieldFilter parses a field expression; one that does not parse keeps
+// the previous filter
+func (l *LogsPanel) setFieldFilter(expr string) {
+	f, err := k8s.ParseFieldFilter(expr)
+	if err != nil {
+		l.fieldErr = err.Error()
+		return
+	}
+	l.fieldFilter, l.fieldErr = f, ""
 	l.updateContent()
 }
 
+// searchModes labels the active search modes for the header
+func (l LogsPanel) searchModes() string {
+	var modes []string
+	if l.regex {
+		modes = append(modes, "regex")
+	}
+	if l.matchCase {
+		modes = append(modes, "case")
+	}
+	if len(modes) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(modes, ",") + "]"
+}
+
 func (l *LogsPanel) ToggleFollow() {
 	l.following = !l.following
 	if l.following {
-		l.viewport.GotoBottom()
+		l.scrollTo(len(l.filtered))
 	}
 }
 
+// updateContent re-applies the filters and renders the window at the
+// current position, or at the tail when following
 func (l *LogsPanel) updateContent() {
 	if !l.ready {
 		return
 	}
 
-	var content strings.Builder
-	filteredLogs := l.getFilteredLogs()
+	top := l.top()
+	var anchor *logRow
+	if top < len(l.filtered) {
+		anchor = &l.filtered[top]
+	}
+	lines := l.getFilteredLogs()
+	l.filtered = l.withGapMarkers(lines)
+	l.matches = 0
+	if l.matcher != nil {
+		for _, log := range lines {
+			l.matches += len(l.matcher.Spans(k8s.StripANSI(log.Content)))
+		}
+	}
+	if l.following {
+		top = len(l.filtered)
+	} else if anchor != nil {
+		top = l.rowIndex(*anchor, top)
+	}
+	l.renderWindow(top)
+}
 
-	for _, log := range filteredLogs {
-		line := l.formatLogLine(log)
-		content.WriteString(line)
-		content.WriteString("\n")
+// rowIndex finds row in filtered, looking first at and before near, since
+// dropping the oldest lines moves rows up. Without it, near stays.
+func (l LogsPanel) rowIndex(row logRow, near int) int {
+	near = min(near, len(l.filtered)-1)
+	for i := near; i >= 0; i-- {
+		if l.filtered[i] == row {
+			return i
+		}
 	}
+	for i := near + 1; i < len(l.filtered); i++ {
+		if l.filtered[i] == row {
+			return i
+		}
+	}
+	return near
+}
 
-	l.viewport.SetContent(content.String())
+// top is the index in filtered of the first line on screen
+func (l LogsPanel) top() int {
+	if len(l.rowOffsets) < 2 {
+		return l.windowStart
+	}
+	rows := len(l.rowOffsets) - 1
+	i := sort.Search(rows, func(i int) bool { return l.rowOffsets[i] > l.viewport.YOffset })
+	return l.windowStart + max(0, i-1)
+}
 
-	if l.following {
-		l.viewport.GotoBottom()
+func (l *LogsPanel) scrollTo(top int) {
+	if !l.ready {
+		return
+	}
+	l.renderWindow(top)
+}
+
+// renderWindow formats only the lines around top into the viewport, so the
+// cost of an update is independent of the buffer size
+func (l *LogsPanel) renderWindow(top int) {
+	atEnd := top >= len(l.filtered)-l.viewport.Height
+	top = max(0, min(top, len(l.filtered)-l.viewport.Height))
+	start := max(0, top-logRenderMargin)
+	end := min(len(l.filtered), top+l.viewport.Height+logRenderMargin)
+
+	var content strings.Builder
+	offsets := make([]int, 0, end-start+1)
+	lines := 0
+	for _, row := range l.filtered[start:end] {
+		var text string
+		if row.gap > 0 {
+			text = l.formatGapMarker(row.gap)
+		} else if l.wrap {
+			text = wrapHanging(l.logLinePrefix(row.line), l.logLineBody(row.line), l.width)
+		} else {
+			text = l.formatLogLine(row.line)
+		}
+		offsets = append(offsets, lines)
+		lines += strings.Count(text, "\n") + 1
+		content.WriteString(text)
+		content.WriteString("\n")
+	}
+
+	l.windowStart = start
+	l.windowEnd = end
+	l.rowOffsets = append(offsets, lines)
+	setViewportContent(&l.viewport, &l.lastHash, content.String())
+	if atEnd {
+		// Wrapped rows take more than a line each, so the last rows only
+		// fit by scrolling to the last line rather than to a row
+		l.viewport.SetYOffset(max(0, lines-l.viewport.Height))
+	} else {
+		l.viewport.SetYOffset(offsets[top-start])
 	}
 }
 
@@ -302,13 +682,32 @@ func (l LogsPanel) getFilteredLogs() []k8s.LogLine {
 
 	// First filter by container if specific container selected
 	selectedContainer := l.SelectedContainer()
-	for _, log := range l.logs {
+	for i := 0; i < l.store.Len(); i++ {
+		log := l.store.At(i)
 		if selectedContainer != "" && log.Container != selectedContainer {
 			continue
 		}
 		filtered = append(filtered, log)
 	}
 
+	// Then by level; lines without one, like stack trace frames, take the
+	// level of the line before them from the same container
+	if l.minLevel != k8s.LevelUnknown {
+		var levelFiltered []k8s.LogLine
+		last := make(map[string]k8s.LogLevel)
+		for _, log := range filtered {
+			level := log.Level
+			if level == k8s.LevelUnknown {
+				level = last[log.Container]
+			}
+			last[log.Container] = level
+			if level >= l.minLevel {
+				levelFiltered = append(levelFiltered, log)
+			}
+		}
+		filtered = levelFiltered
+	}
+
 	// Then filter by time if set
 	if timeDuration > 0 {
 		cutoff := now.Add(-timeDuration)
@@ -321,81 +720,266 @@ func (l LogsPanel) getFilteredLogs() []k8s.LogLine {
 		filtered = timeFiltered
 	}
 
-	// Then filter by text filter if set
-	if l.filter != "" {
-		filter := strings.ToLower(l.filter)
-		var textFiltered []k8s.LogLine
+	// Then by JSON fields; lines that are not JSON have none to match
+	if l.fieldFilter != nil {
+		var fieldFiltered []k8s.LogLine
 		for _, log := range filtered {
-			if strings.Contains(strings.ToLower(log.Content), filter) {
-				textFiltered = append(textFiltered, log)
+			if parsed, ok := k8s.ParseJSONLog(log.Content); ok && l.fieldFilter.Match(parsed) {
+				fieldFiltered = append(fieldFiltered, log)
 			}
 		}
-		filtered = textFiltered
+		filtered = fieldFiltered
 	}
 
 	return filtered
 }
 
+// withGapMarkers puts a marker row before each line that follows a silence
+// longer than the threshold. A field or level filter hides lines, so the
+// time between the lines left says nothing about the pod going quiet and
+// gets no markers.
+func (l LogsPanel) withGapMarkers(lines []k8s.LogLine) []logRow {
+	rows := make([]logRow, 0, len(lines))
+	var prev time.Time
+	for _, log := range lines {
+		if l.gapThreshold > 0 && l.fieldFilter == nil && l.minLevel == k8s.LevelUnknown && !prev.IsZero() && !log.Timestamp.IsZero() {
+			if gap := log.Timestamp.Sub(prev); gap > l.gapThreshold {
+				rows = append(rows, logRow{gap: gap})
+			}
+		}
+		if !log.Timestamp.IsZero() {
+			prev = log.Timestamp
+		}
+		rows = append(rows, logRow{line: log})
+	}
+	return rows
+}
+
+func (l LogsPanel) formatGapMarker(gap time.Duration) string {
+	label := fmt.Sprintf(" %s without logs ", k8s.FormatDuration(gap))
+	width := max(0, l.width-len(label)-4)
+	return styles.LogTimestamp.Render("──" + label + strings.Repeat("─", width))
+}
+
 func (l LogsPanel) formatLogLine(log k8s.LogLine) string {
+	return l.logLinePrefix(log) + l.logLineBody(log)
+}
+
+// logLinePrefix renders the timestamp and, when viewing all containers, the
+// container name before a line; wrapped lines are indented past it
+func (l LogsPanel) logLinePrefix(log k8s.LogLine) string {
 	var b strings.Builder
 
-	if !log.Timestamp.IsZero() {
-		ts := log.Timestamp.Format("15:04:05")
+	if ts := l.formatTimestamp(log.Timestamp); ts != "" {
 		b.WriteString(styles.LogTimestamp.Render(ts))
 		b.WriteString(" ")
 	}
 
 	// Show container name when viewing all containers
 	if log.Container != "" && l.containerIdx == -1 && len(l.containers) > 1 {
-		b.WriteString(styles.LogContainer.Render(fmt.Sprintf("[%s]", log.Container)))
+		b.WriteString(styles.LabelStyle(log.Container).Render(fmt.Sprintf("[%s]", log.Container)))
 		b.WriteString(" ")
 	}
+	return b.String()
+}
+
+// logLineBody renders a line's content. Escape sequences in it are dropped,
+// or with ansiColors only its colors kept, in place of the level color;
+// while searching, matches are highlighted over plain text instead.
+func (l LogsPanel) logLineBody(log k8s.LogLine) string {
+	if l.structured {
+		if parsed, ok := k8s.ParseJSONLog(log.Content); ok {
+			return l.formatJSONLog(parsed)
+		}
+	}
+	content := k8s.StripANSI(log.Content)
+	if l.ansiColors && l.matcher == nil && content != log.Content {
+		return k8s.SanitizeANSI(log.Content)
+	}
+	return l.highlight(content, levelStyle(log.Level))
+}
+
+// formatTimestamp renders a line's time in the panel's timestamp mode.
+// Relative times are padded so the lines after them stay aligned.
+func (l LogsPanel) formatTimestamp(ts time.Time) string {
+	if ts.IsZero() {
+		return ""
+	}
+	switch l.timestamps {
+	case TimestampFull:
+		return ts.Format(time.RFC3339)
+	case TimestampRelative:
+		return fmt.Sprintf("%8s", k8s.FormatDuration(time.Since(ts))+" ago")
+	case TimestampOff:
+		return ""
+	}
+	return ts.Format("15:04:05")
+}
+
+// SetANSIColors shows the colors applications write into their logs as they
+// are, rather than stripping them and coloring by level
+func (l *LogsPanel) SetANSIColors(on bool) {
+	l.ansiColors = on
+	l.updateContent()
+}
+
+// SetTimestampMode sets how line timestamps are shown
+func (l *LogsPanel) SetTimestampMode(mode TimestampMode) {
+	l.timestamps = mode
+	l.updateContent()
+}
+
+// formatJSONLog renders a JSON line as its level, its message padded to a
+// column and the remaining fields as key=value, colored by level
+func (l LogsPanel) formatJSONLog(parsed k8s.JSONLog) string {
+	var b strings.Builder
+
+	level := parsed.Level
+	if level == "" {
+		level = "-"
+	}
+	b.WriteString(levelStyle(k8s.ParseLogLevel(parsed.Level)).Render(fmt.Sprintf("%-5s", strings.ToUpper(styles.Truncate(level, 5)))))
+	b.WriteString(" ")
+
+	msgStyle := styles.LogNormal
+	if k8s.ParseLogLevel(parsed.Level) >= k8s.LevelError {
+		msgStyle = styles.LogError
+	}
+	b.WriteString(l.highlight(parsed.Message, msgStyle))
+	if len(parsed.Extra) > 0 {
+		b.WriteString(strings.Repeat(" ", max(1, jsonMessageWidth-len(parsed.Message))))
+	}
+
+	for i, key := range parsed.Extra {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(styles.LogTimestamp.Render(key + "="))
+		b.WriteString(l.highlight(parsed.Fields[key], styles.LogContainer))
+	}
+	return b.String()
+}
+
+// levelStyle is the color for lines of a level
+func levelStyle(level k8s.LogLevel) lipgloss.Style {
+	switch level {
+	case k8s.LevelError, k8s.LevelFatal:
+		return styles.LogError
+	case k8s.LevelWarn:
+		return styles.EventWarning
+	case k8s.LevelDebug:
+		return styles.LogTimestamp
+	}
+	return styles.LogNormal
+}
 
-	if log.IsError {
-		b.WriteString(styles.LogError.Render(log.Content))
+// cycleMinLevel steps the level filter through info, warn, error and fatal
+// and back to showing every line
+func (l *LogsPanel) cycleMinLevel() {
+	if l.minLevel == k8s.LevelFatal {
+		l.minLevel = k8s.LevelUnknown
 	} else {
-		b.WriteString(styles.LogNormal.Render(log.Content))
+		l.minLevel = max(l.minLevel+1, k8s.LevelInfo)
 	}
+}
 
+// highlight renders text in style with each match of the search picked out
+func (l LogsPanel) highlight(text string, style lipgloss.Style) string {
+	var b strings.Builder
+	pos := 0
+	for _, span := range l.matcher.Spans(text) {
+		b.WriteString(style.Render(text[pos:span[0]]))
+		b.WriteString(styles.LogMatch.Render(text[span[0]:span[1]]))
+		pos = span[1]
+	}
+	b.WriteString(style.Render(text[pos:]))
 	return b.String()
 }
 
-func (l *LogsPanel) jumpToNextError() {
-	content := l.viewport.View()
-	lines := strings.Split(content, "\n")
-	currentLine := l.viewport.YOffset
-
-	for i := currentLine + 1; i < len(lines); i++ {
-		if strings.Contains(strings.ToLower(lines[i]), "error") ||
-			strings.Contains(strings.ToLower(lines[i]), "fatal") ||
-			strings.Contains(strings.ToLower(lines[i]), "panic") {
-			l.viewport.SetYOffset(i)
+// jumpToMatch scrolls to the next (dir 1) or previous (dir -1) line with a
+// match of the search after the top one, wrapping around
+func (l *LogsPanel) jumpToMatch(dir int) {
+	n := len(l.filtered)
+	if l.matcher == nil || n == 0 {
+		return
+	}
+	current := l.top()
+	for step := 1; step <= n; step++ {
+		i := ((current+dir*step)%n + n) % n
+		if l.filtered[i].gap == 0 && l.matcher.Match(k8s.StripANSI(l.filtered[i].line.Content)) {
+			l.following = false
+			l.scrollTo(i)
 			return
 		}
 	}
+}
 
-	for i := 0; i < currentLine; i++ {
-		if strings.Contains(strings.ToLower(lines[i]), "error") ||
-			strings.Contains(strings.ToLower(lines[i]), "fatal") ||
-			strings.Contains(strings.ToLower(lines[i]), "panic") {
-			l.viewport.SetYOffset(i)
+// jumpToNextError scrolls to the next line logged at error level or worse,
+// wrapping around
+func (l *LogsPanel) jumpToNextError() {
+	n := len(l.filtered)
+	current := l.top()
+
+	for step := 1; step <= n; step++ {
+		i := (current + step) % n
+		if l.filtered[i].gap == 0 && l.filtered[i].line.IsError {
+			l.following = false
+			l.scrollTo(i)
 			return
 		}
 	}
 }
 
+// PagerContent renders the visible log lines, colors included, for an
+// external pager
+func (l LogsPanel) PagerContent() string {
+	var b strings.Builder
+	for _, log := range l.getFilteredLogs() {
+		b.WriteString(l.formatLogLine(log))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// VisibleLogs returns the log lines remaining after the container, level,
+// time and field filters
+func (l LogsPanel) VisibleLogs() []k8s.LogLine {
+	return l.getFilteredLogs()
+}
+
+// InsertLogs adds lines from one of several streams among the stored ones in
+// time order, for logs merged from many pods, dropping the oldest beyond
+// the budget
+func (l *LogsPanel) InsertLogs(lines []k8s.LogLine) {
+	if len(lines) == 0 {
+		return
+	}
+	l.store.Insert(lines)
+	l.updateContent()
+}
+
+// Containers returns the container names, or log sources, [ and ] cycle
+func (l LogsPanel) Containers() []string {
+	return l.containers
+}
+
+// LoadedLogs returns every stored line of every container, unfiltered
+func (l LogsPanel) LoadedLogs() []k8s.LogLine {
+	return l.store.Lines()
+}
+
 func (l LogsPanel) IsFollowing() bool {
 	return l.following
 }
 
 func (l LogsPanel) LogCount() int {
-	return len(l.logs)
+	return l.store.Len()
 }
 
 func (l LogsPanel) ErrorCount() int {
 	count := 0
-	for _, log := range l.logs {
-		if log.IsError {
+	for i := 0; i < l.store.Len(); i++ {
+		if l.store.At(i).IsError {
 			count++
 		}
 	}
@@ -403,7 +987,7 @@ func (l LogsPanel) ErrorCount() int {
 }
 
 func (l LogsPanel) IsSearching() bool {
-	return l.searching
+	return l.searching || l.editFields
 }
 
 func (l LogsPanel) Filter() string {
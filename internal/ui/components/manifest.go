@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,23 +17,65 @@ const (
 	ManifestViewSummary ManifestViewMode = iota
 	ManifestViewDetails
 	ManifestViewResources
+	ManifestViewVolumes
+	ManifestViewHistory
 )
 
 var manifestViewModeLabels = map[ManifestViewMode]string{
 	ManifestViewSummary:   "Summary",
 	ManifestViewDetails:   "Details",
 	ManifestViewResources: "Resources",
+	ManifestViewVolumes:   "Volumes",
+	ManifestViewHistory:   "History",
 }
 
 type ManifestPanel struct {
 	pod       *k8s.PodInfo
 	related   *k8s.RelatedResources
 	helpers   []k8s.DebugHelper
+	lint      []k8s.DebugHelper // best practice findings, shown when showLint
+	showLint  bool
+	node      *k8s.NodeSummary
+	restarts  []k8s.RestartTimeline
+	startup   []k8s.StartupPhase
+	volumes   []k8s.VolumeInfo
+	drift     []k8s.ConfigDrift
+	vulns     []k8s.ImageVulnerabilities
+	rollout   []k8s.RolloutRevision // owner Deployment's revisions, nil for other pods
 	viewport  viewport.Model
 	ready     bool
 	width     int
 	height    int
 	viewMode  ManifestViewMode
+	errMsg    string // last load error, shown in the header
+	lastHash  uint64 // hash of the content last set on the viewport
+	yaml      *ManifestYAMLRequest // object shown as YAML, nil for the summary views
+	yamlDoc   string
+	yamlErr   string
+}
+
+// ManifestYAMLRequest asks app.go for an object's YAML. Kind is the pod's
+// owner kind as recorded on the pod, e.g. "ReplicaSet"; the app resolves it
+// to the workload that owns it.
+type ManifestYAMLRequest struct {
+	Namespace string
+	Kind      string
+	Name      string
+}
+
+// PodLintMsg reports the best practice hints being turned on or off with
+// b, to remember it
+type PodLintMsg struct {
+	On bool
+}
+
+// ManifestYAMLMsg carries the YAML fetched for a ManifestYAMLRequest
+type ManifestYAMLMsg struct {
+	Request ManifestYAMLRequest
+	Kind    string // resolved kind and name, e.g. the Deployment of a ReplicaSet
+	Name    string
+	YAML    string
+	Err     error
 }
 
 func NewManifestPanel() ManifestPanel {
@@ -50,9 +93,21 @@ func (m ManifestPanel) Update(msg tea.Msg) (ManifestPanel, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "d":
-			m.viewMode = (m.viewMode + 1) % 3
+			if m.yaml != nil {
+				// Back to the summary view that was showing
+				m.yaml = nil
+			} else {
+				m.viewMode = (m.viewMode + 1) % ManifestViewMode(len(manifestViewModeLabels))
+			}
 			m.updateContent()
 			return m, nil
+		case "y":
+			return m, m.nextYAML()
+		case "b":
+			m.showLint = !m.showLint
+			m.updateContent()
+			on := m.showLint
+			return m, func() tea.Msg { return PodLintMsg{On: on} }
 		}
 	}
 
@@ -67,29 +122,141 @@ func (m ManifestPanel) View() string {
 
 	var header strings.Builder
 	header.WriteString(styles.PanelTitleStyle.Render("Pod Details"))
-	header.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf(" [%s]", manifestViewModeLabels[m.viewMode])))
-	header.WriteString(styles.HelpDescStyle.Render(" (d:cycle)"))
+	if m.yaml != nil {
+		header.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf(" [YAML %s/%s]", m.yaml.Kind, m.yaml.Name)))
+		header.WriteString(styles.HelpDescStyle.Render(" (y:next d:back)"))
+	} else {
+		header.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf(" [%s]", manifestViewModeLabels[m.viewMode])))
+		header.WriteString(styles.HelpDescStyle.Render(" (d:cycle y:yaml b:lint)"))
+	}
+	if m.errMsg != "" {
+		header.WriteString(styles.StatusError.Render(" [" + m.errMsg + "]"))
+	}
 	header.WriteString("\n")
 
 	return header.String() + m.viewport.View()
 }
 
 func (m *ManifestPanel) SetPod(pod *k8s.PodInfo) {
+	if m.yaml != nil && (pod == nil || m.pod == nil || pod.Name != m.pod.Name || pod.Namespace != m.pod.Namespace) {
+		m.yaml = nil
+	}
 	m.pod = pod
 	m.updateContent()
 }
 
+// nextYAML steps from the summary views to the pod's YAML, then its owner's,
+// then back, and asks for the object that is now shown
+func (m *ManifestPanel) nextYAML() tea.Cmd {
+	if m.pod == nil {
+		return nil
+	}
+	var req *ManifestYAMLRequest
+	switch {
+	case m.yaml == nil:
+		req = &ManifestYAMLRequest{Namespace: m.pod.Namespace, Kind: "Pod", Name: m.pod.Name}
+	case m.yaml.Kind == "Pod" && m.pod.OwnerKind != "":
+		req = &ManifestYAMLRequest{Namespace: m.pod.Namespace, Kind: m.pod.OwnerKind, Name: m.pod.OwnerRef}
+	}
+	m.yaml = req
+	m.yamlDoc, m.yamlErr = "", ""
+	m.updateContent()
+	m.viewport.GotoTop()
+	if req == nil {
+		return nil
+	}
+	r := *req
+	return func() tea.Msg { return r }
+}
+
+// SetYAML shows fetched YAML, unless the panel has moved on from the object
+// it was requested for
+func (m *ManifestPanel) SetYAML(msg ManifestYAMLMsg) {
+	if m.yaml == nil || *m.yaml != msg.Request {
+		return
+	}
+	if msg.Err != nil {
+		m.yamlErr = k8s.ShortError(msg.Err)
+	} else {
+		m.yamlDoc = HighlightYAML(msg.YAML)
+	}
+	m.yaml.Kind, m.yaml.Name = msg.Kind, msg.Name
+	m.updateContent()
+}
+
 func (m *ManifestPanel) SetRelated(related *k8s.RelatedResources) {
 	m.related = related
 	m.updateContent()
 }
 
+// Related returns the related resources last set, nil before they load
+func (m ManifestPanel) Related() *k8s.RelatedResources {
+	return m.related
+}
+
+func (m *ManifestPanel) SetNode(node *k8s.NodeSummary) {
+	m.node = node
+	m.updateContent()
+}
+
+// SetError shows msg in the header; pass "" to clear it
+func (m *ManifestPanel) SetError(msg string) {
+	m.errMsg = msg
+}
+
+func (m *ManifestPanel) SetVolumes(volumes []k8s.VolumeInfo) {
+	m.volumes = volumes
+	m.updateContent()
+}
+
+func (m *ManifestPanel) SetRolloutHistory(revisions []k8s.RolloutRevision) {
+	m.rollout = revisions
+	m.updateContent()
+}
+
+func (m *ManifestPanel) SetVulnerabilities(vulns []k8s.ImageVulnerabilities) {
+	m.vulns = vulns
+	m.updateContent()
+}
+
+func (m *ManifestPanel) SetStartupTiming(phases []k8s.StartupPhase) {
+	m.startup = phases
+	m.updateContent()
+}
+
+func (m *ManifestPanel) SetRestartTimelines(timelines []k8s.RestartTimeline) {
+	m.restarts = timelines
+	m.updateContent()
+}
+
+// SetConfigDrift sets the ConfigMaps and Secrets that changed after the
+// containers using them started
+func (m *ManifestPanel) SetConfigDrift(drift []k8s.ConfigDrift) {
+	m.drift = drift
+	m.updateContent()
+}
+
 func (m *ManifestPanel) SetHelpers(helpers []k8s.DebugHelper) {
 	m.helpers = helpers
 	m.updateContent()
 }
 
+// SetLint sets the pod's best practice findings, see k8s.LintPod
+func (m *ManifestPanel) SetLint(lint []k8s.DebugHelper) {
+	m.lint = lint
+	m.updateContent()
+}
+
+// SetShowLint shows or hides the best practice findings
+func (m *ManifestPanel) SetShowLint(on bool) {
+	m.showLint = on
+	m.updateContent()
+}
+
 func (m *ManifestPanel) SetSize(width, height int) {
+	if m.ready && width == m.width && height-2 == m.height {
+		return // called on every render; nothing to redo
+	}
 	m.width = width
 	m.height = height - 2
 
@@ -111,20 +278,78 @@ func (m *ManifestPanel) updateContent() {
 
 	var content strings.Builder
 
+	if m.yaml != nil {
+		switch {
+		case m.yamlErr != "":
+			content.WriteString(styles.StatusError.Render("Failed to load YAML: " + m.yamlErr))
+		case m.yamlDoc == "":
+			content.WriteString(styles.StatusMuted.Render("Loading YAML..."))
+		default:
+			content.WriteString(m.yamlDoc)
+		}
+		setViewportContent(&m.viewport, &m.lastHash, content.String())
+		return
+	}
+
 	switch m.viewMode {
 	case ManifestViewSummary:
 		// Summary: Basic pod info and debug hints
 		content.WriteString(m.renderPodInfo())
+		if termination := m.renderTerminationMessages(); termination != "" {
+			content.WriteString("\n")
+			content.WriteString(termination)
+		}
+		if len(m.drift) > 0 {
+			content.WriteString("\n")
+			content.WriteString(m.renderConfigDrift())
+		}
 		if len(m.helpers) > 0 {
 			content.WriteString("\n")
 			content.WriteString(m.renderHelpers())
 		}
+		if m.showLint && len(m.lint) > 0 {
+			content.WriteString("\n")
+			content.WriteString(m.renderLint())
+		}
+		if len(m.startup) > 0 {
+			content.WriteString("\n")
+			content.WriteString(m.renderStartup())
+		}
+		if len(m.restarts) > 0 {
+			content.WriteString("\n")
+			content.WriteString(m.renderRestarts())
+		}
+		if len(m.vulns) > 0 {
+			content.WriteString("\n")
+			content.WriteString(m.renderVulnerabilities())
+		}
+		if n := m.volumeIssues(); n > 0 {
+			content.WriteString("\n")
+			content.WriteString(styles.StatusError.Render(fmt.Sprintf("Volumes: %d with issues (d: Volumes view)", n)))
+			content.WriteString("\n")
+		}
+		if m.related != nil && !m.related.Provenance.IsEmpty() {
+			content.WriteString("\n")
+			content.WriteString(m.renderProvenance())
+		}
 
 	case ManifestViewDetails:
 		// Details: Pod info, containers, labels, conditions
 		content.WriteString(m.renderPodInfo())
+		if m.node != nil && m.pod.Node != "" {
+			content.WriteString("\n")
+			content.WriteString(m.renderPlacement())
+		}
+		if m.pod.Object != nil {
+			content.WriteString("\n")
+			content.WriteString(m.renderNetwork())
+		}
 		content.WriteString("\n")
 		content.WriteString(m.renderContainers())
+		if probes := m.renderProbes(); probes != "" {
+			content.WriteString("\n")
+			content.WriteString(probes)
+		}
 		content.WriteString("\n")
 		content.WriteString(m.renderLabels())
 		content.WriteString("\n")
@@ -137,9 +362,15 @@ func (m *ManifestPanel) updateContent() {
 			content.WriteString("\n")
 			content.WriteString(m.renderRelated())
 		}
+
+	case ManifestViewVolumes:
+		content.WriteString(m.renderVolumes())
+
+	case ManifestViewHistory:
+		content.WriteString(m.renderHistory())
 	}
 
-	m.viewport.SetContent(content.String())
+	setViewportContent(&m.viewport, &m.lastHash, content.String())
 }
 
 func (m ManifestPanel) renderPodInfo() string {
@@ -149,6 +380,9 @@ func (m ManifestPanel) renderPodInfo() string {
 	b.WriteString(fmt.Sprintf("  Name:      %s\n", m.pod.Name))
 	b.WriteString(fmt.Sprintf("  Namespace: %s\n", m.pod.Namespace))
 	b.WriteString(fmt.Sprintf("  Node:      %s\n", m.pod.Node))
+	if m.node != nil && m.node.ContainerRuntime != "" {
+		b.WriteString(fmt.Sprintf("  Runtime:   %s (%s, %s)\n", m.node.ContainerRuntime, m.node.KubeletVersion, m.node.Architecture))
+	}
 	b.WriteString(fmt.Sprintf("  IP:        %s\n", m.pod.IP))
 
 	statusStyle := styles.GetStatusStyle(m.pod.Status)
@@ -156,6 +390,9 @@ func (m ManifestPanel) renderPodInfo() string {
 	b.WriteString(fmt.Sprintf("  Ready:     %s\n", m.pod.Ready))
 	b.WriteString(fmt.Sprintf("  Restarts:  %d\n", m.pod.Restarts))
 	b.WriteString(fmt.Sprintf("  Age:       %s\n", m.pod.Age))
+	if priority := k8s.PodPriority(m.pod.Object); priority != "" {
+		b.WriteString(fmt.Sprintf("  Priority:  %s\n", priority))
+	}
 
 	if m.pod.OwnerRef != "" {
 		b.WriteString(fmt.Sprintf("  Owner:     %s/%s\n", m.pod.OwnerKind, m.pod.OwnerRef))
@@ -186,6 +423,370 @@ func (m ManifestPanel) renderHelpers() string {
 	return b.String()
 }
 
+// renderLint lists the best practice findings, kept apart from the debug
+// hints since none of them is a failure
+func (m ManifestPanel) renderLint() string {
+	var b strings.Builder
+
+	b.WriteString(styles.SubtitleStyle.Render("Best Practices (b to hide)\n"))
+	for _, helper := range m.lint {
+		b.WriteString(styles.StatusMuted.Render(fmt.Sprintf("  [%s] %s\n", helper.Severity, helper.Issue)))
+		for _, suggestion := range helper.Suggestions {
+			b.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf("    • %s\n", suggestion)))
+		}
+	}
+
+	return b.String()
+}
+
+// renderVulnerabilities shows the CVE counts trivy-operator found in each
+// container's image, with critical ones called out
+func (m ManifestPanel) renderVulnerabilities() string {
+	var b strings.Builder
+
+	b.WriteString(styles.SubtitleStyle.Render("Vulnerabilities (trivy-operator)\n"))
+	for _, v := range m.vulns {
+		r := v.Report
+		severity := styles.StatusRunning
+		switch {
+		case r.Critical > 0:
+			severity = styles.StatusError
+		case r.High > 0:
+			severity = styles.EventWarning
+		}
+		b.WriteString(fmt.Sprintf("  %s %s\n", v.Container, styles.StatusMuted.Render(r.Image)))
+		b.WriteString(severity.Render(fmt.Sprintf("    %d critical, %d high", r.Critical, r.High)))
+		b.WriteString(styles.StatusMuted.Render(fmt.Sprintf(", %d medium, %d low", r.Medium, r.Low)))
+		if !r.Updated.IsZero() {
+			b.WriteString(styles.StatusMuted.Render(" • scanned " + k8s.FormatDuration(time.Since(r.Updated)) + " ago"))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderVolumes lists each volume with its source and mounts, flagging
+// volumes whose backing object is missing or unbound
+func (m ManifestPanel) renderVolumes() string {
+	var b strings.Builder
+
+	b.WriteString(styles.SubtitleStyle.Render("Volumes\n"))
+	if len(m.volumes) == 0 {
+		b.WriteString("  <none>\n")
+		return b.String()
+	}
+
+	for _, v := range m.volumes {
+		line := fmt.Sprintf("  %s  %s", v.Name, v.Type)
+		if v.Source != "" {
+			line += " " + v.Source
+		}
+		if v.Issue != "" {
+			b.WriteString(styles.StatusError.Render("✗ "+strings.TrimPrefix(line, "  ")) + "\n")
+		} else {
+			b.WriteString(styles.LogContainer.Render(line) + "\n")
+		}
+		if v.Status != "" {
+			b.WriteString(styles.StatusMuted.Render("    "+v.Status) + "\n")
+		}
+		if v.Issue != "" {
+			b.WriteString(styles.StatusError.Render("    "+v.Issue) + "\n")
+		}
+
+		if len(v.Mounts) == 0 {
+			b.WriteString(styles.StatusMuted.Render("    not mounted") + "\n")
+		}
+		for _, mount := range v.Mounts {
+			path := mount.Path
+			if mount.SubPath != "" {
+				path += " (subPath " + mount.SubPath + ")"
+			}
+			mode := "rw"
+			if mount.ReadOnly {
+				mode = "ro"
+			}
+			b.WriteString(fmt.Sprintf("    %-12s %s %s\n", mount.Container, mode, path))
+		}
+	}
+
+	return b.String()
+}
+
+func (m ManifestPanel) volumeIssues() int {
+	n := 0
+	for _, v := range m.volumes {
+		if v.Issue != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// renderHistory lists the owner Deployment's revisions, marking the one
+// running now
+func (m ManifestPanel) renderHistory() string {
+	var b strings.Builder
+
+	b.WriteString(styles.SubtitleStyle.Render("Rollout History\n"))
+	if len(m.rollout) == 0 {
+		b.WriteString(styles.StatusMuted.Render("  Not managed by a Deployment") + "\n")
+		return b.String()
+	}
+	for _, line := range strings.Split(strings.TrimRight(k8s.FormatRolloutHistory(m.rollout), "\n"), "\n") {
+		if strings.HasPrefix(line, "→") {
+			line = styles.StatusRunning.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(styles.HelpDescStyle.Render("  H on the deployment in the list to undo to a revision") + "\n")
+
+	return b.String()
+}
+
+// renderStartup breaks the time to ready down by phase, with a bar for each
+// phase's share so the slow one stands out
+func (m ManifestPanel) renderStartup() string {
+	var b strings.Builder
+
+	var total time.Duration
+	done := true
+	for _, p := range m.startup {
+		total += p.Duration
+		done = done && p.Done
+	}
+	title := "Startup: " + k8s.FormatDuration(total) + " to ready"
+	if !done {
+		title = "Startup: " + k8s.FormatDuration(total) + " so far"
+	}
+	b.WriteString(styles.SubtitleStyle.Render(title + "\n"))
+
+	const barWidth = 20
+	for _, p := range m.startup {
+		bar := 0
+		if total > 0 {
+			bar = int(int64(barWidth) * int64(p.Duration) / int64(total))
+		}
+		line := fmt.Sprintf("  %-16s %7s %s", p.Name, k8s.FormatDuration(p.Duration), strings.Repeat("█", bar))
+		if !p.Done {
+			b.WriteString(styles.StatusPending.Render(line+" (in progress)") + "\n")
+			continue
+		}
+		b.WriteString(line)
+		if p.Note != "" {
+			b.WriteString(styles.StatusMuted.Render(" " + p.Note))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderPlacement explains why the pod runs on its node: which selector
+// and affinity terms the node matches, which taints the pod tolerates and
+// which topology spread domain it is counted in
+func (m ManifestPanel) renderPlacement() string {
+	var b strings.Builder
+
+	b.WriteString(styles.SubtitleStyle.Render("Placement\n"))
+	if len(m.node.Placement) == 0 {
+		b.WriteString(styles.StatusMuted.Render("  No node constraints; the scheduler chose " + m.node.Name + " by score") + "\n")
+		return b.String()
+	}
+	for _, r := range m.node.Placement {
+		mark, style := "✓", styles.StatusRunning
+		if !r.Matched {
+			mark, style = "✗", styles.StatusMuted
+		}
+		kind := r.Kind
+		if r.Weight > 0 {
+			kind = fmt.Sprintf("%s (weight %d)", r.Kind, r.Weight)
+		}
+		b.WriteString(fmt.Sprintf("  %s %s: %s\n", style.Render(mark), kind, r.Detail))
+	}
+	if matched, total := k8s.PreferenceScore(m.node.Placement); total > 0 {
+		b.WriteString(styles.StatusMuted.Render(fmt.Sprintf("  Node matches %d of %d preferred affinity weight", matched, total)) + "\n")
+	}
+
+	return b.String()
+}
+
+// renderNetwork shows the pod's addresses, DNS settings and the cluster IPs
+// of the Services selecting it, with hints for DNS setups that misbehave
+func (m ManifestPanel) renderNetwork() string {
+	var b strings.Builder
+	n := k8s.GetPodNetwork(m.pod.Object)
+
+	b.WriteString(styles.SubtitleStyle.Render("Network\n"))
+	ips := "none yet"
+	if len(n.IPs) > 0 {
+		ips = k8s.IPFamilies(n.IPs)
+	}
+	b.WriteString(fmt.Sprintf("  IPs:          %s\n", ips))
+	hostNetwork := "no"
+	if n.HostNetwork {
+		hostNetwork = "yes, the node's network namespace"
+	}
+	b.WriteString(fmt.Sprintf("  Host network: %s\n", hostNetwork))
+	b.WriteString(fmt.Sprintf("  DNS policy:   %s\n", n.DNSPolicy))
+	if len(n.Nameservers) > 0 {
+		b.WriteString(fmt.Sprintf("  Nameservers:  %s\n", strings.Join(n.Nameservers, ", ")))
+	}
+	if len(n.Searches) > 0 {
+		b.WriteString(fmt.Sprintf("  Searches:     %s\n", strings.Join(n.Searches, " ")))
+	}
+	if len(n.Options) > 0 {
+		b.WriteString(fmt.Sprintf("  DNS options:  %s\n", strings.Join(n.Options, " ")))
+	}
+	if m.related != nil {
+		for _, svc := range m.related.Services {
+			ips := svc.ClusterIP
+			if len(svc.ClusterIPs) > 1 {
+				ips = strings.Join(svc.ClusterIPs, ", ")
+			}
+			b.WriteString(fmt.Sprintf("  Service:      %s %s (%s)\n", svc.Name, ips, svc.Type))
+		}
+	}
+	for _, hint := range n.Hints {
+		b.WriteString(styles.StatusPending.Render("  ! "+hint) + "\n")
+	}
+
+	return b.String()
+}
+
+// renderTerminationMessages shows what crashed containers wrote to their
+// termination message path, which often is the fatal error itself
+func (m ManifestPanel) renderTerminationMessages() string {
+	messages := k8s.TerminationMessages(m.pod.Object)
+	if len(messages) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.StatusError.Render("Termination Messages\n"))
+	for _, t := range messages {
+		header := "  " + t.Container
+		if t.Init {
+			header += " (init)"
+		}
+		header += fmt.Sprintf("  exit %d", t.ExitCode)
+		if meaning := t.ExitMeaning(); meaning != "" {
+			header += " " + meaning
+		}
+		if t.Reason != "" {
+			header += ", " + t.Reason
+		}
+		if !t.FinishedAt.IsZero() {
+			header += " at " + t.FinishedAt.Local().Format("15:04:05")
+		}
+		b.WriteString(styles.LogContainer.Render(header) + "\n")
+		if t.MayBeLogTail() {
+			b.WriteString(styles.StatusMuted.Render("    (FallbackToLogsOnError: may be the log tail)") + "\n")
+		}
+		for _, line := range t.Lines {
+			b.WriteString(styles.RenderWithWidth(styles.StatusError, "    "+line, m.width-2) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// renderConfigDrift lists the ConfigMaps and Secrets changed since the
+// containers using them started, and how to pick the change up
+func (m ManifestPanel) renderConfigDrift() string {
+	var b strings.Builder
+
+	title := "Config drift: mounted files were updated in place"
+	for _, d := range m.drift {
+		if d.Stale() {
+			title = "Config drift: pod is running stale config"
+			break
+		}
+	}
+	b.WriteString(styles.StatusError.Render(title + "\n"))
+	for _, d := range m.drift {
+		b.WriteString(styles.LogContainer.Render(fmt.Sprintf("  %s %s", d.Kind, d.Name)))
+		b.WriteString(fmt.Sprintf("  %s via %s, changed %s after it started\n", d.Container, d.Via, k8s.FormatDuration(d.ChangedAt.Sub(d.StartedAt))))
+		if !d.Stale() {
+			b.WriteString(styles.StatusMuted.Render("    files are current; restart if the app reads them only at startup") + "\n")
+		}
+	}
+	b.WriteString(styles.SubtitleStyle.Render("    → a: diff running vs current, R: rollout restart the workload") + "\n")
+
+	return b.String()
+}
+
+// renderRestarts shows each restarted container's recent restarts, oldest first
+func (m ManifestPanel) renderRestarts() string {
+	var b strings.Builder
+
+	b.WriteString(styles.SubtitleStyle.Render("Restart History\n"))
+	for _, tl := range m.restarts {
+		line := fmt.Sprintf("  %s  %d restarts", tl.Container, tl.Restarts)
+		if tl.Interval > 0 {
+			line += ", every ~" + k8s.FormatDuration(tl.Interval)
+		}
+		b.WriteString(styles.LogContainer.Render(line) + "\n")
+
+		for _, e := range tl.Entries {
+			when := "--:--:--"
+			if !e.At.IsZero() {
+				when = e.At.Local().Format("15:04:05")
+			}
+			entry := fmt.Sprintf("    %s  %s", when, e.What)
+			if e.Ran > 0 {
+				entry += " after " + k8s.FormatDuration(e.Ran)
+			}
+			b.WriteString(styles.EventWarning.Render(entry) + "\n")
+			if e.Detail != "" {
+				b.WriteString(styles.StatusMuted.Render("              "+styles.Truncate(e.Detail, m.width-16)) + "\n")
+			}
+		}
+		if tl.Current != "" {
+			b.WriteString(fmt.Sprintf("    now       %s\n", tl.Current))
+		}
+		if tl.Cadence != "" {
+			b.WriteString(styles.SubtitleStyle.Render("    → "+tl.Cadence) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+func (m ManifestPanel) renderProvenance() string {
+	var b strings.Builder
+	p := m.related.Provenance
+
+	b.WriteString(styles.SubtitleStyle.Render("Source\n"))
+	b.WriteString(fmt.Sprintf("  From:      %s\n", p.Source))
+	if p.ManagedBy != "" {
+		b.WriteString(fmt.Sprintf("  Managed:   %s\n", p.ManagedBy))
+	}
+	if p.HelmChart != "" {
+		b.WriteString(fmt.Sprintf("  Chart:     %s\n", p.HelmChart))
+	}
+	if p.HelmRelease != "" {
+		b.WriteString(fmt.Sprintf("  Release:   %s\n", p.HelmRelease))
+	}
+	if p.KustomizeOrigin != "" {
+		b.WriteString(fmt.Sprintf("  Kustomize: %s\n", p.KustomizeOrigin))
+	}
+	if p.GitOps != "" {
+		b.WriteString(fmt.Sprintf("  GitOps:    %s\n", p.GitOps))
+	}
+
+	// A changed config checksum is the usual reason a Helm/Kustomize rollout happened
+	if len(p.ChangedChecksums) > 0 {
+		b.WriteString(styles.EventWarning.Render(fmt.Sprintf("  Rollout:   %s changed since revision %s\n",
+			strings.Join(p.ChangedChecksums, ", "), p.PreviousRevision)))
+	} else if len(p.Checksums) > 0 && p.PreviousRevision != "" {
+		b.WriteString(fmt.Sprintf("  Rollout:   config checksums unchanged since revision %s\n", p.PreviousRevision))
+	}
+
+	return b.String()
+}
+
 func (m ManifestPanel) renderContainers() string {
 	var b strings.Builder
 
@@ -195,6 +796,9 @@ func (m ManifestPanel) renderContainers() string {
 
 		b.WriteString(styles.LogContainer.Render(fmt.Sprintf("  %s\n", c.Name)))
 		b.WriteString(fmt.Sprintf("    Image:    %s\n", styles.Truncate(c.Image, m.width-14)))
+		if c.Digest != "" {
+			b.WriteString(fmt.Sprintf("    Digest:   %s\n", k8s.ShortDigest(c.Digest)))
+		}
 		b.WriteString(fmt.Sprintf("    State:    %s", stateStyle.Render(c.State)))
 		if c.Reason != "" {
 			b.WriteString(fmt.Sprintf(" (%s)", c.Reason))
@@ -215,6 +819,32 @@ func (m ManifestPanel) renderContainers() string {
 	return b.String()
 }
 
+// renderProbes lists each container's probes with their targets and timing
+func (m ManifestPanel) renderProbes() string {
+	probes := k8s.DescribeProbes(m.pod.Object)
+	if len(probes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.SubtitleStyle.Render("Probes"))
+	b.WriteString(styles.HelpDescStyle.Render(" (a: check live)\n"))
+	container := ""
+	for _, p := range probes {
+		if p.Container != container {
+			container = p.Container
+			b.WriteString(styles.LogContainer.Render("  "+container) + "\n")
+		}
+		b.WriteString(fmt.Sprintf("    %-10s %s\n", p.Kind, styles.Truncate(p.Target(), m.width-16)))
+		for _, hdr := range p.Headers {
+			b.WriteString(styles.StatusMuted.Render("               "+hdr) + "\n")
+		}
+		b.WriteString(styles.StatusMuted.Render("               "+p.Timing()) + "\n")
+	}
+
+	return b.String()
+}
+
 func (m ManifestPanel) renderRelated() string {
 	var b strings.Builder
 
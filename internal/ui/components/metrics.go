@@ -18,6 +18,8 @@ type MetricsPanel struct {
 	width     int
 	height    int
 	available bool
+	errMsg    string // last load error, shown in the header
+	lastHash  uint64 // hash of the content last set on the viewport
 }
 
 func NewMetricsPanel() MetricsPanel {
@@ -41,7 +43,9 @@ func (m MetricsPanel) View() string {
 
 	var header strings.Builder
 	header.WriteString(styles.PanelTitleStyle.Render("Resource Usage"))
-	if !m.available {
+	if m.errMsg != "" {
+		header.WriteString(styles.StatusError.Render(" [" + m.errMsg + "]"))
+	} else if !m.available {
 		header.WriteString(styles.SubtitleStyle.Render(" (metrics-server not available)"))
 	}
 	header.WriteString("\n")
@@ -55,12 +59,20 @@ func (m *MetricsPanel) SetMetrics(metrics *k8s.PodMetrics) {
 	m.updateContent()
 }
 
+// SetError shows msg in the header; pass "" to clear it
+func (m *MetricsPanel) SetError(msg string) {
+	m.errMsg = msg
+}
+
 func (m *MetricsPanel) SetPod(pod *k8s.PodInfo) {
 	m.pod = pod
 	m.updateContent()
 }
 
 func (m *MetricsPanel) SetSize(width, height int) {
+	if m.ready && width == m.width && height-2 == m.height {
+		return // called on every render; nothing to redo
+	}
 	m.width = width
 	m.height = height - 2
 
@@ -84,7 +96,7 @@ func (m *MetricsPanel) updateContent() {
 
 	if m.pod == nil {
 		content.WriteString(styles.StatusMuted.Render("No pod selected"))
-		m.viewport.SetContent(content.String())
+		setViewportContent(&m.viewport, &m.lastHash, content.String())
 		return
 	}
 
@@ -112,6 +124,22 @@ func (m *MetricsPanel) updateContent() {
 		content.WriteString("\n")
 	}
 
+	// Per-container numbers alone are hard to judge against node capacity or a quota
+	if len(m.pod.Containers) > 1 {
+		t := k8s.PodResourceTotals(m.pod, m.metrics)
+		content.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf("  Pod total (%d containers)\n", len(m.pod.Containers))))
+		content.WriteString(fmt.Sprintf("    CPU Request:    %s\n", formatResourceValue(t.CPURequest)))
+		content.WriteString(fmt.Sprintf("    CPU Limit:      %s\n", formatResourceValue(t.CPULimit)))
+		content.WriteString(fmt.Sprintf("    Memory Request: %s\n", formatResourceValue(t.MemoryRequest)))
+		content.WriteString(fmt.Sprintf("    Memory Limit:   %s\n", formatResourceValue(t.MemoryLimit)))
+		if t.CPUUsage != "" {
+			content.WriteString("\n")
+			content.WriteString(styles.StatusRunning.Render(fmt.Sprintf("    CPU Usage:      %s\n", t.CPUUsage)))
+			content.WriteString(styles.StatusRunning.Render(fmt.Sprintf("    Memory Usage:   %s\n", t.MemoryUsage)))
+		}
+		content.WriteString("\n")
+	}
+
 	if m.metrics == nil && m.available {
 		content.WriteString(styles.StatusMuted.Render("\n  Waiting for metrics data..."))
 	}
@@ -124,7 +152,7 @@ func (m *MetricsPanel) updateContent() {
 		}
 	}
 
-	m.viewport.SetContent(content.String())
+	setViewportContent(&m.viewport, &m.lastHash, content.String())
 }
 
 func (m MetricsPanel) checkResourceIssues() []string {
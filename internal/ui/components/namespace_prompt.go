@@ -0,0 +1,133 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+// NamespacePromptResult is returned when the user submits a new namespace
+type NamespacePromptResult struct {
+	Name   string
+	Labels map[string]string
+}
+
+// NamespacePrompt asks for the name and optional labels of a namespace to
+// create
+type NamespacePrompt struct {
+	name    textinput.Model
+	labels  textinput.Model
+	errMsg  string
+	visible bool
+}
+
+func NewNamespacePrompt() NamespacePrompt {
+	name := textinput.New()
+	name.Placeholder = "scratch-debug"
+	name.CharLimit = 63
+	name.Width = 32
+
+	labels := textinput.New()
+	labels.Placeholder = "owner=me, purpose=debug"
+	labels.CharLimit = 256
+	labels.Width = 32
+
+	return NamespacePrompt{name: name, labels: labels}
+}
+
+func (p *NamespacePrompt) Show() {
+	p.name.SetValue("")
+	p.labels.SetValue("")
+	p.errMsg = ""
+	p.labels.Blur()
+	p.name.Focus()
+	p.visible = true
+}
+
+func (p *NamespacePrompt) Hide() {
+	p.visible = false
+	p.name.Blur()
+	p.labels.Blur()
+}
+
+func (p NamespacePrompt) IsVisible() bool {
+	return p.visible
+}
+
+func (p NamespacePrompt) Update(msg tea.Msg) (NamespacePrompt, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			p.Hide()
+			return p, nil
+		case "tab", "shift+tab", "up", "down":
+			if p.name.Focused() {
+				p.name.Blur()
+				p.labels.Focus()
+			} else {
+				p.labels.Blur()
+				p.name.Focus()
+			}
+			return p, nil
+		case "enter":
+			name := strings.TrimSpace(p.name.Value())
+			labels, err := k8s.ParseNamespaceSpec(name, p.labels.Value())
+			if err != nil {
+				p.errMsg = err.Error()
+				return p, nil
+			}
+			p.Hide()
+			result := NamespacePromptResult{Name: name, Labels: labels}
+			return p, func() tea.Msg { return result }
+		}
+	}
+
+	var cmd tea.Cmd
+	if p.name.Focused() {
+		p.name, cmd = p.name.Update(msg)
+	} else {
+		p.labels, cmd = p.labels.Update(msg)
+	}
+	return p, cmd
+}
+
+func (p NamespacePrompt) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	b.WriteString(titleStyle.Render("Create Namespace"))
+	b.WriteString("\n\n")
+
+	b.WriteString(styles.HelpKeyStyle.Render("name   "))
+	b.WriteString(p.name.View())
+	b.WriteString("\n")
+	b.WriteString(styles.HelpKeyStyle.Render("labels "))
+	b.WriteString(p.labels.View())
+	b.WriteString("\n")
+	if p.errMsg != "" {
+		b.WriteString(styles.StatusError.Render(p.errMsg))
+		b.WriteString("\n")
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("labels are optional • Tab to switch • Enter to create • Esc to cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Background(styles.Background)
+	return boxStyle.Render(b.String())
+}
@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -20,23 +21,87 @@ const (
 	ModePods
 	ModeNamespace
 	ModeResourceType
+	ModeContext
 )
 
+// Lists at least this long are re-filtered only after typing pauses for
+// filterDebounce, so each keystroke doesn't rescan thousands of rows
+const (
+	filterDebounce      = 150 * time.Millisecond
+	filterDebounceItems = 500
+)
+
+type filterDebounceMsg struct {
+	seq int
+}
+
 type Navigator struct {
-	workloads    []k8s.WorkloadInfo
-	pods         []k8s.PodInfo
-	namespaces   []string
-	cursor       int
-	mode         NavigatorMode
-	width        int
-	height       int
-	searchInput  textinput.Model
-	searching    bool
-	searchQuery  string
-	resourceType k8s.ResourceType
-	keys         keys.KeyMap
+	workloads     []k8s.WorkloadInfo
+	pods          []k8s.PodInfo
+	namespaces    []string
+	cursor        int
+	mode          NavigatorMode
+	width         int
+	height        int
+	searchInput   textinput.Model
+	searching     bool
+	searchQuery   string
+	labelFilter   *k8s.LabelFilter // narrows workloads until cleared with c
+	resourceType  k8s.ResourceType
+	resourceTypes []k8s.ResourceType // offered in ModeResourceType, in order
+	keys          keys.KeyMap
+	watched       map[string]bool
+
+	// Lowercased search text per item, built once when the list is set
+	workloadKeys  []string
+	podKeys       []string
+	namespaceKeys []string
+
+	// Filter results, recomputed only when the data or the query changes
+	shownWorkloads  []k8s.WorkloadInfo
+	shownPods       []k8s.PodInfo
+	shownNamespaces []string
+
+	filterSeq int // bumped per keystroke so stale debounce ticks are ignored
+
+	// Why a list could not be loaded (e.g. "forbidden"), shown in its place
+	unavailable     map[k8s.ResourceType]string
+	podsUnavailable string
+
+	loading bool // first workload list not loaded yet; rows render as skeletons
+
+	// Items a watch reported deleted; they stay dimmed until PruneDeleted
+	deletedWorkloads map[string]bool
+	deletedPods      map[string]bool
+
+	// Pods marked for side-by-side comparison, oldest first
+	marked []k8s.PodInfo
+
+	// Kubeconfig contexts for ModeContext
+	contexts       []string
+	currentContext string
+
+	// Rollout state and claims when the pods belong to a StatefulSet, and
+	// the ordinal it waits on, recomputed as the pods change
+	statefulSet   *k8s.StatefulSetStatus
+	blockedPod    string
+	blockedReason string
+
+	// Extra columns from labels and annotations, shown for workloads and pods
+	columns []k8s.MetadataColumn
+
+	// Recent events of the workload whose pods are listed, newest first
+	workloadEvents []k8s.EventInfo
 }
 
+// maxMarkedPods is how many pods can be marked; a comparison takes two
+const maxMarkedPods = 2
+
+const skeletonRows = 6
+
+// maxWorkloadEvents is how many workload events the pod list shows below it
+const maxWorkloadEvents = 5
+
 func NewNavigator() Navigator {
 	ti := textinput.New()
 	ti.Placeholder = "type to filter..."
@@ -44,9 +109,10 @@ func NewNavigator() Navigator {
 	ti.Width = 30
 
 	return Navigator{
-		resourceType: k8s.ResourceDeployments,
-		searchInput:  ti,
-		keys:         keys.DefaultKeyMap(),
+		resourceType:  k8s.ResourceDeployments,
+		resourceTypes: k8s.AllResourceTypes,
+		searchInput:   ti,
+		keys:          keys.DefaultKeyMap(),
 	}
 }
 
@@ -63,14 +129,19 @@ func (n Navigator) Update(msg tea.Msg) (Navigator, tea.Cmd) {
 		if n.searching {
 			switch msg.String() {
 			case "enter", "esc":
-				n.searching = false
-				n.searchQuery = n.searchInput.Value()
-				n.cursor = 0 // Reset cursor after filter
+				n.CloseSearch()
 			default:
 				n.searchInput, cmd = n.searchInput.Update(msg)
-				// Live filter as user types
-				n.searchQuery = n.searchInput.Value()
-				n.cursor = 0
+				// Live filter as user types; large lists wait for a pause
+				if n.listSize() < filterDebounceItems {
+					n.setQuery(n.searchInput.Value())
+					return n, cmd
+				}
+				n.filterSeq++
+				seq := n.filterSeq
+				return n, tea.Batch(cmd, tea.Tick(filterDebounce, func(time.Time) tea.Msg {
+					return filterDebounceMsg{seq: seq}
+				}))
 			}
 			return n, cmd
 		}
@@ -98,8 +169,14 @@ func (n Navigator) Update(msg tea.Msg) (Navigator, tea.Cmd) {
 			n.searchInput.Focus()
 			return n, textinput.Blink
 		case key.Matches(msg, n.keys.Clear):
+			n.labelFilter = nil
 			n.ClearSearch()
 		}
+
+	case filterDebounceMsg:
+		if msg.seq == n.filterSeq && n.searching {
+			n.setQuery(n.searchInput.Value())
+		}
 	}
 
 	return n, nil
@@ -145,7 +222,22 @@ func (n Navigator) maxItems() int {
 	case ModeNamespace:
 		return len(n.filteredNamespaces())
 	case ModeResourceType:
-		return len(k8s.AllResourceTypes)
+		return len(n.resourceTypes)
+	case ModeContext:
+		return len(n.contexts)
+	}
+	return 0
+}
+
+// listSize is the unfiltered length of the list shown in the current mode
+func (n Navigator) listSize() int {
+	switch n.mode {
+	case ModeWorkloads:
+		return len(n.workloads)
+	case ModePods:
+		return len(n.pods)
+	case ModeNamespace:
+		return len(n.namespaces)
 	}
 	return 0
 }
@@ -165,12 +257,19 @@ func (n Navigator) View() string {
 			Padding(0, 1)
 		b.WriteString(searchStyle.Render("/ " + n.searchInput.View()))
 		b.WriteString("\n\n")
-	} else if n.searchQuery != "" {
+	} else if n.searchQuery != "" || n.showsLabelFilter() {
 		filterStyle := lipgloss.NewStyle().
 			Foreground(styles.Secondary).
 			Bold(true)
 		clearHint := styles.HelpDescStyle.Render(" (c to clear)")
-		b.WriteString(filterStyle.Render(fmt.Sprintf("Filter: %s", n.searchQuery)))
+		var filters []string
+		if n.showsLabelFilter() {
+			filters = append(filters, "Labels: "+n.labelFilter.String())
+		}
+		if n.searchQuery != "" {
+			filters = append(filters, "Filter: "+n.searchQuery)
+		}
+		b.WriteString(filterStyle.Render(strings.Join(filters, "  ")))
 		b.WriteString(clearHint)
 		b.WriteString("\n\n")
 	} else {
@@ -187,6 +286,8 @@ func (n Navigator) View() string {
 		b.WriteString(n.renderNamespaces())
 	case ModeResourceType:
 		b.WriteString(n.renderResourceTypes())
+	case ModeContext:
+		b.WriteString(n.renderContexts())
 	}
 
 	return b.String()
@@ -208,18 +309,42 @@ func (n Navigator) renderHeader() string {
 	case ModeResourceType:
 		icon = "◆"
 		title = "SELECT RESOURCE TYPE"
+	case ModeContext:
+		icon = "⎈"
+		title = "SELECT CONTEXT"
 	}
 
 	iconStyle := lipgloss.NewStyle().Foreground(styles.Primary).Bold(true)
 	titleStyle := lipgloss.NewStyle().Foreground(styles.Text).Bold(true)
 
-	return iconStyle.Render(icon) + " " + titleStyle.Render(title)
+	header := iconStyle.Render(icon) + " " + titleStyle.Render(title)
+	if n.mode == ModePods && n.statefulSet != nil {
+		header += n.renderStatefulSetHint()
+	}
+	if n.mode == ModeNamespace {
+		header += styles.HelpDescStyle.Render("  [+ new, a actions, ctrl+d delete empty]")
+	}
+	if n.mode == ModePods && len(n.marked) > 0 {
+		hint := fmt.Sprintf("  [%d/%d marked", len(n.marked), maxMarkedPods)
+		if len(n.marked) == maxMarkedPods {
+			hint += ", x:compare"
+		}
+		header += styles.HelpDescStyle.Render(hint + "]")
+	}
+	return header
 }
 
 func (n Navigator) renderWorkloads() string {
+	if reason := n.unavailable[n.resourceType]; reason != "" {
+		return styles.StatusError.Render(fmt.Sprintf("  %s unavailable: %s", n.resourceType, reason))
+	}
+
 	workloads := n.filteredWorkloads()
+	if n.loading && len(workloads) == 0 {
+		return n.renderSkeleton()
+	}
 	if len(workloads) == 0 {
-		if n.searchQuery != "" {
+		if n.searchQuery != "" || n.labelFilter != nil {
 			return styles.StatusMuted.Render("  No workloads match filter")
 		}
 		return styles.StatusMuted.Render("  No workloads found")
@@ -228,7 +353,7 @@ func (n Navigator) renderWorkloads() string {
 	var b strings.Builder
 
 	// Header
-	header := fmt.Sprintf("  %-32s %-10s %-15s %-8s", "NAME", "READY", "STATUS", "AGE")
+	header := fmt.Sprintf("    %-32s %-10s %-15s %-8s", "NAME", "READY", "STATUS", "AGE") + n.workloadExtraHeader()
 	b.WriteString(styles.TableHeaderStyle.Render(header))
 	b.WriteString("\n")
 
@@ -242,9 +367,38 @@ func (n Navigator) renderWorkloads() string {
 
 	// Scroll indicator
 	b.WriteString(n.renderScrollIndicator(visible, len(workloads)))
+	b.WriteString(n.renderStatusMessage())
 	return b.String()
 }
 
+// renderStatusMessage explains the selected workload's status, e.g. why a
+// Deployment is stuck, from the condition it was derived from
+func (n Navigator) renderStatusMessage() string {
+	if n.statusMessageRows() == 0 {
+		return ""
+	}
+	w := n.SelectedWorkload()
+	if w == nil || w.Message == "" {
+		return "\n"
+	}
+	line := styles.Truncate(fmt.Sprintf("  %s: %s", w.Status, strings.ReplaceAll(w.Message, "\n", " ")), max(40, n.width-2))
+	return "\n" + styles.GetStatusStyle(w.Status).Render(line)
+}
+
+// statusMessageRows is how many lines renderStatusMessage takes, kept while
+// any workload has a message so the list does not jump with the cursor
+func (n Navigator) statusMessageRows() int {
+	if n.mode != ModeWorkloads {
+		return 0
+	}
+	for _, w := range n.filteredWorkloads() {
+		if w.Message != "" {
+			return 1
+		}
+	}
+	return 0
+}
+
 func (n Navigator) renderWorkloadRow(w k8s.WorkloadInfo, selected bool) string {
 	cursor := "  "
 	if selected {
@@ -253,18 +407,103 @@ func (n Navigator) renderWorkloadRow(w k8s.WorkloadInfo, selected bool) string {
 
 	name := styles.Truncate(w.Name, 32)
 	statusStyle := styles.GetStatusStyle(w.Status)
+	marker := n.watchMarker(k8s.WatchKey(w.Namespace, w.Type, w.Name))
+	extra := n.workloadExtraColumns(w)
+
+	if n.deletedWorkloads[w.Name] {
+		return styles.StatusMuted.Render(fmt.Sprintf("%s%s%-32s %-10s %-15s %-8s%s",
+			cursor, marker, name, w.Ready, "Deleted", w.Age, extra))
+	}
 
 	if selected {
 		rowStyle := lipgloss.NewStyle().Background(styles.Surface)
-		return rowStyle.Render(fmt.Sprintf("%s%-32s %-10s %-15s %-8s",
-			cursor, name, w.Ready, statusStyle.Render(w.Status), w.Age))
+		return rowStyle.Render(fmt.Sprintf("%s%s%-32s %-10s %-15s %-8s%s",
+			cursor, marker, name, w.Ready, statusStyle.Render(w.Status), w.Age, extra))
+	}
+
+	return fmt.Sprintf("%s%s%-32s %-10s %-15s %-8s%s",
+		cursor, marker, name, w.Ready, statusStyle.Render(w.Status), w.Age, extra)
+}
+
+// workloadImageColumns reports whether the resource type has a pod template
+// to take the IMAGE column from
+func (n Navigator) workloadImageColumns() bool {
+	return n.resourceType != k8s.ResourceServices && n.resourceType != k8s.ResourceNodes
+}
+
+// workloadExtraHeader heads the REV (Deployments only), LAST and NEXT
+// (CronJobs only), configured and IMAGE columns
+func (n Navigator) workloadExtraHeader() string {
+	var h string
+	if n.resourceType == k8s.ResourceDeployments {
+		h += fmt.Sprintf(" %-4s", "REV")
+	}
+	if n.resourceType == k8s.ResourceCronJobs {
+		h += fmt.Sprintf(" %-8s %-8s", "LAST", "NEXT")
+	}
+	h += n.metadataHeader()
+	if n.workloadImageColumns() {
+		h += " IMAGE"
+	}
+	return h
+}
+
+// workloadExtraColumns renders the REV, LAST/NEXT, configured and IMAGE
+// cells, the image cut to the width left after the fixed columns
+func (n Navigator) workloadExtraColumns(w k8s.WorkloadInfo) string {
+	var cols string
+	used := 76 // the fixed columns plus the panel border
+	if n.resourceType == k8s.ResourceDeployments {
+		rev := w.Revision
+		if rev == "" {
+			rev = "-"
+		}
+		cols += fmt.Sprintf(" %-4s", rev)
+		used += 5
 	}
+	if n.resourceType == k8s.ResourceCronJobs {
+		last, next := "-", "-"
+		if !w.LastSchedule.IsZero() {
+			last = k8s.FormatDuration(time.Since(w.LastSchedule))
+		}
+		if !w.NextSchedule.IsZero() {
+			next = k8s.FormatDuration(time.Until(w.NextSchedule))
+		}
+		cols += fmt.Sprintf(" %-8s %-8s", last, next)
+		used += 18
+	}
+	cols += n.metadataCells(w.ObjectLabels, w.Annotations)
+	for _, c := range n.columns {
+		used += c.ColumnWidth() + 1
+	}
+	if n.workloadImageColumns() {
+		cols += " " + styles.Truncate(k8s.ShortImage(w.Image), max(16, n.width-used))
+	}
+	return cols
+}
 
-	return fmt.Sprintf("%s%-32s %-10s %-15s %-8s",
-		cursor, name, w.Ready, statusStyle.Render(w.Status), w.Age)
+// renderSkeleton draws placeholder rows while the first list is in flight,
+// so the UI is usable before the API answers
+func (n Navigator) renderSkeleton() string {
+	var b strings.Builder
+	header := fmt.Sprintf("    %-32s %-10s %-15s %-8s", "NAME", "READY", "STATUS", "AGE") + n.workloadExtraHeader()
+	b.WriteString(styles.TableHeaderStyle.Render(header))
+	b.WriteString("\n")
+
+	for i := 0; i < skeletonRows; i++ {
+		name := strings.Repeat("░", 24-(i*5)%12)
+		row := fmt.Sprintf("    %-32s %-10s %-15s %-8s", name, "░░░", "░░░░░░░", "░░")
+		b.WriteString(styles.StatusMuted.Render(row))
+		b.WriteString("\n")
+	}
+	return b.String()
 }
 
 func (n Navigator) renderPods() string {
+	if n.podsUnavailable != "" {
+		return styles.StatusError.Render("  pods unavailable: " + n.podsUnavailable)
+	}
+
 	pods := n.filteredPods()
 	if len(pods) == 0 {
 		if n.searchQuery != "" {
@@ -276,7 +515,10 @@ func (n Navigator) renderPods() string {
 	var b strings.Builder
 
 	// Header
-	header := fmt.Sprintf("  %-38s %-8s %-18s %-8s %-6s", "NAME", "READY", "STATUS", "RESTARTS", "AGE")
+	header := fmt.Sprintf("    %-38s %-8s %-18s %-8s %-6s", "NAME", "READY", "STATUS", "RESTARTS", "AGE") + n.metadataHeader()
+	if n.statefulSet != nil {
+		header += n.statefulSetHeader()
+	}
 	b.WriteString(styles.TableHeaderStyle.Render(header))
 	b.WriteString("\n")
 
@@ -290,9 +532,41 @@ func (n Navigator) renderPods() string {
 
 	// Scroll indicator
 	b.WriteString(n.renderScrollIndicator(visible, len(pods)))
+	b.WriteString(n.renderWorkloadEvents())
 	return b.String()
 }
 
+// renderWorkloadEvents lists the newest events of the workload, its
+// ReplicaSets or Jobs and its pods below the pod list, where a FailedCreate
+// or a failing rollout shows before picking a pod
+func (n Navigator) renderWorkloadEvents() string {
+	if len(n.workloadEvents) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(styles.TableHeaderStyle.Render(fmt.Sprintf("    %-6s %-8s %-20s %s", "AGE", "TYPE", "REASON", "OBJECT: MESSAGE")))
+	b.WriteString("\n")
+	for _, e := range n.workloadEvents[:min(len(n.workloadEvents), maxWorkloadEvents)] {
+		line := fmt.Sprintf("    %-6s %-8s %-20s %s: %s", e.Age, e.Type, styles.Truncate(e.Reason, 20), e.Object, strings.ReplaceAll(e.Message, "\n", " "))
+		line = styles.Truncate(line, max(40, n.width-2))
+		if e.Type == "Warning" {
+			line = styles.EventWarning.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// workloadEventRows is how many lines renderWorkloadEvents takes
+func (n Navigator) workloadEventRows() int {
+	if n.mode != ModePods || len(n.workloadEvents) == 0 {
+		return 0
+	}
+	return min(len(n.workloadEvents), maxWorkloadEvents) + 2
+}
+
 func (n Navigator) renderPodRow(p k8s.PodInfo, selected bool) string {
 	cursor := "  "
 	if selected {
@@ -307,14 +581,111 @@ func (n Navigator) renderPodRow(p k8s.PodInfo, selected bool) string {
 		restarts = styles.StatusError.Render(restarts)
 	}
 
+	marker := n.watchMarker(k8s.WatchKey(p.Namespace, k8s.ResourcePods, p.Name))
+	if n.isMarked(p) {
+		marker = styles.CursorStyle.Render("◆ ")
+	}
+
+	extra := n.metadataCells(p.Labels, p.Annotations)
+	if n.deletedPods[p.Name] {
+		return styles.StatusMuted.Render(fmt.Sprintf("%s%s%-38s %-8s %-18s %-8d %-6s%s",
+			cursor, marker, name, p.Ready, "Deleted", p.Restarts, p.Age, extra))
+	}
+	if n.statefulSet != nil {
+		extra += n.statefulSetColumns(p)
+	}
+
 	if selected {
 		rowStyle := lipgloss.NewStyle().Background(styles.Surface)
-		return rowStyle.Render(fmt.Sprintf("%s%-38s %-8s %-18s %-8s %-6s",
-			cursor, name, p.Ready, statusStyle.Render(p.Status), restarts, p.Age))
+		return rowStyle.Render(fmt.Sprintf("%s%s%-38s %-8s %-18s %-8s %-6s%s",
+			cursor, marker, name, p.Ready, statusStyle.Render(p.Status), restarts, p.Age, extra))
+	}
+
+	return fmt.Sprintf("%s%s%-38s %-8s %-18s %-8s %-6s%s",
+		cursor, marker, name, p.Ready, statusStyle.Render(p.Status), restarts, p.Age, extra)
+}
+
+// metadataHeader heads the columns configured from labels and annotations
+func (n Navigator) metadataHeader() string {
+	var h string
+	for _, c := range n.columns {
+		h += fmt.Sprintf(" %-*s", c.ColumnWidth(), styles.Truncate(c.Title(), c.ColumnWidth()))
+	}
+	return h
+}
+
+// metadataCells renders an object's value for each configured column, cut
+// to the column's width
+func (n Navigator) metadataCells(labels, annotations map[string]string) string {
+	var cells string
+	for _, c := range n.columns {
+		cells += fmt.Sprintf(" %-*s", c.ColumnWidth(), styles.Truncate(c.Value(labels, annotations), c.ColumnWidth()))
+	}
+	return cells
+}
+
+// renderStatefulSetHint names the ordinal a StatefulSet is waiting on
+func (n Navigator) renderStatefulSetHint() string {
+	if n.blockedPod != "" {
+		return styles.EventWarning.Render(fmt.Sprintf("  [waiting on %s: %s]", n.blockedPod, n.blockedReason))
+	}
+	if n.statefulSet.Rolling() {
+		updated := 0
+		for _, p := range n.pods {
+			if n.statefulSet.Updated(p) {
+				updated++
+			}
+		}
+		return styles.HelpDescStyle.Render(fmt.Sprintf("  [rolling update %d/%d]", updated, n.statefulSet.Replicas))
 	}
+	return ""
+}
+
+func (n Navigator) statefulSetHeader() string {
+	header := ""
+	if n.statefulSet.Rolling() {
+		header += fmt.Sprintf(" %-4s", "REV")
+	}
+	if len(n.statefulSet.ClaimTemplates) > 0 {
+		header += " PVC"
+	}
+	return header
+}
+
+// statefulSetColumns shows the pod's revision during a rollout and the
+// claims bound to its ordinal
+func (n Navigator) statefulSetColumns(p k8s.PodInfo) string {
+	var b strings.Builder
+	if n.statefulSet.Rolling() {
+		if n.statefulSet.Updated(p) {
+			b.WriteString(fmt.Sprintf(" %-4s", "new"))
+		} else {
+			b.WriteString(styles.StatusMuted.Render(fmt.Sprintf(" %-4s", "old")))
+		}
+	}
+	for _, c := range n.statefulSet.PodClaims(p.Name) {
+		text := " " + c.Name + " " + c.Phase
+		if c.Capacity != "" {
+			text += " " + c.Capacity
+		}
+		if c.Phase == "Bound" {
+			b.WriteString(text)
+		} else {
+			b.WriteString(styles.StatusError.Render(text))
+		}
+	}
+	if p.Name == n.blockedPod {
+		b.WriteString(styles.EventWarning.Render(" ← blocking"))
+	}
+	return b.String()
+}
 
-	return fmt.Sprintf("%s%-38s %-8s %-18s %-8s %-6s",
-		cursor, name, p.Ready, statusStyle.Render(p.Status), restarts, p.Age)
+// watchMarker renders the two-column indicator shown before watched items
+func (n Navigator) watchMarker(key string) string {
+	if n.watched[key] {
+		return styles.EventWarning.Render("◉ ")
+	}
+	return "  "
 }
 
 func (n Navigator) renderNamespaces() string {
@@ -346,7 +717,7 @@ func (n Navigator) renderNamespaces() string {
 func (n Navigator) renderResourceTypes() string {
 	var b strings.Builder
 
-	for i, rt := range k8s.AllResourceTypes {
+	for i, rt := range n.resourceTypes {
 		cursor := "  "
 		if i == n.cursor {
 			cursor = styles.CursorStyle.Render("> ")
@@ -355,9 +726,40 @@ func (n Navigator) renderResourceTypes() string {
 		} else {
 			b.WriteString(cursor + string(rt))
 		}
+		if reason := n.unavailable[rt]; reason != "" {
+			b.WriteString(styles.StatusMuted.Render(" (" + reason + ")"))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (n Navigator) renderContexts() string {
+	if len(n.contexts) == 0 {
+		return styles.StatusMuted.Render("  No contexts found in kubeconfig")
+	}
+
+	var b strings.Builder
+	visible := n.visibleRange(len(n.contexts))
+
+	for i := visible.start; i < visible.end; i++ {
+		name := n.contexts[i]
+		if name == n.currentContext {
+			name += styles.StatusMuted.Render(" (current)")
+		}
+		cursor := "  "
+		if i == n.cursor {
+			cursor = styles.CursorStyle.Render("> ")
+			rowStyle := lipgloss.NewStyle().Background(styles.Surface)
+			b.WriteString(rowStyle.Render(cursor + name))
+		} else {
+			b.WriteString(cursor + name)
+		}
 		b.WriteString("\n")
 	}
 
+	b.WriteString(n.renderScrollIndicator(visible, len(n.contexts)))
 	return b.String()
 }
 
@@ -366,7 +768,7 @@ type visibleRange struct {
 }
 
 func (n Navigator) visibleRange(total int) visibleRange {
-	maxVisible := n.height - 8
+	maxVisible := n.height - 8 - n.workloadEventRows() - n.statusMessageRows()
 	if maxVisible < 5 {
 		maxVisible = 15
 	}
@@ -407,73 +809,337 @@ func (n Navigator) renderScrollIndicator(visible visibleRange, total int) string
 }
 
 func (n Navigator) filteredWorkloads() []k8s.WorkloadInfo {
-	if n.searchQuery == "" {
-		return n.workloads
-	}
-
-	query := strings.ToLower(n.searchQuery)
-	var filtered []k8s.WorkloadInfo
-	for _, w := range n.workloads {
-		if strings.Contains(strings.ToLower(w.Name), query) ||
-			strings.Contains(strings.ToLower(w.Status), query) {
-			filtered = append(filtered, w)
-		}
-	}
-	return filtered
+	return n.shownWorkloads
 }
 
 func (n Navigator) filteredPods() []k8s.PodInfo {
-	if n.searchQuery == "" {
-		return n.pods
+	return n.shownPods
+}
+
+func (n Navigator) filteredNamespaces() []string {
+	return n.shownNamespaces
+}
+
+// setQuery applies a new filter, keeping the selected item under the cursor
+// when it still matches
+func (n *Navigator) setQuery(query string) {
+	if query == n.searchQuery {
+		return
 	}
+	selected := n.selectedKey()
+	n.searchQuery = query
+	n.refilter()
+	n.selectKey(selected)
+}
 
+func (n *Navigator) refilter() {
 	query := strings.ToLower(n.searchQuery)
-	var filtered []k8s.PodInfo
-	for _, p := range n.pods {
-		if strings.Contains(strings.ToLower(p.Name), query) ||
-			strings.Contains(strings.ToLower(p.Status), query) ||
-			strings.Contains(strings.ToLower(p.Node), query) {
-			filtered = append(filtered, p)
+	n.shownWorkloads = n.labelFilter.Filter(filterByKey(n.workloads, n.workloadKeys, query))
+	n.shownPods = filterByKey(n.pods, n.podKeys, query)
+	n.shownNamespaces = filterByKey(n.namespaces, n.namespaceKeys, query)
+}
+
+func filterByKey[T any](items []T, keys []string, query string) []T {
+	if query == "" {
+		return items
+	}
+	var filtered []T
+	for i, k := range keys {
+		if strings.Contains(k, query) {
+			filtered = append(filtered, items[i])
 		}
 	}
 	return filtered
 }
 
-func (n Navigator) filteredNamespaces() []string {
-	if n.searchQuery == "" {
-		return n.namespaces
+// selectedKey identifies the item under the cursor so it can be found again
+// after the list is filtered or refreshed
+func (n Navigator) selectedKey() string {
+	switch n.mode {
+	case ModeWorkloads:
+		if w := n.SelectedWorkload(); w != nil {
+			return w.Name
+		}
+	case ModePods:
+		if p := n.SelectedPod(); p != nil {
+			return p.Name
+		}
+	case ModeNamespace:
+		return n.SelectedNamespace()
 	}
+	return ""
+}
 
-	query := strings.ToLower(n.searchQuery)
-	var filtered []string
-	for _, ns := range n.namespaces {
-		if strings.Contains(strings.ToLower(ns), query) {
-			filtered = append(filtered, ns)
+// selectKey moves the cursor to the item named key, or to the top when it is gone
+func (n *Navigator) selectKey(key string) {
+	n.cursor = 0
+	if key == "" {
+		return
+	}
+	switch n.mode {
+	case ModeWorkloads:
+		for i, w := range n.shownWorkloads {
+			if w.Name == key {
+				n.cursor = i
+				return
+			}
+		}
+	case ModePods:
+		for i, p := range n.shownPods {
+			if p.Name == key {
+				n.cursor = i
+				return
+			}
+		}
+	case ModeNamespace:
+		for i, ns := range n.shownNamespaces {
+			if ns == key {
+				n.cursor = i
+				return
+			}
 		}
 	}
-	return filtered
 }
 
 func (n *Navigator) SetWorkloads(workloads []k8s.WorkloadInfo) {
+	n.deletedWorkloads = nil
+	n.setWorkloads(workloads)
+}
+
+func (n *Navigator) setWorkloads(workloads []k8s.WorkloadInfo) {
+	selected := n.selectedKey()
 	n.workloads = workloads
-	if n.cursor >= len(n.filteredWorkloads()) {
-		n.cursor = 0
+	n.workloadKeys = make([]string, len(workloads))
+	for i, w := range workloads {
+		n.workloadKeys[i] = strings.ToLower(w.Name + "\x00" + w.Status)
 	}
+	n.refilter()
+	if n.mode == ModeWorkloads {
+		n.selectKey(selected)
+	}
+}
+
+// SetStatefulSet shows the pods that follow by ordinal, with rollout state
+// and claims; pass nil for other workloads
+func (n *Navigator) SetStatefulSet(status *k8s.StatefulSetStatus) {
+	n.statefulSet = status
+}
+
+// SetWorkloadEvents shows the events of the workload whose pods are listed
+func (n *Navigator) SetWorkloadEvents(events []k8s.EventInfo) {
+	n.workloadEvents = events
 }
 
 func (n *Navigator) SetPods(pods []k8s.PodInfo) {
-	n.pods = pods
+	n.deletedPods = nil
+	n.setPods(pods)
 	n.cursor = 0
 }
 
+func (n *Navigator) setPods(pods []k8s.PodInfo) {
+	n.blockedPod, n.blockedReason = "", ""
+	if n.statefulSet != nil {
+		pods = append([]k8s.PodInfo(nil), pods...)
+		k8s.SortPodsByOrdinal(n.statefulSet.Name, pods)
+		n.blockedPod, n.blockedReason = n.statefulSet.Blocker(pods)
+	}
+	n.pods = pods
+	n.podKeys = make([]string, len(pods))
+	for i, p := range pods {
+		n.podKeys[i] = strings.ToLower(p.Name + "\x00" + p.Status + "\x00" + p.Node)
+	}
+	n.refilter()
+}
+
+// ApplyWorkloadEvent updates one row from a watch. Deleted workloads are
+// dimmed rather than removed so the change is noticeable; see PruneDeleted.
+func (n *Navigator) ApplyWorkloadEvent(ev k8s.WorkloadEvent) {
+	if ev.Workload.Type != n.resourceType {
+		return
+	}
+	workloads := upsertByName(n.workloads, ev.Workload, func(w k8s.WorkloadInfo) string { return w.Name })
+	if ev.Deleted {
+		if n.deletedWorkloads == nil {
+			n.deletedWorkloads = make(map[string]bool)
+		}
+		n.deletedWorkloads[ev.Workload.Name] = true
+	} else {
+		delete(n.deletedWorkloads, ev.Workload.Name)
+	}
+	n.setWorkloads(workloads)
+}
+
+// ApplyPodEvent is ApplyWorkloadEvent for the pod list
+func (n *Navigator) ApplyPodEvent(ev k8s.PodEvent) {
+	selected := n.selectedKey()
+	pods := upsertByName(n.pods, ev.Pod, func(p k8s.PodInfo) string { return p.Name })
+	if ev.Deleted {
+		if n.deletedPods == nil {
+			n.deletedPods = make(map[string]bool)
+		}
+		n.deletedPods[ev.Pod.Name] = true
+	} else {
+		delete(n.deletedPods, ev.Pod.Name)
+	}
+	n.setPods(pods)
+	if n.mode == ModePods {
+		n.selectKey(selected)
+	}
+}
+
+// PruneDeleted drops the rows that ApplyWorkloadEvent and ApplyPodEvent
+// left dimmed
+func (n *Navigator) PruneDeleted() {
+	if len(n.deletedWorkloads) > 0 {
+		var kept []k8s.WorkloadInfo
+		for _, w := range n.workloads {
+			if !n.deletedWorkloads[w.Name] {
+				kept = append(kept, w)
+			}
+		}
+		n.deletedWorkloads = nil
+		n.setWorkloads(kept)
+	}
+	if len(n.deletedPods) > 0 {
+		selected := n.selectedKey()
+		var kept []k8s.PodInfo
+		for _, p := range n.pods {
+			if !n.deletedPods[p.Name] {
+				kept = append(kept, p)
+			}
+		}
+		n.deletedPods = nil
+		n.setPods(kept)
+		if n.mode == ModePods {
+			n.selectKey(selected)
+		}
+	}
+}
+
+// upsertByName replaces the item with the same name, or inserts it in name
+// order to match the API's list order
+func upsertByName[T any](items []T, item T, name func(T) string) []T {
+	key := name(item)
+	out := make([]T, 0, len(items)+1)
+	inserted := false
+	for _, it := range items {
+		switch {
+		case name(it) == key:
+			out = append(out, item)
+			inserted = true
+			continue
+		case !inserted && name(it) > key:
+			out = append(out, item)
+			inserted = true
+		}
+		out = append(out, it)
+	}
+	if !inserted {
+		out = append(out, item)
+	}
+	return out
+}
+
 func (n *Navigator) SetNamespaces(namespaces []string) {
 	n.namespaces = namespaces
+	n.namespaceKeys = make([]string, len(namespaces))
+	for i, ns := range namespaces {
+		n.namespaceKeys[i] = strings.ToLower(ns)
+	}
+	n.refilter()
+}
+
+// SetUnavailable marks a resource type whose API is forbidden or missing;
+// an empty reason clears it
+func (n *Navigator) SetUnavailable(rt k8s.ResourceType, reason string) {
+	if n.unavailable == nil {
+		n.unavailable = make(map[k8s.ResourceType]string)
+	}
+	if reason == "" {
+		delete(n.unavailable, rt)
+	} else {
+		n.unavailable[rt] = reason
+	}
+}
+
+// SetLoading shows skeleton rows until the first workloads arrive
+func (n *Navigator) SetLoading(loading bool) {
+	n.loading = loading
+}
+
+// SetPodsUnavailable explains why the pod list could not be loaded
+func (n *Navigator) SetPodsUnavailable(reason string) {
+	n.podsUnavailable = reason
+}
+
+// ToggleMark marks or unmarks the selected pod for comparison. Marking a
+// third pod drops the oldest mark.
+func (n *Navigator) ToggleMark() {
+	pod := n.SelectedPod()
+	if pod == nil {
+		return
+	}
+	for i, p := range n.marked {
+		if p.Namespace == pod.Namespace && p.Name == pod.Name {
+			n.marked = append(n.marked[:i], n.marked[i+1:]...)
+			return
+		}
+	}
+	n.marked = append(n.marked, *pod)
+	if len(n.marked) > maxMarkedPods {
+		n.marked = n.marked[1:]
+	}
+}
+
+// MarkedPods returns the pods marked for comparison, oldest first
+func (n Navigator) MarkedPods() []k8s.PodInfo {
+	return n.marked
+}
+
+func (n *Navigator) ClearMarks() {
+	n.marked = nil
+}
+
+func (n Navigator) isMarked(pod k8s.PodInfo) bool {
+	for _, p := range n.marked {
+		if p.Namespace == pod.Namespace && p.Name == pod.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Navigator) SetWatched(items []string) {
+	n.watched = make(map[string]bool, len(items))
+	for _, item := range items {
+		n.watched[item] = true
+	}
+}
+
+// SetResourceTypes limits the types offered to types, switching to the first
+// of them when the current type is not among them
+func (n *Navigator) SetResourceTypes(types []k8s.ResourceType) {
+	if len(types) == 0 {
+		return
+	}
+	n.resourceTypes = types
+	for _, rt := range types {
+		if rt == n.resourceType {
+			return
+		}
+	}
+	n.resourceType = types[0]
 }
 
 func (n *Navigator) SetResourceType(rt k8s.ResourceType) {
 	n.resourceType = rt
 }
 
+// SetColumns sets the extra columns shown from labels and annotations
+func (n *Navigator) SetColumns(columns []k8s.MetadataColumn) {
+	n.columns = columns
+}
+
 func (n *Navigator) SetMode(mode NavigatorMode) {
 	n.mode = mode
 	n.cursor = 0
@@ -510,10 +1176,31 @@ func (n Navigator) SelectedNamespace() string {
 }
 
 func (n Navigator) SelectedResourceType() k8s.ResourceType {
-	if n.cursor >= 0 && n.cursor < len(k8s.AllResourceTypes) {
-		return k8s.AllResourceTypes[n.cursor]
+	if n.cursor >= 0 && n.cursor < len(n.resourceTypes) {
+		return n.resourceTypes[n.cursor]
+	}
+	return n.resourceType
+}
+
+// SetContexts fills the context picker, placing the cursor on current
+func (n *Navigator) SetContexts(contexts []string, current string) {
+	n.contexts = contexts
+	n.currentContext = current
+	if n.mode != ModeContext {
+		return
+	}
+	for i, name := range contexts {
+		if name == current {
+			n.cursor = i
+		}
 	}
-	return k8s.ResourceDeployments
+}
+
+func (n Navigator) SelectedContext() string {
+	if n.cursor >= 0 && n.cursor < len(n.contexts) {
+		return n.contexts[n.cursor]
+	}
+	return ""
 }
 
 func (n Navigator) Mode() NavigatorMode {
@@ -536,12 +1223,44 @@ func (n *Navigator) ClearSearch() {
 	n.searchQuery = ""
 	n.searchInput.SetValue("")
 	n.searching = false
+	n.filterSeq++
+	n.refilter()
 	n.cursor = 0
 }
 
+// SetFilter replaces the search filter, e.g. when recalling a saved view
+func (n *Navigator) SetFilter(query string) {
+	n.searching = false
+	n.searchInput.SetValue(query)
+	n.filterSeq++
+	n.setQuery(query)
+}
+
+// SetLabelFilter narrows the workload list to objects matching a label
+// selector; nil shows them all
+func (n *Navigator) SetLabelFilter(f *k8s.LabelFilter) {
+	selected := n.selectedKey()
+	n.labelFilter = f
+	n.refilter()
+	n.selectKey(selected)
+}
+
+func (n Navigator) LabelFilter() *k8s.LabelFilter {
+	return n.labelFilter
+}
+
+func (n Navigator) SearchQuery() string {
+	return n.searchQuery
+}
+
+func (n Navigator) showsLabelFilter() bool {
+	return n.labelFilter != nil && n.mode == ModeWorkloads
+}
+
 func (n *Navigator) CloseSearch() {
 	n.searching = false
-	n.searchQuery = n.searchInput.Value()
+	n.filterSeq++
+	n.setQuery(n.searchInput.Value())
 }
 
 func (n Navigator) Render(width int) string {
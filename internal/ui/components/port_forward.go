@@ -0,0 +1,269 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+// PortForwardPromptResult is returned when the user submits the ports to
+// forward. Exactly one of Pod and Service is set; for a Service RemotePort
+// is the Service's port.
+type PortForwardPromptResult struct {
+	Namespace  string
+	Pod        string
+	Service    string
+	LocalPort  int
+	RemotePort int
+}
+
+// PortForwardStopMsg asks for one forward to be stopped
+type PortForwardStopMsg struct {
+	ID int
+}
+
+// PortForwardPrompt asks which local port to forward to which pod or
+// Service port
+type PortForwardPrompt struct {
+	namespace string
+	pod       string
+	service   string
+	ports     []int32
+	input     textinput.Model
+	errMsg    string
+	visible   bool
+}
+
+func NewPortForwardPrompt() PortForwardPrompt {
+	ti := textinput.New()
+	ti.Placeholder = "local:pod"
+	ti.CharLimit = 11
+	ti.Width = 14
+	return PortForwardPrompt{input: ti}
+}
+
+// Show opens the prompt for a pod, prefilled with its first container port
+func (p *PortForwardPrompt) Show(namespace, pod string, ports []int32) {
+	p.show(namespace, pod, "", ports)
+}
+
+// ShowService opens the prompt for a Service, prefilled with its first port
+func (p *PortForwardPrompt) ShowService(namespace, service string, ports []int32) {
+	p.show(namespace, "", service, ports)
+}
+
+func (p *PortForwardPrompt) show(namespace, pod, service string, ports []int32) {
+	p.namespace = namespace
+	p.pod = pod
+	p.service = service
+	p.ports = ports
+	p.errMsg = ""
+	value := "8080:8080"
+	if len(ports) > 0 {
+		value = fmt.Sprintf("%d:%d", ports[0], ports[0])
+	}
+	p.input.SetValue(value)
+	p.input.CursorEnd()
+	p.input.Focus()
+	p.visible = true
+}
+
+func (p *PortForwardPrompt) Hide() {
+	p.visible = false
+	p.input.Blur()
+}
+
+func (p PortForwardPrompt) IsVisible() bool {
+	return p.visible
+}
+
+func (p PortForwardPrompt) Update(msg tea.Msg) (PortForwardPrompt, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			p.Hide()
+			return p, nil
+		case "enter":
+			local, remote, err := k8s.ParsePortPair(p.input.Value())
+			if err != nil {
+				p.errMsg = err.Error()
+				return p, nil
+			}
+			p.Hide()
+			result := PortForwardPromptResult{Namespace: p.namespace, Pod: p.pod, Service: p.service, LocalPort: local, RemotePort: remote}
+			return p, func() tea.Msg { return result }
+		}
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p PortForwardPrompt) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	target, portsLabel, remoteLabel := p.pod, "container ports: ", "pod"
+	if p.service != "" {
+		target, portsLabel, remoteLabel = "svc/"+p.service, "service ports: ", "service"
+	}
+	b.WriteString(titleStyle.Render("Port Forward " + target))
+	b.WriteString("\n\n")
+
+	b.WriteString(styles.HelpKeyStyle.Render("ports "))
+	b.WriteString(p.input.View())
+	b.WriteString("\n")
+
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	if len(p.ports) > 0 {
+		ports := make([]string, len(p.ports))
+		for i, port := range p.ports {
+			ports[i] = fmt.Sprint(port)
+		}
+		b.WriteString(hintStyle.Render(portsLabel + strings.Join(ports, ", ")))
+		b.WriteString("\n")
+	}
+	if p.errMsg != "" {
+		b.WriteString(styles.StatusError.Render(p.errMsg))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("local:" + remoteLabel + ", local 0 for any free port • Enter to start • Esc to cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Background(styles.Background)
+	return boxStyle.Render(b.String())
+}
+
+// PortForwardPanel lists the running port-forwards and stops them
+type PortForwardPanel struct {
+	forwards []k8s.PortForward
+	lastErr  string
+	cursor   int
+	visible  bool
+}
+
+func NewPortForwardPanel() PortForwardPanel {
+	return PortForwardPanel{}
+}
+
+// SetForwards refreshes the list, keeping the cursor in range
+func (p *PortForwardPanel) SetForwards(forwards []k8s.PortForward, lastErr string) {
+	p.forwards = forwards
+	p.lastErr = lastErr
+	p.cursor = max(0, min(p.cursor, len(forwards)-1))
+}
+
+func (p *PortForwardPanel) Show() {
+	p.visible = true
+}
+
+func (p *PortForwardPanel) Hide() {
+	p.visible = false
+}
+
+func (p PortForwardPanel) IsVisible() bool {
+	return p.visible
+}
+
+func (p PortForwardPanel) Update(msg tea.Msg) (PortForwardPanel, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q", "F":
+			p.visible = false
+		case "up", "k":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+		case "down", "j":
+			if p.cursor < len(p.forwards)-1 {
+				p.cursor++
+			}
+		case "x", "d":
+			if p.cursor < len(p.forwards) {
+				id := p.forwards[p.cursor].ID
+				return p, func() tea.Msg { return PortForwardStopMsg{ID: id} }
+			}
+		}
+	}
+	return p, nil
+}
+
+func (p PortForwardPanel) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Port Forwards (%d)", len(p.forwards))))
+	b.WriteString("\n\n")
+
+	if len(p.forwards) == 0 {
+		b.WriteString(styles.StatusMuted.Render("No port-forwards. Start one from a pod's actions menu (a)."))
+		b.WriteString("\n")
+	}
+	for i, f := range p.forwards {
+		cursor := "  "
+		if i == p.cursor {
+			cursor = styles.CursorStyle.Render("> ")
+		}
+		up := "up " + k8s.FormatDuration(time.Since(f.Started))
+		if f.Reconnects > 0 {
+			up += fmt.Sprintf(", moved pods %dx", f.Reconnects)
+		}
+		status := styles.StatusRunning.Render(up)
+		if f.Waiting {
+			reason := "looking for a ready pod"
+			if f.Err != nil {
+				reason = k8s.ShortError(f.Err)
+			}
+			status = styles.StatusPending.Render("waiting: " + reason)
+		}
+		if f.Ended {
+			reason := "stopped"
+			if f.Err != nil {
+				reason = k8s.ShortError(f.Err)
+			}
+			status = styles.StatusError.Render("ended: " + reason)
+		}
+		b.WriteString(fmt.Sprintf("%s%-50s %s\n", cursor, f.Target(), status))
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	if p.lastErr != "" {
+		b.WriteString("\n")
+		b.WriteString(styles.StatusError.Render("last connection error: " + p.lastErr))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("j/k select • x stop • esc close"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Background(styles.Background)
+	return boxStyle.Render(b.String())
+}
@@ -1,27 +1,55 @@
 package components
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/doganarif/k9sight/internal/k8s"
 	"github.com/doganarif/k9sight/internal/ui/styles"
 )
 
 // ResultViewer displays command output in a scrollable viewport
 type ResultViewer struct {
-	title    string
-	viewport viewport.Model
-	visible  bool
-	ready    bool
-	width    int
-	height   int
+	title       string
+	content     string
+	viewport    viewport.Model
+	visible     bool
+	ready       bool
+	wrap        bool // soft-wrap long lines, kept across results
+	width       int
+	height      int
+	searching   bool
+	searchInput textinput.Model
+	matcher     *k8s.LogMatcher // compiled search, nil for none
+	matchLines  []int           // viewport lines holding a match
+	current     int             // index into matchLines, -1 before a jump
+	places      map[string]resultPlace
+}
+
+// resultPlace is where a result was left, so reopening it in the same
+// session picks up at the same line with the same search
+type resultPlace struct {
+	offset int
+	query  string
 }
 
 func NewResultViewer() ResultViewer {
-	return ResultViewer{}
+	ti := textinput.New()
+	ti.Placeholder = "Search"
+	ti.CharLimit = 100
+	ti.Width = 30
+
+	return ResultViewer{
+		searchInput: ti,
+		current:     -1,
+		places:      make(map[string]resultPlace),
+	}
 }
 
 func (r ResultViewer) Init() tea.Cmd {
@@ -37,16 +65,49 @@ func (r ResultViewer) Update(msg tea.Msg) (ResultViewer, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if r.searching {
+			switch msg.String() {
+			case "esc", "enter":
+				r.searching = false
+				r.searchInput.Blur()
+				return r, nil
+			default:
+				r.searchInput, cmd = r.searchInput.Update(msg)
+				// Live search as you type
+				r.setQuery(r.searchInput.Value())
+				return r, cmd
+			}
+		}
+
 		switch msg.String() {
 		case "esc", "q":
+			r.remember()
 			r.visible = false
 			return r, nil
+		case "/":
+			r.searching = true
+			r.searchInput.Focus()
+			return r, textinput.Blink
+		case "c":
+			r.searchInput.SetValue("")
+			r.setQuery("")
+			return r, nil
+		case "n":
+			r.jumpToMatch(1)
+			return r, nil
+		case "N":
+			r.jumpToMatch(-1)
+			return r, nil
 		case "g":
 			r.viewport.GotoTop()
 			return r, nil
 		case "G":
 			r.viewport.GotoBottom()
 			return r, nil
+		case "w":
+			r.wrap = !r.wrap
+			r.setContent()
+			return r, nil
 		}
 	}
 
@@ -90,7 +151,13 @@ func (r ResultViewer) View() string {
 		)
 	}
 
-	footer := "j/k scroll • g/G top/bottom • q/esc close" + scrollInfo
+	footer := "j/k scroll • g/G top/bottom • / search • w wrap • q/esc close" + scrollInfo
+	switch {
+	case r.searching:
+		footer = "/ " + r.searchInput.View()
+	case r.matcher != nil:
+		footer = r.matchInfo() + " • n/N next/prev • c clear • q/esc close" + scrollInfo
+	}
 	b.WriteString(footerStyle.Render(footer))
 
 	// Wrap in a box
@@ -102,25 +169,149 @@ func (r ResultViewer) View() string {
 	return boxStyle.Render(b.String())
 }
 
+// Show opens a result. A result with a title shown before in this session
+// reopens where it was left, search included.
 func (r *ResultViewer) Show(title, content string, width, height int) {
+	if r.visible {
+		r.remember()
+	}
 	r.title = title
 	r.width = width
 	r.height = height
 	r.visible = true
+	r.searching = false
+	r.searchInput.Blur()
 
 	// Initialize viewport
 	viewportHeight := max(height-6, 5)
 	viewportWidth := max(width-6, 20)
 
+	place := r.places[title]
+	r.content = content
 	r.viewport = viewport.New(viewportWidth, viewportHeight)
-	r.viewport.SetContent(content)
+	r.searchInput.SetValue(place.query)
+	r.matcher, _ = k8s.NewLogMatcher(place.query, false, false)
+	r.setContent()
+	r.viewport.SetYOffset(place.offset)
 	r.ready = true
 }
 
+// setContent fills the viewport with the content, wrapped to its width
+// when wrapping is on and with the search's matches highlighted
+func (r *ResultViewer) setContent() {
+	text := r.content
+	if r.wrap {
+		text = wrapText(text, r.viewport.Width)
+	}
+	r.matchLines, r.current = nil, -1
+	if r.matcher != nil {
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			plain := ansi.Strip(line)
+			spans := r.matcher.Spans(plain)
+			if len(spans) == 0 {
+				continue
+			}
+			r.matchLines = append(r.matchLines, i)
+			lines[i] = highlightSpans(plain, spans)
+		}
+		text = strings.Join(lines, "\n")
+	}
+	r.viewport.SetContent(text)
+}
+
+// highlightSpans picks out the spans of plain text in the match style
+func highlightSpans(plain string, spans [][2]int) string {
+	var b strings.Builder
+	pos := 0
+	for _, span := range spans {
+		b.WriteString(plain[pos:span[0]])
+		b.WriteString(styles.LogMatch.Render(plain[span[0]:span[1]]))
+		pos = span[1]
+	}
+	b.WriteString(plain[pos:])
+	return b.String()
+}
+
+// setQuery searches the result for query, scrolling to the first match
+// unless one is already in view
+func (r *ResultViewer) setQuery(query string) {
+	r.matcher, _ = k8s.NewLogMatcher(query, false, false)
+	r.setContent()
+	top := r.viewport.YOffset
+	for i, line := range r.matchLines {
+		if line >= top {
+			if line >= top+r.viewport.Height {
+				r.viewport.SetYOffset(line)
+			}
+			r.current = i
+			return
+		}
+	}
+	r.jumpToMatch(1)
+}
+
+// jumpToMatch scrolls to the next (dir 1) or previous (dir -1) line with a
+// match after the top one, wrapping around
+func (r *ResultViewer) jumpToMatch(dir int) {
+	n := len(r.matchLines)
+	if n == 0 {
+		return
+	}
+	top := r.viewport.YOffset
+	if r.current >= 0 {
+		top = r.matchLines[r.current]
+	}
+	var next int
+	if dir > 0 {
+		for i, line := range r.matchLines {
+			if line > top {
+				next = i
+				break
+			}
+		}
+	} else {
+		next = n - 1
+		for i := n - 1; i >= 0; i-- {
+			if r.matchLines[i] < top {
+				next = i
+				break
+			}
+		}
+	}
+	r.current = next
+	r.viewport.SetYOffset(r.matchLines[next])
+}
+
+// matchInfo describes the search for the footer, e.g. "match 2/7 for err"
+func (r ResultViewer) matchInfo() string {
+	query := r.searchInput.Value()
+	switch {
+	case len(r.matchLines) == 0:
+		return fmt.Sprintf("no matches for %q", query)
+	case r.current < 0:
+		return fmt.Sprintf("%d matches for %q", len(r.matchLines), query)
+	}
+	return fmt.Sprintf("match %d/%d for %q", r.current+1, len(r.matchLines), query)
+}
+
+// remember records where the shown result was left, for Show
+func (r *ResultViewer) remember() {
+	if r.ready && r.places != nil {
+		r.places[r.title] = resultPlace{offset: r.viewport.YOffset, query: r.searchInput.Value()}
+	}
+}
+
 func (r *ResultViewer) Hide() {
+	r.remember()
 	r.visible = false
 }
 
+// Searching reports whether the search input has the keyboard
+func (r ResultViewer) Searching() bool {
+	return r.searching
+}
+
 func (r ResultViewer) IsVisible() bool {
 	return r.visible
 }
@@ -129,7 +320,10 @@ func (r *ResultViewer) SetSize(width, height int) {
 	r.width = width
 	r.height = height
 	if r.ready {
-		r.viewport.Width = width - 6
-		r.viewport.Height = height - 6
+		r.viewport.Width = max(width-6, 20)
+		r.viewport.Height = max(height-6, 5)
+		if r.wrap {
+			r.setContent()
+		}
 	}
 }
@@ -0,0 +1,114 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+// SavePathResult is returned when the user confirms where to save a file
+type SavePathResult struct {
+	Path string
+	Data interface{} // what to save, passed through from Show
+}
+
+// SavePathPrompt asks where to save a file, starting from a suggested path
+type SavePathPrompt struct {
+	input   textinput.Model
+	title   string
+	summary string // what is being saved
+	data    interface{}
+	errMsg  string
+	visible bool
+}
+
+func NewSavePathPrompt() SavePathPrompt {
+	input := textinput.New()
+	input.Placeholder = "./manifests.yaml"
+	input.CharLimit = 512
+	input.Width = 48
+	return SavePathPrompt{input: input}
+}
+
+// Show opens the prompt with path filled in; data comes back with the result
+func (p *SavePathPrompt) Show(title, summary, path string, data interface{}) {
+	p.title = title
+	p.summary = summary
+	p.data = data
+	p.errMsg = ""
+	p.input.SetValue(path)
+	p.input.CursorEnd()
+	p.input.Focus()
+	p.visible = true
+}
+
+func (p *SavePathPrompt) Hide() {
+	p.visible = false
+	p.input.Blur()
+}
+
+func (p SavePathPrompt) IsVisible() bool {
+	return p.visible
+}
+
+func (p SavePathPrompt) Update(msg tea.Msg) (SavePathPrompt, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			p.Hide()
+			return p, nil
+		case "enter":
+			path := strings.TrimSpace(p.input.Value())
+			if path == "" {
+				p.errMsg = "enter a file path"
+				return p, nil
+			}
+			p.Hide()
+			result := SavePathResult{Path: path, Data: p.data}
+			return p, func() tea.Msg { return result }
+		}
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p SavePathPrompt) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	b.WriteString(titleStyle.Render(p.title))
+	b.WriteString("\n")
+	b.WriteString(styles.HelpDescStyle.Render(p.summary))
+	b.WriteString("\n\n")
+
+	b.WriteString(styles.HelpKeyStyle.Render("path "))
+	b.WriteString(p.input.View())
+	b.WriteString("\n")
+	if p.errMsg != "" {
+		b.WriteString(styles.StatusError.Render(p.errMsg))
+		b.WriteString("\n")
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("~ is your home directory • Enter to save • Esc to cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Background(styles.Background)
+	return boxStyle.Render(b.String())
+}
@@ -0,0 +1,122 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+// ScalePromptResult is returned when the user submits a replica count
+type ScalePromptResult struct {
+	Workload *k8s.WorkloadInfo
+	Replicas int32
+}
+
+// ScalePrompt asks for any replica count, kept within the bounds of the HPA
+// targeting the workload if there is one
+type ScalePrompt struct {
+	input    textinput.Model
+	workload *k8s.WorkloadInfo
+	hpa      *k8s.HPAInfo
+	errMsg   string
+	visible  bool
+}
+
+func NewScalePrompt() ScalePrompt {
+	input := textinput.New()
+	input.CharLimit = 6
+	input.Width = 8
+	input.Validate = func(s string) error {
+		if strings.Trim(s, "0123456789") != "" {
+			return fmt.Errorf("digits only")
+		}
+		return nil
+	}
+	return ScalePrompt{input: input}
+}
+
+func (p *ScalePrompt) Show(workload *k8s.WorkloadInfo, hpa *k8s.HPAInfo) {
+	p.workload = workload
+	p.hpa = hpa
+	p.errMsg = ""
+	p.input.SetValue("")
+	p.input.Placeholder = fmt.Sprint(workload.Replicas)
+	p.input.Focus()
+	p.visible = true
+}
+
+func (p *ScalePrompt) Hide() {
+	p.visible = false
+	p.input.Blur()
+}
+
+func (p ScalePrompt) IsVisible() bool {
+	return p.visible
+}
+
+func (p ScalePrompt) Update(msg tea.Msg) (ScalePrompt, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			p.Hide()
+			return p, nil
+		case "enter":
+			replicas, err := k8s.ParseReplicas(p.input.Value(), p.hpa)
+			if err != nil {
+				p.errMsg = err.Error()
+				return p, nil
+			}
+			p.Hide()
+			result := ScalePromptResult{Workload: p.workload, Replicas: replicas}
+			return p, func() tea.Msg { return result }
+		}
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p ScalePrompt) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	b.WriteString(titleStyle.Render("Scale " + p.workload.Name))
+	b.WriteString("\n\n")
+
+	b.WriteString(styles.HelpKeyStyle.Render("replicas "))
+	b.WriteString(p.input.View())
+	b.WriteString(styles.HelpDescStyle.Render(fmt.Sprintf("  (now %d)", p.workload.Replicas)))
+	b.WriteString("\n")
+	if p.hpa != nil {
+		b.WriteString(styles.StatusPending.Render(fmt.Sprintf("HPA %s scales between %d and %d", p.hpa.Name, p.hpa.MinReplicas, p.hpa.MaxReplicas)))
+		b.WriteString("\n")
+	}
+	if p.errMsg != "" {
+		b.WriteString(styles.StatusError.Render(p.errMsg))
+		b.WriteString("\n")
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("Enter to scale • Esc to cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Background(styles.Background)
+	return boxStyle.Render(b.String())
+}
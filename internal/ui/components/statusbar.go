@@ -13,6 +13,9 @@ type StatusBar struct {
 	namespace string
 	resource  string
 	status    string
+	retrying  bool
+	errors    int
+	forwards  int
 	width     int
 }
 
@@ -36,6 +39,21 @@ func (s *StatusBar) SetStatus(status string) {
 	s.status = status
 }
 
+// SetRetrying shows that an API request is backing off after a transient error
+func (s *StatusBar) SetRetrying(retrying bool) {
+	s.retrying = retrying
+}
+
+// SetErrorCount shows a badge for errors recorded since the viewer was opened
+func (s *StatusBar) SetErrorCount(n int) {
+	s.errors = n
+}
+
+// SetForwardCount shows a badge for running port-forwards
+func (s *StatusBar) SetForwardCount(n int) {
+	s.forwards = n
+}
+
 func (s *StatusBar) SetWidth(width int) {
 	s.width = width
 }
@@ -72,6 +90,18 @@ func (s StatusBar) renderLeft() string {
 		parts = append(parts, fmt.Sprintf("res:%s", styles.StatusBarKeyStyle.Render(s.resource)))
 	}
 
+	if s.retrying {
+		parts = append(parts, styles.StatusPending.Render("retrying…"))
+	}
+
+	if s.errors > 0 {
+		parts = append(parts, styles.StatusError.Render(fmt.Sprintf("⚠ %d (E)", s.errors)))
+	}
+
+	if s.forwards > 0 {
+		parts = append(parts, styles.StatusRunning.Render(fmt.Sprintf("⇄ %d (F)", s.forwards)))
+	}
+
 	return strings.Join(parts, " | ")
 }
 
@@ -85,6 +115,7 @@ func (s StatusBar) renderRight() string {
 type Breadcrumb struct {
 	items []string
 	width int
+	badge string
 }
 
 func NewBreadcrumb() Breadcrumb {
@@ -99,6 +130,24 @@ func (b *Breadcrumb) SetWidth(width int) {
 	b.width = width
 }
 
+// SetBadge shows the pod's status, readiness and restart count after the
+// path, colored by status, e.g. "CrashLoopBackOff 0/1 ↻12". An empty status
+// removes the badge.
+func (b *Breadcrumb) SetBadge(status, ready string, restarts int32) {
+	if status == "" {
+		b.badge = ""
+		return
+	}
+	text := status
+	if ready != "" {
+		text += " " + ready
+	}
+	if restarts > 0 {
+		text += fmt.Sprintf(" ↻%d", restarts)
+	}
+	b.badge = styles.StatusBadge(status).Render(text)
+}
+
 func (b Breadcrumb) View() string {
 	if len(b.items) == 0 {
 		return ""
@@ -114,5 +163,9 @@ func (b Breadcrumb) View() string {
 	}
 
 	sep := styles.BreadcrumbStyle.Render(" > ")
-	return strings.Join(parts, sep)
+	view := strings.Join(parts, sep)
+	if b.badge != "" {
+		view += "  " + b.badge
+	}
+	return view
 }
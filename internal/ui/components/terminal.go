@@ -0,0 +1,91 @@
+package components
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Terminal integrations for running exec shells and port-forwards next to the TUI
+const (
+	IntegrationAuto  = "auto"
+	IntegrationTmux  = "tmux"
+	IntegrationITerm = "iterm"
+	IntegrationNone  = "none"
+)
+
+// ResolveIntegration maps the configured integration to the one usable in the
+// current terminal, returning IntegrationNone when the UI should be suspended instead
+func ResolveIntegration(setting string) string {
+	inTmux := os.Getenv("TMUX") != ""
+	inITerm := os.Getenv("TERM_PROGRAM") == "iTerm.app"
+
+	switch strings.ToLower(setting) {
+	case IntegrationTmux:
+		if inTmux {
+			return IntegrationTmux
+		}
+	case IntegrationITerm:
+		if inITerm {
+			return IntegrationITerm
+		}
+	case IntegrationAuto:
+		if inTmux {
+			return IntegrationTmux
+		}
+		if inITerm {
+			return IntegrationITerm
+		}
+	}
+	return IntegrationNone
+}
+
+// IntegrationLabel describes where OpenInTerminal will run a command
+func IntegrationLabel(integration string, split bool) string {
+	switch integration {
+	case IntegrationTmux:
+		if split {
+			return "a new tmux pane"
+		}
+		return "a new tmux window"
+	case IntegrationITerm:
+		return "a new iTerm tab"
+	}
+	return ""
+}
+
+// OpenInTerminal runs cmdStr in a new tmux pane (split) or window, or a new iTerm tab
+func OpenInTerminal(integration, title, cmdStr string, split bool) error {
+	var cmd *exec.Cmd
+
+	switch integration {
+	case IntegrationTmux:
+		if split {
+			cmd = exec.Command("tmux", "split-window", "-h", cmdStr)
+		} else {
+			cmd = exec.Command("tmux", "new-window", "-n", title, cmdStr)
+		}
+	case IntegrationITerm:
+		script := fmt.Sprintf(
+			`tell application "iTerm2" to tell current window to create tab with default profile command "/bin/sh -c %s"`,
+			appleScriptQuote(shellQuote(cmdStr)),
+		)
+		cmd = exec.Command("osascript", "-e", script)
+	default:
+		return fmt.Errorf("no terminal integration available")
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func appleScriptQuote(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,161 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+// PaletteItem is one saved view or bookmark offered by the palette
+type PaletteItem struct {
+	Name        string
+	Description string
+}
+
+// ViewPaletteResult is returned when an item is picked, or marked for
+// removal with ctrl+d. Palette is the title of the palette it was picked in.
+type ViewPaletteResult struct {
+	Palette string
+	Name    string
+	Remove  bool
+}
+
+// ViewPalette recalls saved views, or bookmarks, by typing part of their name
+type ViewPalette struct {
+	title    string
+	empty    string // shown when there is nothing to pick
+	input    textinput.Model
+	items    []PaletteItem
+	shown    []PaletteItem
+	selected int
+	visible  bool
+}
+
+func NewViewPalette() ViewPalette {
+	return NewPalette("Saved Views", "No saved views yet: press S in a workload list")
+}
+
+// NewPalette is a palette of other named items, titled title
+func NewPalette(title, empty string) ViewPalette {
+	input := textinput.New()
+	input.Placeholder = "type to filter"
+	input.Prompt = "> "
+	input.CharLimit = 64
+	input.Width = 40
+	return ViewPalette{title: title, empty: empty, input: input}
+}
+
+func (p *ViewPalette) Show(items []PaletteItem) {
+	p.items = items
+	p.input.SetValue("")
+	p.input.Focus()
+	p.refilter()
+	p.visible = true
+}
+
+func (p *ViewPalette) Hide() {
+	p.visible = false
+	p.input.Blur()
+}
+
+func (p ViewPalette) IsVisible() bool {
+	return p.visible
+}
+
+func (p *ViewPalette) refilter() {
+	query := strings.ToLower(p.input.Value())
+	p.shown = p.shown[:0]
+	for _, item := range p.items {
+		if strings.Contains(strings.ToLower(item.Name), query) {
+			p.shown = append(p.shown, item)
+		}
+	}
+	p.selected = 0
+}
+
+func (p ViewPalette) Update(msg tea.Msg) (ViewPalette, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			p.Hide()
+			return p, nil
+		case "up", "ctrl+p":
+			if p.selected > 0 {
+				p.selected--
+			}
+			return p, nil
+		case "down", "ctrl+n":
+			if p.selected < len(p.shown)-1 {
+				p.selected++
+			}
+			return p, nil
+		case "enter", "ctrl+d":
+			if p.selected >= len(p.shown) {
+				return p, nil
+			}
+			p.Hide()
+			result := ViewPaletteResult{Palette: p.title, Name: p.shown[p.selected].Name, Remove: msg.String() == "ctrl+d"}
+			return p, func() tea.Msg { return result }
+		}
+	}
+
+	var cmd tea.Cmd
+	before := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != before {
+		p.refilter()
+	}
+	return p, cmd
+}
+
+func (p ViewPalette) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	b.WriteString(titleStyle.Render(p.title))
+	b.WriteString("\n\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	if len(p.items) == 0 {
+		b.WriteString(styles.StatusMuted.Render(p.empty))
+		b.WriteString("\n")
+	} else if len(p.shown) == 0 {
+		b.WriteString(styles.StatusMuted.Render("Nothing matches"))
+		b.WriteString("\n")
+	}
+	for i, item := range p.shown {
+		descStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+		if i == p.selected {
+			selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Background).Background(styles.Primary)
+			b.WriteString(selectedStyle.Render(item.Name))
+		} else {
+			b.WriteString(lipgloss.NewStyle().Foreground(styles.Text).Render(item.Name))
+		}
+		b.WriteString(" ")
+		b.WriteString(descStyle.Render(item.Description))
+		b.WriteString("\n")
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(fmt.Sprintf("%d saved • Enter to open • ctrl+d to remove • Esc to close", len(p.items))))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Background(styles.Background)
+	return boxStyle.Render(b.String())
+}
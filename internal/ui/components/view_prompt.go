@@ -0,0 +1,144 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+// SaveViewResult is returned when the user names the current view to save
+type SaveViewResult struct {
+	Name          string
+	LabelSelector string
+}
+
+// SaveViewPrompt asks for the name of the current workload list, and the
+// label selector to save with it
+type SaveViewPrompt struct {
+	name     textinput.Model
+	selector textinput.Model
+	scope    string // namespace/type/filter being saved, for the title
+	errMsg   string
+	visible  bool
+}
+
+func NewSaveViewPrompt() SaveViewPrompt {
+	name := textinput.New()
+	name.Placeholder = "payments prod errors"
+	name.CharLimit = 64
+	name.Width = 32
+
+	selector := textinput.New()
+	selector.Placeholder = "app=payments,tier!=cache"
+	selector.CharLimit = 256
+	selector.Width = 32
+
+	return SaveViewPrompt{name: name, selector: selector}
+}
+
+// Show opens the prompt for the given scope with the active label selector
+// filled in
+func (p *SaveViewPrompt) Show(scope, selector string) {
+	p.scope = scope
+	p.name.SetValue("")
+	p.selector.SetValue(selector)
+	p.errMsg = ""
+	p.selector.Blur()
+	p.name.Focus()
+	p.visible = true
+}
+
+func (p *SaveViewPrompt) Hide() {
+	p.visible = false
+	p.name.Blur()
+	p.selector.Blur()
+}
+
+func (p SaveViewPrompt) IsVisible() bool {
+	return p.visible
+}
+
+func (p SaveViewPrompt) Update(msg tea.Msg) (SaveViewPrompt, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			p.Hide()
+			return p, nil
+		case "tab", "shift+tab", "up", "down":
+			if p.name.Focused() {
+				p.name.Blur()
+				p.selector.Focus()
+			} else {
+				p.selector.Blur()
+				p.name.Focus()
+			}
+			return p, nil
+		case "enter":
+			name := strings.TrimSpace(p.name.Value())
+			if name == "" {
+				p.errMsg = "name the view"
+				return p, nil
+			}
+			selector := strings.TrimSpace(p.selector.Value())
+			if _, err := k8s.ParseLabelFilter(selector); err != nil {
+				p.errMsg = fmt.Sprintf("label selector: %v", err)
+				return p, nil
+			}
+			p.Hide()
+			result := SaveViewResult{Name: name, LabelSelector: selector}
+			return p, func() tea.Msg { return result }
+		}
+	}
+
+	var cmd tea.Cmd
+	if p.name.Focused() {
+		p.name, cmd = p.name.Update(msg)
+	} else {
+		p.selector, cmd = p.selector.Update(msg)
+	}
+	return p, cmd
+}
+
+func (p SaveViewPrompt) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	b.WriteString(titleStyle.Render("Save View"))
+	b.WriteString("\n")
+	b.WriteString(styles.HelpDescStyle.Render(p.scope))
+	b.WriteString("\n\n")
+
+	b.WriteString(styles.HelpKeyStyle.Render("name   "))
+	b.WriteString(p.name.View())
+	b.WriteString("\n")
+	b.WriteString(styles.HelpKeyStyle.Render("labels "))
+	b.WriteString(p.selector.View())
+	b.WriteString("\n")
+	if p.errMsg != "" {
+		b.WriteString(styles.StatusError.Render(p.errMsg))
+		b.WriteString("\n")
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("labels are optional • Tab to switch • Enter to save • Esc to cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Background(styles.Background)
+	return boxStyle.Render(b.String())
+}
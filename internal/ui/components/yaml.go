@@ -0,0 +1,120 @@
+package components
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+var (
+	yamlKeyStyle     = lipgloss.NewStyle().Foreground(styles.Secondary)
+	yamlStringStyle  = lipgloss.NewStyle().Foreground(styles.Success)
+	yamlLiteralStyle = lipgloss.NewStyle().Foreground(styles.Warning)
+	yamlCommentStyle = lipgloss.NewStyle().Foreground(styles.Muted).Italic(true)
+	yamlMarkerStyle  = lipgloss.NewStyle().Foreground(styles.Primary)
+)
+
+// HighlightYAML colors keys, scalar values and comments line by line. It does
+// not parse the document, so block scalars (the lines after "key: |") are
+// colored as plain values.
+func HighlightYAML(doc string) string {
+	lines := strings.Split(strings.TrimRight(doc, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = highlightYAMLLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func highlightYAMLLine(line string) string {
+	body := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(body)]
+	if body == "" {
+		return line
+	}
+	if strings.HasPrefix(body, "#") {
+		return indent + yamlCommentStyle.Render(body)
+	}
+
+	var b strings.Builder
+	b.WriteString(indent)
+	// List items, possibly "- key: value"
+	for strings.HasPrefix(body, "- ") || body == "-" {
+		b.WriteString(yamlMarkerStyle.Render("-"))
+		body = strings.TrimPrefix(strings.TrimPrefix(body, "-"), " ")
+		if body == "" {
+			return b.String()
+		}
+		b.WriteString(" ")
+	}
+
+	value, comment := splitYAMLComment(body)
+	if key, rest, ok := cutYAMLKey(value); ok {
+		b.WriteString(yamlKeyStyle.Render(key))
+		b.WriteString(":")
+		if rest != "" {
+			b.WriteString(" ")
+			b.WriteString(yamlValueStyle(rest).Render(rest))
+		}
+	} else {
+		b.WriteString(yamlValueStyle(value).Render(value))
+	}
+	if comment != "" {
+		b.WriteString(" ")
+		b.WriteString(yamlCommentStyle.Render(comment))
+	}
+	return b.String()
+}
+
+// cutYAMLKey splits "key: value" or "key:", skipping colons inside quoted
+// keys and values such as "image: nginx:1.25"
+func cutYAMLKey(s string) (key, value string, ok bool) {
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'") {
+		end := strings.IndexByte(s[1:], s[0])
+		if end < 0 {
+			return "", "", false
+		}
+		rest := s[end+2:]
+		if rest == ":" || strings.HasPrefix(rest, ": ") {
+			return s[:end+2], strings.TrimSpace(rest[1:]), true
+		}
+		return "", "", false
+	}
+	if strings.HasSuffix(s, ":") {
+		return s[:len(s)-1], "", true
+	}
+	if k, v, found := strings.Cut(s, ": "); found && !strings.ContainsAny(k, `"'`) {
+		return k, strings.TrimSpace(v), true
+	}
+	return "", "", false
+}
+
+// splitYAMLComment splits off a trailing " # comment" outside quotes
+func splitYAMLComment(s string) (string, string) {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && i > 0 && s[i-1] == ' ':
+			return strings.TrimRight(s[:i], " "), s[i:]
+		}
+	}
+	return s, ""
+}
+
+func yamlValueStyle(value string) lipgloss.Style {
+	switch value {
+	case "true", "false", "null", "~", "{}", "[]", "|", "|-", ">", ">-":
+		return yamlLiteralStyle
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return yamlLiteralStyle
+	}
+	return yamlStringStyle
+}
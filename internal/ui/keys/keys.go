@@ -33,11 +33,16 @@ type KeyMap struct {
 	// Mode switches
 	Namespace    key.Binding
 	ResourceType key.Binding
+	Context      key.Binding
 
 	// Log actions
 	ToggleFollow key.Binding
 	JumpToError  key.Binding
 	ToggleWrap   key.Binding
+	LogsToPager  key.Binding
+	Timeline     key.Binding
+	SaveLogs     key.Binding
+	WorkloadLogs key.Binding
 
 	// Event actions
 	ToggleAllEvents key.Binding
@@ -45,6 +50,10 @@ type KeyMap struct {
 	// Manifest actions
 	ToggleFullView key.Binding
 
+	// Step through the dashboard's earlier snapshots
+	OlderSnapshot key.Binding
+	NewerSnapshot key.Binding
+
 	// Pod actions
 	CopyCommands key.Binding
 	PodActions   key.Binding
@@ -52,6 +61,49 @@ type KeyMap struct {
 	// Workload actions
 	Scale   key.Binding
 	Restart key.Binding
+
+	// Background monitoring
+	Watch key.Binding
+
+	// Pod comparison
+	Mark    key.Binding
+	Compare key.Binding
+
+	// DaemonSet per-node breakdown
+	Nodes key.Binding
+
+	// Native describe of the selected workload or pod
+	Describe key.Binding
+
+	// Deployment revisions to undo to
+	RolloutHistory key.Binding
+
+	// Open the unhealthiest pod in scope
+	JumpToProblem key.Binding
+
+	// Actions for the selected workload: CronJob runs, finished pod cleanup
+	WorkloadActions key.Binding
+
+	// Save the workload list as a named view, and recall saved views
+	SaveView key.Binding
+	Views    key.Binding
+
+	// Jump to a bookmark from the config
+	Bookmarks key.Binding
+
+	// Port-forward the selected Service or pod
+	PortForward key.Binding
+
+	// Namespace list actions
+	CreateNamespace  key.Binding
+	DeleteNamespace  key.Binding
+	NamespaceActions key.Binding
+
+	// Error viewer
+	Errors key.Binding
+
+	// Port-forward manager
+	PortForwards key.Binding
 }
 
 func DefaultKeyMap() KeyMap {
@@ -155,6 +207,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("t"),
 			key.WithHelp("t", "type"),
 		),
+		Context: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "context"),
+		),
 
 		// Log actions
 		ToggleFollow: key.NewBinding(
@@ -169,6 +225,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("w"),
 			key.WithHelp("w", "wrap lines"),
 		),
+		LogsToPager: key.NewBinding(
+			key.WithKeys("|"),
+			key.WithHelp("|", "logs to pager"),
+		),
+		Timeline: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "log+event timeline"),
+		),
+		SaveLogs: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "save logs"),
+		),
+		WorkloadLogs: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "logs of all pods"),
+		),
 
 		// Event actions
 		ToggleAllEvents: key.NewBinding(
@@ -182,6 +254,15 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("v", "full view"),
 		),
 
+		OlderSnapshot: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "older snapshot"),
+		),
+		NewerSnapshot: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "newer snapshot"),
+		),
+
 		// Pod actions
 		CopyCommands: key.NewBinding(
 			key.WithKeys("y"),
@@ -201,5 +282,100 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("R"),
 			key.WithHelp("R", "restart"),
 		),
+
+		// Background monitoring
+		Watch: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "watch"),
+		),
+
+		// Pod comparison
+		Mark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "mark pod"),
+		),
+		Compare: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "compare marked"),
+		),
+
+		// DaemonSet per-node breakdown
+		Nodes: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "nodes"),
+		),
+
+		// Native describe of the selected workload or pod
+		Describe: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "describe"),
+		),
+
+		// Deployment revisions to undo to
+		RolloutHistory: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "rollout history"),
+		),
+
+		// Open the unhealthiest pod in scope
+		JumpToProblem: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "unhealthiest pod"),
+		),
+
+		// Actions for the selected workload: CronJob runs, finished pod cleanup
+		WorkloadActions: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "workload actions"),
+		),
+
+		// Save the workload list as a named view, and recall saved views
+		SaveView: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "save view"),
+		),
+		Views: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "saved views"),
+		),
+
+		// Jump to a bookmark from the config
+		Bookmarks: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "bookmarks"),
+		),
+
+		// Port-forward the selected Service or pod
+		PortForward: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "port-forward"),
+		),
+
+		// Namespace list actions
+		CreateNamespace: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "new namespace"),
+		),
+		// Not ctrl+d, which pages down the list
+		DeleteNamespace: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "delete namespace"),
+		),
+		NamespaceActions: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "namespace actions"),
+		),
+
+		// Error viewer
+		Errors: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "errors"),
+		),
+
+		// Port-forward manager
+		PortForwards: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "port-forwards"),
+		),
 	}
 }
@@ -1,6 +1,10 @@
 package styles
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"hash/fnv"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 var (
 	// Colors - optimized for readability on dark terminals
@@ -91,6 +95,10 @@ var (
 	LogNormal = lipgloss.NewStyle().
 			Foreground(Text)
 
+	LogMatch = lipgloss.NewStyle().
+			Foreground(Background).
+			Background(Warning)
+
 	// Table styles
 	TableHeaderStyle = lipgloss.NewStyle().
 				Bold(true).
@@ -157,19 +165,40 @@ var (
 			Padding(0, 1)
 )
 
+// labelColors tell log sources apart; amber and red are left to log levels
+var labelColors = []lipgloss.Color{Secondary, Primary, Success, Accent, "#60A5FA", "#FB923C", "#A3E635", "#2DD4BF"}
+
+// LabelStyle is a color for a container or pod/container name that stays
+// the same for the name, so lines merged from many sources keep theirs
+func LabelStyle(label string) lipgloss.Style {
+	h := fnv.New32a()
+	h.Write([]byte(label))
+	return lipgloss.NewStyle().Foreground(labelColors[h.Sum32()%uint32(len(labelColors))]).Bold(true)
+}
+
 func GetStatusStyle(status string) lipgloss.Style {
 	switch status {
 	case "Running", "Completed", "Active", "Ready":
 		return StatusRunning
-	case "Pending", "Progressing", "ContainerCreating":
+	case "Pending", "Progressing", "ContainerCreating", "Paused":
 		return StatusPending
-	case "Failed", "Error", "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "OOMKilled", "NotReady", "Terminating":
+	case "Failed", "Error", "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "OOMKilled", "NotReady", "Terminating", "DeadlineExceeded", "ReplicaFailure":
 		return StatusError
 	default:
 		return StatusMuted
 	}
 }
 
+// StatusBadge is GetStatusStyle's color as a background, for a status that
+// must stand out, e.g. in the breadcrumb
+func StatusBadge(status string) lipgloss.Style {
+	return lipgloss.NewStyle().
+		Background(GetStatusStyle(status).GetForeground()).
+		Foreground(lipgloss.Color("#111827")).
+		Bold(true).
+		Padding(0, 1)
+}
+
 func RenderWithWidth(s lipgloss.Style, content string, width int) string {
 	return s.Width(width).Render(content)
 }
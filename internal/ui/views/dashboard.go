@@ -1,13 +1,17 @@
 package views
 
 import (
+	"fmt"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/tracing"
 	"github.com/doganarif/k9sight/internal/ui/components"
 	"github.com/doganarif/k9sight/internal/ui/keys"
 	"github.com/doganarif/k9sight/internal/ui/styles"
@@ -41,10 +45,30 @@ type Dashboard struct {
 	keys          keys.KeyMap
 	statusMsg     string // Temporary status message (e.g., "Copied!")
 	namespace     string // Current namespace for kubectl commands
-	context       string // Current context for kubectl commands
+	kubectl       string // kubectl addressing the pod's cluster, see k8s.KubectlFor
 	pendingAction *components.PodActionItem // Action waiting for confirmation
+	traceExtractor *tracing.Extractor
+	traceLinks     []tracing.Link
+	integration    string // resolved terminal integration for exec/port-forward
+	lastEvents     []k8s.EventInfo
+	lastHelpers    []k8s.DebugHelper
+	drift          []k8s.ConfigDrift
+	node           *k8s.NodeSummary
+	pager          string // external pager for large outputs, empty for the built-in viewer
+	diffTool       string // external diff tool for diff views
+	debugImage     string // image of injected debug containers
+	logExportDir   string // where saved logs go
+	recentCommands []components.PodActionItem // execs and port-forwards run on this workload's pods
+	sectionErrors  map[string]string // per-section load errors, keyed by section name
+	lastMetrics    *k8s.PodMetrics
+	snapshots      []k8s.PodSnapshot // one per refresh, oldest first
+	snapshotIdx    int               // snapshot shown in the result viewer, -1 for none
 }
 
+// maxSnapshots bounds the dashboard's history; at the default 5 second
+// refresh it reaches back 10 minutes
+const maxSnapshots = 120
+
 func NewDashboard() Dashboard {
 	return Dashboard{
 		logs:          components.NewLogsPanel(),
@@ -59,6 +83,10 @@ func NewDashboard() Dashboard {
 		resultViewer:  components.NewResultViewer(),
 		focus:         FocusLogs,
 		keys:          keys.DefaultKeyMap(),
+		integration:   components.IntegrationNone,
+		debugImage:    k8s.DefaultDebugImage,
+		kubectl:       "kubectl",
+		snapshotIdx:   -1,
 	}
 }
 
@@ -70,6 +98,71 @@ func (d Dashboard) Init() tea.Cmd {
 type DeletePodRequest struct {
 	Namespace string
 	PodName   string
+	Evict     bool // go through the Eviction API, which honors PodDisruptionBudgets
+}
+
+// ScheduleCheckRequest asks app.go to check a pending pod against every node
+type ScheduleCheckRequest struct {
+	Namespace string
+	PodName   string
+}
+
+// SaveYAMLRequest asks app.go where to save the objects' YAML, and to save it
+type SaveYAMLRequest struct {
+	Namespace string
+	Objects   []k8s.ObjectRef
+}
+
+// runsBeside reports whether an exec opens in a tmux or iTerm pane instead
+// of suspending the UI. The pane runs kubectl, so without it exec falls back
+// to the in-process session.
+func (d Dashboard) runsBeside() bool {
+	if d.integration == components.IntegrationNone {
+		return false
+	}
+	_, err := exec.LookPath("kubectl")
+	return err == nil
+}
+
+// PortForwardRequest is sent to app.go to ask for the ports of a new
+// port-forward to the pod
+type PortForwardRequest struct {
+	Namespace string
+	PodName   string
+	Ports     []int32 // the pod's container ports, to suggest
+}
+
+// ExecPodRequest is sent to app.go to exec into a container with the
+// in-process client
+type ExecPodRequest struct {
+	Namespace string
+	PodName   string
+	Container string
+	Command   []string
+}
+
+// CommandRunMsg tells app.go an exec was started from the dashboard, to
+// remember it for the pod's workload
+type CommandRunMsg struct {
+	Pod  *k8s.PodInfo
+	Item components.PodActionItem
+}
+
+// DebugPodRequest asks app.go to inject an ephemeral debug container into
+// the pod and reply with DebugContainerMsg once it runs
+type DebugPodRequest struct {
+	Namespace string
+	PodName   string
+	Image     string
+	Target    string // container whose processes the debugger sees
+}
+
+// DebugContainerMsg reports the injected debug container, to exec into
+type DebugContainerMsg struct {
+	Namespace string
+	PodName   string
+	Container string
+	Err       error
 }
 
 // ExecFinishedMsg is sent when an external command finishes
@@ -77,13 +170,55 @@ type ExecFinishedMsg struct {
 	Err error
 }
 
-// DescribeOutputMsg contains the output of kubectl describe
+// DescribePodRequest asks app.go for the pod's describe output
+type DescribePodRequest struct {
+	Namespace string
+	PodName   string
+}
+
+// DescribeOutputMsg contains a pod's describe output
 type DescribeOutputMsg struct {
 	Title   string
 	Content string
 	Err     error
 }
 
+// ConfigDiffRequest asks app.go to read what a container runs with for a
+// drifted ConfigMap or Secret and reply with ConfigDiffMsg
+type ConfigDiffRequest struct {
+	Namespace string
+	PodName   string
+	Drift     k8s.ConfigDrift
+}
+
+// ConfigDiffMsg carries the container's view of a drifted object, named
+// like the drift's Targets
+type ConfigDiffMsg struct {
+	Drift   k8s.ConfigDrift
+	Running map[string]string
+	Err     error
+}
+
+// RestartOwnerRequest asks app.go to offer a rollout restart of the
+// workload that owns the pod
+type RestartOwnerRequest struct {
+	Pod *k8s.PodInfo
+}
+
+// ProbeCheckMsg contains the output of a probe replayed from a debug container
+type ProbeCheckMsg struct {
+	Title  string
+	Output string
+	Err    error
+}
+
+// LogsSavedMsg reports log lines written to a file
+type LogsSavedMsg struct {
+	Path  string
+	Lines int
+	Err   error
+}
+
 func (d Dashboard) Update(msg tea.Msg) (Dashboard, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
@@ -102,8 +237,71 @@ func (d Dashboard) Update(msg tea.Msg) (Dashboard, tea.Cmd) {
 	if result, ok := msg.(DescribeOutputMsg); ok {
 		if result.Err != nil {
 			d.statusMsg = "Describe failed: " + result.Err.Error()
+			return d, nil
+		}
+		return d, d.showResult(result.Title, result.Content)
+	}
+
+	// Offer a shell in the debug container once it runs
+	if result, ok := msg.(DebugContainerMsg); ok {
+		if d.pod == nil || result.Namespace != d.pod.Namespace || result.PodName != d.pod.Name {
+			return d, nil
+		}
+		if result.Err != nil {
+			d.statusMsg = "Debug failed: " + k8s.ShortError(result.Err)
+			return d, nil
+		}
+		d.statusMsg = ""
+		d.pendingAction = &components.PodActionItem{
+			Label:     "Exec into '" + result.Container + "' (sh)",
+			Action:    "exec",
+			Command:   fmt.Sprintf("%s exec -it -n %s %s -c %s -- sh", d.kubectl, d.pod.Namespace, d.pod.Name, result.Container),
+			Container: result.Container,
+			Exec:      []string{"sh"},
+		}
+		d.confirmDialog.ShowCommand(
+			"Exec into Debug Container",
+			"Debug container '"+result.Container+"' is running. Open a shell in it?",
+			d.pendingAction.Command,
+			"exec",
+			d.pod,
+		)
+		return d, nil
+	}
+
+	// Diff what the container runs with against the object now
+	if result, ok := msg.(ConfigDiffMsg); ok {
+		dr := result.Drift
+		title := fmt.Sprintf("%s %s (%s via %s)", dr.Kind, dr.Name, dr.Container, dr.Via)
+		if result.Err != nil {
+			// Without env or cat in the image only the current data can be shown
+			d.statusMsg = "Cannot read the running config: " + k8s.ShortError(result.Err)
+			return d, d.showResult(title, dr.Format(dr.Current))
+		}
+		d.statusMsg = ""
+		return d, d.showDiff(title, "running", dr.Format(result.Running), "current", dr.Format(dr.Current))
+	}
+
+	if result, ok := msg.(components.ManifestYAMLMsg); ok {
+		d.manifest.SetYAML(result)
+		return d, nil
+	}
+
+	// Handle ProbeCheckMsg; a failing check exits non-zero but still has output to judge
+	if result, ok := msg.(ProbeCheckMsg); ok {
+		if result.Err != nil && strings.TrimSpace(result.Output) == "" {
+			d.statusMsg = "Probe check failed: " + result.Err.Error()
+			return d, nil
+		}
+		content := k8s.ProbeCheckVerdict(result.Output) + "\n\n" + result.Output
+		return d, d.showResult(result.Title, content)
+	}
+
+	if result, ok := msg.(LogsSavedMsg); ok {
+		if result.Err != nil {
+			d.statusMsg = "Save failed: " + result.Err.Error()
 		} else {
-			d.resultViewer.Show(result.Title, result.Content, d.width-4, d.height-4)
+			d.statusMsg = fmt.Sprintf("Saved %d lines to %s", result.Lines, result.Path)
 		}
 		return d, nil
 	}
@@ -123,49 +321,98 @@ func (d Dashboard) Update(msg tea.Msg) (Dashboard, tea.Cmd) {
 		switch result.Item.Action {
 		case "delete":
 			// Show confirmation dialog
-			d.confirmDialog.Show(
+			d.confirmDialog.ShowCommand(
 				"Delete Pod",
 				"Are you sure you want to delete pod '"+d.pod.Name+"'?",
+				result.Item.Command,
 				"delete",
 				d.pod,
 			)
 			return d, nil
+		case "evict":
+			d.confirmDialog.ShowCommand(
+				"Evict Pod",
+				"Evict pod '"+d.pod.Name+"'?\nA PodDisruptionBudget may refuse the eviction.",
+				result.Item.Command,
+				"evict",
+				d.pod,
+			)
+			return d, nil
 		case "exec":
 			// Show confirmation before exec
 			d.pendingAction = &result.Item
-			d.confirmDialog.Show(
+			detail := "This will suspend the UI until you exit the shell."
+			if d.runsBeside() {
+				detail = "The shell opens in " + components.IntegrationLabel(d.integration, true) + "."
+			}
+			d.confirmDialog.ShowCommand(
 				"Exec into Pod",
-				"Open shell in '"+d.pod.Name+"'?\nThis will suspend the UI until you exit the shell.",
+				"Open shell in '"+d.pod.Name+"'?\n"+detail,
+				result.Item.Command,
 				"exec",
 				d.pod,
 			)
 			return d, nil
+		case "debug":
+			d.confirmDialog.ShowCommand(
+				"Debug Pod",
+				"Add an ephemeral "+d.debugImage+" container to '"+d.pod.Name+"' and open a shell in it?\nIt stays in the pod until the pod is deleted.",
+				result.Item.Command,
+				"debug",
+				DebugPodRequest{Namespace: d.pod.Namespace, PodName: d.pod.Name, Image: d.debugImage, Target: result.Item.Container},
+			)
+			return d, nil
+		case "recent":
+			d.podActionMenu.Show("Recent Commands", d.recentCommands)
+			return d, nil
+		case "port-forward-again":
+			req := components.PortForwardPromptResult{
+				Namespace: d.pod.Namespace, Pod: d.pod.Name,
+				LocalPort: result.Item.LocalPort, RemotePort: result.Item.RemotePort,
+			}
+			return d, func() tea.Msg { return req }
 		case "port-forward":
-			// Show confirmation before port-forward
+			var ports []int32
+			for _, c := range d.pod.Containers {
+				ports = append(ports, c.Ports...)
+			}
+			req := PortForwardRequest{Namespace: d.pod.Namespace, PodName: d.pod.Name, Ports: ports}
+			return d, func() tea.Msg { return req }
+		case "yaml-save":
+			req := SaveYAMLRequest{Namespace: d.pod.Namespace, Objects: result.Item.Objects}
+			return d, func() tea.Msg { return req }
+		case "schedule-check":
+			d.statusMsg = "Simulating scheduling..."
+			req := ScheduleCheckRequest{Namespace: d.pod.Namespace, PodName: d.pod.Name}
+			return d, func() tea.Msg { return req }
+		case "probe-check":
+			// The debug container cannot be removed from the pod afterwards
 			d.pendingAction = &result.Item
-			d.confirmDialog.Show(
-				"Port Forward",
-				"Start port forwarding for '"+d.pod.Name+"'?\nPress Ctrl+C in terminal to stop and return.",
-				"port-forward",
+			d.confirmDialog.ShowCommand(
+				"Check Probe",
+				"Replay "+result.Item.Description+" from a debug container?\nThis adds an ephemeral "+k8s.ProbeCheckImage+" container to '"+d.pod.Name+"'.",
+				result.Item.Command,
+				"probe-check",
 				d.pod,
 			)
 			return d, nil
+		case "config-diff":
+			if result.Item.Drift >= len(d.drift) {
+				return d, nil
+			}
+			d.statusMsg = "Reading running config..."
+			req := ConfigDiffRequest{Namespace: d.pod.Namespace, PodName: d.pod.Name, Drift: d.drift[result.Item.Drift]}
+			return d, func() tea.Msg { return req }
 		case "describe":
-			// Run describe command and capture output
-			d.statusMsg = "Loading describe..."
-			cmdStr := result.Item.Command
-			podName := d.pod.Name
-			return d, func() tea.Msg {
-				c := exec.Command("sh", "-c", cmdStr)
-				output, err := c.CombinedOutput()
-				if err != nil {
-					return DescribeOutputMsg{Err: err}
-				}
-				return DescribeOutputMsg{
-					Title:   "Pod: " + podName,
-					Content: string(output),
-				}
+			// The pod and events the dashboard already shows describe it
+			// exactly as displayed; before the events arrive app.go fetches
+			// both and replies with DescribeOutputMsg
+			if d.pod.Object != nil && d.lastEvents != nil {
+				return d, d.showResult("Pod: "+d.pod.Name, k8s.FormatPodDescription(d.pod.Object, d.lastEvents))
 			}
+			d.statusMsg = "Loading describe..."
+			req := DescribePodRequest{Namespace: d.pod.Namespace, PodName: d.pod.Name}
+			return d, func() tea.Msg { return req }
 		case "copy":
 			// Copy the command to clipboard
 			err := components.CopyToClipboard(result.Item.Command)
@@ -175,36 +422,121 @@ func (d Dashboard) Update(msg tea.Msg) (Dashboard, tea.Cmd) {
 				d.statusMsg = "Copy failed: " + err.Error()
 			}
 			return d, nil
+		case "open-url":
+			if err := components.OpenURL(result.Item.Command); err != nil {
+				d.statusMsg = "Open failed: " + err.Error()
+			} else {
+				d.statusMsg = "Opened: " + result.Item.Command
+			}
+			return d, nil
+		case "issue-copy":
+			if err := components.CopyToClipboard(d.issueReport()); err != nil {
+				d.statusMsg = "Copy failed: " + err.Error()
+			} else {
+				d.statusMsg = "Copied issue report"
+			}
+			return d, nil
+		case "logs-save", "logs-save-raw":
+			lines := d.logs.VisibleLogs()
+			if result.Item.AllLogs {
+				lines = d.logs.LoadedLogs()
+			}
+			content := k8s.FormatLogExport(lines, result.Item.Action == "logs-save-raw")
+			path := result.Item.Command
+			return d, func() tea.Msg {
+				return LogsSavedMsg{Path: path, Lines: len(lines), Err: k8s.SaveExport(path, content)}
+			}
+		case "issue-save":
+			if err := os.WriteFile(result.Item.Command, []byte(d.issueReport()), 0644); err != nil {
+				d.statusMsg = "Save failed: " + err.Error()
+			} else {
+				d.statusMsg = "Saved issue report to " + result.Item.Command
+			}
+			return d, nil
 		}
 		return d, nil
 	}
 
 	// Handle ConfirmResult
 	if result, ok := msg.(components.ConfirmResult); ok {
+		if result.Copied || result.Err != nil {
+			d.pendingAction = nil
+			if result.Err != nil {
+				d.statusMsg = "Copy failed: " + result.Err.Error()
+			} else {
+				d.statusMsg = "Copied: " + result.Command
+			}
+			return d, nil
+		}
 		if result.Confirmed {
 			switch result.Action {
-			case "delete":
+			case "delete", "evict":
 				if pod, ok := result.Data.(*k8s.PodInfo); ok {
+					evict := result.Action == "evict"
 					d.statusMsg = "Deleting pod..."
+					if evict {
+						d.statusMsg = "Evicting pod..."
+					}
 					return d, func() tea.Msg {
 						return DeletePodRequest{
 							Namespace: pod.Namespace,
 							PodName:   pod.Name,
+							Evict:     evict,
 						}
 					}
 				}
-			case "exec", "port-forward":
+			case "debug":
+				if req, ok := result.Data.(DebugPodRequest); ok {
+					d.statusMsg = "Starting debug container..."
+					return d, func() tea.Msg { return req }
+				}
+			case "probe-check":
+				if d.pendingAction != nil {
+					item := *d.pendingAction
+					d.pendingAction = nil
+					d.statusMsg = "Checking probe..."
+					return d, func() tea.Msg {
+						output, err := exec.Command("sh", "-c", item.Command).CombinedOutput()
+						return ProbeCheckMsg{Title: item.Label, Output: string(output), Err: err}
+					}
+				}
+			case "exec":
 				// Execute the pending action
 				if d.pendingAction != nil {
-					cmdStr := d.pendingAction.Command
+					item := *d.pendingAction
+					cmdStr := item.Command
 					d.pendingAction = nil
+					run := CommandRunMsg{Pod: d.pod, Item: item}
+					remember := func() tea.Msg { return run }
+
+					// Run beside the TUI instead of suspending it when tmux/iTerm is available
+					if d.runsBeside() {
+						if err := components.OpenInTerminal(d.integration, "exec:"+d.pod.Name, cmdStr, true); err != nil {
+							d.statusMsg = "Open failed: " + err.Error()
+							return d, nil
+						}
+						d.statusMsg = "Opened in " + components.IntegrationLabel(d.integration, true)
+						return d, remember
+					}
+
+					if len(item.Exec) > 0 {
+						return d, tea.Batch(remember, func() tea.Msg {
+							return ExecPodRequest{
+								Namespace: d.pod.Namespace,
+								PodName:   d.pod.Name,
+								Container: item.Container,
+								Command:   item.Exec,
+							}
+						})
+					}
+
 					c := exec.Command("sh", "-c", cmdStr)
-					return d, tea.ExecProcess(c, func(err error) tea.Msg {
+					return d, tea.Batch(remember, tea.ExecProcess(c, func(err error) tea.Msg {
 						if err != nil {
 							return ExecFinishedMsg{Err: err}
 						}
 						return ExecFinishedMsg{}
-					})
+					}))
 				}
 			}
 		} else {
@@ -224,7 +556,20 @@ func (d Dashboard) Update(msg tea.Msg) (Dashboard, tea.Cmd) {
 
 		// Result viewer takes priority (for describe output etc)
 		if d.resultViewer.IsVisible() {
+			if d.snapshotIdx >= 0 && !d.resultViewer.Searching() {
+				switch {
+				case key.Matches(msg, d.keys.OlderSnapshot):
+					d.showSnapshot(d.snapshotIdx - 1)
+					return d, nil
+				case key.Matches(msg, d.keys.NewerSnapshot):
+					d.showSnapshot(d.snapshotIdx + 1)
+					return d, nil
+				}
+			}
 			d.resultViewer, cmd = d.resultViewer.Update(msg)
+			if !d.resultViewer.IsVisible() {
+				d.snapshotIdx = -1
+			}
 			return d, cmd
 		}
 
@@ -264,12 +609,28 @@ func (d Dashboard) Update(msg tea.Msg) (Dashboard, tea.Cmd) {
 				for _, c := range d.pod.Containers {
 					containers = append(containers, c.Name)
 				}
-				items := components.PodActions(d.namespace, d.pod.Name, containers)
+				var items []components.PodActionItem
+				if n := len(d.recentCommands); n > 0 {
+					items = append(items, components.PodActionItem{
+						Label:       "Recent commands...",
+						Description: fmt.Sprintf("%d run on this workload", n),
+						Action:      "recent",
+					})
+				}
+				items = append(items, components.PodActions(d.kubectl, d.namespace, d.pod.Name, containers)...)
+				items = append(items, components.SchedulingActions(d.pod)...)
+				items = append(items, components.DebugActions(d.kubectl, d.pod, d.debugImage)...)
+				items = append(items, components.ProbeActions(d.kubectl, d.pod)...)
+				items = append(items, components.ConfigDriftActions(d.pod.Namespace, d.drift)...)
+				items = append(items, components.ManifestExportActions(d.pod, d.manifest.Related())...)
+				items = append(items, components.TraceActions(d.recentTraceIDs(), d.traceLinks)...)
+				items = append(items, components.ConsoleActions(d.nodeProviderID())...)
 				d.podActionMenu.Show("Pod Actions", items)
 			}
 			return d, nil
 
-		case key.Matches(msg, d.keys.CopyCommands):
+		case key.Matches(msg, d.keys.CopyCommands) && d.focus != FocusManifest:
+			// The manifest panel uses y for its YAML view
 			if d.pod != nil {
 				var containers []string
 				for _, c := range d.pod.Containers {
@@ -312,6 +673,43 @@ func (d Dashboard) Update(msg tea.Msg) (Dashboard, tea.Cmd) {
 		case key.Matches(msg, d.keys.ToggleFullView):
 			d.fullscreen = !d.fullscreen
 			return d, nil
+
+		case key.Matches(msg, d.keys.OlderSnapshot) && d.pod != nil:
+			if len(d.snapshots) < 2 {
+				d.statusMsg = "No earlier snapshots yet"
+				return d, nil
+			}
+			d.showSnapshot(len(d.snapshots) - 2)
+			return d, nil
+
+		case key.Matches(msg, d.keys.LogsToPager) && d.pod != nil:
+			return d, d.logsToPager()
+
+		case key.Matches(msg, d.keys.Timeline) && d.pod != nil:
+			return d, d.showResult("Timeline: "+d.pod.Name, k8s.FormatTimeline(d.logs.LoadedLogs(), d.lastEvents))
+
+		case key.Matches(msg, d.keys.SaveLogs) && d.focus == FocusLogs && d.pod != nil:
+			if d.logs.LogCount() == 0 {
+				d.statusMsg = "No logs to save"
+				return d, nil
+			}
+			now := time.Now()
+			container := d.logs.SelectedContainer()
+			// With a container selected only its logs are loaded
+			var allPath string
+			if container == "" {
+				allPath = k8s.LogExportPath(d.logExportDir, d.pod.Name, "", now)
+			}
+			d.podActionMenu.Show("Save Logs", components.LogExportActions(
+				k8s.LogExportPath(d.logExportDir, d.pod.Name, container, now),
+				allPath,
+			))
+			return d, nil
+
+		case key.Matches(msg, d.keys.Restart) && d.pod != nil:
+			// app.go finds the owner and asks for confirmation
+			req := RestartOwnerRequest{Pod: d.pod}
+			return d, func() tea.Msg { return req }
 		}
 	}
 
@@ -333,6 +731,86 @@ func (d Dashboard) Update(msg tea.Msg) (Dashboard, tea.Cmd) {
 	return d, tea.Batch(cmds...)
 }
 
+// recentTraceIDs returns the newest trace ids in the currently visible logs
+func (d Dashboard) recentTraceIDs() []string {
+	if d.traceExtractor == nil {
+		return nil
+	}
+	logs := d.logs.VisibleLogs()
+	lines := make([]string, len(logs))
+	for i, log := range logs {
+		lines[i] = log.Content
+	}
+	return d.traceExtractor.RecentIDs(lines, 3)
+}
+
+// issueReport formats the pod, hints, recent errors and events as Markdown
+func (d Dashboard) issueReport() string {
+	return k8s.FormatIssueReport(d.pod, d.lastHelpers, d.lastEvents, d.logs.VisibleLogs())
+}
+
+// showResult opens output in the configured pager, or the built-in viewer
+func (d *Dashboard) showResult(title, content string) tea.Cmd {
+	if d.pager != "" {
+		ext, err := components.PagerCommand(d.pager, title+".txt", content)
+		if err == nil {
+			return runExternal(ext)
+		}
+		d.statusMsg = "Pager failed: " + err.Error()
+	}
+	d.resultViewer.Show(title, content, d.width-4, d.height-4)
+	return nil
+}
+
+// logsToPager opens the loaded logs, after the panel's filters, in the
+// configured pager, $PAGER or less
+func (d *Dashboard) logsToPager() tea.Cmd {
+	if d.logs.LogCount() == 0 {
+		d.statusMsg = "No logs to page"
+		return nil
+	}
+	ext, err := components.PagerCommand(components.LogPager(d.pager), d.pod.Name+".log", d.logs.PagerContent())
+	if err != nil {
+		d.statusMsg = "Pager failed: " + err.Error()
+		return nil
+	}
+	return runExternal(ext)
+}
+
+// showDiff opens two versions of a document in the configured diff tool, or
+// as a line diff in the result viewer without one or when it cannot start
+func (d *Dashboard) showDiff(title, leftName, left, rightName, right string) tea.Cmd {
+	cmd, diff, err := Diff(d.diffTool, leftName, left, rightName, right)
+	if err != nil {
+		d.statusMsg = "Diff tool failed: " + err.Error()
+	}
+	if cmd != nil {
+		return cmd
+	}
+	return d.showResult(title, diff)
+}
+
+// Diff returns the command that opens both sides in tool. Without a tool, or
+// when it cannot start, it returns their line diff to show instead, with the
+// error that kept the tool from starting.
+func Diff(tool, leftName, left, rightName, right string) (tea.Cmd, string, error) {
+	if tool == "" {
+		return nil, k8s.SimpleDiff(left, right), nil
+	}
+	ext, err := components.DiffCommand(tool, leftName, left, rightName, right)
+	if err != nil {
+		return nil, k8s.SimpleDiff(left, right), err
+	}
+	return runExternal(ext), "", nil
+}
+
+func runExternal(ext components.ExternalCommand) tea.Cmd {
+	return tea.ExecProcess(ext.Cmd, func(err error) tea.Msg {
+		ext.Cleanup()
+		return ExecFinishedMsg{Err: err}
+	})
+}
+
 func (d *Dashboard) nextPanel() {
 	d.focus = (d.focus + 1) % 4
 }
@@ -476,6 +954,8 @@ func (d Dashboard) renderFloatingDialog(dialogContent string) string {
 
 func (d *Dashboard) SetPod(pod *k8s.PodInfo) {
 	d.pod = pod
+	d.lastEvents = nil // they belong to the previous pod
+	d.breadcrumb.SetBadge(pod.Status, pod.Ready, pod.Restarts)
 	d.manifest.SetPod(pod)
 	d.metrics.SetPod(pod)
 
@@ -485,41 +965,248 @@ func (d *Dashboard) SetPod(pod *k8s.PodInfo) {
 		containerNames = append(containerNames, c.Name)
 	}
 	d.logs.SetContainers(containerNames)
+	d.logs.SetLogs(nil) // the previous pod's; the first load fills them
+	d.lastMetrics = nil
+	d.snapshots = nil
+	d.updateTimelines()
+}
+
+// RefreshPod updates the pod details after a reload. Unlike SetPod it keeps
+// the logs panel's container selection; a pod's containers never change.
+func (d *Dashboard) RefreshPod(pod *k8s.PodInfo) {
+	d.pod = pod
+	d.breadcrumb.SetBadge(pod.Status, pod.Ready, pod.Restarts)
+	d.manifest.SetPod(pod)
+	d.metrics.SetPod(pod)
+	d.updateTimelines()
+}
+
+// RecordSnapshot adds the pod's state at this refresh to the history,
+// dropping the oldest beyond maxSnapshots. It is called once every section
+// of a refresh has arrived, so the pod's status is kept with the events and
+// usage of the same refresh.
+func (d *Dashboard) RecordSnapshot() {
+	if d.pod == nil {
+		return
+	}
+	d.snapshots = append(d.snapshots, k8s.TakePodSnapshot(d.pod, d.lastEvents, d.lastMetrics, time.Now()))
+	if len(d.snapshots) > maxSnapshots {
+		d.snapshots = d.snapshots[len(d.snapshots)-maxSnapshots:]
+		if d.snapshotIdx > 0 {
+			d.snapshotIdx-- // keep showing the same one
+		}
+	}
+}
+
+// showSnapshot opens snapshot i, compared with the latest, in the result
+// viewer, where < and > step through the others
+func (d *Dashboard) showSnapshot(i int) {
+	i = max(0, min(i, len(d.snapshots)-1))
+	d.snapshotIdx = i
+	title := fmt.Sprintf("Snapshot %d/%d (< older, > newer, esc back)", i+1, len(d.snapshots))
+	content := k8s.FormatPodSnapshot(d.snapshots[i], d.snapshots[len(d.snapshots)-1], time.Now())
+	d.resultViewer.Show(title, content, d.width-4, d.height-4)
 }
 
 func (d *Dashboard) SetLogs(logs []k8s.LogLine) {
 	d.logs.SetLogs(logs)
 }
 
+// RefreshLogs adds the new lines of a periodic log fetch
+func (d *Dashboard) RefreshLogs(logs []k8s.LogLine) {
+	d.logs.RefreshLogs(logs)
+}
+
+// ApplyEvent adds or updates an event from the event watch, reporting
+// whether it was new or seen again
+func (d *Dashboard) ApplyEvent(e k8s.EventInfo) bool {
+	events, changed := k8s.UpsertEvent(d.lastEvents, e)
+	if changed {
+		d.SetEvents(events)
+	}
+	return changed
+}
+
+// FlashEvent flags a new warning in the events panel header for a while
+func (d *Dashboard) FlashEvent(reason string, duration time.Duration) {
+	d.events.Flash(reason, time.Now().Add(duration))
+}
+
 func (d *Dashboard) SetEvents(events []k8s.EventInfo) {
+	d.lastEvents = events
 	d.events.SetEvents(events)
+	d.updateTimelines()
+}
+
+// updateTimelines rebuilds the startup timing and restart history from the
+// current pod status and events; either may arrive first
+func (d *Dashboard) updateTimelines() {
+	if d.pod == nil {
+		return
+	}
+	now := time.Now()
+	d.manifest.SetStartupTiming(k8s.BuildStartupTiming(d.pod.Object, d.lastEvents, now))
+	d.manifest.SetRestartTimelines(k8s.BuildRestartTimelines(d.pod.Object, d.lastEvents, now))
 }
 
 func (d *Dashboard) SetMetrics(metrics *k8s.PodMetrics) {
+	d.lastMetrics = metrics
 	d.metrics.SetMetrics(metrics)
 }
 
+func (d *Dashboard) SetVolumes(volumes []k8s.VolumeInfo) {
+	d.manifest.SetVolumes(volumes)
+}
+
 func (d *Dashboard) SetRelated(related *k8s.RelatedResources) {
 	d.manifest.SetRelated(related)
 }
 
+func (d *Dashboard) SetRolloutHistory(revisions []k8s.RolloutRevision) {
+	d.manifest.SetRolloutHistory(revisions)
+}
+
+func (d *Dashboard) SetVulnerabilities(vulns []k8s.ImageVulnerabilities) {
+	d.manifest.SetVulnerabilities(vulns)
+}
+
+// SetConfigDrift sets the ConfigMaps and Secrets that changed after the
+// containers using them started
+func (d *Dashboard) SetConfigDrift(drift []k8s.ConfigDrift) {
+	d.drift = drift
+	d.manifest.SetConfigDrift(drift)
+}
+
 func (d *Dashboard) SetHelpers(helpers []k8s.DebugHelper) {
+	d.lastHelpers = helpers
 	d.manifest.SetHelpers(helpers)
 }
 
+// SetLint sets the pod's best practice findings
+func (d *Dashboard) SetLint(lint []k8s.DebugHelper) {
+	d.manifest.SetLint(lint)
+}
+
+// SetShowLint shows or hides the best practice findings in the summary
+func (d *Dashboard) SetShowLint(on bool) {
+	d.manifest.SetShowLint(on)
+}
+
+// SetSectionError records the load error for one dashboard section and shows
+// it in the header of the panel that displays it; a nil err clears it.
+// Sections are pod, logs, events, metrics, related, volumes, node, rollout
+// and vulnerabilities.
+func (d *Dashboard) SetSectionError(section string, err error) {
+	if d.sectionErrors == nil {
+		d.sectionErrors = make(map[string]string)
+	}
+	if err != nil {
+		d.sectionErrors[section] = section + ": " + k8s.ShortError(err)
+	} else {
+		delete(d.sectionErrors, section)
+	}
+
+	switch section {
+	case "logs":
+		d.logs.SetError(d.sectionErrors["logs"])
+	case "events":
+		d.events.SetError(d.sectionErrors["events"])
+	case "metrics":
+		d.metrics.SetError(d.sectionErrors["metrics"])
+	case "pod", "related", "volumes", "drift", "node", "rollout", "vulnerabilities":
+		var msgs []string
+		for _, s := range []string{"pod", "related", "volumes", "drift", "node", "rollout", "vulnerabilities"} {
+			if msg := d.sectionErrors[s]; msg != "" {
+				msgs = append(msgs, msg)
+			}
+		}
+		d.manifest.SetError(strings.Join(msgs, ", "))
+	}
+}
+
+// SetLogGapThreshold marks silences in the logs longer than d
+func (d *Dashboard) SetLogGapThreshold(gap time.Duration) {
+	d.logs.SetGapThreshold(gap)
+}
+
+// SetLogBudget caps the memory used by stored logs
+func (d *Dashboard) SetLogBudget(maxLines, maxBytes int) {
+	d.logs.SetBudget(maxLines, maxBytes)
+}
+
+// SetExternalTools configures the pager and diff tool used instead of the built-in viewer
+func (d *Dashboard) SetExternalTools(pager, diffTool string) {
+	d.pager = pager
+	d.diffTool = diffTool
+}
+
+func (d *Dashboard) SetNode(node *k8s.NodeSummary) {
+	d.node = node
+	d.manifest.SetNode(node)
+}
+
+func (d Dashboard) nodeProviderID() string {
+	if d.node == nil {
+		return ""
+	}
+	return d.node.ProviderID
+}
+
 func (d *Dashboard) SetSize(width, height int) {
 	d.width = width
 	d.height = height
 	d.breadcrumb.SetWidth(width)
 	d.help.SetSize(width, height)
+	d.resultViewer.SetSize(width-4, height-4)
 }
 
 func (d *Dashboard) SetBreadcrumb(items ...string) {
 	d.breadcrumb.SetItems(items...)
 }
 
-func (d *Dashboard) SetContext(ctx string) {
-	d.context = ctx
+func (d *Dashboard) SetTracing(extractor *tracing.Extractor, links []tracing.Link) {
+	d.traceExtractor = extractor
+	d.traceLinks = links
+}
+
+// SetIntegration sets the terminal integration used for exec and port-forward
+func (d *Dashboard) SetIntegration(integration string) {
+	d.integration = integration
+}
+
+// SetRecentCommands sets the execs and port-forwards offered again under
+// "Recent commands" in the pod actions
+func (d *Dashboard) SetRecentCommands(items []components.PodActionItem) {
+	d.recentCommands = items
+}
+
+// SetLogANSIColors passes applications' own log colors through to the logs
+// panel
+func (d *Dashboard) SetLogANSIColors(on bool) {
+	d.logs.SetANSIColors(on)
+}
+
+// SetLogTimestamps sets how the logs panel shows line timestamps
+func (d *Dashboard) SetLogTimestamps(mode components.TimestampMode) {
+	d.logs.SetTimestampMode(mode)
+}
+
+// SetLogExportDir sets the directory saved logs are written to
+func (d *Dashboard) SetLogExportDir(dir string) {
+	d.logExportDir = dir
+}
+
+// SetDebugImage sets the image of the debug container the Debug action injects
+func (d *Dashboard) SetDebugImage(image string) {
+	if image != "" {
+		d.debugImage = image
+	}
+}
+
+// SetKubectl sets the kubectl invocation the pod's commands start with, so
+// they go to the cluster shown
+func (d *Dashboard) SetKubectl(kubectl string) {
+	d.kubectl = kubectl
 }
 
 func (d *Dashboard) SetNamespace(ns string) {
@@ -547,6 +1234,37 @@ func (d Dashboard) LogsShowPrevious() bool {
 	return d.logs.ShowPrevious()
 }
 
+func (d Dashboard) LogsUseExternalSource() bool {
+	return d.logs.UseExternalSource()
+}
+
+func (d Dashboard) LogsTimeRange() time.Duration {
+	return d.logs.TimeRange()
+}
+
+func (d Dashboard) LogsFollowing() bool {
+	return d.logs.IsFollowing()
+}
+
+// LogsNewest returns the timestamp of the newest log line, zero when there is none
+func (d Dashboard) LogsNewest() time.Time {
+	return d.logs.Newest()
+}
+
+// AppendLogs adds lines from the live log stream
+func (d *Dashboard) AppendLogs(lines []k8s.LogLine) {
+	d.logs.AppendLogs(lines)
+}
+
+// SetLogsLive marks whether the logs panel is fed by a live stream
+func (d *Dashboard) SetLogsLive(live bool) {
+	d.logs.SetLive(live)
+}
+
+func (d *Dashboard) SetLogSource(name string) {
+	d.logs.SetLogSource(name)
+}
+
 func (d *Dashboard) GetPod() *k8s.PodInfo {
 	return d.pod
 }
@@ -0,0 +1,210 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/doganarif/k9sight/internal/k8s"
+	"github.com/doganarif/k9sight/internal/ui/components"
+	"github.com/doganarif/k9sight/internal/ui/styles"
+)
+
+// WorkloadDashboard is the screen between the workload list and its pod
+// list: replica status, rollout, strategy, conditions and autoscaler next to
+// a short pod table and the workload's recent events
+type WorkloadDashboard struct {
+	detail     *k8s.WorkloadDetail
+	breadcrumb components.Breadcrumb
+	width      int
+	height     int
+}
+
+func NewWorkloadDashboard() WorkloadDashboard {
+	return WorkloadDashboard{breadcrumb: components.NewBreadcrumb()}
+}
+
+func (w *WorkloadDashboard) SetSize(width, height int) {
+	w.width = width
+	w.height = height
+	w.breadcrumb.SetWidth(width)
+}
+
+// SetDetail shows a workload, or a freshly fetched state of the same one
+func (w *WorkloadDashboard) SetDetail(detail *k8s.WorkloadDetail) {
+	w.detail = detail
+	wl := detail.Workload
+	w.breadcrumb.SetItems(wl.Namespace, string(wl.Type), wl.Name)
+	w.breadcrumb.SetBadge(wl.Status, wl.Ready, wl.RestartCount)
+}
+
+func (w WorkloadDashboard) View() string {
+	if w.detail == nil {
+		return styles.PanelStyle.Render("No workload selected")
+	}
+
+	halfWidth := w.width / 2
+	panelHeight := (w.height - 6) / 2
+
+	status := w.wrapPanel("Status", w.renderStatus(halfWidth-4), halfWidth-2, panelHeight)
+	conditions := w.wrapPanel("Conditions", w.renderConditions(halfWidth-4), halfWidth-2, panelHeight)
+	pods := w.wrapPanel(fmt.Sprintf("Pods (%d)", len(w.detail.Pods)), w.renderPods(halfWidth-4, panelHeight-2), halfWidth-2, panelHeight)
+	events := w.wrapPanel("Events", w.renderEvents(halfWidth-4, panelHeight-2), halfWidth-2, panelHeight)
+
+	hint := styles.HelpDescStyle.Render("  enter pod list, esc back")
+	return lipgloss.JoinVertical(lipgloss.Left,
+		w.breadcrumb.View()+hint,
+		lipgloss.JoinHorizontal(lipgloss.Top, status, conditions),
+		lipgloss.JoinHorizontal(lipgloss.Top, pods, events),
+	)
+}
+
+func (w WorkloadDashboard) wrapPanel(title, content string, width, height int) string {
+	body := styles.PanelTitleStyle.Render(title) + "\n" + content
+	return styles.PanelStyle.
+		Width(width).
+		Height(height).
+		MaxHeight(height + 2).
+		Render(body)
+}
+
+func (w WorkloadDashboard) renderStatus(width int) string {
+	d := w.detail
+	var b strings.Builder
+
+	counts := make([]string, len(d.Replicas))
+	for i, r := range d.Replicas {
+		counts[i] = fmt.Sprintf("%s %d", r.Label, r.Count)
+	}
+	b.WriteString(styles.Truncate(strings.Join(counts, "  "), width))
+	b.WriteString("\n\n")
+
+	if d.Rollout != "" {
+		rollout := styles.StatusRunning.Render(d.Rollout)
+		if d.RolloutFailed {
+			rollout = styles.StatusError.Render(d.Rollout)
+		} else if d.Rollout != "successfully rolled out" {
+			rollout = styles.StatusPending.Render(d.Rollout)
+		}
+		b.WriteString(fieldLabel("Rollout") + rollout + "\n")
+	}
+	if d.Strategy != "" {
+		b.WriteString(fieldLabel("Strategy") + styles.Truncate(d.Strategy, max(10, width-10)) + "\n")
+	}
+	if k8s.IsScalable(d.Workload.Type) {
+		if len(d.HPAs) == 0 {
+			b.WriteString(fieldLabel("Autoscaler") + styles.StatusMuted.Render("none") + "\n")
+		}
+		for _, hpa := range d.HPAs {
+			b.WriteString(fieldLabel("Autoscaler") + styles.Truncate(formatHPA(hpa), max(10, width-11)) + "\n")
+			if len(hpa.Metrics) > 0 {
+				b.WriteString(strings.Repeat(" ", 11) + styles.Truncate(k8s.FormatHPAMetrics(hpa.Metrics), max(10, width-11)) + "\n")
+			}
+		}
+		for _, h := range d.Hints {
+			style := styles.StatusPending
+			if h.Severity == "High" {
+				style = styles.StatusError
+			}
+			b.WriteString(style.Render(styles.Truncate("! "+h.Issue, width)) + "\n")
+		}
+	}
+	if d.Workload.Image != "" {
+		b.WriteString(fieldLabel("Image") + styles.Truncate(d.Workload.Image, max(10, width-10)) + "\n")
+	}
+	return b.String()
+}
+
+// fieldLabel pads a field name so the values line up
+func fieldLabel(s string) string {
+	return styles.HelpKeyStyle.Render(fmt.Sprintf("%-11s", s+":"))
+}
+
+func formatHPA(hpa k8s.HPAInfo) string {
+	return fmt.Sprintf("%s, %d-%d replicas, current %d, desired %d",
+		hpa.Name, hpa.MinReplicas, hpa.MaxReplicas, hpa.CurrentReplicas, hpa.DesiredReplicas)
+}
+
+func (w WorkloadDashboard) renderConditions(width int) string {
+	if len(w.detail.Conditions) == 0 {
+		return styles.StatusMuted.Render("No conditions reported")
+	}
+	var b strings.Builder
+	for _, c := range w.detail.Conditions {
+		style := styles.StatusRunning
+		if conditionBad(c) {
+			style = styles.StatusError
+		}
+		line := style.Render(c.Type + "=" + c.Status)
+		if c.Reason != "" {
+			line += " " + c.Reason
+		}
+		if !c.Since.IsZero() {
+			line += " " + styles.StatusMuted.Render(k8s.FormatDuration(time.Since(c.Since))+" ago")
+		}
+		b.WriteString(line + "\n")
+		if c.Message != "" {
+			b.WriteString("  " + styles.HelpDescStyle.Render(styles.Truncate(c.Message, max(10, width-2))) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// conditionBad reports whether a condition means the workload is in trouble
+func conditionBad(c k8s.WorkloadCondition) bool {
+	switch c.Type {
+	case "ReplicaFailure", "Failed", "FailureTarget":
+		return c.Status == "True"
+	case "Available", "Progressing":
+		return c.Status == "False" || c.Reason == "ProgressDeadlineExceeded"
+	}
+	return false
+}
+
+func (w WorkloadDashboard) renderPods(width, height int) string {
+	pods := w.detail.Pods
+	if len(pods) == 0 {
+		return styles.StatusMuted.Render("No pods")
+	}
+	nameWidth := max(12, min(40, width-45))
+	var b strings.Builder
+	b.WriteString(styles.TableHeaderStyle.Render(fmt.Sprintf("%-*s %-18s %-6s %-8s %s", nameWidth, "NAME", "STATUS", "READY", "RESTARTS", "AGE")))
+	b.WriteString("\n")
+
+	rows := height - 1
+	if len(pods) > rows {
+		rows-- // room for the "more" line
+	}
+	for _, p := range pods[:max(0, min(len(pods), rows))] {
+		restarts := fmt.Sprintf("%-8d", p.Restarts)
+		if p.Restarts > 0 {
+			restarts = styles.StatusError.Render(restarts)
+		}
+		b.WriteString(fmt.Sprintf("%-*s %s %-6s %s %s\n",
+			nameWidth, styles.Truncate(p.Name, nameWidth),
+			styles.GetStatusStyle(p.Status).Render(fmt.Sprintf("%-18s", styles.Truncate(p.Status, 18))),
+			p.Ready, restarts, p.Age))
+	}
+	if more := len(pods) - max(0, rows); more > 0 {
+		b.WriteString(styles.StatusMuted.Render(fmt.Sprintf("... %d more, enter for the pod list", more)))
+	}
+	return b.String()
+}
+
+func (w WorkloadDashboard) renderEvents(width, height int) string {
+	events := w.detail.Events
+	if len(events) == 0 {
+		return styles.StatusMuted.Render("No recent events")
+	}
+	var b strings.Builder
+	for _, e := range events[:min(len(events), max(0, height))] {
+		line := fmt.Sprintf("%-6s %s %s: %s", e.Age, e.Reason, e.Object, strings.ReplaceAll(e.Message, "\n", " "))
+		line = styles.Truncate(line, width)
+		if e.Type == "Warning" {
+			line = styles.EventWarning.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}